@@ -68,8 +68,15 @@ func run() error {
 	server := web.NewServer(
 		db, port,
 		repos.Experiments,
-		repos.ExperimentVariables, repos.Pricing, repos.Sessions, repos.Metrics,
-		repos.Stats, repos.Projects,
+		repos.ExperimentVariables, repos.ExperimentNotes, repos.Pricing, repos.Sessions, repos.Metrics,
+		repos.Stats, repos.Projects, repos.Budgets, repos.Plans, repos.Usage,
+		repos.PlanPrices,
+		repos.PricingProfiles,
+		repos.Billing,
+		repos.Commits,
+		repos.Commands,
+		repos.Files,
+		repos.ToolCalls,
 	)
 	return server.Start(ctx)
 }
@@ -0,0 +1,212 @@
+// Package git reads the state of git working trees with the local git
+// binary.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+// DefaultTimeout bounds every git invocation, so a slow repository cannot
+// hold up a hook.
+const DefaultTimeout = 2 * time.Second
+
+type Inspector struct {
+	timeout time.Duration
+}
+
+func NewInspector() *Inspector {
+	return &Inspector{timeout: DefaultTimeout}
+}
+
+// State returns the state of the working tree containing dir. It returns nil
+// without an error when dir is not inside a git repository or git is not
+// installed.
+func (i *Inspector) State(ctx context.Context, dir string) (*domain.GitState, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, nil
+	}
+
+	root, err := i.run(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		// Not a repository, or not one git can read
+		return nil, nil
+	}
+	state := &domain.GitState{RepoRoot: root}
+
+	// Branch and HEAD from the porcelain header; the OID is "(initial)"
+	// before the first commit and the head "(detached)" without a branch
+	status, err := i.run(ctx, root, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git status of %s: %w", root, err)
+	}
+	for _, line := range strings.Split(status, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.oid "):
+			if oid := strings.TrimPrefix(line, "# branch.oid "); oid != "(initial)" {
+				state.Commit = oid
+			}
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				state.Branch = head
+			}
+		case line != "" && !strings.HasPrefix(line, "#"):
+			state.Dirty = true
+		}
+	}
+
+	// A repository without an origin remote is fine
+	if remote, err := i.run(ctx, root, "remote", "get-url", "origin"); err == nil {
+		state.RemoteURL = redactRemote(remote)
+	}
+	return state, nil
+}
+
+// DiffSince returns the lines added and removed per file between commit and
+// the working tree of the repository at root, by absolute path. Binary files
+// and untracked files are left out.
+func (i *Inspector) DiffSince(ctx context.Context, root, commit string) ([]*domain.SessionFileChange, error) {
+	out, err := i.run(ctx, root, "diff", "--numstat", "-z", "--no-renames", commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s since %s: %w", root, commit, err)
+	}
+
+	var changes []*domain.SessionFileChange
+	for _, record := range strings.Split(out, "\x00") {
+		// added<TAB>removed<TAB>path, with "-" counts for binary files
+		fields := strings.SplitN(record, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		added, errAdded := strconv.ParseInt(fields[0], 10, 64)
+		removed, errRemoved := strconv.ParseInt(fields[1], 10, 64)
+		if errAdded != nil || errRemoved != nil {
+			continue
+		}
+		changes = append(changes, &domain.SessionFileChange{
+			FilePath:     filepath.Join(root, filepath.FromSlash(fields[2])),
+			LinesAdded:   added,
+			LinesRemoved: removed,
+		})
+	}
+	return changes, nil
+}
+
+// Untracked returns the absolute paths of the files in the repository at
+// root that are neither tracked nor ignored.
+func (i *Inspector) Untracked(ctx context.Context, root string) ([]string, error) {
+	out, err := i.run(ctx, root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files of %s: %w", root, err)
+	}
+	var paths []string
+	for _, path := range strings.Split(out, "\x00") {
+		if path != "" {
+			paths = append(paths, filepath.Join(root, filepath.FromSlash(path)))
+		}
+	}
+	return paths, nil
+}
+
+// Commits returns the commits reachable from to but not from from, or from
+// to alone when from is empty, committed between since and until, newest
+// first. Merge commits count no changes.
+func (i *Inspector) Commits(ctx context.Context, root, from, to string, since, until time.Time) ([]*domain.SessionCommit, error) {
+	rev := to
+	if from != "" {
+		rev = from + ".." + to
+	}
+	out, err := i.run(ctx, root, "log", "--no-color", "--shortstat",
+		"--format=%x1e%H%x1f%aN%x1f%cI%x1f%B%x1f",
+		"--since="+since.UTC().Format(time.RFC3339),
+		"--until="+until.UTC().Format(time.RFC3339),
+		rev, "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits of %s in %s: %w", root, rev, err)
+	}
+
+	var commits []*domain.SessionCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		// hash, author, commit date, message, then the --shortstat line
+		fields := strings.SplitN(record, "\x1f", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		committedAt, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date of commit %s: %w", fields[0], err)
+		}
+		commit := &domain.SessionCommit{
+			Hash:        fields[0],
+			Author:      fields[1],
+			CommittedAt: committedAt.UTC(),
+			Message:     strings.TrimSpace(fields[3]),
+		}
+		commit.FilesChanged = shortStat(fields[4], filesChangedPattern)
+		commit.LinesAdded = shortStat(fields[4], insertionsPattern)
+		commit.LinesRemoved = shortStat(fields[4], deletionsPattern)
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// Counts in a --shortstat line such as
+// " 2 files changed, 3 insertions(+), 1 deletion(-)".
+var (
+	filesChangedPattern = regexp.MustCompile(`(\d+) files? changed`)
+	insertionsPattern   = regexp.MustCompile(`(\d+) insertions?\(\+\)`)
+	deletionsPattern    = regexp.MustCompile(`(\d+) deletions?\(-\)`)
+)
+
+func shortStat(stat string, pattern *regexp.Regexp) int64 {
+	m := pattern.FindStringSubmatch(stat)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.ParseInt(m[1], 10, 64)
+	return n
+}
+
+// run runs git in dir and returns its trimmed output.
+func (i *Inspector) run(ctx context.Context, dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"--no-optional-locks", "-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// redactRemote removes credentials from an HTTP remote URL, such as a token
+// embedded for pushing. SSH remotes, whose user is part of the address, are
+// left as they are.
+func redactRemote(remote string) string {
+	u, err := url.Parse(remote)
+	if err != nil || u.User == nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return remote
+	}
+	u.User = nil
+	return u.String()
+}
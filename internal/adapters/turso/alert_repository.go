@@ -0,0 +1,59 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type AlertRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewAlertRepository(db *sql.DB) *AlertRepository {
+	return &AlertRepository{queries: sqlc.New(db)}
+}
+
+// Claim records the firing unless the rule already fired for the same key.
+// It reports whether the firing is new and should be delivered.
+func (r *AlertRepository) Claim(ctx context.Context, firing *domain.AlertFiring) (bool, error) {
+	n, err := r.queries.ClaimAlertFiring(ctx, sqlc.ClaimAlertFiringParams{
+		Rule:      firing.Rule,
+		AlertKey:  firing.Key,
+		Scope:     string(firing.Scope),
+		Value:     firing.Value,
+		Threshold: firing.Threshold,
+		Message:   firing.Message,
+		FiredAt:   firing.FiredAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to record alert: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (r *AlertRepository) ListRecent(ctx context.Context, limit int) ([]*domain.AlertFiring, error) {
+	rows, err := r.queries.ListAlertFirings(ctx, int64(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	firings := make([]*domain.AlertFiring, len(rows))
+	for i, row := range rows {
+		firedAt, _ := time.Parse(time.RFC3339, row.FiredAt)
+		firings[i] = &domain.AlertFiring{
+			Rule:      row.Rule,
+			Scope:     domain.AlertScope(row.Scope),
+			Key:       row.AlertKey,
+			Value:     row.Value,
+			Threshold: row.Threshold,
+			Message:   row.Message,
+			FiredAt:   firedAt,
+		}
+	}
+	return firings, nil
+}
@@ -0,0 +1,62 @@
+package turso_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+func TestAlertRepository_ClaimAndList(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := turso.NewAlertRepository(db)
+
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)
+	firing := &domain.AlertFiring{
+		Rule:      "daily-80",
+		Scope:     domain.AlertScopeDaily,
+		Key:       "daily 2026-03-04",
+		Value:     8.5,
+		Threshold: 8,
+		Message:   "daily-80: daily.cost = 8.50 > budget * 0.8 = 8 (daily 2026-03-04)",
+		FiredAt:   now,
+	}
+
+	claimed, err := repo.Claim(ctx, firing)
+	if err != nil || !claimed {
+		t.Fatalf("expected first claim to succeed, got %v, %v", claimed, err)
+	}
+
+	// Same rule and key again: already fired
+	repeat := *firing
+	repeat.FiredAt = now.Add(time.Hour)
+	claimed, err = repo.Claim(ctx, &repeat)
+	if err != nil || claimed {
+		t.Fatalf("expected repeated claim to be rejected, got %v, %v", claimed, err)
+	}
+
+	// A new period fires again
+	next := *firing
+	next.Key = "daily 2026-03-05"
+	next.FiredAt = now.Add(24 * time.Hour)
+	if claimed, err = repo.Claim(ctx, &next); err != nil || !claimed {
+		t.Fatalf("expected claim for a new key to succeed, got %v, %v", claimed, err)
+	}
+
+	firings, err := repo.ListRecent(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListRecent failed: %v", err)
+	}
+	if len(firings) != 2 {
+		t.Fatalf("expected 2 firings, got %d", len(firings))
+	}
+	if firings[0].Key != "daily 2026-03-05" || !firings[1].FiredAt.Equal(now) {
+		t.Errorf("expected newest first with the original fire time, got %+v, %+v", firings[0], firings[1])
+	}
+	if firings[1].Scope != domain.AlertScopeDaily || firings[1].Message != firing.Message {
+		t.Errorf("unexpected firing: %+v", firings[1])
+	}
+}
@@ -0,0 +1,65 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type AnomalyRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewAnomalyRepository(db *sql.DB) *AnomalyRepository {
+	return &AnomalyRepository{queries: sqlc.New(db)}
+}
+
+// Set stores the anomaly, replacing any earlier flag for the same session.
+func (r *AnomalyRepository) Set(ctx context.Context, anomaly *domain.SessionAnomaly) error {
+	reasons, err := json.Marshal(anomaly.Reasons)
+	if err != nil {
+		return fmt.Errorf("failed to encode anomaly reasons: %w", err)
+	}
+	err = r.queries.UpsertSessionAnomaly(ctx, sqlc.UpsertSessionAnomalyParams{
+		SessionID:  anomaly.SessionID,
+		Score:      anomaly.Score,
+		Reasons:    string(reasons),
+		DetectedAt: anomaly.DetectedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set session anomaly: %w", err)
+	}
+	return nil
+}
+
+func (r *AnomalyRepository) GetBySessionID(ctx context.Context, sessionID string) (*domain.SessionAnomaly, error) {
+	row, err := r.queries.GetSessionAnomaly(ctx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session anomaly: %w", err)
+	}
+	detectedAt, _ := time.Parse(time.RFC3339, row.DetectedAt)
+	anomaly := &domain.SessionAnomaly{
+		SessionID:  row.SessionID,
+		Score:      row.Score,
+		DetectedAt: detectedAt,
+	}
+	if err := json.Unmarshal([]byte(row.Reasons), &anomaly.Reasons); err != nil {
+		return nil, fmt.Errorf("failed to decode anomaly reasons: %w", err)
+	}
+	return anomaly, nil
+}
+
+func (r *AnomalyRepository) Clear(ctx context.Context, sessionID string) error {
+	if err := r.queries.DeleteSessionAnomaly(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to clear session anomaly: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package turso_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestAnomalyRepository_FlagAndList(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-anomaly", Path: "/anomaly", Name: "anomaly", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+
+	sessions := turso.NewSessionRepository(db)
+	for i := range 4 {
+		err := sessions.Create(ctx, &domain.Session{
+			ID:        fmt.Sprintf("sess-anomaly-%d", i),
+			ProjectID: "proj-anomaly",
+			Cwd:       "/anomaly",
+			CreatedAt: now.Add(time.Duration(i) * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+
+	stats := turso.NewStatsRepository(db)
+	baseline, err := stats.ListSamplesByProject(ctx, "proj-anomaly", "sess-anomaly-2", now.Add(2*time.Hour).Format(time.RFC3339), 10)
+	if err != nil {
+		t.Fatalf("ListSamplesByProject failed: %v", err)
+	}
+	if len(baseline) != 2 || baseline[0].SessionID != "sess-anomaly-1" || baseline[1].SessionID != "sess-anomaly-0" {
+		t.Errorf("expected earlier sessions newest first, got %+v", baseline)
+	}
+
+	repo := turso.NewAnomalyRepository(db)
+	anomaly := &domain.SessionAnomaly{
+		SessionID:  "sess-anomaly-3",
+		Score:      8.5,
+		Reasons:    []domain.AnomalyReason{{Metric: "cost", Value: 4, Median: 0.5, Score: 8.5}},
+		DetectedAt: now,
+	}
+	if err := repo.Set(ctx, anomaly); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	got, err := repo.GetBySessionID(ctx, "sess-anomaly-3")
+	if err != nil {
+		t.Fatalf("GetBySessionID failed: %v", err)
+	}
+	if got == nil || got.Score != 8.5 || len(got.Reasons) != 1 || got.Reasons[0].Metric != "cost" {
+		t.Errorf("unexpected anomaly: %+v", got)
+	}
+
+	projectID := "proj-anomaly"
+	items, err := sessions.ListWithMetrics(ctx, ports.ListSessionsOptions{ProjectID: &projectID, AnomalousOnly: true})
+	if err != nil {
+		t.Fatalf("ListWithMetrics failed: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "sess-anomaly-3" || items[0].Anomaly == nil {
+		t.Fatalf("expected only the flagged session, got %+v", items)
+	}
+	if items[0].Anomaly.Reasons[0].Value != 4 {
+		t.Errorf("expected decoded reasons, got %+v", items[0].Anomaly.Reasons)
+	}
+
+	all, err := sessions.ListWithMetrics(ctx, ports.ListSessionsOptions{})
+	if err != nil {
+		t.Fatalf("ListWithMetrics failed: %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("expected 4 sessions without the filter, got %d", len(all))
+	}
+
+	if err := repo.Clear(ctx, "sess-anomaly-3"); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	got, err = repo.GetBySessionID(ctx, "sess-anomaly-3")
+	if err != nil {
+		t.Fatalf("GetBySessionID failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected anomaly cleared, got %+v", got)
+	}
+}
@@ -0,0 +1,110 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+// billingDateLayout is the format of billing_usage.usage_date.
+const billingDateLayout = "2006-01-02"
+
+type BillingRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewBillingRepository(db *sql.DB) *BillingRepository {
+	return &BillingRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// SetBatch stores the usage, replacing any usage billed for the same day,
+// model, workspace and API key.
+func (r *BillingRepository) SetBatch(ctx context.Context, usage []domain.BillingUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := r.queries.WithTx(tx)
+	for _, u := range usage {
+		err := qtx.UpsertBillingUsage(ctx, sqlc.UpsertBillingUsageParams{
+			UsageDate:         u.Date.UTC().Format(billingDateLayout),
+			Model:             u.Model,
+			Workspace:         u.Workspace,
+			ApiKey:            u.APIKey,
+			TokenInput:        u.Tokens.Input,
+			TokenOutput:       u.Tokens.Output,
+			TokenCacheRead:    u.Tokens.CacheRead,
+			TokenCacheWrite:   u.Tokens.CacheWrite,
+			TokenCacheWrite1h: u.Tokens.CacheWrite1h,
+			CostUsd:           util.NullFloat64(u.CostUsd),
+			ImportedAt:        u.ImportedAt.Format(time.RFC3339),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set billing usage for %s on %s: %w", u.Model, u.Date.Format(billingDateLayout), err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListSince returns the usage billed from the day of the given time, oldest
+// first.
+func (r *BillingRepository) ListSince(ctx context.Context, since string) ([]domain.BillingUsage, error) {
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		since = t.UTC().Format(billingDateLayout)
+	}
+	rows, err := r.queries.ListBillingUsageSince(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list billing usage: %w", err)
+	}
+
+	usage := make([]domain.BillingUsage, len(rows))
+	for i, row := range rows {
+		date := parseBillingDate(row.UsageDate)
+		importedAt, _ := time.Parse(time.RFC3339, row.ImportedAt)
+		var cost *float64
+		if row.CostUsd.Valid {
+			cost = &row.CostUsd.Float64
+		}
+		usage[i] = domain.BillingUsage{
+			Date:      date,
+			Model:     row.Model,
+			Workspace: row.Workspace,
+			APIKey:    row.ApiKey,
+			Tokens: domain.TokenUsage{
+				Input:        row.TokenInput,
+				Output:       row.TokenOutput,
+				CacheRead:    row.TokenCacheRead,
+				CacheWrite:   row.TokenCacheWrite,
+				CacheWrite1h: row.TokenCacheWrite1h,
+			},
+			CostUsd:    cost,
+			ImportedAt: importedAt,
+		}
+	}
+	return usage, nil
+}
+
+// parseBillingDate parses a usage_date, which the driver may hand back as a
+// timestamp.
+func parseBillingDate(s string) time.Time {
+	if t, err := time.Parse(billingDateLayout, s); err == nil {
+		return t
+	}
+	t, _ := time.Parse(time.RFC3339, s)
+	return t.UTC()
+}
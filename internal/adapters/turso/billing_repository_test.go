@@ -0,0 +1,103 @@
+package turso_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestBillingRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := turso.NewBillingRepository(db)
+
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	cost := 4.5
+	err := repo.SetBatch(ctx, []domain.BillingUsage{
+		{Date: day, Model: "opus", Workspace: "Default", APIKey: "dev", Tokens: domain.TokenUsage{Input: 10, CacheWrite: 5, CacheWrite1h: 2}, CostUsd: &cost, ImportedAt: day},
+		{Date: day.AddDate(0, 0, -1), Model: "opus", ImportedAt: day},
+	})
+	if err != nil {
+		t.Fatalf("SetBatch failed: %v", err)
+	}
+
+	// Re-importing a row replaces it
+	cost = 6
+	if err := repo.SetBatch(ctx, []domain.BillingUsage{
+		{Date: day, Model: "opus", Workspace: "Default", APIKey: "dev", Tokens: domain.TokenUsage{Input: 20}, CostUsd: &cost, ImportedAt: day},
+	}); err != nil {
+		t.Fatalf("SetBatch failed: %v", err)
+	}
+
+	usage, err := repo.ListSince(ctx, "2026-03-02T00:00:00Z")
+	if err != nil {
+		t.Fatalf("ListSince failed: %v", err)
+	}
+	if len(usage) != 1 {
+		t.Fatalf("expected 1 row since March 2, got %+v", usage)
+	}
+	u := usage[0]
+	if !u.Date.Equal(day) || u.Workspace != "Default" || u.APIKey != "dev" || u.Tokens.Input != 20 || u.Tokens.CacheWrite != 0 || u.CostUsd == nil || *u.CostUsd != 6 {
+		t.Errorf("unexpected usage: %+v", u)
+	}
+
+	all, err := repo.ListSince(ctx, "2026-03-01")
+	if err != nil || len(all) != 2 || all[0].CostUsd != nil {
+		t.Errorf("expected both days, the first without cost, got %+v (%v)", all, err)
+	}
+}
+
+func TestStatsRepository_ListDailyUsageByModel(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-bill", Path: "/bill", Name: "bill", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	if err := turso.NewSessionRepository(db).Create(ctx, &domain.Session{
+		ID: "sess-bill", ProjectID: "proj-bill", Cwd: "/bill", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	// Session metrics include the sub-agent's tokens and cost
+	if err := queries.CreateSessionMetrics(ctx, sqlc.CreateSessionMetricsParams{
+		SessionID:       "sess-bill",
+		ModelID:         sql.NullString{String: "opus", Valid: true},
+		TokenInput:      140,
+		TokenOutput:     50,
+		CostEstimateUsd: sql.NullFloat64{Float64: 2.25, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create metrics: %v", err)
+	}
+	model := "haiku"
+	cost := 0.25
+	if err := turso.NewSessionSubagentRepository(db).CreateBatch(ctx, []*domain.SessionSubagent{
+		{SessionID: "sess-bill", AgentType: "Explore", AgentKind: "task", Model: &model, TokenInput: 40, CostEstimateUSD: &cost},
+	}); err != nil {
+		t.Fatalf("failed to create sub-agent: %v", err)
+	}
+
+	usage, err := turso.NewStatsRepository(db).ListDailyUsageByModel(ctx, "2026-03-01T00:00:00Z", "2026-03-03T00:00:00Z")
+	if err != nil {
+		t.Fatalf("ListDailyUsageByModel failed: %v", err)
+	}
+	if len(usage) != 2 {
+		t.Fatalf("expected the session and its sub-agent by model, got %+v", usage)
+	}
+	if usage[0].Model != "haiku" || usage[0].Tokens.Input != 40 || usage[0].CostUsd != 0.25 || !usage[0].Date.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected sub-agent usage: %+v", usage[0])
+	}
+	// The sub-agent is not counted again under the session's model
+	if usage[1].Model != "opus" || usage[1].Tokens.Total() != 150 || usage[1].CostUsd != 2 {
+		t.Errorf("unexpected session usage: %+v", usage[1])
+	}
+}
@@ -0,0 +1,77 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type BudgetRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewBudgetRepository(db *sql.DB) *BudgetRepository {
+	return &BudgetRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// Set creates the budget, or replaces the amount and hardness of the existing
+// budget with the same period and scope.
+func (r *BudgetRepository) Set(ctx context.Context, budget *domain.Budget) error {
+	budget.ID = domain.BudgetID(budget.Period, budget.ProjectID, budget.ExperimentID)
+	err := r.queries.UpsertBudget(ctx, sqlc.UpsertBudgetParams{
+		ID:           budget.ID,
+		Period:       string(budget.Period),
+		AmountUsd:    budget.AmountUsd,
+		ProjectID:    util.NullStringPtr(budget.ProjectID),
+		ExperimentID: util.NullStringPtr(budget.ExperimentID),
+		IsHard:       util.BoolToInt64(budget.Hard),
+		CreatedAt:    budget.CreatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set budget: %w", err)
+	}
+	return nil
+}
+
+func (r *BudgetRepository) List(ctx context.Context) ([]*domain.Budget, error) {
+	rows, err := r.queries.ListBudgets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	budgets := make([]*domain.Budget, len(rows))
+	for i, row := range rows {
+		budgets[i] = budgetFromRow(row)
+	}
+	return budgets, nil
+}
+
+func (r *BudgetRepository) Delete(ctx context.Context, id string) (bool, error) {
+	n, err := r.queries.DeleteBudget(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete budget: %w", err)
+	}
+	return n > 0, nil
+}
+
+func budgetFromRow(row sqlc.Budget) *domain.Budget {
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	return &domain.Budget{
+		ID:           row.ID,
+		Period:       domain.BudgetPeriod(row.Period),
+		AmountUsd:    row.AmountUsd,
+		ProjectID:    util.NullStringToPtr(row.ProjectID),
+		ExperimentID: util.NullStringToPtr(row.ExperimentID),
+		Hard:         row.IsHard != 0,
+		CreatedAt:    createdAt,
+	}
+}
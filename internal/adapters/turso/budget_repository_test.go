@@ -0,0 +1,82 @@
+package turso_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestBudgetRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	queries := sqlc.New(db)
+	err := queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
+		ID:        "exp-1",
+		Name:      "test-experiment",
+		StartedAt: now.Format(time.RFC3339),
+		IsActive:  1,
+		CreatedAt: now.Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("failed to seed experiment: %v", err)
+	}
+
+	repo := turso.NewBudgetRepository(db)
+	expID := "exp-1"
+	for _, b := range []*domain.Budget{
+		{Period: domain.BudgetDaily, AmountUsd: 5, CreatedAt: now},
+		{Period: domain.BudgetWeekly, AmountUsd: 20, ExperimentID: &expID, Hard: true, CreatedAt: now},
+	} {
+		if err := repo.Set(ctx, b); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	// Setting the same period and scope again replaces the budget
+	if err := repo.Set(ctx, &domain.Budget{Period: domain.BudgetDaily, AmountUsd: 8, Hard: true, CreatedAt: now}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	budgets, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(budgets) != 2 {
+		t.Fatalf("expected 2 budgets, got %d", len(budgets))
+	}
+	daily := budgets[0]
+	if daily.ID != "daily" || daily.AmountUsd != 8 || !daily.Hard || daily.Scope() != domain.BudgetScopeGlobal {
+		t.Errorf("unexpected daily budget: %+v", daily)
+	}
+	weekly := budgets[1]
+	if weekly.Period != domain.BudgetWeekly || weekly.ExperimentID == nil || *weekly.ExperimentID != expID {
+		t.Errorf("unexpected weekly budget: %+v", weekly)
+	}
+
+	deleted, err := repo.Delete(ctx, "daily")
+	if err != nil || !deleted {
+		t.Fatalf("Delete = %v, %v; want true, nil", deleted, err)
+	}
+	deleted, err = repo.Delete(ctx, "daily")
+	if err != nil || deleted {
+		t.Fatalf("second Delete = %v, %v; want false, nil", deleted, err)
+	}
+
+	// Scoped budgets are removed with their experiment
+	if err := queries.DeleteExperiment(ctx, expID); err != nil {
+		t.Fatalf("DeleteExperiment failed: %v", err)
+	}
+	budgets, err = repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(budgets) != 0 {
+		t.Errorf("expected no budgets left, got %d", len(budgets))
+	}
+}
@@ -0,0 +1,158 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type CommitRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewCommitRepository(db *sql.DB) *CommitRepository {
+	return &CommitRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *CommitRepository) ReplaceForSession(ctx context.Context, sessionID string, commits []*domain.SessionCommit) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.DeleteSessionCommitsBySession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to delete session commits: %w", err)
+	}
+	for _, c := range commits {
+		err := qtx.CreateSessionCommit(ctx, sqlc.CreateSessionCommitParams{
+			SessionID:    sessionID,
+			CommitHash:   c.Hash,
+			Message:      c.Message,
+			Author:       util.NullString(c.Author),
+			CommittedAt:  c.CommittedAt.UTC().Format(time.RFC3339),
+			FilesChanged: c.FilesChanged,
+			LinesAdded:   c.LinesAdded,
+			LinesRemoved: c.LinesRemoved,
+			DetectedBy:   c.DetectedBy,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create session commit %s: %w", c.Hash, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *CommitRepository) ListBySessionID(ctx context.Context, sessionID string) ([]*domain.SessionCommit, error) {
+	rows, err := r.queries.ListSessionCommitsBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session commits: %w", err)
+	}
+
+	commits := make([]*domain.SessionCommit, len(rows))
+	for i, row := range rows {
+		committedAt, _ := time.Parse(time.RFC3339, row.CommittedAt)
+		commits[i] = &domain.SessionCommit{
+			SessionID:    row.SessionID,
+			Hash:         row.CommitHash,
+			Message:      row.Message,
+			Author:       row.Author.String,
+			CommittedAt:  committedAt,
+			FilesChanged: row.FilesChanged,
+			LinesAdded:   row.LinesAdded,
+			LinesRemoved: row.LinesRemoved,
+			DetectedBy:   row.DetectedBy,
+		}
+	}
+	return commits, nil
+}
+
+func (r *CommitRepository) List(ctx context.Context, opts ports.ListCommitsOptions) ([]*domain.CommitListItem, error) {
+	limit := int64(opts.Limit)
+	if limit == 0 {
+		limit = 50
+	}
+
+	var rows []sqlc.ListCommitsWithCostRow
+	if opts.ExperimentID != nil {
+		expRows, err := r.queries.ListCommitsWithCostByExperiment(ctx, sqlc.ListCommitsWithCostByExperimentParams{
+			ExperimentID: util.NullStringPtr(opts.ExperimentID),
+			CommittedAt:  opts.Since,
+			Limit:        limit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits: %w", err)
+		}
+		for _, row := range expRows {
+			rows = append(rows, sqlc.ListCommitsWithCostRow(row))
+		}
+	} else {
+		var err error
+		rows, err = r.queries.ListCommitsWithCost(ctx, sqlc.ListCommitsWithCostParams{
+			CommittedAt: opts.Since,
+			Limit:       limit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits: %w", err)
+		}
+	}
+
+	items := make([]*domain.CommitListItem, len(rows))
+	for i, row := range rows {
+		committedAt, _ := time.Parse(time.RFC3339, row.CommittedAt)
+		item := &domain.CommitListItem{
+			SessionCommit: domain.SessionCommit{
+				SessionID:    row.SessionID,
+				Hash:         row.CommitHash,
+				Message:      row.Message,
+				Author:       row.Author.String,
+				CommittedAt:  committedAt,
+				FilesChanged: row.FilesChanged,
+				LinesAdded:   row.LinesAdded,
+				LinesRemoved: row.LinesRemoved,
+				DetectedBy:   row.DetectedBy,
+			},
+			ExperimentID:   util.NullStringToPtr(row.ExperimentID),
+			ExperimentName: util.NullStringToPtr(row.ExperimentName),
+			SessionCommits: row.SessionCommitCount,
+		}
+		if row.CostEstimateUsd.Valid {
+			item.SessionCostUsd = &row.CostEstimateUsd.Float64
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+func (r *CommitRepository) ListStatsByExperiment(ctx context.Context) ([]domain.ExperimentCommitStats, error) {
+	rows, err := r.queries.ListCommitStatsByExperiment(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit stats by experiment: %w", err)
+	}
+
+	stats := make([]domain.ExperimentCommitStats, len(rows))
+	for i, row := range rows {
+		stats[i] = domain.ExperimentCommitStats{
+			ExperimentID:        row.ID,
+			ExperimentName:      row.Name,
+			Sessions:            row.SessionCount,
+			SessionsWithCommits: util.ToInt64(row.SessionsWithCommits),
+			Commits:             util.ToInt64(row.CommitCount),
+			LinesAdded:          util.ToInt64(row.LinesAdded),
+			LinesRemoved:        util.ToInt64(row.LinesRemoved),
+			CostUsd:             util.ToFloat64(row.TotalCostUsd),
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,97 @@
+package turso_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestCommitRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-commits", Path: "/commits", Name: "commits", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	if err := queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
+		ID: "exp-commits", Name: "commits", StartedAt: now.Format(time.RFC3339), CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed experiment: %v", err)
+	}
+	expID := "exp-commits"
+	sessionRepo := turso.NewSessionRepository(db)
+	for i, id := range []string{"sess-commits-1", "sess-commits-2", "sess-commits-3"} {
+		if err := sessionRepo.Create(ctx, &domain.Session{
+			ID: id, ProjectID: "proj-commits", ExperimentID: &expID, Cwd: "/commits", CreatedAt: now,
+		}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		if err := queries.CreateSessionMetrics(ctx, sqlc.CreateSessionMetricsParams{
+			SessionID:       id,
+			CostEstimateUsd: sql.NullFloat64{Float64: float64(i + 1), Valid: true},
+		}); err != nil {
+			t.Fatalf("failed to create metrics: %v", err)
+		}
+	}
+
+	repo := turso.NewCommitRepository(db)
+	commit := func(hash string, minutes int, added int64) *domain.SessionCommit {
+		return &domain.SessionCommit{
+			Hash: hash, Message: "Commit " + hash, Author: "dev", CommittedAt: now.Add(time.Duration(minutes) * time.Minute),
+			FilesChanged: 1, LinesAdded: added, LinesRemoved: 1, DetectedBy: domain.CommitDetectedByHead,
+		}
+	}
+	if err := repo.ReplaceForSession(ctx, "sess-commits-1", []*domain.SessionCommit{commit("aaa", 1, 5), commit("old", 2, 1)}); err != nil {
+		t.Fatalf("ReplaceForSession failed: %v", err)
+	}
+	// Re-recording drops the commit that was amended away
+	if err := repo.ReplaceForSession(ctx, "sess-commits-1", []*domain.SessionCommit{commit("aaa", 1, 5), commit("bbb", 3, 10)}); err != nil {
+		t.Fatalf("ReplaceForSession failed: %v", err)
+	}
+
+	// An excluded session's commits do not count toward its experiment
+	if err := repo.ReplaceForSession(ctx, "sess-commits-3", []*domain.SessionCommit{commit("ccc", 0, 20)}); err != nil {
+		t.Fatalf("ReplaceForSession failed: %v", err)
+	}
+	if err := sessionRepo.SetExcluded(ctx, []string{"sess-commits-3"}, true, nil); err != nil {
+		t.Fatalf("SetExcluded failed: %v", err)
+	}
+
+	commits, err := repo.ListBySessionID(ctx, "sess-commits-1")
+	if err != nil || len(commits) != 2 {
+		t.Fatalf("expected 2 commits, got %+v (%v)", commits, err)
+	}
+	if c := commits[0]; c.Hash != "aaa" || c.Author != "dev" || !c.CommittedAt.Equal(now.Add(time.Minute)) || c.LinesAdded != 5 || c.DetectedBy != domain.CommitDetectedByHead {
+		t.Errorf("unexpected commit: %+v", c)
+	}
+
+	items, err := repo.List(ctx, ports.ListCommitsOptions{ExperimentID: &expID, Since: now.Format(time.RFC3339)})
+	if err != nil || len(items) != 2 {
+		t.Fatalf("expected 2 commits in the experiment, got %+v (%v)", items, err)
+	}
+	if it := items[0]; it.Hash != "bbb" || it.ExperimentName == nil || *it.ExperimentName != "commits" || it.CostUsd() == nil || *it.CostUsd() != 0.5 {
+		t.Errorf("expected the newest commit with half the session cost, got %+v", it)
+	}
+	if items, err := repo.List(ctx, ports.ListCommitsOptions{Since: now.Add(2 * time.Minute).Format(time.RFC3339)}); err != nil || len(items) != 1 {
+		t.Errorf("expected 1 commit since the cutoff, got %+v (%v)", items, err)
+	}
+
+	stats, err := repo.ListStatsByExperiment(ctx)
+	if err != nil || len(stats) != 1 {
+		t.Fatalf("expected stats for 1 experiment, got %+v (%v)", stats, err)
+	}
+	s := stats[0]
+	if s.Sessions != 2 || s.SessionsWithCommits != 1 || s.Commits != 2 || s.LinesAdded != 15 || s.CostUsd != 3 {
+		t.Errorf("unexpected experiment stats: %+v", s)
+	}
+}
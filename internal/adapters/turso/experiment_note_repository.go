@@ -0,0 +1,50 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type ExperimentNoteRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewExperimentNoteRepository(db *sql.DB) *ExperimentNoteRepository {
+	return &ExperimentNoteRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *ExperimentNoteRepository) Create(ctx context.Context, note *domain.ExperimentNote) error {
+	return r.queries.CreateExperimentNote(ctx, sqlc.CreateExperimentNoteParams{
+		ExperimentID: note.ExperimentID,
+		Content:      note.Content,
+		CreatedAt:    note.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+func (r *ExperimentNoteRepository) ListByExperimentID(ctx context.Context, experimentID string) ([]*domain.ExperimentNote, error) {
+	rows, err := r.queries.ListExperimentNotesByExperimentID(ctx, experimentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiment notes: %w", err)
+	}
+
+	notes := make([]*domain.ExperimentNote, len(rows))
+	for i, row := range rows {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		notes[i] = &domain.ExperimentNote{
+			ID:           row.ID,
+			ExperimentID: row.ExperimentID,
+			Content:      row.Content,
+			CreatedAt:    createdAt,
+		}
+	}
+	return notes, nil
+}
@@ -0,0 +1,68 @@
+package turso_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestExperimentNoteRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	err := queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
+		ID:        "exp-1",
+		Name:      "test-experiment",
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+		IsActive:  1,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("failed to seed experiment: %v", err)
+	}
+
+	repo := turso.NewExperimentNoteRepository(db)
+
+	first := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	second := first.Add(26 * time.Hour)
+	// Insert out of order to check entries come back chronologically
+	for _, n := range []*domain.ExperimentNote{
+		{ExperimentID: "exp-1", Content: "switched to plan mode", CreatedAt: second},
+		{ExperimentID: "exp-1", Content: "started with default settings", CreatedAt: first},
+	} {
+		if err := repo.Create(ctx, n); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	notes, err := repo.ListByExperimentID(ctx, "exp-1")
+	if err != nil {
+		t.Fatalf("ListByExperimentID failed: %v", err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].Content != "started with default settings" || !notes[0].CreatedAt.Equal(first) {
+		t.Errorf("unexpected first note: %+v", notes[0])
+	}
+	if notes[1].Content != "switched to plan mode" || !notes[1].CreatedAt.Equal(second) {
+		t.Errorf("unexpected second note: %+v", notes[1])
+	}
+
+	// Notes are removed with their experiment
+	if err := queries.DeleteExperiment(ctx, "exp-1"); err != nil {
+		t.Fatalf("DeleteExperiment failed: %v", err)
+	}
+	notes, err = repo.ListByExperimentID(ctx, "exp-1")
+	if err != nil {
+		t.Fatalf("ListByExperimentID failed: %v", err)
+	}
+	if len(notes) != 0 {
+		t.Errorf("expected notes to be deleted with experiment, got %d", len(notes))
+	}
+}
@@ -30,17 +30,19 @@ func (r *ExperimentRepository) Create(ctx context.Context, experiment *domain.Ex
 	}
 
 	return r.queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
-		ID:          experiment.ID,
-		Name:        experiment.Name,
-		Description: util.NullStringPtr(experiment.Description),
-		Hypothesis:  util.NullStringPtr(experiment.Hypothesis),
-		StartedAt:   experiment.StartedAt.Format(time.RFC3339),
-		EndedAt:     endedAt,
-		IsActive:    util.BoolToInt64(experiment.IsActive),
-		CreatedAt:   experiment.CreatedAt.Format(time.RFC3339),
-		ModelID:     util.NullStringPtr(experiment.ModelID),
-		PlanType:    util.NullStringPtr(experiment.PlanType),
-		Notes:       util.NullStringPtr(experiment.Notes),
+		ID:             experiment.ID,
+		Name:           experiment.Name,
+		Description:    util.NullStringPtr(experiment.Description),
+		Hypothesis:     util.NullStringPtr(experiment.Hypothesis),
+		StartedAt:      experiment.StartedAt.Format(time.RFC3339),
+		EndedAt:        endedAt,
+		IsActive:       util.BoolToInt64(experiment.IsActive),
+		CreatedAt:      experiment.CreatedAt.Format(time.RFC3339),
+		ModelID:        util.NullStringPtr(experiment.ModelID),
+		PlanType:       util.NullStringPtr(experiment.PlanType),
+		Notes:          util.NullStringPtr(experiment.Notes),
+		ScheduledStart: util.NullTimePtr(experiment.ScheduledStart),
+		ScheduledEnd:   util.NullTimePtr(experiment.ScheduledEnd),
 	})
 }
 
@@ -97,16 +99,27 @@ func (r *ExperimentRepository) Update(ctx context.Context, experiment *domain.Ex
 	}
 
 	return r.queries.UpdateExperiment(ctx, sqlc.UpdateExperimentParams{
-		Name:        experiment.Name,
-		Description: util.NullStringPtr(experiment.Description),
-		Hypothesis:  util.NullStringPtr(experiment.Hypothesis),
-		StartedAt:   experiment.StartedAt.Format(time.RFC3339),
-		EndedAt:     endedAt,
-		IsActive:    util.BoolToInt64(experiment.IsActive),
-		ModelID:     util.NullStringPtr(experiment.ModelID),
-		PlanType:    util.NullStringPtr(experiment.PlanType),
-		Notes:       util.NullStringPtr(experiment.Notes),
-		ID:          experiment.ID,
+		Name:           experiment.Name,
+		Description:    util.NullStringPtr(experiment.Description),
+		Hypothesis:     util.NullStringPtr(experiment.Hypothesis),
+		StartedAt:      experiment.StartedAt.Format(time.RFC3339),
+		EndedAt:        endedAt,
+		IsActive:       util.BoolToInt64(experiment.IsActive),
+		ModelID:        util.NullStringPtr(experiment.ModelID),
+		PlanType:       util.NullStringPtr(experiment.PlanType),
+		Notes:          util.NullStringPtr(experiment.Notes),
+		ScheduledStart: util.NullTimePtr(experiment.ScheduledStart),
+		ScheduledEnd:   util.NullTimePtr(experiment.ScheduledEnd),
+		ID:             experiment.ID,
+	})
+}
+
+func (r *ExperimentRepository) SetConclusion(ctx context.Context, id string, verdict, conclusion *string, concludedAt *time.Time) error {
+	return r.queries.SetExperimentConclusion(ctx, sqlc.SetExperimentConclusionParams{
+		Verdict:     util.NullStringPtr(verdict),
+		Conclusion:  util.NullStringPtr(conclusion),
+		ConcludedAt: util.NullTimePtr(concludedAt),
+		ID:          id,
 	})
 }
 
@@ -137,16 +150,21 @@ func experimentFromRow(row sqlc.Experiment) *domain.Experiment {
 	}
 
 	return &domain.Experiment{
-		ID:          row.ID,
-		Name:        row.Name,
-		Description: util.NullStringToPtr(row.Description),
-		Hypothesis:  util.NullStringToPtr(row.Hypothesis),
-		StartedAt:   startedAt,
-		EndedAt:     endedAt,
-		IsActive:    row.IsActive == 1,
-		CreatedAt:   createdAt,
-		ModelID:     util.NullStringToPtr(row.ModelID),
-		PlanType:    util.NullStringToPtr(row.PlanType),
-		Notes:       util.NullStringToPtr(row.Notes),
+		ID:             row.ID,
+		Name:           row.Name,
+		Description:    util.NullStringToPtr(row.Description),
+		Hypothesis:     util.NullStringToPtr(row.Hypothesis),
+		StartedAt:      startedAt,
+		EndedAt:        endedAt,
+		IsActive:       row.IsActive == 1,
+		CreatedAt:      createdAt,
+		ModelID:        util.NullStringToPtr(row.ModelID),
+		PlanType:       util.NullStringToPtr(row.PlanType),
+		Notes:          util.NullStringToPtr(row.Notes),
+		ScheduledStart: util.NullTimeToPtr(row.ScheduledStart),
+		ScheduledEnd:   util.NullTimeToPtr(row.ScheduledEnd),
+		Verdict:        util.NullStringToPtr(row.Verdict),
+		Conclusion:     util.NullStringToPtr(row.Conclusion),
+		ConcludedAt:    util.NullTimeToPtr(row.ConcludedAt),
 	}
 }
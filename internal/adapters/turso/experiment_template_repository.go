@@ -0,0 +1,99 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type ExperimentTemplateRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewExperimentTemplateRepository(db *sql.DB) *ExperimentTemplateRepository {
+	return &ExperimentTemplateRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *ExperimentTemplateRepository) Save(ctx context.Context, tmpl *domain.ExperimentTemplate) error {
+	vars := tmpl.Variables
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	encoded, err := json.Marshal(vars)
+	if err != nil {
+		return fmt.Errorf("failed to encode template variables: %w", err)
+	}
+
+	return r.queries.UpsertExperimentTemplate(ctx, sqlc.UpsertExperimentTemplateParams{
+		Name:        tmpl.Name,
+		Description: util.NullStringPtr(tmpl.Description),
+		Hypothesis:  util.NullStringPtr(tmpl.Hypothesis),
+		ModelID:     util.NullStringPtr(tmpl.ModelID),
+		PlanType:    util.NullStringPtr(tmpl.PlanType),
+		Notes:       util.NullStringPtr(tmpl.Notes),
+		Variables:   string(encoded),
+		CreatedAt:   tmpl.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+func (r *ExperimentTemplateRepository) GetByName(ctx context.Context, name string) (*domain.ExperimentTemplate, error) {
+	row, err := r.queries.GetExperimentTemplateByName(ctx, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get experiment template: %w", err)
+	}
+	return experimentTemplateFromRow(row)
+}
+
+func (r *ExperimentTemplateRepository) List(ctx context.Context) ([]*domain.ExperimentTemplate, error) {
+	rows, err := r.queries.ListExperimentTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiment templates: %w", err)
+	}
+
+	templates := make([]*domain.ExperimentTemplate, len(rows))
+	for i, row := range rows {
+		tmpl, err := experimentTemplateFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		templates[i] = tmpl
+	}
+	return templates, nil
+}
+
+func (r *ExperimentTemplateRepository) Delete(ctx context.Context, name string) error {
+	return r.queries.DeleteExperimentTemplate(ctx, name)
+}
+
+func experimentTemplateFromRow(row sqlc.ExperimentTemplate) (*domain.ExperimentTemplate, error) {
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+
+	vars := map[string]string{}
+	if err := json.Unmarshal([]byte(row.Variables), &vars); err != nil {
+		return nil, fmt.Errorf("failed to decode variables for template %q: %w", row.Name, err)
+	}
+
+	return &domain.ExperimentTemplate{
+		Name:        row.Name,
+		Description: util.NullStringToPtr(row.Description),
+		Hypothesis:  util.NullStringToPtr(row.Hypothesis),
+		ModelID:     util.NullStringToPtr(row.ModelID),
+		PlanType:    util.NullStringToPtr(row.PlanType),
+		Notes:       util.NullStringToPtr(row.Notes),
+		Variables:   vars,
+		CreatedAt:   createdAt,
+	}, nil
+}
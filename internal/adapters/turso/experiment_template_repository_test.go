@@ -0,0 +1,77 @@
+package turso_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+func TestExperimentTemplateRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	repo := turso.NewExperimentTemplateRepository(db)
+
+	model := "claude-opus-4-6"
+	tmpl := &domain.ExperimentTemplate{
+		Name:      "opus-max",
+		ModelID:   &model,
+		Variables: map[string]string{"effort": "high", "permission_mode": "plan"},
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := repo.Save(ctx, tmpl); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := repo.GetByName(ctx, "opus-max")
+	if err != nil {
+		t.Fatalf("GetByName failed: %v", err)
+	}
+	if got == nil {
+		t.Fatal("expected template, got nil")
+	}
+	if got.ModelID == nil || *got.ModelID != model {
+		t.Errorf("expected model %q, got %v", model, got.ModelID)
+	}
+	if len(got.Variables) != 2 || got.Variables["effort"] != "high" {
+		t.Errorf("expected variables to round-trip, got %v", got.Variables)
+	}
+
+	// Saving again replaces the template
+	plan := "max_20x"
+	tmpl.ModelID = nil
+	tmpl.PlanType = &plan
+	tmpl.Variables = nil
+	if err := repo.Save(ctx, tmpl); err != nil {
+		t.Fatalf("Save (replace) failed: %v", err)
+	}
+	got, err = repo.GetByName(ctx, "opus-max")
+	if err != nil {
+		t.Fatalf("GetByName failed: %v", err)
+	}
+	if got.ModelID != nil || got.PlanType == nil || *got.PlanType != plan || len(got.Variables) != 0 {
+		t.Errorf("expected replaced template, got %+v", got)
+	}
+
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected 1 template, got %d", len(list))
+	}
+
+	if err := repo.Delete(ctx, "opus-max"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	got, err = repo.GetByName(ctx, "opus-max")
+	if err != nil {
+		t.Fatalf("GetByName failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected nil after delete, got %+v", got)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	"github.com/emiliopalmerini/mclaude/internal/util"
 	"github.com/emiliopalmerini/mclaude/sqlc/generated"
 )
 
@@ -63,6 +65,19 @@ func (r *SessionMetricsRepository) Create(ctx context.Context, metrics *domain.S
 		OutputRate:            outputRate,
 		CacheReadRate:         cacheReadRate,
 		CacheWriteRate:        cacheWriteRate,
+		WebSearchRequests:     metrics.WebSearchRequests,
+		WebFetchRequests:      metrics.WebFetchRequests,
+		ServerToolCostUsd:     util.NullFloat64(metrics.ServerToolCostUSD),
+		TokenCacheWrite5m:     metrics.TokenCacheWrite5m,
+		TokenCacheWrite1h:     metrics.TokenCacheWrite1h,
+		CacheWrite1hRate:      util.NullFloat64(metrics.CacheWrite1hRate),
+		LinesAdded:            metrics.LinesAdded,
+		LinesRemoved:          metrics.LinesRemoved,
+		LinesSource:           util.NullString(metrics.LinesSource),
+		TestsPassed:           util.NullBoolPtr(metrics.Outcome.TestsPassed),
+		BuildPassed:           util.NullBoolPtr(metrics.Outcome.BuildPassed),
+		LintPassed:            util.NullBoolPtr(metrics.Outcome.LintPassed),
+		EndedGreen:            util.NullBoolPtr(metrics.Outcome.EndedGreen()),
 	})
 }
 
@@ -99,6 +114,16 @@ func (r *SessionMetricsRepository) GetBySessionID(ctx context.Context, sessionID
 		cacheWriteRate = &row.CacheWriteRate.Float64
 	}
 
+	var serverToolCost *float64
+	if row.ServerToolCostUsd.Valid {
+		serverToolCost = &row.ServerToolCostUsd.Float64
+	}
+
+	var cacheWrite1hRate *float64
+	if row.CacheWrite1hRate.Valid {
+		cacheWrite1hRate = &row.CacheWrite1hRate.Float64
+	}
+
 	return &domain.SessionMetrics{
 		SessionID:             row.SessionID,
 		ModelID:               modelID,
@@ -115,6 +140,20 @@ func (r *SessionMetricsRepository) GetBySessionID(ctx context.Context, sessionID
 		OutputRate:            outputRate,
 		CacheReadRate:         cacheReadRate,
 		CacheWriteRate:        cacheWriteRate,
+		WebSearchRequests:     row.WebSearchRequests,
+		WebFetchRequests:      row.WebFetchRequests,
+		ServerToolCostUSD:     serverToolCost,
+		TokenCacheWrite5m:     row.TokenCacheWrite5m,
+		TokenCacheWrite1h:     row.TokenCacheWrite1h,
+		CacheWrite1hRate:      cacheWrite1hRate,
+		LinesAdded:            row.LinesAdded,
+		LinesRemoved:          row.LinesRemoved,
+		LinesSource:           row.LinesSource.String,
+		Outcome: domain.SessionOutcome{
+			TestsPassed: util.NullInt64ToBoolPtr(row.TestsPassed),
+			BuildPassed: util.NullInt64ToBoolPtr(row.BuildPassed),
+			LintPassed:  util.NullInt64ToBoolPtr(row.LintPassed),
+		},
 	}, nil
 }
 
@@ -182,6 +221,89 @@ func (r *SessionToolRepository) ListBySessionID(ctx context.Context, sessionID s
 	return tools, nil
 }
 
+type SessionToolCallRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewSessionToolCallRepository(db *sql.DB) *SessionToolCallRepository {
+	return &SessionToolCallRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *SessionToolCallRepository) CreateBatch(ctx context.Context, calls []*domain.SessionToolCall) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := r.queries.WithTx(tx)
+	for _, call := range calls {
+		var isError int64
+		if call.IsError {
+			isError = 1
+		}
+		err := qtx.CreateSessionToolCall(ctx, sqlc.CreateSessionToolCallParams{
+			SessionID:   call.SessionID,
+			Sequence:    call.Sequence,
+			ToolName:    call.ToolName,
+			Label:       call.Label,
+			IsError:     isError,
+			ResultBytes: call.ResultBytes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create session tool call: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListSequences returns the tool calls of the sessions created since a time,
+// grouped by session in the order they were made. Sessions excluded from
+// their experiment are left out when filtering by experiment.
+func (r *SessionToolCallRepository) ListSequences(ctx context.Context, opts ports.ListToolCallsOptions) ([]domain.ToolSequence, error) {
+	projectID, experimentID := "%", "%"
+	if opts.ProjectID != nil {
+		projectID = likePattern(*opts.ProjectID, false)
+	}
+	if opts.ExperimentID != nil {
+		experimentID = likePattern(*opts.ExperimentID, false)
+	}
+	rows, err := r.queries.ListSessionToolCalls(ctx, sqlc.ListSessionToolCallsParams{
+		Since:        opts.Since,
+		ProjectID:    projectID,
+		ExperimentID: experimentID,
+		SkipExcluded: util.BoolToInt64(opts.ExperimentID != nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session tool calls: %w", err)
+	}
+
+	var sequences []domain.ToolSequence
+	for _, row := range rows {
+		if len(sequences) == 0 || sequences[len(sequences)-1].SessionID != row.SessionID {
+			sequences = append(sequences, domain.ToolSequence{
+				SessionID:    row.SessionID,
+				ExperimentID: util.NullStringToPtr(row.ExperimentID),
+				Excluded:     row.IsExcluded == 1,
+			})
+		}
+		seq := &sequences[len(sequences)-1]
+		seq.Calls = append(seq.Calls, domain.SessionToolCall{
+			SessionID:   row.SessionID,
+			Sequence:    row.Sequence,
+			ToolName:    row.ToolName,
+			Label:       row.Label,
+			IsError:     row.IsError == 1,
+			ResultBytes: row.ResultBytes,
+		})
+	}
+	return sequences, nil
+}
+
 type SessionFileRepository struct {
 	db      *sql.DB
 	queries *sqlc.Queries
@@ -235,6 +357,96 @@ func (r *SessionFileRepository) ListBySessionID(ctx context.Context, sessionID s
 	return files, nil
 }
 
+// ListTouches returns the file operations of the sessions created since a
+// time, with the cost of each session. Sessions excluded from their
+// experiment are left out when filtering by experiment.
+func (r *SessionFileRepository) ListTouches(ctx context.Context, opts ports.ListFileTouchesOptions) ([]domain.FileTouch, error) {
+	projectID := "%"
+	if opts.ProjectID != nil {
+		projectID = likePattern(*opts.ProjectID, false)
+	}
+	experimentID := "%"
+	if opts.ExperimentID != nil {
+		experimentID = likePattern(*opts.ExperimentID, false)
+	}
+	rows, err := r.queries.ListFileTouches(ctx, sqlc.ListFileTouchesParams{
+		Since:        opts.Since,
+		ProjectID:    projectID,
+		ExperimentID: experimentID,
+		SkipExcluded: util.BoolToInt64(opts.ExperimentID != nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file touches: %w", err)
+	}
+
+	touches := make([]domain.FileTouch, len(rows))
+	for i, row := range rows {
+		touches[i] = domain.FileTouch{
+			SessionID:      row.SessionID,
+			ProjectID:      row.ProjectID,
+			ExperimentID:   util.NullStringToPtr(row.ExperimentID),
+			Excluded:       row.IsExcluded == 1,
+			FilePath:       row.FilePath,
+			Operation:      row.Operation,
+			Count:          row.OperationCount,
+			SessionCostUsd: nullFloat64ToPtr(row.CostEstimateUsd),
+		}
+	}
+	return touches, nil
+}
+
+type SessionFileChangeRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewSessionFileChangeRepository(db *sql.DB) *SessionFileChangeRepository {
+	return &SessionFileChangeRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *SessionFileChangeRepository) CreateBatch(ctx context.Context, changes []*domain.SessionFileChange) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := r.queries.WithTx(tx)
+	for _, change := range changes {
+		err := qtx.CreateSessionFileChange(ctx, sqlc.CreateSessionFileChangeParams{
+			SessionID:    change.SessionID,
+			FilePath:     change.FilePath,
+			LinesAdded:   change.LinesAdded,
+			LinesRemoved: change.LinesRemoved,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create session file change %s: %w", change.FilePath, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *SessionFileChangeRepository) ListBySessionID(ctx context.Context, sessionID string) ([]*domain.SessionFileChange, error) {
+	rows, err := r.queries.ListSessionFileChangesBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session file changes: %w", err)
+	}
+
+	changes := make([]*domain.SessionFileChange, len(rows))
+	for i, row := range rows {
+		changes[i] = &domain.SessionFileChange{
+			SessionID:    row.SessionID,
+			FilePath:     row.FilePath,
+			LinesAdded:   row.LinesAdded,
+			LinesRemoved: row.LinesRemoved,
+		}
+	}
+	return changes, nil
+}
+
 type SessionCommandRepository struct {
 	db      *sql.DB
 	queries *sqlc.Queries
@@ -310,6 +522,36 @@ func (r *SessionCommandRepository) ListBySessionID(ctx context.Context, sessionI
 	return commands, nil
 }
 
+// ListRuns returns the commands of the sessions created since a time,
+// grouped by session in the order they ran.
+func (r *SessionCommandRepository) ListRuns(ctx context.Context, opts ports.ListCommandRunsOptions) ([]domain.CommandRun, error) {
+	projectID := "%"
+	if opts.ProjectID != nil {
+		projectID = likePattern(*opts.ProjectID, false)
+	}
+	rows, err := r.queries.ListCommandRuns(ctx, sqlc.ListCommandRunsParams{
+		Since:     opts.Since,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list command runs: %w", err)
+	}
+
+	runs := make([]domain.CommandRun, len(rows))
+	for i, row := range rows {
+		runs[i] = domain.CommandRun{
+			SessionID: row.SessionID,
+			ProjectID: row.ProjectID,
+			Command:   row.Command,
+		}
+		if row.ExitCode.Valid {
+			exitCode := int(row.ExitCode.Int64)
+			runs[i].ExitCode = &exitCode
+		}
+	}
+	return runs, nil
+}
+
 type SessionSubagentRepository struct {
 	db      *sql.DB
 	queries *sqlc.Queries
@@ -352,19 +594,21 @@ func (r *SessionSubagentRepository) CreateBatch(ctx context.Context, subagents [
 		}
 
 		err := qtx.CreateSessionSubagent(ctx, sqlc.CreateSessionSubagentParams{
-			SessionID:       sa.SessionID,
-			AgentType:       sa.AgentType,
-			AgentKind:       sa.AgentKind,
-			Description:     description,
-			Model:           model,
-			TotalTokens:     sa.TotalTokens,
-			TokenInput:      sa.TokenInput,
-			TokenOutput:     sa.TokenOutput,
-			TokenCacheRead:  sa.TokenCacheRead,
-			TokenCacheWrite: sa.TokenCacheWrite,
-			TotalDurationMs: totalDurationMs,
-			ToolUseCount:    sa.ToolUseCount,
-			CostEstimateUsd: costEstimate,
+			SessionID:         sa.SessionID,
+			AgentType:         sa.AgentType,
+			AgentKind:         sa.AgentKind,
+			Description:       description,
+			Model:             model,
+			TotalTokens:       sa.TotalTokens,
+			TokenInput:        sa.TokenInput,
+			TokenOutput:       sa.TokenOutput,
+			TokenCacheRead:    sa.TokenCacheRead,
+			TokenCacheWrite:   sa.TokenCacheWrite,
+			TotalDurationMs:   totalDurationMs,
+			ToolUseCount:      sa.ToolUseCount,
+			CostEstimateUsd:   costEstimate,
+			TokenCacheWrite5m: sa.TokenCacheWrite5m,
+			TokenCacheWrite1h: sa.TokenCacheWrite1h,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create session subagent %s: %w", sa.AgentType, err)
@@ -402,20 +646,22 @@ func (r *SessionSubagentRepository) ListBySessionID(ctx context.Context, session
 		}
 
 		subagents[i] = &domain.SessionSubagent{
-			ID:              row.ID,
-			SessionID:       row.SessionID,
-			AgentType:       row.AgentType,
-			AgentKind:       row.AgentKind,
-			Description:     description,
-			Model:           model,
-			TotalTokens:     row.TotalTokens,
-			TokenInput:      row.TokenInput,
-			TokenOutput:     row.TokenOutput,
-			TokenCacheRead:  row.TokenCacheRead,
-			TokenCacheWrite: row.TokenCacheWrite,
-			TotalDurationMs: totalDurationMs,
-			ToolUseCount:    row.ToolUseCount,
-			CostEstimateUSD: costEstimate,
+			ID:                row.ID,
+			SessionID:         row.SessionID,
+			AgentType:         row.AgentType,
+			AgentKind:         row.AgentKind,
+			Description:       description,
+			Model:             model,
+			TotalTokens:       row.TotalTokens,
+			TokenInput:        row.TokenInput,
+			TokenOutput:       row.TokenOutput,
+			TokenCacheRead:    row.TokenCacheRead,
+			TokenCacheWrite:   row.TokenCacheWrite,
+			TotalDurationMs:   totalDurationMs,
+			ToolUseCount:      row.ToolUseCount,
+			CostEstimateUSD:   costEstimate,
+			TokenCacheWrite5m: row.TokenCacheWrite5m,
+			TokenCacheWrite1h: row.TokenCacheWrite1h,
 		}
 	}
 	return subagents, nil
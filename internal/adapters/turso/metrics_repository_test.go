@@ -0,0 +1,301 @@
+package turso_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestSessionFileChangeRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-lines", Path: "/lines", Name: "lines", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	if err := turso.NewSessionRepository(db).Create(ctx, &domain.Session{
+		ID: "sess-lines", ProjectID: "proj-lines", Cwd: "/lines", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	metricsRepo := turso.NewSessionMetricsRepository(db)
+	cost := 0.5
+	if err := metricsRepo.Create(ctx, &domain.SessionMetrics{
+		SessionID: "sess-lines", CostEstimateUSD: &cost,
+		LinesAdded: 40, LinesRemoved: 10, LinesSource: domain.LinesSourceGit,
+	}); err != nil {
+		t.Fatalf("failed to create metrics: %v", err)
+	}
+	metrics, err := metricsRepo.GetBySessionID(ctx, "sess-lines")
+	if err != nil || metrics.LinesAdded != 40 || metrics.LinesRemoved != 10 || metrics.LinesSource != domain.LinesSourceGit {
+		t.Errorf("unexpected line counts: %+v (%v)", metrics, err)
+	}
+
+	repo := turso.NewSessionFileChangeRepository(db)
+	if err := repo.CreateBatch(ctx, []*domain.SessionFileChange{
+		{SessionID: "sess-lines", FilePath: "/lines/a.go", LinesAdded: 5, LinesRemoved: 5},
+		{SessionID: "sess-lines", FilePath: "/lines/b.go", LinesAdded: 35, LinesRemoved: 5},
+	}); err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+	// Recording the session again replaces its counts
+	if err := repo.CreateBatch(ctx, []*domain.SessionFileChange{
+		{SessionID: "sess-lines", FilePath: "/lines/a.go", LinesAdded: 1},
+	}); err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+	changes, err := repo.ListBySessionID(ctx, "sess-lines")
+	if err != nil {
+		t.Fatalf("ListBySessionID failed: %v", err)
+	}
+	if len(changes) != 2 || changes[0].FilePath != "/lines/b.go" || changes[1].LinesAdded != 1 || changes[1].LinesRemoved != 0 {
+		t.Errorf("expected b.go first and a.go replaced, got %+v %+v", changes[0], changes[1])
+	}
+
+	stats, err := turso.NewStatsRepository(db).GetAggregateByProject(ctx, "proj-lines", "1970-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GetAggregateByProject failed: %v", err)
+	}
+	if stats.LinesChanged() != 50 || stats.CostPer100Lines() != 1 {
+		t.Errorf("expected 50 lines at $1 per 100, got %d at $%f", stats.LinesChanged(), stats.CostPer100Lines())
+	}
+}
+
+func TestSessionMetricsRepository_Outcome(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-outcome", Path: "/outcome", Name: "outcome", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+
+	passed, failed := true, false
+	outcomes := map[string]domain.SessionOutcome{
+		"sess-green":     {TestsPassed: &passed, BuildPassed: &passed},
+		"sess-red":       {TestsPassed: &passed, LintPassed: &failed},
+		"sess-unchecked": {},
+	}
+	sessionRepo := turso.NewSessionRepository(db)
+	metricsRepo := turso.NewSessionMetricsRepository(db)
+	for id, outcome := range outcomes {
+		if err := sessionRepo.Create(ctx, &domain.Session{ID: id, ProjectID: "proj-outcome", Cwd: "/outcome", CreatedAt: now}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		if err := metricsRepo.Create(ctx, &domain.SessionMetrics{SessionID: id, Outcome: outcome}); err != nil {
+			t.Fatalf("failed to create metrics: %v", err)
+		}
+	}
+
+	metrics, err := metricsRepo.GetBySessionID(ctx, "sess-red")
+	if err != nil || metrics.Outcome.Summary() != "tests passed, lint failed" {
+		t.Errorf("unexpected outcome: %+v (%v)", metrics, err)
+	}
+
+	projectID := "proj-outcome"
+	for outcome, want := range map[string]string{
+		domain.SessionOutcomeGreen:     "sess-green",
+		domain.SessionOutcomeRed:       "sess-red",
+		domain.SessionOutcomeUnchecked: "sess-unchecked",
+	} {
+		items, err := sessionRepo.ListWithMetrics(ctx, ports.ListSessionsOptions{ProjectID: &projectID, Outcome: outcome, Limit: 10})
+		if err != nil || len(items) != 1 || items[0].ID != want {
+			t.Errorf("expected only %s for outcome %s, got %+v (%v)", want, outcome, items, err)
+			continue
+		}
+		if got := domain.OutcomeOf(items[0].EndedGreen); got != outcome {
+			t.Errorf("expected %s to be listed as %s, got %s", want, outcome, got)
+		}
+	}
+
+	stats, err := turso.NewStatsRepository(db).GetAggregateByProject(ctx, projectID, "1970-01-01T00:00:00Z")
+	if err != nil || stats.SessionsWithChecks != 2 || stats.SessionsEndedGreen != 1 {
+		t.Errorf("expected 1 of 2 checked sessions to end green, got %+v (%v)", stats, err)
+	}
+}
+
+func TestSessionCommandRepository_ListRuns(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sessionRepo := turso.NewSessionRepository(db)
+	for i, id := range []string{"proj-runs-1", "proj-runs-2"} {
+		if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+			ID: id, Path: "/" + id, Name: id, CreatedAt: now.Format(time.RFC3339),
+		}); err != nil {
+			t.Fatalf("failed to seed project: %v", err)
+		}
+		// The second project's session is older than the cutoff
+		created := now.Add(time.Duration(-48*i) * time.Hour)
+		if err := sessionRepo.Create(ctx, &domain.Session{ID: "sess-" + id, ProjectID: id, Cwd: "/" + id, CreatedAt: created}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+
+	repo := turso.NewSessionCommandRepository(db)
+	failed, passed := 1, 0
+	for _, id := range []string{"proj-runs-1", "proj-runs-2"} {
+		if err := repo.CreateBatch(ctx, []*domain.SessionCommand{
+			{SessionID: "sess-" + id, Command: "go test ./...", ExitCode: &failed},
+			{SessionID: "sess-" + id, Command: "go test ./...", ExitCode: &passed},
+			{SessionID: "sess-" + id, Command: "ls"},
+		}); err != nil {
+			t.Fatalf("CreateBatch failed: %v", err)
+		}
+	}
+
+	since := now.Add(-time.Hour).Format(time.RFC3339)
+	runs, err := repo.ListRuns(ctx, ports.ListCommandRunsOptions{Since: since})
+	if err != nil || len(runs) != 3 {
+		t.Fatalf("expected the 3 commands of the recent session, got %+v (%v)", runs, err)
+	}
+	if r := runs[0]; r.ProjectID != "proj-runs-1" || r.Command != "go test ./..." || r.ExitCode == nil || *r.ExitCode != 1 {
+		t.Errorf("unexpected first run: %+v", r)
+	}
+	if runs[2].ExitCode != nil {
+		t.Errorf("expected an unknown exit code, got %d", *runs[2].ExitCode)
+	}
+
+	projectID := "proj-runs-2"
+	runs, err = repo.ListRuns(ctx, ports.ListCommandRunsOptions{Since: "1970-01-01T00:00:00Z", ProjectID: &projectID})
+	if err != nil || len(runs) != 3 || runs[0].SessionID != "sess-proj-runs-2" {
+		t.Errorf("expected the project's 3 commands, got %+v (%v)", runs, err)
+	}
+}
+
+func TestSessionFileRepository_ListTouches(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-touches", Path: "/touches", Name: "touches", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	if err := turso.NewSessionRepository(db).Create(ctx, &domain.Session{
+		ID: "sess-touches", ProjectID: "proj-touches", Cwd: "/touches", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	cost := 2.5
+	if err := turso.NewSessionMetricsRepository(db).Create(ctx, &domain.SessionMetrics{SessionID: "sess-touches", CostEstimateUSD: &cost}); err != nil {
+		t.Fatalf("failed to create metrics: %v", err)
+	}
+	repo := turso.NewSessionFileRepository(db)
+	if err := repo.CreateBatch(ctx, []*domain.SessionFile{
+		{SessionID: "sess-touches", FilePath: "/touches/a.go", Operation: "read", OperationCount: 3},
+		{SessionID: "sess-touches", FilePath: "/touches/a.go", Operation: "edit", OperationCount: 1},
+	}); err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+
+	projectID := "proj-touches"
+	touches, err := repo.ListTouches(ctx, ports.ListFileTouchesOptions{Since: now.Add(-time.Hour).Format(time.RFC3339), ProjectID: &projectID})
+	if err != nil || len(touches) != 2 {
+		t.Fatalf("expected 2 touches, got %+v (%v)", touches, err)
+	}
+	if tc := touches[0]; tc.FilePath != "/touches/a.go" || tc.ProjectID != "proj-touches" || tc.SessionCostUsd == nil || *tc.SessionCostUsd != 2.5 {
+		t.Errorf("unexpected touch: %+v", tc)
+	}
+	if touches, _ := repo.ListTouches(ctx, ports.ListFileTouchesOptions{Since: now.Add(time.Hour).Format(time.RFC3339)}); len(touches) != 0 {
+		t.Errorf("expected no touches after the session, got %+v", touches)
+	}
+	experimentID := "exp-none"
+	if touches, _ := repo.ListTouches(ctx, ports.ListFileTouchesOptions{Since: now.Add(-time.Hour).Format(time.RFC3339), ExperimentID: &experimentID}); len(touches) != 0 {
+		t.Errorf("expected no touches of another experiment, got %+v", touches)
+	}
+
+	if err := queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
+		ID: "exp-touches", Name: "touches", StartedAt: now.Format(time.RFC3339), CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed experiment: %v", err)
+	}
+	sessions := turso.NewSessionRepository(db)
+	if err := sessions.AssignExperiment(ctx, []string{"sess-touches"}, "exp-touches"); err != nil {
+		t.Fatalf("AssignExperiment failed: %v", err)
+	}
+	if err := sessions.SetExcluded(ctx, []string{"sess-touches"}, true, nil); err != nil {
+		t.Fatalf("SetExcluded failed: %v", err)
+	}
+	experimentID = "exp-touches"
+	if touches, _ := repo.ListTouches(ctx, ports.ListFileTouchesOptions{Since: now.Add(-time.Hour).Format(time.RFC3339), ExperimentID: &experimentID}); len(touches) != 0 {
+		t.Errorf("expected no touches of a session excluded from the experiment, got %+v", touches)
+	}
+}
+
+func TestSessionToolCallRepository_ListSequences(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-calls", Path: "/calls", Name: "calls", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	sessions := turso.NewSessionRepository(db)
+	for _, id := range []string{"sess-calls-1", "sess-calls-2"} {
+		if err := sessions.Create(ctx, &domain.Session{ID: id, ProjectID: "proj-calls", Cwd: "/calls", CreatedAt: now}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+	repo := turso.NewSessionToolCallRepository(db)
+	if err := repo.CreateBatch(ctx, []*domain.SessionToolCall{
+		{SessionID: "sess-calls-1", Sequence: 1, ToolName: "Edit", Label: "Edit"},
+		{SessionID: "sess-calls-1", Sequence: 2, ToolName: "Bash", Label: "Bash(test)", IsError: true, ResultBytes: 120},
+		{SessionID: "sess-calls-2", Sequence: 1, ToolName: "Read", Label: "Read"},
+	}); err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+
+	sequences, err := repo.ListSequences(ctx, ports.ListToolCallsOptions{Since: now.Add(-time.Hour).Format(time.RFC3339)})
+	if err != nil || len(sequences) != 2 {
+		t.Fatalf("expected 2 sequences, got %+v (%v)", sequences, err)
+	}
+	first := sequences[0]
+	if first.SessionID != "sess-calls-1" || len(first.Calls) != 2 || first.Calls[1].Label != "Bash(test)" || !first.Calls[1].IsError || first.Calls[1].ResultBytes != 120 {
+		t.Errorf("unexpected sequence: %+v", first)
+	}
+	experimentID := "exp-none"
+	if sequences, _ := repo.ListSequences(ctx, ports.ListToolCallsOptions{Since: now.Add(-time.Hour).Format(time.RFC3339), ExperimentID: &experimentID}); len(sequences) != 0 {
+		t.Errorf("expected no sequences of another experiment, got %+v", sequences)
+	}
+
+	// Sessions excluded from an experiment do not shape its workflows
+	if err := queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
+		ID: "exp-calls", Name: "calls", StartedAt: now.Format(time.RFC3339), CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed experiment: %v", err)
+	}
+	if err := sessions.AssignExperiment(ctx, []string{"sess-calls-1", "sess-calls-2"}, "exp-calls"); err != nil {
+		t.Fatalf("AssignExperiment failed: %v", err)
+	}
+	if err := sessions.SetExcluded(ctx, []string{"sess-calls-2"}, true, nil); err != nil {
+		t.Fatalf("SetExcluded failed: %v", err)
+	}
+	experimentID = "exp-calls"
+	if sequences, _ := repo.ListSequences(ctx, ports.ListToolCallsOptions{Since: now.Add(-time.Hour).Format(time.RFC3339), ExperimentID: &experimentID}); len(sequences) != 1 || sequences[0].SessionID != "sess-calls-1" {
+		t.Errorf("expected only the included session of the experiment, got %+v", sequences)
+	}
+	if sequences, _ := repo.ListSequences(ctx, ports.ListToolCallsOptions{Since: now.Add(-time.Hour).Format(time.RFC3339)}); len(sequences) != 2 || !sequences[1].Excluded {
+		t.Errorf("expected the excluded session flagged without an experiment filter, got %+v", sequences)
+	}
+}
@@ -0,0 +1,62 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type PlanPriceRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewPlanPriceRepository(db *sql.DB) *PlanPriceRepository {
+	return &PlanPriceRepository{queries: sqlc.New(db)}
+}
+
+// Set stores the price, replacing any price effective from the same month.
+func (r *PlanPriceRepository) Set(ctx context.Context, price *domain.PlanPrice) error {
+	err := r.queries.UpsertPlanPrice(ctx, sqlc.UpsertPlanPriceParams{
+		EffectiveFrom: price.EffectiveFrom.UTC().Format(time.RFC3339),
+		PlanType:      price.PlanType,
+		MonthlyUsd:    price.MonthlyUsd,
+		CreatedAt:     price.CreatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set plan price: %w", err)
+	}
+	return nil
+}
+
+// List returns the prices ordered by the month they take effect.
+func (r *PlanPriceRepository) List(ctx context.Context) ([]*domain.PlanPrice, error) {
+	rows, err := r.queries.ListPlanPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plan prices: %w", err)
+	}
+
+	prices := make([]*domain.PlanPrice, len(rows))
+	for i, row := range rows {
+		effectiveFrom, _ := time.Parse(time.RFC3339, row.EffectiveFrom)
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		prices[i] = &domain.PlanPrice{
+			EffectiveFrom: effectiveFrom.UTC(),
+			PlanType:      row.PlanType,
+			MonthlyUsd:    row.MonthlyUsd,
+			CreatedAt:     createdAt,
+		}
+	}
+	return prices, nil
+}
+
+func (r *PlanPriceRepository) Delete(ctx context.Context, effectiveFrom time.Time) (bool, error) {
+	n, err := r.queries.DeletePlanPrice(ctx, effectiveFrom.UTC().Format(time.RFC3339))
+	if err != nil {
+		return false, fmt.Errorf("failed to delete plan price: %w", err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,59 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type PlanRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewPlanRepository(db *sql.DB) *PlanRepository {
+	return &PlanRepository{queries: sqlc.New(db)}
+}
+
+func (r *PlanRepository) Get(ctx context.Context) (*domain.PlanConfig, error) {
+	row, err := r.queries.GetPlanConfig(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+	return &domain.PlanConfig{
+		PlanType:      row.PlanType,
+		WeeklyResetAt: util.NullTimeToPtr(row.WeeklyResetAt),
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+	}, nil
+}
+
+// Set stores the plan, replacing any existing one.
+func (r *PlanRepository) Set(ctx context.Context, plan *domain.PlanConfig) error {
+	err := r.queries.UpsertPlanConfig(ctx, sqlc.UpsertPlanConfigParams{
+		PlanType:      plan.PlanType,
+		WeeklyResetAt: util.NullTimePtr(plan.WeeklyResetAt),
+		CreatedAt:     plan.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     plan.UpdatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set plan: %w", err)
+	}
+	return nil
+}
+
+func (r *PlanRepository) Clear(ctx context.Context) error {
+	if err := r.queries.DeletePlanConfig(ctx); err != nil {
+		return fmt.Errorf("failed to clear plan: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type PricingProfileRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewPricingProfileRepository(db *sql.DB) *PricingProfileRepository {
+	return &PricingProfileRepository{queries: sqlc.New(db)}
+}
+
+// Set stores the profile and replaces its model rates. Whether it is the
+// default profile is changed with SetDefault.
+func (r *PricingProfileRepository) Set(ctx context.Context, profile *domain.PricingProfile) error {
+	err := r.queries.UpsertPricingProfile(ctx, sqlc.UpsertPricingProfileParams{
+		Name:        profile.Name,
+		Description: util.NullStringPtr(profile.Description),
+		Multiplier:  profile.Multiplier,
+		IsDefault:   util.BoolToInt64(profile.IsDefault),
+		CreatedAt:   profile.CreatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set pricing profile: %w", err)
+	}
+	if err := r.queries.DeletePricingProfileRates(ctx, profile.Name); err != nil {
+		return fmt.Errorf("failed to clear pricing profile rates: %w", err)
+	}
+	for _, rate := range profile.Rates {
+		if err := r.SetRate(ctx, profile.Name, rate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PricingProfileRepository) GetByName(ctx context.Context, name string) (*domain.PricingProfile, error) {
+	row, err := r.queries.GetPricingProfile(ctx, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pricing profile: %w", err)
+	}
+	return r.withRates(ctx, row)
+}
+
+func (r *PricingProfileRepository) GetDefault(ctx context.Context) (*domain.PricingProfile, error) {
+	row, err := r.queries.GetDefaultPricingProfile(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get default pricing profile: %w", err)
+	}
+	return r.withRates(ctx, row)
+}
+
+func (r *PricingProfileRepository) List(ctx context.Context) ([]*domain.PricingProfile, error) {
+	rows, err := r.queries.ListPricingProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pricing profiles: %w", err)
+	}
+
+	profiles := make([]*domain.PricingProfile, len(rows))
+	for i, row := range rows {
+		if profiles[i], err = r.withRates(ctx, row); err != nil {
+			return nil, err
+		}
+	}
+	return profiles, nil
+}
+
+func (r *PricingProfileRepository) SetDefault(ctx context.Context, name string) error {
+	if err := r.queries.SetDefaultPricingProfile(ctx, name); err != nil {
+		return fmt.Errorf("failed to set default pricing profile: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the profile with its rates and project assignments.
+func (r *PricingProfileRepository) Delete(ctx context.Context, name string) (bool, error) {
+	if err := r.queries.DeletePricingProfileRates(ctx, name); err != nil {
+		return false, fmt.Errorf("failed to delete pricing profile rates: %w", err)
+	}
+	if err := r.queries.DeleteProjectPricingProfilesByProfile(ctx, name); err != nil {
+		return false, fmt.Errorf("failed to unassign pricing profile: %w", err)
+	}
+	n, err := r.queries.DeletePricingProfile(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete pricing profile: %w", err)
+	}
+	return n > 0, nil
+}
+
+// SetRate stores the profile's pricing of a model, replacing any existing one.
+func (r *PricingProfileRepository) SetRate(ctx context.Context, name string, rate *domain.ModelPricing) error {
+	err := r.queries.UpsertPricingProfileRate(ctx, sqlc.UpsertPricingProfileRateParams{
+		ProfileName:                 name,
+		ModelID:                     rate.ID,
+		InputPerMillion:             rate.InputPerMillion,
+		OutputPerMillion:            rate.OutputPerMillion,
+		CacheReadPerMillion:         util.NullFloat64(rate.CacheReadPerMillion),
+		CacheWritePerMillion:        util.NullFloat64(rate.CacheWritePerMillion),
+		CacheWrite1hPerMillion:      util.NullFloat64(rate.CacheWrite1hPerMillion),
+		LongContextInputPerMillion:  util.NullFloat64(rate.LongContextInputPerMillion),
+		LongContextOutputPerMillion: util.NullFloat64(rate.LongContextOutputPerMillion),
+		LongContextThreshold:        util.NullInt64(rate.LongContextThreshold),
+		WebSearchPerThousand:        util.NullFloat64(rate.WebSearchPerThousand),
+		WebFetchPerThousand:         util.NullFloat64(rate.WebFetchPerThousand),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set pricing profile rate: %w", err)
+	}
+	return nil
+}
+
+func (r *PricingProfileRepository) DeleteRate(ctx context.Context, name, modelID string) (bool, error) {
+	n, err := r.queries.DeletePricingProfileRate(ctx, sqlc.DeletePricingProfileRateParams{
+		ProfileName: name,
+		ModelID:     modelID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete pricing profile rate: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (r *PricingProfileRepository) SetProject(ctx context.Context, projectID, name string) error {
+	err := r.queries.SetProjectPricingProfile(ctx, sqlc.SetProjectPricingProfileParams{
+		ProjectID:   projectID,
+		ProfileName: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set project pricing profile: %w", err)
+	}
+	return nil
+}
+
+func (r *PricingProfileRepository) ClearProject(ctx context.Context, projectID string) (bool, error) {
+	n, err := r.queries.DeleteProjectPricingProfile(ctx, projectID)
+	if err != nil {
+		return false, fmt.Errorf("failed to clear project pricing profile: %w", err)
+	}
+	return n > 0, nil
+}
+
+// GetForProject returns the profile assigned to the project, or the default
+// profile when it has none.
+func (r *PricingProfileRepository) GetForProject(ctx context.Context, projectID string) (*domain.PricingProfile, error) {
+	name, err := r.queries.GetProjectPricingProfile(ctx, projectID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get project pricing profile: %w", err)
+	}
+	if err == nil {
+		profile, err := r.GetByName(ctx, name)
+		if err != nil || profile != nil {
+			return profile, err
+		}
+	}
+	return r.GetDefault(ctx)
+}
+
+// ListProjects returns the assigned profile name by project ID.
+func (r *PricingProfileRepository) ListProjects(ctx context.Context) (map[string]string, error) {
+	rows, err := r.queries.ListProjectPricingProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project pricing profiles: %w", err)
+	}
+	projects := make(map[string]string, len(rows))
+	for _, row := range rows {
+		projects[row.ProjectID] = row.ProfileName
+	}
+	return projects, nil
+}
+
+func (r *PricingProfileRepository) withRates(ctx context.Context, row sqlc.PricingProfile) (*domain.PricingProfile, error) {
+	rates, err := r.queries.ListPricingProfileRates(ctx, row.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pricing profile rates: %w", err)
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	profile := &domain.PricingProfile{
+		Name:        row.Name,
+		Description: util.NullStringToPtr(row.Description),
+		Multiplier:  row.Multiplier,
+		IsDefault:   row.IsDefault == 1,
+		Rates:       make([]*domain.ModelPricing, len(rates)),
+		CreatedAt:   createdAt,
+	}
+	for i, rate := range rates {
+		profile.Rates[i] = pricingFromRow(sqlc.ModelPricing{
+			ID:                          rate.ModelID,
+			DisplayName:                 rate.ModelID,
+			InputPerMillion:             rate.InputPerMillion,
+			OutputPerMillion:            rate.OutputPerMillion,
+			CacheReadPerMillion:         rate.CacheReadPerMillion,
+			CacheWritePerMillion:        rate.CacheWritePerMillion,
+			CacheWrite1hPerMillion:      rate.CacheWrite1hPerMillion,
+			LongContextInputPerMillion:  rate.LongContextInputPerMillion,
+			LongContextOutputPerMillion: rate.LongContextOutputPerMillion,
+			LongContextThreshold:        rate.LongContextThreshold,
+			WebSearchPerThousand:        rate.WebSearchPerThousand,
+			WebFetchPerThousand:         rate.WebFetchPerThousand,
+		})
+	}
+	return profile, nil
+}
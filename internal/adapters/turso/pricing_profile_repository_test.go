@@ -0,0 +1,103 @@
+package turso_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestPricingProfileRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := turso.NewPricingProfileRepository(db)
+
+	// The migration seeds the API list price profile as the default
+	def, err := repo.GetDefault(ctx)
+	if err != nil || def == nil || def.Name != domain.DefaultPricingProfile || def.Multiplier != 1 {
+		t.Fatalf("unexpected default profile: %+v, %v", def, err)
+	}
+
+	description := "Negotiated discount"
+	cacheRead := 0.4
+	profile := &domain.PricingProfile{
+		Name:        "enterprise",
+		Description: &description,
+		Multiplier:  0.8,
+		CreatedAt:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Rates: []*domain.ModelPricing{
+			{ID: "claude-opus-4-6-20260206", InputPerMillion: 4, OutputPerMillion: 20, CacheReadPerMillion: &cacheRead},
+		},
+	}
+	if err := repo.Set(ctx, profile); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := repo.SetRate(ctx, "enterprise", &domain.ModelPricing{ID: "claude-sonnet-4-5-20250929", InputPerMillion: 2, OutputPerMillion: 10}); err != nil {
+		t.Fatalf("SetRate failed: %v", err)
+	}
+
+	got, err := repo.GetByName(ctx, "enterprise")
+	if err != nil || got == nil {
+		t.Fatalf("GetByName failed: %+v, %v", got, err)
+	}
+	if got.Multiplier != 0.8 || *got.Description != description || got.IsDefault || len(got.Rates) != 2 {
+		t.Errorf("unexpected profile: %+v", got)
+	}
+	if r := got.Rate("claude-opus-4-6-20260206"); r == nil || r.InputPerMillion != 4 || *r.CacheReadPerMillion != 0.4 {
+		t.Errorf("unexpected opus rate: %+v", r)
+	}
+
+	// Projects use the default profile until one is assigned
+	queries := sqlc.New(db)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-profile", Path: "/profile", Name: "profile", CreatedAt: "2026-03-01T00:00:00Z",
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	if p, _ := repo.GetForProject(ctx, "proj-profile"); p == nil || p.Name != domain.DefaultPricingProfile {
+		t.Errorf("expected the default profile, got %+v", p)
+	}
+	if err := repo.SetProject(ctx, "proj-profile", "enterprise"); err != nil {
+		t.Fatalf("SetProject failed: %v", err)
+	}
+	if p, _ := repo.GetForProject(ctx, "proj-profile"); p == nil || p.Name != "enterprise" {
+		t.Errorf("expected the enterprise profile, got %+v", p)
+	}
+	if projects, _ := repo.ListProjects(ctx); projects["proj-profile"] != "enterprise" {
+		t.Errorf("unexpected assignments: %v", projects)
+	}
+
+	// Set replaces the rates
+	profile.Rates = nil
+	if err := repo.Set(ctx, profile); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, _ := repo.GetByName(ctx, "enterprise"); len(got.Rates) != 0 {
+		t.Errorf("expected no rates, got %+v", got.Rates)
+	}
+
+	if err := repo.SetDefault(ctx, "enterprise"); err != nil {
+		t.Fatalf("SetDefault failed: %v", err)
+	}
+	if def, _ := repo.GetDefault(ctx); def == nil || def.Name != "enterprise" {
+		t.Errorf("expected enterprise as the default, got %+v", def)
+	}
+
+	// Deleting a profile unassigns its projects
+	if err := repo.SetDefault(ctx, domain.DefaultPricingProfile); err != nil {
+		t.Fatalf("SetDefault failed: %v", err)
+	}
+	deleted, err := repo.Delete(ctx, "enterprise")
+	if err != nil || !deleted {
+		t.Fatalf("Delete = %v, %v", deleted, err)
+	}
+	if p, _ := repo.GetForProject(ctx, "proj-profile"); p == nil || p.Name != domain.DefaultPricingProfile {
+		t.Errorf("expected the default profile after delete, got %+v", p)
+	}
+	if deleted, _ := repo.Delete(ctx, "enterprise"); deleted {
+		t.Error("expected nothing to delete the second time")
+	}
+}
@@ -31,9 +31,12 @@ func (r *PricingRepository) Create(ctx context.Context, pricing *domain.ModelPri
 		OutputPerMillion:            pricing.OutputPerMillion,
 		CacheReadPerMillion:         util.NullFloat64(pricing.CacheReadPerMillion),
 		CacheWritePerMillion:        util.NullFloat64(pricing.CacheWritePerMillion),
+		CacheWrite1hPerMillion:      util.NullFloat64(pricing.CacheWrite1hPerMillion),
 		LongContextInputPerMillion:  util.NullFloat64(pricing.LongContextInputPerMillion),
 		LongContextOutputPerMillion: util.NullFloat64(pricing.LongContextOutputPerMillion),
 		LongContextThreshold:        util.NullInt64(pricing.LongContextThreshold),
+		WebSearchPerThousand:        util.NullFloat64(pricing.WebSearchPerThousand),
+		WebFetchPerThousand:         util.NullFloat64(pricing.WebFetchPerThousand),
 		IsDefault:                   util.BoolToInt64(pricing.IsDefault),
 		CreatedAt:                   pricing.CreatedAt.Format(time.RFC3339),
 	})
@@ -81,9 +84,12 @@ func (r *PricingRepository) Update(ctx context.Context, pricing *domain.ModelPri
 		OutputPerMillion:            pricing.OutputPerMillion,
 		CacheReadPerMillion:         util.NullFloat64(pricing.CacheReadPerMillion),
 		CacheWritePerMillion:        util.NullFloat64(pricing.CacheWritePerMillion),
+		CacheWrite1hPerMillion:      util.NullFloat64(pricing.CacheWrite1hPerMillion),
 		LongContextInputPerMillion:  util.NullFloat64(pricing.LongContextInputPerMillion),
 		LongContextOutputPerMillion: util.NullFloat64(pricing.LongContextOutputPerMillion),
 		LongContextThreshold:        util.NullInt64(pricing.LongContextThreshold),
+		WebSearchPerThousand:        util.NullFloat64(pricing.WebSearchPerThousand),
+		WebFetchPerThousand:         util.NullFloat64(pricing.WebFetchPerThousand),
 		IsDefault:                   util.BoolToInt64(pricing.IsDefault),
 		ID:                          pricing.ID,
 	})
@@ -100,13 +106,16 @@ func (r *PricingRepository) Delete(ctx context.Context, id string) error {
 func pricingFromRow(row sqlc.ModelPricing) *domain.ModelPricing {
 	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
 
-	var cacheReadPerMillion, cacheWritePerMillion *float64
+	var cacheReadPerMillion, cacheWritePerMillion, cacheWrite1hPerMillion *float64
 	if row.CacheReadPerMillion.Valid {
 		cacheReadPerMillion = &row.CacheReadPerMillion.Float64
 	}
 	if row.CacheWritePerMillion.Valid {
 		cacheWritePerMillion = &row.CacheWritePerMillion.Float64
 	}
+	if row.CacheWrite1hPerMillion.Valid {
+		cacheWrite1hPerMillion = &row.CacheWrite1hPerMillion.Float64
+	}
 
 	var longContextInputPerMillion, longContextOutputPerMillion *float64
 	var longContextThreshold *int64
@@ -120,6 +129,14 @@ func pricingFromRow(row sqlc.ModelPricing) *domain.ModelPricing {
 		longContextThreshold = &row.LongContextThreshold.Int64
 	}
 
+	var webSearchPerThousand, webFetchPerThousand *float64
+	if row.WebSearchPerThousand.Valid {
+		webSearchPerThousand = &row.WebSearchPerThousand.Float64
+	}
+	if row.WebFetchPerThousand.Valid {
+		webFetchPerThousand = &row.WebFetchPerThousand.Float64
+	}
+
 	return &domain.ModelPricing{
 		ID:                          row.ID,
 		DisplayName:                 row.DisplayName,
@@ -127,9 +144,12 @@ func pricingFromRow(row sqlc.ModelPricing) *domain.ModelPricing {
 		OutputPerMillion:            row.OutputPerMillion,
 		CacheReadPerMillion:         cacheReadPerMillion,
 		CacheWritePerMillion:        cacheWritePerMillion,
+		CacheWrite1hPerMillion:      cacheWrite1hPerMillion,
 		LongContextInputPerMillion:  longContextInputPerMillion,
 		LongContextOutputPerMillion: longContextOutputPerMillion,
 		LongContextThreshold:        longContextThreshold,
+		WebSearchPerThousand:        webSearchPerThousand,
+		WebFetchPerThousand:         webFetchPerThousand,
 		IsDefault:                   row.IsDefault == 1,
 		CreatedAt:                   createdAt,
 	}
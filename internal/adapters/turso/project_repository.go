@@ -48,7 +48,7 @@ func (r *ProjectRepository) GetByID(ctx context.Context, id string) (*domain.Pro
 func (r *ProjectRepository) GetOrCreate(ctx context.Context, path string) (*domain.Project, error) {
 	now := time.Now().UTC()
 	project := &domain.Project{
-		ID:        hashPath(path),
+		ID:        ProjectIDForPath(path),
 		Path:      path,
 		Name:      filepath.Base(path),
 		CreatedAt: now,
@@ -94,7 +94,8 @@ func projectFromRow(row sqlc.Project) *domain.Project {
 	}
 }
 
-func hashPath(path string) string {
+// ProjectIDForPath returns the ID of the project rooted at path.
+func ProjectIDForPath(path string) string {
 	hash := sha256.Sum256([]byte(path))
 	return hex.EncodeToString(hash[:])
 }
@@ -11,15 +11,30 @@ type Repositories struct {
 	Sessions            ports.SessionRepository
 	Metrics             ports.SessionMetricsRepository
 	Tools               ports.SessionToolRepository
+	ToolCalls           ports.SessionToolCallRepository
 	Files               ports.SessionFileRepository
+	FileChanges         ports.SessionFileChangeRepository
 	Commands            ports.SessionCommandRepository
 	Subagents           ports.SessionSubagentRepository
+	GitStarts           ports.SessionGitStartRepository
 	ToolEvents          ports.ToolEventRepository
 	Experiments         ports.ExperimentRepository
 	ExperimentVariables ports.ExperimentVariableRepository
+	ExperimentTemplates ports.ExperimentTemplateRepository
+	ExperimentNotes     ports.ExperimentNoteRepository
+	RotationGroups      ports.RotationGroupRepository
 	Projects            ports.ProjectRepository
 	Pricing             ports.PricingRepository
 	Stats               ports.StatsRepository
+	Budgets             ports.BudgetRepository
+	Plans               ports.PlanRepository
+	Usage               ports.UsageRepository
+	Anomalies           ports.AnomalyRepository
+	Alerts              ports.AlertRepository
+	PlanPrices          ports.PlanPriceRepository
+	PricingProfiles     ports.PricingProfileRepository
+	Billing             ports.BillingRepository
+	Commits             ports.CommitRepository
 }
 
 // NewRepositories creates all turso repository implementations from a database connection.
@@ -28,14 +43,29 @@ func NewRepositories(db *sql.DB) *Repositories {
 		Sessions:            NewSessionRepository(db),
 		Metrics:             NewSessionMetricsRepository(db),
 		Tools:               NewSessionToolRepository(db),
+		ToolCalls:           NewSessionToolCallRepository(db),
 		Files:               NewSessionFileRepository(db),
+		FileChanges:         NewSessionFileChangeRepository(db),
 		Commands:            NewSessionCommandRepository(db),
 		Subagents:           NewSessionSubagentRepository(db),
+		GitStarts:           NewSessionGitStartRepository(db),
 		ToolEvents:          NewToolEventRepository(db),
 		Experiments:         NewExperimentRepository(db),
 		ExperimentVariables: NewExperimentVariableRepository(db),
+		ExperimentTemplates: NewExperimentTemplateRepository(db),
+		ExperimentNotes:     NewExperimentNoteRepository(db),
+		RotationGroups:      NewRotationGroupRepository(db),
 		Projects:            NewProjectRepository(db),
 		Pricing:             NewPricingRepository(db),
 		Stats:               NewStatsRepository(db),
+		Budgets:             NewBudgetRepository(db),
+		Plans:               NewPlanRepository(db),
+		Usage:               NewUsageRepository(db),
+		Anomalies:           NewAnomalyRepository(db),
+		Alerts:              NewAlertRepository(db),
+		PlanPrices:          NewPlanPriceRepository(db),
+		PricingProfiles:     NewPricingProfileRepository(db),
+		Billing:             NewBillingRepository(db),
+		Commits:             NewCommitRepository(db),
 	}
 }
@@ -0,0 +1,116 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type RotationGroupRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewRotationGroupRepository(db *sql.DB) *RotationGroupRepository {
+	return &RotationGroupRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *RotationGroupRepository) Create(ctx context.Context, group *domain.RotationGroup) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.CreateRotationGroup(ctx, sqlc.CreateRotationGroupParams{
+		ID:              group.ID,
+		Name:            group.Name,
+		IntervalSeconds: int64(group.Interval / time.Second),
+		AnchorAt:        group.AnchorAt.Format(time.RFC3339),
+		EndsAt:          util.NullTimePtr(group.EndsAt),
+		CreatedAt:       group.CreatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create rotation group: %w", err)
+	}
+
+	for i, expID := range group.ExperimentIDs {
+		err := qtx.AddRotationGroupMember(ctx, sqlc.AddRotationGroupMemberParams{
+			GroupID:      group.ID,
+			ExperimentID: expID,
+			Position:     int64(i),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add rotation group member %s: %w", expID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *RotationGroupRepository) GetByName(ctx context.Context, name string) (*domain.RotationGroup, error) {
+	row, err := r.queries.GetRotationGroupByName(ctx, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get rotation group: %w", err)
+	}
+	return r.withMembers(ctx, row)
+}
+
+func (r *RotationGroupRepository) List(ctx context.Context) ([]*domain.RotationGroup, error) {
+	rows, err := r.queries.ListRotationGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotation groups: %w", err)
+	}
+
+	groups := make([]*domain.RotationGroup, 0, len(rows))
+	for _, row := range rows {
+		group, err := r.withMembers(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func (r *RotationGroupRepository) Delete(ctx context.Context, id string) error {
+	return r.queries.DeleteRotationGroup(ctx, id)
+}
+
+func (r *RotationGroupRepository) withMembers(ctx context.Context, row sqlc.RotationGroup) (*domain.RotationGroup, error) {
+	members, err := r.queries.ListRotationGroupMembers(ctx, row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotation group members: %w", err)
+	}
+
+	group := rotationGroupFromRow(row)
+	for _, m := range members {
+		group.ExperimentIDs = append(group.ExperimentIDs, m.ExperimentID)
+	}
+	return group, nil
+}
+
+func rotationGroupFromRow(row sqlc.RotationGroup) *domain.RotationGroup {
+	anchorAt, _ := time.Parse(time.RFC3339, row.AnchorAt)
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+
+	return &domain.RotationGroup{
+		ID:        row.ID,
+		Name:      row.Name,
+		Interval:  time.Duration(row.IntervalSeconds) * time.Second,
+		AnchorAt:  anchorAt,
+		EndsAt:    util.NullTimeToPtr(row.EndsAt),
+		CreatedAt: createdAt,
+	}
+}
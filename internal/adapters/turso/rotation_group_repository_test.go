@@ -0,0 +1,138 @@
+package turso_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestRotationGroupRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, id := range []string{"exp-a", "exp-b"} {
+		err := queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
+			ID:        id,
+			Name:      id,
+			StartedAt: now,
+			CreatedAt: now,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed experiment: %v", err)
+		}
+	}
+
+	repo := turso.NewRotationGroupRepository(db)
+
+	anchor := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	ends := anchor.Add(14 * 24 * time.Hour)
+	group := &domain.RotationGroup{
+		ID:            "rot-1",
+		Name:          "crossover",
+		Interval:      24 * time.Hour,
+		AnchorAt:      anchor,
+		EndsAt:        &ends,
+		ExperimentIDs: []string{"exp-b", "exp-a"},
+		CreatedAt:     anchor,
+	}
+	if err := repo.Create(ctx, group); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	got, err := repo.GetByName(ctx, "crossover")
+	if err != nil {
+		t.Fatalf("GetByName failed: %v", err)
+	}
+	if got == nil {
+		t.Fatal("expected rotation group, got nil")
+	}
+	if got.Interval != 24*time.Hour {
+		t.Errorf("expected interval 24h, got %v", got.Interval)
+	}
+	if !got.AnchorAt.Equal(anchor) {
+		t.Errorf("expected anchor %v, got %v", anchor, got.AnchorAt)
+	}
+	if got.EndsAt == nil || !got.EndsAt.Equal(ends) {
+		t.Errorf("expected ends_at %v, got %v", ends, got.EndsAt)
+	}
+	if len(got.ExperimentIDs) != 2 || got.ExperimentIDs[0] != "exp-b" || got.ExperimentIDs[1] != "exp-a" {
+		t.Errorf("expected members in position order [exp-b exp-a], got %v", got.ExperimentIDs)
+	}
+
+	missing, err := repo.GetByName(ctx, "missing")
+	if err != nil {
+		t.Fatalf("GetByName(missing) failed: %v", err)
+	}
+	if missing != nil {
+		t.Errorf("expected nil for missing group, got %+v", missing)
+	}
+
+	// Deleting an experiment removes it from the rotation
+	if err := queries.DeleteExperiment(ctx, "exp-a"); err != nil {
+		t.Fatalf("DeleteExperiment failed: %v", err)
+	}
+	groups, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(groups) != 1 || len(groups[0].ExperimentIDs) != 1 {
+		t.Fatalf("expected 1 group with 1 member, got %+v", groups)
+	}
+
+	if err := repo.Delete(ctx, "rot-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	groups, err = repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("expected 0 groups after delete, got %d", len(groups))
+	}
+}
+
+func TestRotationGroupRepository_WeightedMembers(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, id := range []string{"exp-a", "exp-b"} {
+		err := queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
+			ID:        id,
+			Name:      id,
+			StartedAt: now,
+			CreatedAt: now,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed experiment: %v", err)
+		}
+	}
+
+	repo := turso.NewRotationGroupRepository(db)
+	anchor := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := repo.Create(ctx, &domain.RotationGroup{
+		ID:            "rot-weighted",
+		Name:          "weighted",
+		Interval:      24 * time.Hour,
+		AnchorAt:      anchor,
+		ExperimentIDs: []string{"exp-a", "exp-a", "exp-b"},
+		CreatedAt:     anchor,
+	}); err != nil {
+		t.Fatalf("Create with a repeated experiment failed: %v", err)
+	}
+
+	got, err := repo.GetByName(ctx, "weighted")
+	if err != nil || got == nil {
+		t.Fatalf("GetByName failed: %v", err)
+	}
+	if len(got.ExperimentIDs) != 3 || got.ExperimentIDs[0] != "exp-a" || got.ExperimentIDs[1] != "exp-a" || got.ExperimentIDs[2] != "exp-b" {
+		t.Errorf("expected members [exp-a exp-a exp-b], got %v", got.ExperimentIDs)
+	}
+}
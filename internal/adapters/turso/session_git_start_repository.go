@@ -0,0 +1,61 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type SessionGitStartRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewSessionGitStartRepository(db *sql.DB) *SessionGitStartRepository {
+	return &SessionGitStartRepository{queries: sqlc.New(db)}
+}
+
+// Set stores the state unless one was already captured for the session.
+func (r *SessionGitStartRepository) Set(ctx context.Context, sessionID string, state *domain.GitState, capturedAt time.Time) error {
+	err := r.queries.InsertSessionGitStart(ctx, sqlc.InsertSessionGitStartParams{
+		SessionID:  sessionID,
+		RepoRoot:   state.RepoRoot,
+		RemoteUrl:  state.RemoteURL,
+		Branch:     state.Branch,
+		CommitHash: state.Commit,
+		Dirty:      util.BoolToInt64(state.Dirty),
+		CapturedAt: capturedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set session git start: %w", err)
+	}
+	return nil
+}
+
+func (r *SessionGitStartRepository) Get(ctx context.Context, sessionID string) (*domain.GitState, error) {
+	row, err := r.queries.GetSessionGitStart(ctx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session git start: %w", err)
+	}
+	return &domain.GitState{
+		RepoRoot:  row.RepoRoot,
+		RemoteURL: row.RemoteUrl,
+		Branch:    row.Branch,
+		Commit:    row.CommitHash,
+		Dirty:     row.Dirty == 1,
+	}, nil
+}
+
+func (r *SessionGitStartRepository) Delete(ctx context.Context, sessionID string) error {
+	if err := r.queries.DeleteSessionGitStart(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to delete session git start: %w", err)
+	}
+	return nil
+}
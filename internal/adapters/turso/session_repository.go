@@ -3,7 +3,9 @@ package turso
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
@@ -24,6 +26,9 @@ func NewSessionRepository(db *sql.DB) *SessionRepository {
 	}
 }
 
+// Create saves a session, updating it when it exists. An existing session
+// keeps its experiment and exclusion, which hooks saving it again after every
+// turn must not undo once sessions assign or sessions exclude changed them.
 func (r *SessionRepository) Create(ctx context.Context, session *domain.Session) error {
 	var startedAt, endedAt sql.NullString
 	if session.StartedAt != nil {
@@ -38,6 +43,11 @@ func (r *SessionRepository) Create(ctx context.Context, session *domain.Session)
 		durationSeconds = sql.NullInt64{Int64: *session.DurationSeconds, Valid: true}
 	}
 
+	git := session.Git
+	if git == nil {
+		git = &domain.SessionGit{}
+	}
+
 	return r.queries.CreateSession(ctx, sqlc.CreateSessionParams{
 		ID:                   session.ID,
 		ProjectID:            session.ProjectID,
@@ -51,6 +61,13 @@ func (r *SessionRepository) Create(ctx context.Context, session *domain.Session)
 		EndedAt:              endedAt,
 		DurationSeconds:      durationSeconds,
 		CreatedAt:            session.CreatedAt.Format(time.RFC3339),
+		GitRepoRoot:          util.NullString(git.RepoRoot),
+		GitRemoteUrl:         util.NullString(git.RemoteURL),
+		GitBranch:            util.NullString(git.Branch),
+		GitStartCommit:       util.NullString(git.StartCommit),
+		GitEndCommit:         util.NullString(git.EndCommit),
+		GitStartDirty:        util.NullBoolPtr(git.DirtyAtStart),
+		GitEndDirty:          util.NullBoolPtr(git.DirtyAtEnd),
 	})
 }
 
@@ -104,6 +121,10 @@ func (r *SessionRepository) ListWithMetrics(ctx context.Context, opts ports.List
 	if limit == 0 {
 		limit = 50
 	}
+	anomalousOnly := util.BoolToInt64(opts.AnomalousOnly)
+	gitBranch := likePattern(opts.Branch, false)
+	gitRepository := likePattern(opts.Repository, true)
+	endedGreen := outcomePattern(opts.Outcome)
 
 	type listRow struct {
 		ID              string
@@ -116,7 +137,11 @@ func (r *SessionRepository) ListWithMetrics(ctx context.Context, opts ports.List
 		TotalTokens     int64
 		CostEstimateUsd sql.NullFloat64
 		ModelID         sql.NullString
+		GitBranch       sql.NullString
 		SubagentCount   int64
+		AnomalyScore    sql.NullFloat64
+		AnomalyReasons  sql.NullString
+		EndedGreen      sql.NullInt64
 	}
 
 	var genericRows []listRow
@@ -125,37 +150,55 @@ func (r *SessionRepository) ListWithMetrics(ctx context.Context, opts ports.List
 	switch {
 	case opts.ProjectID != nil && opts.ExperimentID != nil:
 		rows, err := r.queries.ListSessionsWithMetricsFullByProjectAndExperiment(ctx, sqlc.ListSessionsWithMetricsFullByProjectAndExperimentParams{
-			ProjectID:    *opts.ProjectID,
-			ExperimentID: util.NullStringPtr(opts.ExperimentID),
-			Limit:        limit,
+			ProjectID:     *opts.ProjectID,
+			ExperimentID:  util.NullStringPtr(opts.ExperimentID),
+			AnomalousOnly: anomalousOnly,
+			GitBranch:     gitBranch,
+			GitRepository: gitRepository,
+			EndedGreen:    endedGreen,
+			Limit:         limit,
 		})
 		queryErr = err
 		for _, row := range rows {
-			genericRows = append(genericRows, listRow{row.ID, row.ProjectID, row.ExperimentID, row.ExitReason, row.CreatedAt, row.DurationSeconds, row.TurnCount, row.TotalTokens, row.CostEstimateUsd, row.ModelID, row.SubagentCount})
+			genericRows = append(genericRows, listRow{row.ID, row.ProjectID, row.ExperimentID, row.ExitReason, row.CreatedAt, row.DurationSeconds, row.TurnCount, row.TotalTokens, row.CostEstimateUsd, row.ModelID, row.GitBranch, row.SubagentCount, row.AnomalyScore, row.AnomalyReasons, row.EndedGreen})
 		}
 	case opts.ProjectID != nil:
 		rows, err := r.queries.ListSessionsWithMetricsFullByProject(ctx, sqlc.ListSessionsWithMetricsFullByProjectParams{
-			ProjectID: *opts.ProjectID,
-			Limit:     limit,
+			ProjectID:     *opts.ProjectID,
+			AnomalousOnly: anomalousOnly,
+			GitBranch:     gitBranch,
+			GitRepository: gitRepository,
+			EndedGreen:    endedGreen,
+			Limit:         limit,
 		})
 		queryErr = err
 		for _, row := range rows {
-			genericRows = append(genericRows, listRow{row.ID, row.ProjectID, row.ExperimentID, row.ExitReason, row.CreatedAt, row.DurationSeconds, row.TurnCount, row.TotalTokens, row.CostEstimateUsd, row.ModelID, row.SubagentCount})
+			genericRows = append(genericRows, listRow{row.ID, row.ProjectID, row.ExperimentID, row.ExitReason, row.CreatedAt, row.DurationSeconds, row.TurnCount, row.TotalTokens, row.CostEstimateUsd, row.ModelID, row.GitBranch, row.SubagentCount, row.AnomalyScore, row.AnomalyReasons, row.EndedGreen})
 		}
 	case opts.ExperimentID != nil:
 		rows, err := r.queries.ListSessionsWithMetricsFullByExperiment(ctx, sqlc.ListSessionsWithMetricsFullByExperimentParams{
-			ExperimentID: util.NullStringPtr(opts.ExperimentID),
-			Limit:        limit,
+			ExperimentID:  util.NullStringPtr(opts.ExperimentID),
+			AnomalousOnly: anomalousOnly,
+			GitBranch:     gitBranch,
+			GitRepository: gitRepository,
+			EndedGreen:    endedGreen,
+			Limit:         limit,
 		})
 		queryErr = err
 		for _, row := range rows {
-			genericRows = append(genericRows, listRow{row.ID, row.ProjectID, row.ExperimentID, row.ExitReason, row.CreatedAt, row.DurationSeconds, row.TurnCount, row.TotalTokens, row.CostEstimateUsd, row.ModelID, row.SubagentCount})
+			genericRows = append(genericRows, listRow{row.ID, row.ProjectID, row.ExperimentID, row.ExitReason, row.CreatedAt, row.DurationSeconds, row.TurnCount, row.TotalTokens, row.CostEstimateUsd, row.ModelID, row.GitBranch, row.SubagentCount, row.AnomalyScore, row.AnomalyReasons, row.EndedGreen})
 		}
 	default:
-		rows, err := r.queries.ListSessionsWithMetricsFull(ctx, limit)
+		rows, err := r.queries.ListSessionsWithMetricsFull(ctx, sqlc.ListSessionsWithMetricsFullParams{
+			AnomalousOnly: anomalousOnly,
+			GitBranch:     gitBranch,
+			GitRepository: gitRepository,
+			EndedGreen:    endedGreen,
+			Limit:         limit,
+		})
 		queryErr = err
 		for _, row := range rows {
-			genericRows = append(genericRows, listRow{row.ID, row.ProjectID, row.ExperimentID, row.ExitReason, row.CreatedAt, row.DurationSeconds, row.TurnCount, row.TotalTokens, row.CostEstimateUsd, row.ModelID, row.SubagentCount})
+			genericRows = append(genericRows, listRow{row.ID, row.ProjectID, row.ExperimentID, row.ExitReason, row.CreatedAt, row.DurationSeconds, row.TurnCount, row.TotalTokens, row.CostEstimateUsd, row.ModelID, row.GitBranch, row.SubagentCount, row.AnomalyScore, row.AnomalyReasons, row.EndedGreen})
 		}
 	}
 
@@ -176,12 +219,48 @@ func (r *SessionRepository) ListWithMetrics(ctx context.Context, opts ports.List
 			TotalTokens:   row.TotalTokens,
 			Cost:          nullFloat64ToPtr(row.CostEstimateUsd),
 			ModelID:       util.NullStringToPtr(row.ModelID),
+			GitBranch:     util.NullStringToPtr(row.GitBranch),
 			SubagentCount: row.SubagentCount,
+			EndedGreen:    util.NullInt64ToBoolPtr(row.EndedGreen),
+		}
+		if row.AnomalyScore.Valid {
+			anomaly := &domain.SessionAnomaly{SessionID: row.ID, Score: row.AnomalyScore.Float64}
+			if err := json.Unmarshal([]byte(row.AnomalyReasons.String), &anomaly.Reasons); err != nil {
+				return nil, fmt.Errorf("failed to decode anomaly reasons for session %s: %w", row.ID, err)
+			}
+			items[i].Anomaly = anomaly
 		}
 	}
 	return items, nil
 }
 
+// likePattern returns a LIKE pattern, escaped with '\', matching the value
+// exactly or, with contains, anywhere. An empty value matches everything.
+func likePattern(value string, contains bool) string {
+	if value == "" {
+		return "%"
+	}
+	value = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+	if contains {
+		return "%" + value + "%"
+	}
+	return value
+}
+
+// outcomePattern returns a LIKE pattern for the ended_green column, as text,
+// matching a session outcome. An empty outcome matches everything.
+func outcomePattern(outcome string) string {
+	switch outcome {
+	case domain.SessionOutcomeGreen:
+		return "1"
+	case domain.SessionOutcomeRed:
+		return "0"
+	case domain.SessionOutcomeUnchecked:
+		return ""
+	}
+	return "%"
+}
+
 func nullInt64ToPtr(n sql.NullInt64) *int64 {
 	if !n.Valid {
 		return nil
@@ -200,6 +279,32 @@ func (r *SessionRepository) Delete(ctx context.Context, id string) error {
 	return r.queries.DeleteSession(ctx, id)
 }
 
+func (r *SessionRepository) DeleteDetails(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := r.queries.WithTx(tx)
+	for _, del := range []struct {
+		what string
+		fn   func(context.Context, string) error
+	}{
+		{"tools", qtx.DeleteSessionToolsBySession},
+		{"tool calls", qtx.DeleteSessionToolCallsBySession},
+		{"files", qtx.DeleteSessionFilesBySession},
+		{"file changes", qtx.DeleteSessionFileChangesBySession},
+		{"commands", qtx.DeleteSessionCommandsBySession},
+		{"sub-agents", qtx.DeleteSessionSubagentsBySession},
+	} {
+		if err := del.fn(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete session %s: %w", del.what, err)
+		}
+	}
+	return tx.Commit()
+}
+
 func (r *SessionRepository) DeleteBefore(ctx context.Context, before string) (int64, error) {
 	return r.queries.DeleteSessionsBefore(ctx, before)
 }
@@ -248,6 +353,113 @@ func (r *SessionRepository) GetTranscriptPathsByExperiment(ctx context.Context,
 	return paths, nil
 }
 
+func (r *SessionRepository) FindIDsByPrefix(ctx context.Context, prefix string) ([]string, error) {
+	ids, err := r.queries.ListSessionIDsByPrefix(ctx, prefix+"%")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *SessionRepository) ListGitBranches(ctx context.Context) ([]string, error) {
+	rows, err := r.queries.ListSessionGitBranches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git branches: %w", err)
+	}
+	branches := make([]string, 0, len(rows))
+	for _, row := range rows {
+		branches = append(branches, row.String)
+	}
+	return branches, nil
+}
+
+func (r *SessionRepository) ListGitRepositories(ctx context.Context) ([]string, error) {
+	repositories, err := r.queries.ListSessionGitRepositories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git repositories: %w", err)
+	}
+	return repositories, nil
+}
+
+func (r *SessionRepository) ListIDsCreatedBetween(ctx context.Context, since, until string, projectID *string) ([]string, error) {
+	var ids []string
+	var err error
+	if projectID != nil {
+		ids, err = r.queries.ListSessionIDsCreatedBetweenByProject(ctx, sqlc.ListSessionIDsCreatedBetweenByProjectParams{
+			ProjectID:   *projectID,
+			CreatedAt:   since,
+			CreatedAt_2: until,
+		})
+	} else {
+		ids, err = r.queries.ListSessionIDsCreatedBetween(ctx, sqlc.ListSessionIDsCreatedBetweenParams{
+			CreatedAt:   since,
+			CreatedAt_2: until,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *SessionRepository) ListExcluded(ctx context.Context, limit int) ([]*domain.Session, error) {
+	if limit == 0 {
+		limit = 50
+	}
+	rows, err := r.queries.ListExcludedSessions(ctx, int64(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list excluded sessions: %w", err)
+	}
+	sessions := make([]*domain.Session, len(rows))
+	for i, row := range rows {
+		sessions[i] = sessionFromRow(row)
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) AssignExperiment(ctx context.Context, sessionIDs []string, experimentID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := r.queries.WithTx(tx)
+	for _, id := range sessionIDs {
+		if err := qtx.UpdateSessionExperiment(ctx, sqlc.UpdateSessionExperimentParams{
+			ExperimentID: util.NullString(experimentID),
+			ID:           id,
+		}); err != nil {
+			return fmt.Errorf("failed to assign session %s: %w", id, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *SessionRepository) SetExcluded(ctx context.Context, sessionIDs []string, excluded bool, reason *string) error {
+	if !excluded {
+		reason = nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := r.queries.WithTx(tx)
+	for _, id := range sessionIDs {
+		if err := qtx.SetSessionExclusion(ctx, sqlc.SetSessionExclusionParams{
+			IsExcluded:      util.BoolToInt64(excluded),
+			ExclusionReason: util.NullStringPtr(reason),
+			ID:              id,
+		}); err != nil {
+			return fmt.Errorf("failed to update session %s: %w", id, err)
+		}
+	}
+	return tx.Commit()
+}
+
 func sessionFromRow(row sqlc.Session) *domain.Session {
 	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
 
@@ -279,5 +491,23 @@ func sessionFromRow(row sqlc.Session) *domain.Session {
 		EndedAt:              endedAt,
 		DurationSeconds:      durationSeconds,
 		CreatedAt:            createdAt,
+		Excluded:             row.IsExcluded == 1,
+		ExclusionReason:      util.NullStringToPtr(row.ExclusionReason),
+		Git:                  sessionGitFromRow(row),
+	}
+}
+
+func sessionGitFromRow(row sqlc.Session) *domain.SessionGit {
+	if !row.GitRepoRoot.Valid {
+		return nil
+	}
+	return &domain.SessionGit{
+		RepoRoot:     row.GitRepoRoot.String,
+		RemoteURL:    row.GitRemoteUrl.String,
+		Branch:       row.GitBranch.String,
+		StartCommit:  row.GitStartCommit.String,
+		EndCommit:    row.GitEndCommit.String,
+		DirtyAtStart: util.NullInt64ToBoolPtr(row.GitStartDirty),
+		DirtyAtEnd:   util.NullInt64ToBoolPtr(row.GitEndDirty),
 	}
 }
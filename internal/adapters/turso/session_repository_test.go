@@ -0,0 +1,266 @@
+package turso_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestSessionRepository_AssignAndExclude(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-1", Path: "/project", Name: "project", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	if err := queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
+		ID: "exp-assign", Name: "assign", StartedAt: now.Format(time.RFC3339), CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed experiment: %v", err)
+	}
+
+	repo := turso.NewSessionRepository(db)
+	ids := []string{"sess-aaa-1", "sess-aaa-2", "sess-bbb-1"}
+	for i, id := range ids {
+		err := repo.Create(ctx, &domain.Session{
+			ID:        id,
+			ProjectID: "proj-1",
+			Cwd:       "/project",
+			CreatedAt: now.Add(time.Duration(i) * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		if err := queries.CreateSessionTool(ctx, sqlc.CreateSessionToolParams{
+			SessionID: id, ToolName: "Read", InvocationCount: 10,
+		}); err != nil {
+			t.Fatalf("failed to seed tool: %v", err)
+		}
+	}
+
+	matches, err := repo.FindIDsByPrefix(ctx, "sess-aaa")
+	if err != nil {
+		t.Fatalf("FindIDsByPrefix failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Errorf("expected 2 prefix matches, got %v", matches)
+	}
+
+	inRange, err := repo.ListIDsCreatedBetween(ctx,
+		now.Add(30*time.Minute).Format(time.RFC3339), now.Add(3*time.Hour).Format(time.RFC3339), nil)
+	if err != nil {
+		t.Fatalf("ListIDsCreatedBetween failed: %v", err)
+	}
+	if len(inRange) != 2 || inRange[0] != "sess-aaa-2" || inRange[1] != "sess-bbb-1" {
+		t.Errorf("expected [sess-aaa-2 sess-bbb-1], got %v", inRange)
+	}
+
+	if err := repo.AssignExperiment(ctx, ids, "exp-assign"); err != nil {
+		t.Fatalf("AssignExperiment failed: %v", err)
+	}
+
+	reason := "debugging marathon"
+	if err := repo.SetExcluded(ctx, []string{"sess-bbb-1"}, true, &reason); err != nil {
+		t.Fatalf("SetExcluded failed: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, "sess-bbb-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.ExperimentID == nil || *got.ExperimentID != "exp-assign" {
+		t.Errorf("expected session assigned to exp-assign, got %v", got.ExperimentID)
+	}
+	if !got.Excluded || got.ExclusionReason == nil || *got.ExclusionReason != reason {
+		t.Errorf("expected session excluded with reason %q, got %v %v", reason, got.Excluded, got.ExclusionReason)
+	}
+
+	for i, id := range ids {
+		if err := queries.CreateSessionMetrics(ctx, sqlc.CreateSessionMetricsParams{
+			SessionID:       id,
+			CostEstimateUsd: sql.NullFloat64{Float64: float64(i + 1), Valid: true},
+		}); err != nil {
+			t.Fatalf("failed to seed metrics: %v", err)
+		}
+	}
+
+	stats := turso.NewStatsRepository(db)
+	agg, err := stats.GetAggregateByExperiment(ctx, "exp-assign", "1970-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GetAggregateByExperiment failed: %v", err)
+	}
+	if agg.SessionCount != 2 {
+		t.Errorf("expected 2 sessions in aggregate, got %d", agg.SessionCount)
+	}
+	// Budgets count the spend of excluded sessions
+	spent, err := stats.GetExperimentSpend(ctx, "exp-assign", "1970-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GetExperimentSpend failed: %v", err)
+	}
+	if agg.TotalCostUsd != 3 || spent != 6 {
+		t.Errorf("expected $3 in stats and $6 spent, got %v and %v", agg.TotalCostUsd, spent)
+	}
+	toolCalls, err := stats.GetTotalToolCallsByExperiment(ctx, "exp-assign")
+	if err != nil {
+		t.Fatalf("GetTotalToolCallsByExperiment failed: %v", err)
+	}
+	if toolCalls != 20 {
+		t.Errorf("expected 20 tool calls, got %d", toolCalls)
+	}
+	samples, err := stats.ListSamplesByExperiment(ctx, "exp-assign")
+	if err != nil {
+		t.Fatalf("ListSamplesByExperiment failed: %v", err)
+	}
+	var sampleToolCalls int64
+	for _, sample := range samples {
+		if sample.SessionID == "sess-bbb-1" {
+			t.Errorf("excluded session returned in samples")
+		}
+		sampleToolCalls += sample.ToolCalls
+	}
+	if len(samples) != 2 || sampleToolCalls != 20 {
+		t.Errorf("expected 2 samples with 20 tool calls, got %d with %d", len(samples), sampleToolCalls)
+	}
+
+	// Saving a session again, as the Stop and SessionEnd hooks do, keeps its
+	// assignment and exclusion
+	if err := repo.Create(ctx, &domain.Session{
+		ID:        "sess-bbb-1",
+		ProjectID: "proj-1",
+		Cwd:       "/project",
+		CreatedAt: now.Add(2 * time.Hour),
+	}); err != nil {
+		t.Fatalf("failed to save session again: %v", err)
+	}
+	got, _ = repo.GetByID(ctx, "sess-bbb-1")
+	if got.ExperimentID == nil || *got.ExperimentID != "exp-assign" {
+		t.Errorf("expected saving again to keep exp-assign, got %v", got.ExperimentID)
+	}
+	if !got.Excluded || got.ExclusionReason == nil || *got.ExclusionReason != reason {
+		t.Errorf("expected saving again to keep the exclusion, got %v %v", got.Excluded, got.ExclusionReason)
+	}
+
+	excluded, err := repo.ListExcluded(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListExcluded failed: %v", err)
+	}
+	if len(excluded) != 1 || excluded[0].ID != "sess-bbb-1" {
+		t.Errorf("expected [sess-bbb-1] excluded, got %+v", excluded)
+	}
+
+	if err := repo.SetExcluded(ctx, []string{"sess-bbb-1"}, false, &reason); err != nil {
+		t.Fatalf("SetExcluded(false) failed: %v", err)
+	}
+	got, _ = repo.GetByID(ctx, "sess-bbb-1")
+	if got.Excluded || got.ExclusionReason != nil {
+		t.Errorf("expected session included with no reason, got %v %v", got.Excluded, got.ExclusionReason)
+	}
+}
+
+func TestSessionRepository_Git(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-git", Path: "/repo", Name: "repo", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+
+	repo := turso.NewSessionRepository(db)
+	git := domain.NewSessionGit(
+		&domain.GitState{RepoRoot: "/repo", Branch: "feat/x_1", Commit: "aaa", Dirty: true},
+		&domain.GitState{RepoRoot: "/repo", RemoteURL: "git@github.com:o/r.git", Branch: "feat/x_1", Commit: "bbb"},
+	)
+	sessions := []*domain.Session{
+		{ID: "sess-git-1", ProjectID: "proj-git", Cwd: "/repo", CreatedAt: now, Git: git},
+		{ID: "sess-git-2", ProjectID: "proj-git", Cwd: "/repo", CreatedAt: now, Git: &domain.SessionGit{RepoRoot: "/other", Branch: "featAx"}},
+		{ID: "sess-git-3", ProjectID: "proj-git", Cwd: "/tmp", CreatedAt: now},
+	}
+	for _, s := range sessions {
+		if err := repo.Create(ctx, s); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+
+	got, err := repo.GetByID(ctx, "sess-git-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Git == nil || got.Git.StartCommit != "aaa" || got.Git.EndCommit != "bbb" || got.Git.RemoteURL != "git@github.com:o/r.git" {
+		t.Fatalf("unexpected git context: %+v", got.Git)
+	}
+	if got.Git.DirtyAtStart == nil || !*got.Git.DirtyAtStart || got.Git.DirtyAtEnd == nil || *got.Git.DirtyAtEnd {
+		t.Errorf("expected dirty at start only, got %v %v", got.Git.DirtyAtStart, got.Git.DirtyAtEnd)
+	}
+	if got, _ := repo.GetByID(ctx, "sess-git-3"); got.Git != nil {
+		t.Errorf("expected no git context outside a repository, got %+v", got.Git)
+	}
+
+	// Branches match exactly, with LIKE wildcards escaped
+	items, err := repo.ListWithMetrics(ctx, ports.ListSessionsOptions{Branch: "feat/x_1", Limit: 10})
+	if err != nil {
+		t.Fatalf("ListWithMetrics failed: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "sess-git-1" || items[0].GitBranch == nil || *items[0].GitBranch != "feat/x_1" {
+		t.Errorf("expected sess-git-1 on feat/x_1, got %+v", items)
+	}
+	// Repositories match part of the remote or root
+	items, _ = repo.ListWithMetrics(ctx, ports.ListSessionsOptions{Repository: "o/r", Limit: 10})
+	if len(items) != 1 || items[0].ID != "sess-git-1" {
+		t.Errorf("expected sess-git-1 by remote, got %+v", items)
+	}
+	items, _ = repo.ListWithMetrics(ctx, ports.ListSessionsOptions{Repository: "other", Limit: 10})
+	if len(items) != 1 || items[0].ID != "sess-git-2" {
+		t.Errorf("expected sess-git-2 by root, got %+v", items)
+	}
+
+	branches, err := repo.ListGitBranches(ctx)
+	if err != nil || len(branches) != 2 || branches[0] != "feat/x_1" {
+		t.Errorf("expected 2 branches, got %v (%v)", branches, err)
+	}
+	repositories, err := repo.ListGitRepositories(ctx)
+	if err != nil || len(repositories) != 2 || repositories[0] != "/other" || repositories[1] != "git@github.com:o/r.git" {
+		t.Errorf("expected the root and the remote, got %v (%v)", repositories, err)
+	}
+}
+
+func TestSessionGitStartRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := turso.NewSessionGitStartRepository(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if got, err := repo.Get(ctx, "sess-start"); err != nil || got != nil {
+		t.Fatalf("expected no start state, got %+v (%v)", got, err)
+	}
+	if err := repo.Set(ctx, "sess-start", &domain.GitState{RepoRoot: "/repo", Branch: "main", Commit: "aaa", Dirty: true}, now); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	// A second SessionStart, e.g. after /clear, keeps the first capture
+	if err := repo.Set(ctx, "sess-start", &domain.GitState{RepoRoot: "/repo", Branch: "main", Commit: "bbb"}, now); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	got, err := repo.Get(ctx, "sess-start")
+	if err != nil || got == nil || got.Commit != "aaa" || !got.Dirty || got.Branch != "main" {
+		t.Fatalf("expected the first capture, got %+v (%v)", got, err)
+	}
+	if err := repo.Delete(ctx, "sess-start"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if got, _ := repo.Get(ctx, "sess-start"); got != nil {
+		t.Errorf("expected the start state deleted, got %+v", got)
+	}
+}
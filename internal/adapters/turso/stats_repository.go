@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
 	"github.com/emiliopalmerini/mclaude/internal/util"
@@ -32,8 +34,16 @@ func (r *StatsRepository) GetAggregate(ctx context.Context, since string) (*doma
 		TotalTokenOutput:       util.ToInt64(row.TotalTokenOutput),
 		TotalTokenCacheRead:    util.ToInt64(row.TotalTokenCacheRead),
 		TotalTokenCacheWrite:   util.ToInt64(row.TotalTokenCacheWrite),
+		TotalTokenCacheWrite1h: util.ToInt64(row.TotalTokenCacheWrite1h),
 		TotalCostUsd:           util.ToFloat64(row.TotalCostUsd),
 		TotalErrors:            util.ToInt64(row.TotalErrors),
+		TotalWebSearchRequests: util.ToInt64(row.TotalWebSearchRequests),
+		TotalWebFetchRequests:  util.ToInt64(row.TotalWebFetchRequests),
+		TotalServerToolCostUsd: util.ToFloat64(row.TotalServerToolCostUsd),
+		TotalLinesAdded:        util.ToInt64(row.TotalLinesAdded),
+		TotalLinesRemoved:      util.ToInt64(row.TotalLinesRemoved),
+		SessionsWithChecks:     util.ToInt64(row.SessionsWithChecks),
+		SessionsEndedGreen:     util.ToInt64(row.SessionsEndedGreen),
 	}, nil
 }
 
@@ -54,8 +64,16 @@ func (r *StatsRepository) GetAggregateByExperiment(ctx context.Context, experime
 		TotalTokenOutput:       util.ToInt64(row.TotalTokenOutput),
 		TotalTokenCacheRead:    util.ToInt64(row.TotalTokenCacheRead),
 		TotalTokenCacheWrite:   util.ToInt64(row.TotalTokenCacheWrite),
+		TotalTokenCacheWrite1h: util.ToInt64(row.TotalTokenCacheWrite1h),
 		TotalCostUsd:           util.ToFloat64(row.TotalCostUsd),
 		TotalErrors:            util.ToInt64(row.TotalErrors),
+		TotalWebSearchRequests: util.ToInt64(row.TotalWebSearchRequests),
+		TotalWebFetchRequests:  util.ToInt64(row.TotalWebFetchRequests),
+		TotalServerToolCostUsd: util.ToFloat64(row.TotalServerToolCostUsd),
+		TotalLinesAdded:        util.ToInt64(row.TotalLinesAdded),
+		TotalLinesRemoved:      util.ToInt64(row.TotalLinesRemoved),
+		SessionsWithChecks:     util.ToInt64(row.SessionsWithChecks),
+		SessionsEndedGreen:     util.ToInt64(row.SessionsEndedGreen),
 	}, nil
 }
 
@@ -76,11 +94,30 @@ func (r *StatsRepository) GetAggregateByProject(ctx context.Context, projectID s
 		TotalTokenOutput:       util.ToInt64(row.TotalTokenOutput),
 		TotalTokenCacheRead:    util.ToInt64(row.TotalTokenCacheRead),
 		TotalTokenCacheWrite:   util.ToInt64(row.TotalTokenCacheWrite),
+		TotalTokenCacheWrite1h: util.ToInt64(row.TotalTokenCacheWrite1h),
 		TotalCostUsd:           util.ToFloat64(row.TotalCostUsd),
 		TotalErrors:            util.ToInt64(row.TotalErrors),
+		TotalWebSearchRequests: util.ToInt64(row.TotalWebSearchRequests),
+		TotalWebFetchRequests:  util.ToInt64(row.TotalWebFetchRequests),
+		TotalServerToolCostUsd: util.ToFloat64(row.TotalServerToolCostUsd),
+		TotalLinesAdded:        util.ToInt64(row.TotalLinesAdded),
+		TotalLinesRemoved:      util.ToInt64(row.TotalLinesRemoved),
+		SessionsWithChecks:     util.ToInt64(row.SessionsWithChecks),
+		SessionsEndedGreen:     util.ToInt64(row.SessionsEndedGreen),
 	}, nil
 }
 
+func (r *StatsRepository) GetExperimentSpend(ctx context.Context, experimentID string, since string) (float64, error) {
+	result, err := r.queries.GetExperimentSpend(ctx, sqlc.GetExperimentSpendParams{
+		ExperimentID: util.NullString(experimentID),
+		CreatedAt:    since,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get experiment spend: %w", err)
+	}
+	return util.ToFloat64(result), nil
+}
+
 func (r *StatsRepository) GetTopTools(ctx context.Context, since string, limit int) ([]domain.ToolUsageStats, error) {
 	rows, err := r.queries.GetTopToolsUsage(ctx, sqlc.GetTopToolsUsageParams{
 		CreatedAt: since,
@@ -108,6 +145,233 @@ func (r *StatsRepository) GetTotalToolCallsByExperiment(ctx context.Context, exp
 	return util.ToInt64(result), nil
 }
 
+func (r *StatsRepository) GetTopToolsByExperiment(ctx context.Context, experimentID string, limit int) ([]domain.ToolUsageStats, error) {
+	rows, err := r.queries.GetTopToolsUsageByExperiment(ctx, sqlc.GetTopToolsUsageByExperimentParams{
+		ExperimentID: util.NullString(experimentID),
+		Limit:        int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiment top tools: %w", err)
+	}
+	tools := make([]domain.ToolUsageStats, len(rows))
+	for i, row := range rows {
+		tools[i] = domain.ToolUsageStats{
+			ToolName:         row.ToolName,
+			TotalInvocations: int64(util.ToFloat64(row.TotalInvocations)),
+			TotalErrors:      int64(util.ToFloat64(row.TotalErrors)),
+		}
+	}
+	return tools, nil
+}
+
+func (r *StatsRepository) ListSamplesByExperiment(ctx context.Context, experimentID string) ([]domain.SessionSample, error) {
+	rows, err := r.queries.ListSessionSamplesByExperiment(ctx, util.NullString(experimentID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiment sessions: %w", err)
+	}
+	samples := make([]domain.SessionSample, len(rows))
+	for i, row := range rows {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		samples[i] = domain.SessionSample{
+			SessionID:       row.ID,
+			CreatedAt:       createdAt,
+			DurationSeconds: row.DurationSeconds.Int64,
+			Turns:           row.TurnCount,
+			Tokens:          util.ToInt64(row.TotalTokens),
+			CostUsd:         row.CostUsd,
+			Errors:          row.ErrorCount,
+			ToolCalls:       util.ToInt64(row.ToolCalls),
+		}
+	}
+	return samples, nil
+}
+
+// ListSamplesByProject returns up to limit of the project's most recent
+// non-excluded sessions created no later than before, skipping excludeSessionID.
+func (r *StatsRepository) ListSamplesByProject(ctx context.Context, projectID, excludeSessionID, before string, limit int) ([]domain.SessionSample, error) {
+	rows, err := r.queries.ListSessionSamplesByProject(ctx, sqlc.ListSessionSamplesByProjectParams{
+		ProjectID: projectID,
+		ID:        excludeSessionID,
+		CreatedAt: before,
+		Limit:     int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project sessions: %w", err)
+	}
+	samples := make([]domain.SessionSample, len(rows))
+	for i, row := range rows {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		samples[i] = domain.SessionSample{
+			SessionID:       row.ID,
+			CreatedAt:       createdAt,
+			DurationSeconds: row.DurationSeconds.Int64,
+			Turns:           row.TurnCount,
+			Tokens:          util.ToInt64(row.TotalTokens),
+			CostUsd:         row.CostUsd,
+			Errors:          row.ErrorCount,
+			ToolCalls:       util.ToInt64(row.ToolCalls),
+		}
+	}
+	return samples, nil
+}
+
+// ListDailyCostByProject returns the cost of each project per UTC day since
+// the given time, in chronological order. Days without sessions are omitted.
+func (r *StatsRepository) ListDailyCostByProject(ctx context.Context, since string) ([]domain.DailyCost, error) {
+	rows, err := r.queries.GetDailyCostByProject(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily cost: %w", err)
+	}
+	costs := make([]domain.DailyCost, len(rows))
+	for i, row := range rows {
+		costs[i] = domain.DailyCost{
+			Date:      parseSQLDate(row.Date),
+			ProjectID: row.ProjectID,
+			CostUsd:   util.ToFloat64(row.TotalCost),
+		}
+	}
+	return costs, nil
+}
+
+// ListDailyCostByModel returns the cost and session count of each project and
+// model per UTC day since the given time, oldest first.
+func (r *StatsRepository) ListDailyCostByModel(ctx context.Context, since string) ([]domain.DailyModelCost, error) {
+	rows, err := r.queries.GetDailyCostByModelAndProject(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily cost by model: %w", err)
+	}
+	costs := make([]domain.DailyModelCost, len(rows))
+	for i, row := range rows {
+		costs[i] = domain.DailyModelCost{
+			Date:      parseSQLDate(row.Date),
+			ProjectID: row.ProjectID,
+			Model:     row.ModelID.String,
+			Sessions:  row.SessionCount,
+			CostUsd:   util.ToFloat64(row.TotalCost),
+		}
+	}
+	return costs, nil
+}
+
+// ListDailyUsageByModel returns the tokens and estimated cost of each model
+// per UTC day for the sessions created in [since, until), oldest first.
+// Sub-agents count toward the model they used.
+func (r *StatsRepository) ListDailyUsageByModel(ctx context.Context, since, until string) ([]domain.DailyModelUsage, error) {
+	sessions, err := r.ListSessionUsage(ctx, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		date  time.Time
+		model string
+	}
+	byDay := make(map[key]*domain.DailyModelUsage)
+	add := func(date time.Time, model string, tokens domain.TokenUsage, cost float64) {
+		k := key{date, model}
+		u, ok := byDay[k]
+		if !ok {
+			u = &domain.DailyModelUsage{Date: date, Model: model}
+			byDay[k] = u
+		}
+		u.Tokens = u.Tokens.Add(tokens)
+		u.CostUsd += cost
+	}
+	for _, s := range sessions {
+		date := s.CreatedAt.Truncate(24 * time.Hour)
+		// Sessions saved without metrics have no usage to count
+		if s.Model != "" || s.Tokens.Total() > 0 || s.CostUsd > 0 {
+			add(date, s.Model, s.Tokens, s.CostUsd)
+		}
+		for _, sa := range s.Subagents {
+			add(date, sa.Model, sa.Tokens, sa.CostUsd)
+		}
+	}
+
+	usage := make([]domain.DailyModelUsage, 0, len(byDay))
+	for _, u := range byDay {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if !usage[i].Date.Equal(usage[j].Date) {
+			return usage[i].Date.Before(usage[j].Date)
+		}
+		return usage[i].Model < usage[j].Model
+	})
+	return usage, nil
+}
+
+// ListSessionUsage returns the token usage and recorded cost of the sessions
+// created in [since, until), with their sub-agents, oldest first.
+func (r *StatsRepository) ListSessionUsage(ctx context.Context, since, until string) ([]domain.SessionUsage, error) {
+	rows, err := r.queries.ListSessionUsageBetween(ctx, sqlc.ListSessionUsageBetweenParams{
+		CreatedAt:   since,
+		CreatedAt_2: until,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session usage: %w", err)
+	}
+	subagents, err := r.queries.ListSubagentUsageBetween(ctx, sqlc.ListSubagentUsageBetweenParams{
+		CreatedAt:   since,
+		CreatedAt_2: until,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sub-agent usage: %w", err)
+	}
+
+	usage := make([]domain.SessionUsage, len(rows))
+	index := make(map[string]int, len(rows))
+	for i, row := range rows {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		usage[i] = domain.SessionUsage{
+			SessionID:    row.ID,
+			ProjectID:    row.ProjectID,
+			ExperimentID: util.NullStringToPtr(row.ExperimentID),
+			CreatedAt:    createdAt.UTC(),
+			Model:        row.ModelID.String,
+			Tokens: domain.TokenUsage{
+				Input:        row.TokenInput.Int64,
+				Output:       row.TokenOutput.Int64,
+				CacheRead:    row.TokenCacheRead.Int64,
+				CacheWrite:   row.TokenCacheWrite.Int64,
+				CacheWrite1h: row.TokenCacheWrite1h.Int64,
+				ServerTools: domain.ServerToolUsage{
+					WebSearchRequests: row.WebSearchRequests.Int64,
+					WebFetchRequests:  row.WebFetchRequests.Int64,
+				},
+			},
+			CostUsd: row.CostEstimateUsd.Float64,
+		}
+		index[row.ID] = i
+	}
+	for _, sa := range subagents {
+		i, ok := index[sa.SessionID]
+		if !ok {
+			continue
+		}
+		usage[i].Subagents = append(usage[i].Subagents, domain.SubagentUsage{
+			Model: sa.Model.String,
+			Tokens: domain.TokenUsage{
+				Input:        sa.TokenInput,
+				Output:       sa.TokenOutput,
+				CacheRead:    sa.TokenCacheRead,
+				CacheWrite:   sa.TokenCacheWrite,
+				CacheWrite1h: sa.TokenCacheWrite1h,
+			},
+			CostUsd: sa.CostEstimateUsd.Float64,
+		})
+	}
+
+	// Session metrics include the sub-agents, which are listed on their own
+	for i := range usage {
+		for _, sa := range usage[i].Subagents {
+			usage[i].Tokens = usage[i].Tokens.Sub(sa.Tokens)
+			usage[i].CostUsd = max(usage[i].CostUsd-sa.CostUsd, 0)
+		}
+	}
+	return usage, nil
+}
+
 func (r *StatsRepository) GetAllExperimentStats(ctx context.Context) ([]domain.ExperimentStats, error) {
 	rows, err := r.queries.GetStatsForAllExperiments(ctx)
 	if err != nil {
@@ -127,10 +391,33 @@ func (r *StatsRepository) GetAllExperimentStats(ctx context.Context) ([]domain.E
 				TotalTokenOutput:       util.ToInt64(row.TotalTokenOutput),
 				TotalTokenCacheRead:    util.ToInt64(row.TotalTokenCacheRead),
 				TotalTokenCacheWrite:   util.ToInt64(row.TotalTokenCacheWrite),
+				TotalTokenCacheWrite1h: util.ToInt64(row.TotalTokenCacheWrite1h),
 				TotalCostUsd:           util.ToFloat64(row.TotalCostUsd),
 				TotalErrors:            util.ToInt64(row.TotalErrors),
+				TotalWebSearchRequests: util.ToInt64(row.TotalWebSearchRequests),
+				TotalWebFetchRequests:  util.ToInt64(row.TotalWebFetchRequests),
+				TotalServerToolCostUsd: util.ToFloat64(row.TotalServerToolCostUsd),
+				TotalLinesAdded:        util.ToInt64(row.TotalLinesAdded),
+				TotalLinesRemoved:      util.ToInt64(row.TotalLinesRemoved),
+				SessionsWithChecks:     util.ToInt64(row.SessionsWithChecks),
+				SessionsEndedGreen:     util.ToInt64(row.SessionsEndedGreen),
 			},
 		}
 	}
 	return stats, nil
 }
+
+// parseSQLDate converts a DATE() column, which the driver may return as a
+// time or as text.
+func parseSQLDate(v interface{}) time.Time {
+	var date time.Time
+	switch d := v.(type) {
+	case time.Time:
+		date = d
+	case string:
+		date, _ = time.Parse(time.DateOnly, d)
+	case []byte:
+		date, _ = time.Parse(time.DateOnly, string(d))
+	}
+	return date
+}
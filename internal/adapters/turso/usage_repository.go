@@ -0,0 +1,114 @@
+package turso
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type UsageRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewUsageRepository(db *sql.DB) *UsageRepository {
+	return &UsageRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// ReplaceForSession replaces the requests and rate-limit hits recorded for a
+// session, so re-parsing a transcript does not duplicate them.
+func (r *UsageRepository) ReplaceForSession(ctx context.Context, sessionID string, requests []domain.UsageRequest, hits []domain.RateLimitHit) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.DeleteUsageRequestsBySession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to delete usage requests: %w", err)
+	}
+	if err := qtx.DeleteRateLimitHitsBySession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to delete rate limit hits: %w", err)
+	}
+
+	for _, req := range requests {
+		err := qtx.CreateUsageRequest(ctx, sqlc.CreateUsageRequestParams{
+			SessionID:       sessionID,
+			Timestamp:       req.Timestamp.UTC().Format(time.RFC3339),
+			Model:           util.NullStringPtr(req.Model),
+			TokenInput:      req.TokenInput,
+			TokenOutput:     req.TokenOutput,
+			TokenCacheRead:  req.TokenCacheRead,
+			TokenCacheWrite: req.TokenCacheWrite,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create usage request: %w", err)
+		}
+	}
+
+	for _, hit := range hits {
+		err := qtx.CreateRateLimitHit(ctx, sqlc.CreateRateLimitHitParams{
+			SessionID:  sessionID,
+			HitAt:      hit.HitAt.UTC().Format(time.RFC3339),
+			WindowType: hit.Window,
+			PlanType:   util.NullStringPtr(hit.PlanType),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create rate limit hit: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *UsageRepository) ListRequestsBetween(ctx context.Context, start, end time.Time) ([]domain.UsageRequest, error) {
+	rows, err := r.queries.ListUsageRequestsBetween(ctx, sqlc.ListUsageRequestsBetweenParams{
+		Timestamp:   start.UTC().Format(time.RFC3339),
+		Timestamp_2: end.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usage requests: %w", err)
+	}
+
+	requests := make([]domain.UsageRequest, len(rows))
+	for i, row := range rows {
+		ts, _ := time.Parse(time.RFC3339, row.Timestamp)
+		requests[i] = domain.UsageRequest{
+			SessionID:       row.SessionID,
+			Timestamp:       ts,
+			Model:           util.NullStringToPtr(row.Model),
+			TokenInput:      row.TokenInput,
+			TokenOutput:     row.TokenOutput,
+			TokenCacheRead:  row.TokenCacheRead,
+			TokenCacheWrite: row.TokenCacheWrite,
+		}
+	}
+	return requests, nil
+}
+
+func (r *UsageRepository) ListRateLimitHits(ctx context.Context) ([]domain.RateLimitHit, error) {
+	rows, err := r.queries.ListRateLimitHits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rate limit hits: %w", err)
+	}
+
+	hits := make([]domain.RateLimitHit, len(rows))
+	for i, row := range rows {
+		hitAt, _ := time.Parse(time.RFC3339, row.HitAt)
+		hits[i] = domain.RateLimitHit{
+			SessionID: row.SessionID,
+			HitAt:     hitAt,
+			Window:    row.WindowType,
+			PlanType:  util.NullStringToPtr(row.PlanType),
+		}
+	}
+	return hits, nil
+}
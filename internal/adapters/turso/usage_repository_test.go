@@ -0,0 +1,368 @@
+package turso_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+func TestUsageRepository_ReplaceForSession(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-usage", Path: "/usage", Name: "usage", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	if err := turso.NewSessionRepository(db).Create(ctx, &domain.Session{
+		ID: "sess-usage", ProjectID: "proj-usage", Cwd: "/usage", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	repo := turso.NewUsageRepository(db)
+	model := "claude-sonnet-4-20250514"
+	plan := domain.PlanMax5x
+	requests := []domain.UsageRequest{
+		{SessionID: "sess-usage", Timestamp: now, Model: &model, TokenInput: 100, TokenOutput: 50},
+		{SessionID: "sess-usage", Timestamp: now.Add(time.Hour), TokenInput: 10, TokenCacheWrite: 5},
+	}
+	hits := []domain.RateLimitHit{
+		{SessionID: "sess-usage", HitAt: now.Add(2 * time.Hour), Window: domain.WindowSession, PlanType: &plan},
+	}
+
+	// Recording a session twice replaces its rows
+	for range 2 {
+		if err := repo.ReplaceForSession(ctx, "sess-usage", requests, hits); err != nil {
+			t.Fatalf("ReplaceForSession failed: %v", err)
+		}
+	}
+
+	got, err := repo.ListRequestsBetween(ctx, now, now.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("ListRequestsBetween failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 request in range, got %d", len(got))
+	}
+	if got[0].TokenInput != 100 || got[0].Model == nil || *got[0].Model != model || !got[0].Timestamp.Equal(now) {
+		t.Errorf("unexpected request: %+v", got[0])
+	}
+
+	got, err = repo.ListRequestsBetween(ctx, now.Add(-time.Hour), now.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("ListRequestsBetween failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(got))
+	}
+
+	gotHits, err := repo.ListRateLimitHits(ctx)
+	if err != nil {
+		t.Fatalf("ListRateLimitHits failed: %v", err)
+	}
+	if len(gotHits) != 1 {
+		t.Fatalf("expected 1 rate limit hit, got %d", len(gotHits))
+	}
+	if gotHits[0].Window != domain.WindowSession || gotHits[0].PlanType == nil || *gotHits[0].PlanType != plan {
+		t.Errorf("unexpected hit: %+v", gotHits[0])
+	}
+}
+
+func TestPlanRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := turso.NewPlanRepository(db)
+
+	plan, err := repo.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if plan != nil {
+		t.Fatalf("expected no plan, got %+v", plan)
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	reset := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	if err := repo.Set(ctx, &domain.PlanConfig{PlanType: domain.PlanPro, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := repo.Set(ctx, &domain.PlanConfig{PlanType: domain.PlanMax20x, WeeklyResetAt: &reset, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	plan, err = repo.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if plan == nil || plan.PlanType != domain.PlanMax20x || plan.WeeklyResetAt == nil || !plan.WeeklyResetAt.Equal(reset) {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+
+	if err := repo.Clear(ctx); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if plan, _ := repo.Get(ctx); plan != nil {
+		t.Errorf("expected plan to be cleared, got %+v", plan)
+	}
+}
+
+func TestPlanPriceRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := turso.NewPlanPriceRepository(db)
+
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	apr := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	for _, p := range []*domain.PlanPrice{
+		{EffectiveFrom: apr, PlanType: domain.PlanMax20x, MonthlyUsd: 200, CreatedAt: jan},
+		{EffectiveFrom: jan, PlanType: domain.PlanPro, MonthlyUsd: 20, CreatedAt: jan},
+		// Same month again replaces the price
+		{EffectiveFrom: jan, PlanType: domain.PlanMax5x, MonthlyUsd: 100, CreatedAt: jan},
+	} {
+		if err := repo.Set(ctx, p); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	prices, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(prices) != 2 {
+		t.Fatalf("expected 2 prices, got %d", len(prices))
+	}
+	if !prices[0].EffectiveFrom.Equal(jan) || prices[0].PlanType != domain.PlanMax5x || prices[0].MonthlyUsd != 100 {
+		t.Errorf("unexpected first price: %+v", prices[0])
+	}
+	if !prices[1].EffectiveFrom.Equal(apr) {
+		t.Errorf("expected prices ordered by month, got %+v", prices[1])
+	}
+
+	removed, err := repo.Delete(ctx, apr)
+	if err != nil || !removed {
+		t.Fatalf("expected Delete to remove the April price, got %v, %v", removed, err)
+	}
+	if removed, _ := repo.Delete(ctx, apr); removed {
+		t.Error("expected a second Delete to remove nothing")
+	}
+}
+
+func TestStatsRepository_ListDailyCostByModel(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-value", Path: "/value", Name: "value", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+
+	sessions := turso.NewSessionRepository(db)
+	for i, model := range []string{"opus", "opus", ""} {
+		id := fmt.Sprintf("sess-value-%d", i)
+		if err := sessions.Create(ctx, &domain.Session{
+			ID: id, ProjectID: "proj-value", Cwd: "/value", CreatedAt: now.Add(time.Duration(i) * time.Hour),
+		}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		if err := queries.CreateSessionMetrics(ctx, sqlc.CreateSessionMetricsParams{
+			SessionID:       id,
+			ModelID:         sql.NullString{String: model, Valid: model != ""},
+			CostEstimateUsd: sql.NullFloat64{Float64: 1.5, Valid: true},
+		}); err != nil {
+			t.Fatalf("failed to create metrics: %v", err)
+		}
+	}
+
+	costs, err := turso.NewStatsRepository(db).ListDailyCostByModel(ctx, "2026-03-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("ListDailyCostByModel failed: %v", err)
+	}
+	if len(costs) != 2 {
+		t.Fatalf("expected 2 model groups, got %+v", costs)
+	}
+	// Unknown model sorts first
+	if costs[0].Model != "" || costs[0].Sessions != 1 || costs[0].CostUsd != 1.5 {
+		t.Errorf("unexpected unknown-model cost: %+v", costs[0])
+	}
+	if costs[1].Model != "opus" || costs[1].Sessions != 2 || costs[1].CostUsd != 3 || !costs[1].Date.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected opus cost: %+v", costs[1])
+	}
+}
+
+func TestStatsRepository_ListSessionUsage(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	queries := sqlc.New(db)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if err := queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-sim", Path: "/sim", Name: "sim", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+
+	sessions := turso.NewSessionRepository(db)
+	for i := range 3 {
+		id := fmt.Sprintf("sess-sim-%d", i)
+		if err := sessions.Create(ctx, &domain.Session{
+			ID: id, ProjectID: "proj-sim", Cwd: "/sim", CreatedAt: now.Add(time.Duration(i) * 24 * time.Hour),
+		}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		// The second session's metrics include its sub-agent's output
+		output, cost := int64(0), 2.0
+		if i == 1 {
+			output, cost = 50, 2.5
+		}
+		if err := queries.CreateSessionMetrics(ctx, sqlc.CreateSessionMetricsParams{
+			SessionID:         id,
+			ModelID:           sql.NullString{String: "opus", Valid: true},
+			TokenInput:        100,
+			TokenOutput:       output,
+			TokenCacheRead:    900,
+			WebSearchRequests: 2,
+			CostEstimateUsd:   sql.NullFloat64{Float64: cost, Valid: true},
+		}); err != nil {
+			t.Fatalf("failed to create metrics: %v", err)
+		}
+	}
+	if err := queries.CreateSessionSubagent(ctx, sqlc.CreateSessionSubagentParams{
+		SessionID:       "sess-sim-1",
+		AgentType:       "Explore",
+		AgentKind:       "task",
+		Model:           sql.NullString{String: "haiku", Valid: true},
+		TokenOutput:     50,
+		CostEstimateUsd: sql.NullFloat64{Float64: 0.5, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create sub-agent: %v", err)
+	}
+
+	// The last session falls outside the range
+	usage, err := turso.NewStatsRepository(db).ListSessionUsage(ctx, "2026-03-02T00:00:00Z", "2026-03-04T00:00:00Z")
+	if err != nil {
+		t.Fatalf("ListSessionUsage failed: %v", err)
+	}
+	if len(usage) != 2 || usage[0].SessionID != "sess-sim-0" {
+		t.Fatalf("expected the first two sessions, got %+v", usage)
+	}
+	if usage[0].Model != "opus" || usage[0].Tokens.CacheRead != 900 || usage[0].Tokens.ServerTools.WebSearchRequests != 2 || len(usage[0].Subagents) != 0 {
+		t.Errorf("unexpected usage: %+v", usage[0])
+	}
+	// The sub-agent is not counted twice
+	if len(usage[1].Subagents) != 1 || usage[1].Subagents[0].Model != "haiku" || usage[1].ActualCostUsd() != 2.5 {
+		t.Errorf("unexpected sub-agent usage: %+v", usage[1])
+	}
+	if usage[1].Tokens.Output != 0 || usage[1].Tokens.CacheRead != 900 || usage[1].CostUsd != 2 {
+		t.Errorf("expected the main thread without the sub-agent, got %+v", usage[1])
+	}
+}
+
+func TestSessionMetricsRepository_ServerTools(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if err := sqlc.New(db).CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-web", Path: "/web", Name: "web", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	if err := turso.NewSessionRepository(db).Create(ctx, &domain.Session{
+		ID: "sess-web", ProjectID: "proj-web", Cwd: "/web", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	// Seeded pricing charges for web search
+	pricing, err := turso.NewPricingRepository(db).GetByID(ctx, "claude-opus-4-6-20260206")
+	if err != nil || pricing == nil || pricing.WebSearchPerThousand == nil || *pricing.WebSearchPerThousand != 10 {
+		t.Fatalf("expected seeded web search pricing, got %+v (%v)", pricing, err)
+	}
+
+	metrics := &domain.SessionMetrics{SessionID: "sess-web", WebSearchRequests: 4, WebFetchRequests: 1}
+	serverToolCost := pricing.ServerToolCost(metrics.TokenUsage().ServerTools)
+	metrics.ServerToolCostUSD = &serverToolCost
+	repo := turso.NewSessionMetricsRepository(db)
+	if err := repo.Create(ctx, metrics); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	got, err := repo.GetBySessionID(ctx, "sess-web")
+	if err != nil {
+		t.Fatalf("GetBySessionID failed: %v", err)
+	}
+	if got.WebSearchRequests != 4 || got.WebFetchRequests != 1 || got.ServerToolCostUSD == nil || *got.ServerToolCostUSD != 0.04 {
+		t.Errorf("unexpected metrics: %+v", got)
+	}
+
+	stats, err := turso.NewStatsRepository(db).GetAggregate(ctx, "2026-03-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GetAggregate failed: %v", err)
+	}
+	if stats.TotalWebSearchRequests != 4 || stats.TotalWebFetchRequests != 1 || stats.TotalServerToolCostUsd != 0.04 {
+		t.Errorf("unexpected aggregate: %+v", stats)
+	}
+}
+
+func TestSessionMetricsRepository_CacheWriteTiers(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if err := sqlc.New(db).CreateProject(ctx, sqlc.CreateProjectParams{
+		ID: "proj-cache", Path: "/cache", Name: "cache", CreatedAt: now.Format(time.RFC3339),
+	}); err != nil {
+		t.Fatalf("failed to seed project: %v", err)
+	}
+	if err := turso.NewSessionRepository(db).Create(ctx, &domain.Session{
+		ID: "sess-cache", ProjectID: "proj-cache", Cwd: "/cache", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	// Seeded pricing bills 1-hour cache writes at twice the input rate
+	pricing, err := turso.NewPricingRepository(db).GetByID(ctx, "claude-opus-4-6-20260206")
+	if err != nil || pricing == nil || pricing.CacheWrite1hPerMillion == nil || *pricing.CacheWrite1hPerMillion != 2*pricing.InputPerMillion {
+		t.Fatalf("expected seeded 1-hour cache write pricing, got %+v (%v)", pricing, err)
+	}
+
+	rate := *pricing.CacheWrite1hPerMillion
+	metrics := &domain.SessionMetrics{
+		SessionID:         "sess-cache",
+		TokenCacheWrite:   3000,
+		TokenCacheWrite5m: 1000,
+		TokenCacheWrite1h: 2000,
+		CacheWrite1hRate:  &rate,
+	}
+	repo := turso.NewSessionMetricsRepository(db)
+	if err := repo.Create(ctx, metrics); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	got, err := repo.GetBySessionID(ctx, "sess-cache")
+	if err != nil {
+		t.Fatalf("GetBySessionID failed: %v", err)
+	}
+	if got.TokenCacheWrite5m != 1000 || got.TokenCacheWrite1h != 2000 || got.CacheWrite1hRate == nil || *got.CacheWrite1hRate != rate {
+		t.Errorf("unexpected metrics: %+v", got)
+	}
+
+	stats, err := turso.NewStatsRepository(db).GetAggregate(ctx, "2026-03-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GetAggregate failed: %v", err)
+	}
+	if stats.TotalTokenCacheWrite != 3000 || stats.TotalTokenCacheWrite1h != 2000 {
+		t.Errorf("unexpected aggregate: %+v", stats)
+	}
+}
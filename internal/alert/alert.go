@@ -0,0 +1,250 @@
+// Package alert evaluates alert rules against recorded usage and delivers the
+// alerts that fire.
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// allTime is the lower bound used for experiment totals.
+const allTime = "1970-01-01T00:00:00Z"
+
+// Notifier delivers a fired alert to a channel.
+type Notifier interface {
+	Notify(ctx context.Context, ch domain.AlertChannel, firing *domain.AlertFiring) error
+}
+
+// Evaluator evaluates alert rules and delivers each alert at most once per
+// rule and key.
+type Evaluator struct {
+	stats       ports.StatsRepository
+	budgets     ports.BudgetRepository
+	experiments ports.ExperimentRepository
+	variables   ports.ExperimentVariableRepository
+	firings     ports.AlertRepository
+	notifier    Notifier
+}
+
+func NewEvaluator(stats ports.StatsRepository, budgets ports.BudgetRepository, experiments ports.ExperimentRepository, variables ports.ExperimentVariableRepository, firings ports.AlertRepository, notifier Notifier) *Evaluator {
+	return &Evaluator{
+		stats:       stats,
+		budgets:     budgets,
+		experiments: experiments,
+		variables:   variables,
+		firings:     firings,
+		notifier:    notifier,
+	}
+}
+
+// SessionVars returns the session-scope variables of a recorded session.
+// anomaly may be nil.
+func SessionVars(sample domain.SessionSample, anomaly *domain.SessionAnomaly) map[string]float64 {
+	vars := map[string]float64{
+		"cost":             sample.CostUsd,
+		"tokens":           float64(sample.Tokens),
+		"turns":            float64(sample.Turns),
+		"error_count":      float64(sample.Errors),
+		"tool_calls":       float64(sample.ToolCalls),
+		"duration_minutes": float64(sample.DurationSeconds) / 60,
+		"anomaly_score":    0,
+	}
+	if anomaly != nil {
+		vars["anomaly_score"] = anomaly.Score
+	}
+	return vars
+}
+
+// EvaluateSession evaluates the session rules against a recorded session and
+// returns the alerts that fired.
+func (e *Evaluator) EvaluateSession(ctx context.Context, rules []domain.AlertRule, sample domain.SessionSample, anomaly *domain.SessionAnomaly, now time.Time) ([]*domain.AlertFiring, error) {
+	vars := SessionVars(sample, anomaly)
+	label := "session " + sample.SessionID[:min(8, len(sample.SessionID))]
+
+	var fired []*domain.AlertFiring
+	var errs []error
+	for _, rule := range rulesInScope(rules, domain.AlertScopeSession) {
+		f, err := e.fire(ctx, rule, vars, sample.SessionID, label, now)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if f != nil {
+			fired = append(fired, f)
+		}
+	}
+	return fired, errors.Join(errs...)
+}
+
+// EvaluatePeriodic evaluates the daily, weekly, monthly and experiment rules
+// at now and returns the alerts that fired. Periods start at midnight in
+// now's location, like budgets.
+func (e *Evaluator) EvaluatePeriodic(ctx context.Context, rules []domain.AlertRule, now time.Time) ([]*domain.AlertFiring, error) {
+	var fired []*domain.AlertFiring
+	var errs []error
+	collect := func(f *domain.AlertFiring, err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if f != nil {
+			fired = append(fired, f)
+		}
+	}
+
+	for _, period := range domain.BudgetPeriods {
+		scoped := rulesInScope(rules, domain.AlertScope(period))
+		if len(scoped) == 0 {
+			continue
+		}
+		vars, start, err := e.periodVars(ctx, period, now)
+		if err != nil {
+			return fired, err
+		}
+		key := string(period) + " " + start.Format(time.DateOnly)
+		for _, rule := range scoped {
+			collect(e.fire(ctx, rule, vars, key, key, now))
+		}
+	}
+
+	scoped := rulesInScope(rules, domain.AlertScopeExperiment)
+	if len(scoped) > 0 {
+		experiments, err := e.experiments.List(ctx)
+		if err != nil {
+			return fired, fmt.Errorf("failed to list experiments: %w", err)
+		}
+		for _, exp := range experiments {
+			if exp.EndedAt != nil {
+				continue
+			}
+			vars, err := e.experimentVars(ctx, exp)
+			if err != nil {
+				return fired, err
+			}
+			for _, rule := range scoped {
+				collect(e.fire(ctx, rule, vars, exp.ID, "experiment "+exp.Name, now))
+			}
+		}
+	}
+
+	return fired, errors.Join(errs...)
+}
+
+func (e *Evaluator) periodVars(ctx context.Context, period domain.BudgetPeriod, now time.Time) (map[string]float64, time.Time, error) {
+	start, _ := period.Bounds(now)
+	agg, err := e.stats.GetAggregate(ctx, start.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, start, fmt.Errorf("failed to get %s usage: %w", period, err)
+	}
+	vars := aggregateVars(agg)
+
+	budgets, err := e.budgets.List(ctx)
+	if err != nil {
+		return nil, start, fmt.Errorf("failed to list budgets: %w", err)
+	}
+	for _, b := range budgets {
+		if b.Period == period && b.Scope() == domain.BudgetScopeGlobal {
+			vars["budget"] = b.AmountUsd
+		}
+	}
+	return vars, start, nil
+}
+
+// experimentVars returns the experiment's totals plus its numeric variables,
+// so rules can compare against values such as a target session count.
+func (e *Evaluator) experimentVars(ctx context.Context, exp *domain.Experiment) (map[string]float64, error) {
+	agg, err := e.stats.GetAggregateByExperiment(ctx, exp.ID, allTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiment usage: %w", err)
+	}
+	vars := aggregateVars(agg)
+
+	variables, err := e.variables.ListByExperimentID(ctx, exp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiment variables: %w", err)
+	}
+	for _, v := range variables {
+		if _, builtin := vars[v.Key]; builtin {
+			continue
+		}
+		if n, err := strconv.ParseFloat(v.Value, 64); err == nil {
+			vars[v.Key] = n
+		}
+	}
+	return vars, nil
+}
+
+func aggregateVars(agg *domain.AggregateStats) map[string]float64 {
+	if agg == nil {
+		agg = &domain.AggregateStats{}
+	}
+	return map[string]float64{
+		"cost":        agg.TotalCostUsd,
+		"tokens":      float64(agg.TotalTokenInput + agg.TotalTokenOutput),
+		"sessions":    float64(agg.SessionCount),
+		"error_count": float64(agg.TotalErrors),
+	}
+}
+
+// fire evaluates a rule and, when it holds and has not fired for key yet,
+// records and delivers it. Rules referencing undefined variables are skipped.
+func (e *Evaluator) fire(ctx context.Context, rule domain.AlertRule, vars map[string]float64, key, label string, now time.Time) (*domain.AlertFiring, error) {
+	ok, left, right, err := rule.Condition.Eval(vars)
+	if errors.Is(err, domain.ErrAlertVariableUndefined) || !ok {
+		return nil, nil
+	}
+
+	firing := &domain.AlertFiring{
+		Rule:      rule.Name,
+		Scope:     rule.Condition.Scope,
+		Key:       key,
+		Value:     left,
+		Threshold: right,
+		Message:   message(rule, left, right, label),
+		FiredAt:   now,
+	}
+	claimed, err := e.firings.Claim(ctx, firing)
+	if err != nil || !claimed {
+		return nil, err
+	}
+
+	var errs []error
+	for _, ch := range rule.Channels {
+		if err := e.notifier.Notify(ctx, ch, firing); err != nil {
+			errs = append(errs, fmt.Errorf("alert %q via %s: %w", rule.Name, ch.Type, err))
+		}
+	}
+	return firing, errors.Join(errs...)
+}
+
+// message describes a fired rule, e.g.
+// "daily-spend: daily.cost = 8.50 > budget * 0.8 = 8.00 (daily 2026-03-02)".
+func message(rule domain.AlertRule, left, right float64, label string) string {
+	c := rule.Condition
+	return fmt.Sprintf("%s: %s %s %s (%s)", rule.Name, describeSide(c.Left, left), c.Op, describeSide(c.Right, right), label)
+}
+
+func describeSide(expr string, value float64) string {
+	if _, err := strconv.ParseFloat(expr, 64); err == nil {
+		return expr
+	}
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%s = %.0f", expr, value)
+	}
+	return fmt.Sprintf("%s = %.2f", expr, value)
+}
+
+func rulesInScope(rules []domain.AlertRule, scope domain.AlertScope) []domain.AlertRule {
+	var scoped []domain.AlertRule
+	for _, r := range rules {
+		if r.Condition.Scope == scope {
+			scoped = append(scoped, r)
+		}
+	}
+	return scoped
+}
@@ -0,0 +1,246 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+type fakeStats struct {
+	ports.StatsRepository
+	aggregate    domain.AggregateStats
+	byExperiment map[string]domain.AggregateStats
+}
+
+func (f *fakeStats) GetAggregate(ctx context.Context, since string) (*domain.AggregateStats, error) {
+	return &f.aggregate, nil
+}
+
+func (f *fakeStats) GetAggregateByExperiment(ctx context.Context, experimentID, since string) (*domain.AggregateStats, error) {
+	agg := f.byExperiment[experimentID]
+	return &agg, nil
+}
+
+type fakeBudgets struct {
+	ports.BudgetRepository
+	budgets []*domain.Budget
+}
+
+func (f *fakeBudgets) List(ctx context.Context) ([]*domain.Budget, error) {
+	return f.budgets, nil
+}
+
+type fakeExperiments struct {
+	ports.ExperimentRepository
+	experiments []*domain.Experiment
+}
+
+func (f *fakeExperiments) List(ctx context.Context) ([]*domain.Experiment, error) {
+	return f.experiments, nil
+}
+
+type fakeVariables struct {
+	ports.ExperimentVariableRepository
+	vars map[string][]*domain.ExperimentVariable
+}
+
+func (f *fakeVariables) ListByExperimentID(ctx context.Context, experimentID string) ([]*domain.ExperimentVariable, error) {
+	return f.vars[experimentID], nil
+}
+
+type fakeFirings struct {
+	ports.AlertRepository
+	claimed map[string]bool
+}
+
+func (f *fakeFirings) Claim(ctx context.Context, firing *domain.AlertFiring) (bool, error) {
+	k := firing.Rule + "|" + firing.Key
+	if f.claimed[k] {
+		return false, nil
+	}
+	f.claimed[k] = true
+	return true, nil
+}
+
+type fakeNotifier struct {
+	messages []string
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, ch domain.AlertChannel, firing *domain.AlertFiring) error {
+	f.messages = append(f.messages, firing.Message)
+	return nil
+}
+
+func mustRules(t *testing.T, rules ...RuleConfig) []domain.AlertRule {
+	t.Helper()
+	for i := range rules {
+		rules[i].Notify = []ChannelConfig{{Type: domain.AlertChannelStderr}}
+	}
+	parsed, err := ParseRules(Config{Rules: rules})
+	if err != nil {
+		t.Fatalf("ParseRules failed: %v", err)
+	}
+	return parsed
+}
+
+func TestEvaluator(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)
+	notifier := &fakeNotifier{}
+	e := NewEvaluator(
+		&fakeStats{
+			aggregate:    domain.AggregateStats{TotalCostUsd: 8.5, SessionCount: 3},
+			byExperiment: map[string]domain.AggregateStats{"exp-1": {SessionCount: 30}, "exp-2": {SessionCount: 12}},
+		},
+		&fakeBudgets{budgets: []*domain.Budget{{Period: domain.BudgetDaily, AmountUsd: 10}}},
+		&fakeExperiments{experiments: []*domain.Experiment{{ID: "exp-1", Name: "fast"}, {ID: "exp-2", Name: "slow"}}},
+		&fakeVariables{vars: map[string][]*domain.ExperimentVariable{
+			"exp-1": {{Key: "target", Value: "30"}},
+			"exp-2": {{Key: "target", Value: "30"}},
+		}},
+		&fakeFirings{claimed: map[string]bool{}},
+		notifier,
+	)
+	rules := mustRules(t,
+		RuleConfig{Name: "expensive-session", When: "session.cost > 5"},
+		RuleConfig{Name: "daily-80", When: "daily.cost > budget*0.8"},
+		RuleConfig{Name: "weekly-80", When: "weekly.cost > budget*0.8"},
+		RuleConfig{Name: "target-reached", When: "experiment.sessions == target"},
+	)
+
+	fired, err := e.EvaluatePeriodic(ctx, rules, now)
+	if err != nil {
+		t.Fatalf("EvaluatePeriodic failed: %v", err)
+	}
+	// No weekly budget, so weekly-80 is skipped
+	if len(fired) != 2 || fired[0].Rule != "daily-80" || fired[1].Key != "exp-1" {
+		t.Fatalf("unexpected firings: %+v", fired)
+	}
+	if want := "daily-80: daily.cost = 8.50 > budget * 0.8 = 8 (daily 2026-03-04)"; fired[0].Message != want {
+		t.Errorf("message = %q, want %q", fired[0].Message, want)
+	}
+
+	// Deduplicated on the next evaluation
+	fired, err = e.EvaluatePeriodic(ctx, rules, now.Add(time.Hour))
+	if err != nil || len(fired) != 0 {
+		t.Fatalf("expected no repeated firings, got %+v, %v", fired, err)
+	}
+
+	sample := domain.SessionSample{SessionID: "sess-expensive", CostUsd: 6.25}
+	fired, err = e.EvaluateSession(ctx, rules, sample, nil, now)
+	if err != nil || len(fired) != 1 || fired[0].Key != "sess-expensive" {
+		t.Fatalf("expected the session rule to fire, got %+v, %v", fired, err)
+	}
+	if len(notifier.messages) != 3 {
+		t.Errorf("expected 3 deliveries, got %v", notifier.messages)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	dir := t.TempDir()
+
+	rules, err := LoadRules(filepath.Join(dir, "missing.json"))
+	if err != nil || rules != nil {
+		t.Fatalf("expected no rules for a missing file, got %v, %v", rules, err)
+	}
+
+	path := filepath.Join(dir, "alerts.json")
+	config := `{"rules": [
+		{"name": "big", "when": "session.cost > 5", "notify": [{"type": "webhook", "url": "http://localhost/hook"}, {"type": "desktop"}]}
+	]}`
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = LoadRules(path)
+	if err != nil {
+		t.Fatalf("LoadRules failed: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Condition.Scope != domain.AlertScopeSession || len(rules[0].Channels) != 2 {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+
+	for _, cfg := range []Config{
+		{Rules: []RuleConfig{{When: "session.cost > 5", Notify: []ChannelConfig{{Type: "stderr"}}}}},
+		{Rules: []RuleConfig{{Name: "a", When: "session.cost > 5"}}},
+		{Rules: []RuleConfig{{Name: "a", When: "session.cost > 5", Notify: []ChannelConfig{{Type: "webhook"}}}}},
+		{Rules: []RuleConfig{{Name: "a", When: "session.cost > 5", Notify: []ChannelConfig{{Type: "email"}}}}},
+		{Rules: []RuleConfig{{Name: "a", When: "session.cost >", Notify: []ChannelConfig{{Type: "stderr"}}}}},
+		{Rules: []RuleConfig{
+			{Name: "a", When: "session.cost > 5", Notify: []ChannelConfig{{Type: "stderr"}}},
+			{Name: "a", When: "session.cost > 6", Notify: []ChannelConfig{{Type: "stderr"}}},
+		}},
+	} {
+		if _, err := ParseRules(cfg); err == nil {
+			t.Errorf("ParseRules(%+v) expected error", cfg)
+		}
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	firing := &domain.AlertFiring{
+		Rule:      "big",
+		Scope:     domain.AlertScopeSession,
+		Key:       "sess-1",
+		Value:     6.5,
+		Threshold: 5,
+		Message:   "big: session.cost = 6.50 > 5 (session sess-1)",
+		FiredAt:   time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC),
+	}
+
+	var got Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	var stderr strings.Builder
+	d := NewDispatcher()
+	d.stderr = &stderr
+
+	if err := d.Notify(ctx, domain.AlertChannel{Type: domain.AlertChannelWebhook, URL: srv.URL}, firing); err != nil {
+		t.Fatalf("webhook failed: %v", err)
+	}
+	if got.Rule != "big" || got.Key != "sess-1" || got.Value != 6.5 || got.FiredAt != "2026-03-04T15:00:00Z" {
+		t.Errorf("unexpected payload: %+v", got)
+	}
+
+	out := filepath.Join(t.TempDir(), "alert.out")
+	cmd := `echo "$MCLAUDE_ALERT_RULE $MCLAUDE_ALERT_VALUE" > ` + out + ` && cat >> ` + out
+	if err := d.Notify(ctx, domain.AlertChannel{Type: domain.AlertChannelCommand, Command: cmd}, firing); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "big 6.5\n{\"rule\":\"big\"") {
+		t.Errorf("unexpected command output: %q", data)
+	}
+
+	if err := d.Notify(ctx, domain.AlertChannel{Type: domain.AlertChannelStderr}, firing); err != nil {
+		t.Fatalf("stderr failed: %v", err)
+	}
+	if stderr.String() != "mclaude alert: "+firing.Message+"\n" {
+		t.Errorf("unexpected stderr: %q", stderr.String())
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := d.Notify(ctx, domain.AlertChannel{Type: domain.AlertChannelWebhook, URL: failing.URL}, firing); err == nil {
+		t.Error("expected an error for a failing webhook")
+	}
+}
@@ -0,0 +1,102 @@
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+)
+
+// Config is the layout of the alert rules file.
+type Config struct {
+	Rules []RuleConfig `json:"rules"`
+}
+
+type RuleConfig struct {
+	Name   string          `json:"name"`
+	When   string          `json:"when"`
+	Notify []ChannelConfig `json:"notify"`
+}
+
+type ChannelConfig struct {
+	Type    string `json:"type"`
+	URL     string `json:"url,omitempty"`
+	Command string `json:"command,omitempty"`
+}
+
+// DefaultPath returns the rules file path: $MCLAUDE_ALERTS_FILE if set,
+// otherwise alerts.json in the mclaude config directory.
+func DefaultPath() (string, error) {
+	if path := os.Getenv("MCLAUDE_ALERTS_FILE"); path != "" {
+		return path, nil
+	}
+	dir, err := util.GetXDGConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "alerts.json"), nil
+}
+
+// LoadRules reads and validates the rules file. A missing file means no rules.
+func LoadRules(path string) ([]domain.AlertRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read alert rules: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse alert rules %s: %w", path, err)
+	}
+	return ParseRules(cfg)
+}
+
+// ParseRules validates a rules config and parses each rule's condition.
+func ParseRules(cfg Config) ([]domain.AlertRule, error) {
+	rules := make([]domain.AlertRule, 0, len(cfg.Rules))
+	seen := make(map[string]bool)
+	for i, rc := range cfg.Rules {
+		if rc.Name == "" {
+			return nil, fmt.Errorf("alert rule %d has no name", i+1)
+		}
+		if seen[rc.Name] {
+			return nil, fmt.Errorf("duplicate alert rule %q", rc.Name)
+		}
+		seen[rc.Name] = true
+
+		cond, err := domain.ParseAlertCondition(rc.When)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %q: %w", rc.Name, err)
+		}
+		if len(rc.Notify) == 0 {
+			return nil, fmt.Errorf("alert rule %q has no notify channels", rc.Name)
+		}
+
+		rule := domain.AlertRule{Name: rc.Name, Condition: cond}
+		for _, ch := range rc.Notify {
+			switch ch.Type {
+			case domain.AlertChannelWebhook:
+				if ch.URL == "" {
+					return nil, fmt.Errorf("alert rule %q: webhook needs a url", rc.Name)
+				}
+			case domain.AlertChannelCommand:
+				if ch.Command == "" {
+					return nil, fmt.Errorf("alert rule %q: command channel needs a command", rc.Name)
+				}
+			case domain.AlertChannelStderr, domain.AlertChannelDesktop:
+			default:
+				return nil, fmt.Errorf("alert rule %q: unknown channel type %q (use webhook, command, stderr or desktop)", rc.Name, ch.Type)
+			}
+			rule.Channels = append(rule.Channels, domain.AlertChannel{Type: ch.Type, URL: ch.URL, Command: ch.Command})
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,145 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+// deliveryTimeout bounds each webhook request and shell command.
+const deliveryTimeout = 10 * time.Second
+
+// Payload is the JSON body posted to webhooks and written to the stdin of
+// alert commands.
+type Payload struct {
+	Rule      string  `json:"rule"`
+	Scope     string  `json:"scope"`
+	Key       string  `json:"key"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Message   string  `json:"message"`
+	FiredAt   string  `json:"fired_at"`
+}
+
+func newPayload(f *domain.AlertFiring) Payload {
+	return Payload{
+		Rule:      f.Rule,
+		Scope:     string(f.Scope),
+		Key:       f.Key,
+		Value:     f.Value,
+		Threshold: f.Threshold,
+		Message:   f.Message,
+		FiredAt:   f.FiredAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// Dispatcher delivers alerts over webhooks, shell commands, stderr and
+// desktop notifications.
+type Dispatcher struct {
+	client *http.Client
+	stderr io.Writer
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		client: &http.Client{Timeout: deliveryTimeout},
+		stderr: os.Stderr,
+	}
+}
+
+func (d *Dispatcher) Notify(ctx context.Context, ch domain.AlertChannel, firing *domain.AlertFiring) error {
+	switch ch.Type {
+	case domain.AlertChannelWebhook:
+		return d.webhook(ctx, ch.URL, firing)
+	case domain.AlertChannelCommand:
+		return runCommand(ctx, ch.Command, firing)
+	case domain.AlertChannelStderr:
+		_, err := fmt.Fprintf(d.stderr, "mclaude alert: %s\n", firing.Message)
+		return err
+	case domain.AlertChannelDesktop:
+		return desktopNotify(ctx, firing.Message)
+	default:
+		return fmt.Errorf("unknown channel type %q", ch.Type)
+	}
+}
+
+func (d *Dispatcher) webhook(ctx context.Context, url string, firing *domain.AlertFiring) error {
+	body, err := json.Marshal(newPayload(firing))
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// runCommand runs the command with sh, passing the alert in MCLAUDE_ALERT_*
+// environment variables and as JSON on stdin.
+func runCommand(ctx context.Context, command string, firing *domain.AlertFiring) error {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(newPayload(firing))
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"MCLAUDE_ALERT_RULE="+firing.Rule,
+		"MCLAUDE_ALERT_SCOPE="+string(firing.Scope),
+		"MCLAUDE_ALERT_KEY="+firing.Key,
+		"MCLAUDE_ALERT_VALUE="+strconv.FormatFloat(firing.Value, 'f', -1, 64),
+		"MCLAUDE_ALERT_THRESHOLD="+strconv.FormatFloat(firing.Threshold, 'f', -1, 64),
+		"MCLAUDE_ALERT_MESSAGE="+firing.Message,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("command failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func desktopNotify(ctx context.Context, msg string) error {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title \"mclaude\"", strconv.Quote(msg))
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	case "linux":
+		cmd = exec.CommandContext(ctx, "notify-send", "mclaude", msg)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("desktop notification failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
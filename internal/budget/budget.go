@@ -0,0 +1,155 @@
+// Package budget evaluates spend budgets against the cost of recorded sessions.
+package budget
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// Checker computes the spend of each budget within its current period.
+type Checker struct {
+	budgets     ports.BudgetRepository
+	stats       ports.StatsRepository
+	projects    ports.ProjectRepository
+	experiments ports.ExperimentRepository
+}
+
+func NewChecker(budgets ports.BudgetRepository, stats ports.StatsRepository, projects ports.ProjectRepository, experiments ports.ExperimentRepository) *Checker {
+	return &Checker{budgets: budgets, stats: stats, projects: projects, experiments: experiments}
+}
+
+// Statuses returns the status of every budget at now. Periods start at
+// midnight in now's location.
+func (c *Checker) Statuses(ctx context.Context, now time.Time) ([]domain.BudgetStatus, error) {
+	budgets, err := c.budgets.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.statuses(ctx, budgets, now)
+}
+
+// ForSession returns the status of the budgets that cover a session in
+// projectID. experiment resolves the experiment the session runs under, or
+// "" for none; it is only called when an experiment budget exists, and no
+// spend is computed when no budget covers the session.
+func (c *Checker) ForSession(ctx context.Context, projectID string, experiment func(context.Context) (string, error), now time.Time) ([]domain.BudgetStatus, error) {
+	budgets, err := c.budgets.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var covering []*domain.Budget
+	experimentID, resolved := "", false
+	for _, b := range budgets {
+		if b.Scope() == domain.BudgetScopeExperiment && !resolved {
+			if experimentID, err = experiment(ctx); err != nil {
+				return nil, err
+			}
+			resolved = true
+		}
+		if b.AppliesTo(projectID, experimentID) {
+			covering = append(covering, b)
+		}
+	}
+	return c.statuses(ctx, covering, now)
+}
+
+func (c *Checker) statuses(ctx context.Context, budgets []*domain.Budget, now time.Time) ([]domain.BudgetStatus, error) {
+	statuses := []domain.BudgetStatus{}
+	for _, b := range budgets {
+		s, err := c.status(ctx, b, now)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+func (c *Checker) status(ctx context.Context, b *domain.Budget, now time.Time) (domain.BudgetStatus, error) {
+	start, end := b.Period.Bounds(now)
+	since := start.UTC().Format(time.RFC3339)
+
+	s := domain.BudgetStatus{Budget: b, Label: domain.BudgetScopeGlobal, Start: start, End: end}
+	var agg *domain.AggregateStats
+	var err error
+	switch b.Scope() {
+	case domain.BudgetScopeProject:
+		s.Label = "project " + shortID(*b.ProjectID)
+		if p, _ := c.projects.GetByID(ctx, *b.ProjectID); p != nil {
+			s.Label = "project " + p.Name
+		}
+		agg, err = c.stats.GetAggregateByProject(ctx, *b.ProjectID, since)
+	case domain.BudgetScopeExperiment:
+		s.Label = "experiment " + shortID(*b.ExperimentID)
+		if e, _ := c.experiments.GetByID(ctx, *b.ExperimentID); e != nil {
+			s.Label = "experiment " + e.Name
+		}
+		// Excluding a session from experiment stats does not refund it
+		var spent float64
+		spent, err = c.stats.GetExperimentSpend(ctx, *b.ExperimentID, since)
+		agg = &domain.AggregateStats{TotalCostUsd: spent}
+	default:
+		agg, err = c.stats.GetAggregate(ctx, since)
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to compute spend for %s budget: %w", b.Period, err)
+	}
+	s.SpentUsd = agg.TotalCostUsd
+	return s, nil
+}
+
+// Exceeded splits the exceeded budgets into hard and soft ones.
+func Exceeded(statuses []domain.BudgetStatus) (hard, soft []domain.BudgetStatus) {
+	for _, s := range statuses {
+		if !s.Exceeded() {
+			continue
+		}
+		if s.Budget.Hard {
+			hard = append(hard, s)
+		} else {
+			soft = append(soft, s)
+		}
+	}
+	return hard, soft
+}
+
+// Describe returns a one-line summary of a budget's spend, such as
+// "daily global: $4.20 of $5.00 (84%)".
+func Describe(s domain.BudgetStatus) string {
+	return fmt.Sprintf("%s %s: $%.2f of $%.2f (%.0f%%)",
+		s.Budget.Period, s.Label, s.SpentUsd, s.Budget.AmountUsd, s.UsedFraction()*100)
+}
+
+// Summary describes all statuses on a single line, for session context.
+func Summary(statuses []domain.BudgetStatus) string {
+	parts := make([]string, len(statuses))
+	for i, s := range statuses {
+		parts[i] = Describe(s)
+		if s.Budget.Hard {
+			parts[i] += ", hard"
+		}
+	}
+	return "Budgets: " + strings.Join(parts, "; ")
+}
+
+// ExceededMessage explains which budgets are exhausted and when they reset.
+func ExceededMessage(kind string, statuses []domain.BudgetStatus) string {
+	lines := make([]string, len(statuses))
+	for i, s := range statuses {
+		lines[i] = fmt.Sprintf("%s (resets %s)", Describe(s), s.End.Format("2006-01-02 15:04"))
+	}
+	return fmt.Sprintf("%s budget exceeded: %s", kind, strings.Join(lines, "; "))
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,132 @@
+package budget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+func TestExceededAndMessages(t *testing.T) {
+	end := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+	statuses := []domain.BudgetStatus{
+		{Budget: &domain.Budget{Period: domain.BudgetDaily, AmountUsd: 5, Hard: true}, Label: "global", End: end, SpentUsd: 5.5},
+		{Budget: &domain.Budget{Period: domain.BudgetWeekly, AmountUsd: 20}, Label: "project api", End: end, SpentUsd: 21},
+		{Budget: &domain.Budget{Period: domain.BudgetMonthly, AmountUsd: 100, Hard: true}, Label: "global", End: end, SpentUsd: 40},
+	}
+
+	hard, soft := Exceeded(statuses)
+	if len(hard) != 1 || hard[0].Budget.Period != domain.BudgetDaily {
+		t.Errorf("expected only the daily budget to be hard-exceeded, got %+v", hard)
+	}
+	if len(soft) != 1 || soft[0].Budget.Period != domain.BudgetWeekly {
+		t.Errorf("expected only the weekly budget to be soft-exceeded, got %+v", soft)
+	}
+
+	if got, want := Describe(statuses[0]), "daily global: $5.50 of $5.00 (110%)"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+
+	want := "Budgets: daily global: $5.50 of $5.00 (110%), hard; weekly project api: $21.00 of $20.00 (105%); monthly global: $40.00 of $100.00 (40%), hard"
+	if got := Summary(statuses); got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+
+	want = "Hard budget exceeded: daily global: $5.50 of $5.00 (110%) (resets 2026-03-06 00:00)"
+	if got := ExceededMessage("Hard", hard); got != want {
+		t.Errorf("ExceededMessage = %q, want %q", got, want)
+	}
+}
+
+type fakeStats struct {
+	ports.StatsRepository
+	aggregate       domain.AggregateStats
+	experimentSpend float64
+}
+
+func (f *fakeStats) GetAggregate(ctx context.Context, since string) (*domain.AggregateStats, error) {
+	return &f.aggregate, nil
+}
+
+func (f *fakeStats) GetAggregateByExperiment(ctx context.Context, experimentID, since string) (*domain.AggregateStats, error) {
+	// Stats leave excluded sessions out, budgets must not
+	return &domain.AggregateStats{TotalCostUsd: f.experimentSpend / 2}, nil
+}
+
+func (f *fakeStats) GetExperimentSpend(ctx context.Context, experimentID, since string) (float64, error) {
+	return f.experimentSpend, nil
+}
+
+type fakeBudgets struct {
+	ports.BudgetRepository
+	budgets []*domain.Budget
+}
+
+func (f *fakeBudgets) List(ctx context.Context) ([]*domain.Budget, error) {
+	return f.budgets, nil
+}
+
+type fakeExperiments struct {
+	ports.ExperimentRepository
+}
+
+func (f *fakeExperiments) GetByID(ctx context.Context, id string) (*domain.Experiment, error) {
+	return &domain.Experiment{ID: id, Name: "baseline"}, nil
+}
+
+func TestChecker_ExperimentBudgetCountsExcludedSessions(t *testing.T) {
+	experimentID := "exp-1"
+	checker := NewChecker(
+		&fakeBudgets{budgets: []*domain.Budget{{Period: domain.BudgetDaily, AmountUsd: 5, Hard: true, ExperimentID: &experimentID}}},
+		&fakeStats{experimentSpend: 8},
+		nil,
+		&fakeExperiments{},
+	)
+
+	statuses, err := checker.Statuses(context.Background(), time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Statuses failed: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].SpentUsd != 8 || !statuses[0].Exceeded() {
+		t.Errorf("expected the experiment budget exceeded at $8, got %+v", statuses)
+	}
+	if got, want := statuses[0].Label, "experiment baseline"; got != want {
+		t.Errorf("Label = %q, want %q", got, want)
+	}
+}
+
+func TestChecker_ForSessionSkipsUncoveredSessions(t *testing.T) {
+	otherProject, experimentID := "proj-other", "exp-1"
+	budgets := &fakeBudgets{budgets: []*domain.Budget{{Period: domain.BudgetDaily, AmountUsd: 5, ProjectID: &otherProject}}}
+	// The stats fake has no project spend: computing it would panic
+	checker := NewChecker(budgets, &fakeStats{}, nil, &fakeExperiments{})
+
+	resolved := 0
+	experiment := func(context.Context) (string, error) {
+		resolved++
+		return experimentID, nil
+	}
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	statuses, err := checker.ForSession(context.Background(), "proj-1", experiment, now)
+	if err != nil {
+		t.Fatalf("ForSession failed: %v", err)
+	}
+	if len(statuses) != 0 || resolved != 0 {
+		t.Errorf("expected no statuses without resolving the experiment, got %+v after %d resolutions", statuses, resolved)
+	}
+
+	budgets.budgets = append(budgets.budgets,
+		&domain.Budget{Period: domain.BudgetDaily, AmountUsd: 5, ExperimentID: &experimentID},
+		&domain.Budget{Period: domain.BudgetWeekly, AmountUsd: 20, ExperimentID: &experimentID},
+	)
+	statuses, err = checker.ForSession(context.Background(), "proj-1", experiment, now)
+	if err != nil {
+		t.Fatalf("ForSession failed: %v", err)
+	}
+	if len(statuses) != 2 || resolved != 1 {
+		t.Errorf("expected both experiment budgets after one resolution, got %+v after %d resolutions", statuses, resolved)
+	}
+}
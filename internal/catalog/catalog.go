@@ -0,0 +1,340 @@
+// Package catalog imports and exports the model pricing catalog and the
+// pricing profiles as YAML or JSON files.
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// Format is a catalog file format.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// FormatForPath returns the format of a catalog file from its extension.
+func FormatForPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("unsupported catalog file %q (use .yaml, .yml or .json)", path)
+}
+
+// Catalog is the model pricing catalog with the pricing profiles. Token rates
+// are USD per million tokens and server-side tool rates USD per thousand
+// requests.
+type Catalog struct {
+	Models   []Model   `json:"models" yaml:"models"`
+	Profiles []Profile `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+}
+
+// Rates is the pricing of a model.
+type Rates struct {
+	ID                          string   `json:"id" yaml:"id"`
+	InputPerMillion             float64  `json:"input_per_million" yaml:"input_per_million"`
+	OutputPerMillion            float64  `json:"output_per_million" yaml:"output_per_million"`
+	CacheReadPerMillion         *float64 `json:"cache_read_per_million,omitempty" yaml:"cache_read_per_million,omitempty"`
+	CacheWritePerMillion        *float64 `json:"cache_write_per_million,omitempty" yaml:"cache_write_per_million,omitempty"`
+	CacheWrite1hPerMillion      *float64 `json:"cache_write_1h_per_million,omitempty" yaml:"cache_write_1h_per_million,omitempty"`
+	LongContextInputPerMillion  *float64 `json:"long_context_input_per_million,omitempty" yaml:"long_context_input_per_million,omitempty"`
+	LongContextOutputPerMillion *float64 `json:"long_context_output_per_million,omitempty" yaml:"long_context_output_per_million,omitempty"`
+	LongContextThreshold        *int64   `json:"long_context_threshold,omitempty" yaml:"long_context_threshold,omitempty"`
+	WebSearchPerThousand        *float64 `json:"web_search_per_thousand,omitempty" yaml:"web_search_per_thousand,omitempty"`
+	WebFetchPerThousand         *float64 `json:"web_fetch_per_thousand,omitempty" yaml:"web_fetch_per_thousand,omitempty"`
+}
+
+// Model is a catalog model. Default marks the model used for sessions
+// without known pricing.
+type Model struct {
+	Rates       `yaml:",inline"`
+	DisplayName string `json:"display_name,omitempty" yaml:"display_name,omitempty"`
+	Default     bool   `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// Profile is a pricing profile. A missing multiplier is 1. Default marks the
+// profile used by projects without one.
+type Profile struct {
+	Name        string  `json:"name" yaml:"name"`
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Multiplier  float64 `json:"multiplier,omitempty" yaml:"multiplier,omitempty"`
+	Default     bool    `json:"default,omitempty" yaml:"default,omitempty"`
+	Rates       []Rates `json:"rates,omitempty" yaml:"rates,omitempty"`
+}
+
+// Decode reads and validates a catalog.
+func Decode(r io.Reader, format Format) (*Catalog, error) {
+	var c Catalog
+	var err error
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&c)
+	default:
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(true)
+		err = dec.Decode(&c)
+	}
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse catalog: %w", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Encode writes the catalog.
+func Encode(w io.Writer, c *Catalog, format Format) error {
+	if format == FormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(c)
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Validate checks that models and profiles are named once, rates are not
+// negative and at most one model and one profile are the default.
+func (c *Catalog) Validate() error {
+	models := make(map[string]bool)
+	defaults := 0
+	for _, m := range c.Models {
+		if err := m.Rates.validate("model"); err != nil {
+			return err
+		}
+		if models[m.ID] {
+			return fmt.Errorf("model %q is listed twice", m.ID)
+		}
+		models[m.ID] = true
+		if m.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return fmt.Errorf("%d models are marked as default, expected at most one", defaults)
+	}
+
+	profiles := make(map[string]bool)
+	defaults = 0
+	for _, p := range c.Profiles {
+		if p.Name == "" {
+			return fmt.Errorf("profile without a name")
+		}
+		if profiles[p.Name] {
+			return fmt.Errorf("profile %q is listed twice", p.Name)
+		}
+		profiles[p.Name] = true
+		if p.Multiplier < 0 {
+			return fmt.Errorf("profile %q: multiplier must not be negative", p.Name)
+		}
+		rates := make(map[string]bool)
+		for _, r := range p.Rates {
+			if err := r.validate("profile " + p.Name + " rate"); err != nil {
+				return err
+			}
+			if rates[r.ID] {
+				return fmt.Errorf("profile %q: model %q is listed twice", p.Name, r.ID)
+			}
+			rates[r.ID] = true
+		}
+		if p.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return fmt.Errorf("%d profiles are marked as default, expected at most one", defaults)
+	}
+	return nil
+}
+
+func (r Rates) validate(what string) error {
+	if r.ID == "" {
+		return fmt.Errorf("%s without an id", what)
+	}
+	for _, v := range []*float64{&r.InputPerMillion, &r.OutputPerMillion, r.CacheReadPerMillion, r.CacheWritePerMillion, r.CacheWrite1hPerMillion, r.LongContextInputPerMillion, r.LongContextOutputPerMillion, r.WebSearchPerThousand, r.WebFetchPerThousand} {
+		if v != nil && *v < 0 {
+			return fmt.Errorf("%s %q: rates must not be negative", what, r.ID)
+		}
+	}
+	return nil
+}
+
+// Service moves catalogs in and out of the repositories.
+type Service struct {
+	pricing  ports.PricingRepository
+	profiles ports.PricingProfileRepository
+}
+
+func NewService(pricing ports.PricingRepository, profiles ports.PricingProfileRepository) *Service {
+	return &Service{pricing: pricing, profiles: profiles}
+}
+
+// Export returns the configured models and profiles.
+func (s *Service) Export(ctx context.Context) (*Catalog, error) {
+	pricing, err := s.pricing.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pricing: %w", err)
+	}
+	profiles, err := s.profiles.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{Models: make([]Model, 0, len(pricing))}
+	for _, p := range pricing {
+		m := Model{Rates: ratesFromPricing(p), Default: p.IsDefault}
+		if p.DisplayName != p.ID {
+			m.DisplayName = p.DisplayName
+		}
+		c.Models = append(c.Models, m)
+	}
+	for _, p := range profiles {
+		profile := Profile{Name: p.Name, Multiplier: p.Multiplier, Default: p.IsDefault}
+		if p.Description != nil {
+			profile.Description = *p.Description
+		}
+		for _, r := range p.Rates {
+			profile.Rates = append(profile.Rates, ratesFromPricing(r))
+		}
+		c.Profiles = append(c.Profiles, profile)
+	}
+	return c, nil
+}
+
+// ImportResult counts what an import created and updated.
+type ImportResult struct {
+	ModelsCreated   int
+	ModelsUpdated   int
+	ProfilesCreated int
+	ProfilesUpdated int
+}
+
+// Import adds the catalog's models and profiles, replacing existing ones of
+// the same name; anything not in the catalog is kept. A profile's rates
+// replace all of its existing rates.
+func (s *Service) Import(ctx context.Context, c *Catalog, now time.Time) (*ImportResult, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	result := &ImportResult{}
+
+	for _, m := range c.Models {
+		p := m.Rates.pricing()
+		p.DisplayName = m.DisplayName
+		if p.DisplayName == "" {
+			p.DisplayName = m.ID
+		}
+		p.CreatedAt = now
+
+		existing, err := s.pricing.GetByID(ctx, m.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pricing: %w", err)
+		}
+		if existing != nil {
+			p.IsDefault = existing.IsDefault
+			p.CreatedAt = existing.CreatedAt
+			if err := s.pricing.Update(ctx, p); err != nil {
+				return nil, fmt.Errorf("failed to update pricing: %w", err)
+			}
+			result.ModelsUpdated++
+		} else {
+			if err := s.pricing.Create(ctx, p); err != nil {
+				return nil, fmt.Errorf("failed to create pricing: %w", err)
+			}
+			result.ModelsCreated++
+		}
+		if m.Default {
+			if err := s.pricing.SetDefault(ctx, m.ID); err != nil {
+				return nil, fmt.Errorf("failed to set default pricing: %w", err)
+			}
+		}
+	}
+
+	for _, cp := range c.Profiles {
+		profile := &domain.PricingProfile{Name: cp.Name, Multiplier: cp.Multiplier, CreatedAt: now}
+		if profile.Multiplier == 0 {
+			profile.Multiplier = 1
+		}
+		if cp.Description != "" {
+			profile.Description = &cp.Description
+		}
+		for _, r := range cp.Rates {
+			profile.Rates = append(profile.Rates, r.pricing())
+		}
+
+		existing, err := s.profiles.GetByName(ctx, cp.Name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			profile.CreatedAt = existing.CreatedAt
+			result.ProfilesUpdated++
+		} else {
+			result.ProfilesCreated++
+		}
+		if err := s.profiles.Set(ctx, profile); err != nil {
+			return nil, err
+		}
+		if cp.Default {
+			if err := s.profiles.SetDefault(ctx, cp.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+func ratesFromPricing(p *domain.ModelPricing) Rates {
+	return Rates{
+		ID:                          p.ID,
+		InputPerMillion:             p.InputPerMillion,
+		OutputPerMillion:            p.OutputPerMillion,
+		CacheReadPerMillion:         p.CacheReadPerMillion,
+		CacheWritePerMillion:        p.CacheWritePerMillion,
+		CacheWrite1hPerMillion:      p.CacheWrite1hPerMillion,
+		LongContextInputPerMillion:  p.LongContextInputPerMillion,
+		LongContextOutputPerMillion: p.LongContextOutputPerMillion,
+		LongContextThreshold:        p.LongContextThreshold,
+		WebSearchPerThousand:        p.WebSearchPerThousand,
+		WebFetchPerThousand:         p.WebFetchPerThousand,
+	}
+}
+
+func (r Rates) pricing() *domain.ModelPricing {
+	return &domain.ModelPricing{
+		ID:                          r.ID,
+		DisplayName:                 r.ID,
+		InputPerMillion:             r.InputPerMillion,
+		OutputPerMillion:            r.OutputPerMillion,
+		CacheReadPerMillion:         r.CacheReadPerMillion,
+		CacheWritePerMillion:        r.CacheWritePerMillion,
+		CacheWrite1hPerMillion:      r.CacheWrite1hPerMillion,
+		LongContextInputPerMillion:  r.LongContextInputPerMillion,
+		LongContextOutputPerMillion: r.LongContextOutputPerMillion,
+		LongContextThreshold:        r.LongContextThreshold,
+		WebSearchPerThousand:        r.WebSearchPerThousand,
+		WebFetchPerThousand:         r.WebFetchPerThousand,
+	}
+}
@@ -0,0 +1,182 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+type fakePricing struct {
+	ports.PricingRepository
+	models map[string]*domain.ModelPricing
+}
+
+func (f *fakePricing) List(ctx context.Context) ([]*domain.ModelPricing, error) {
+	var list []*domain.ModelPricing
+	for _, id := range []string{"opus", "sonnet"} {
+		if m, ok := f.models[id]; ok {
+			list = append(list, m)
+		}
+	}
+	return list, nil
+}
+
+func (f *fakePricing) GetByID(ctx context.Context, id string) (*domain.ModelPricing, error) {
+	return f.models[id], nil
+}
+
+func (f *fakePricing) Create(ctx context.Context, p *domain.ModelPricing) error {
+	f.models[p.ID] = p
+	return nil
+}
+
+func (f *fakePricing) Update(ctx context.Context, p *domain.ModelPricing) error {
+	f.models[p.ID] = p
+	return nil
+}
+
+func (f *fakePricing) SetDefault(ctx context.Context, id string) error {
+	for _, m := range f.models {
+		m.IsDefault = m.ID == id
+	}
+	return nil
+}
+
+type fakeProfiles struct {
+	ports.PricingProfileRepository
+	profiles map[string]*domain.PricingProfile
+}
+
+func (f *fakeProfiles) List(ctx context.Context) ([]*domain.PricingProfile, error) {
+	var list []*domain.PricingProfile
+	for _, p := range f.profiles {
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+func (f *fakeProfiles) GetByName(ctx context.Context, name string) (*domain.PricingProfile, error) {
+	return f.profiles[name], nil
+}
+
+func (f *fakeProfiles) Set(ctx context.Context, p *domain.PricingProfile) error {
+	if existing, ok := f.profiles[p.Name]; ok {
+		p.IsDefault = existing.IsDefault
+	}
+	f.profiles[p.Name] = p
+	return nil
+}
+
+func (f *fakeProfiles) SetDefault(ctx context.Context, name string) error {
+	for _, p := range f.profiles {
+		p.IsDefault = p.Name == name
+	}
+	return nil
+}
+
+const catalogYAML = `models:
+  - id: opus
+    display_name: Claude Opus
+    input_per_million: 5
+    output_per_million: 25
+    cache_read_per_million: 0.5
+    web_search_per_thousand: 10
+  - id: sonnet
+    input_per_million: 3
+    output_per_million: 15
+    default: true
+profiles:
+  - name: enterprise
+    description: Negotiated discount
+    multiplier: 0.8
+    default: true
+    rates:
+      - id: sonnet
+        input_per_million: 2
+        output_per_million: 10
+`
+
+func TestImportExport(t *testing.T) {
+	ctx := context.Background()
+	pricing := &fakePricing{models: map[string]*domain.ModelPricing{
+		"opus": {ID: "opus", DisplayName: "Opus", InputPerMillion: 15, OutputPerMillion: 75, IsDefault: true},
+	}}
+	profiles := &fakeProfiles{profiles: map[string]*domain.PricingProfile{
+		domain.DefaultPricingProfile: {Name: domain.DefaultPricingProfile, Multiplier: 1, IsDefault: true},
+	}}
+	svc := NewService(pricing, profiles)
+
+	c, err := Decode(strings.NewReader(catalogYAML), FormatYAML)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	result, err := svc.Import(ctx, c, time.Now())
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.ModelsCreated != 1 || result.ModelsUpdated != 1 || result.ProfilesCreated != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if opus := pricing.models["opus"]; opus.InputPerMillion != 5 || opus.DisplayName != "Claude Opus" || opus.IsDefault {
+		t.Errorf("unexpected opus pricing: %+v", opus)
+	}
+	if sonnet := pricing.models["sonnet"]; !sonnet.IsDefault || sonnet.DisplayName != "sonnet" {
+		t.Errorf("expected sonnet as the default, got %+v", sonnet)
+	}
+	enterprise := profiles.profiles["enterprise"]
+	if !enterprise.IsDefault || profiles.profiles[domain.DefaultPricingProfile].IsDefault || enterprise.Rate("sonnet") == nil {
+		t.Errorf("expected enterprise as the default profile, got %+v", enterprise)
+	}
+
+	// The export reads back as the same catalog, in either format
+	exported, err := svc.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	for _, format := range []Format{FormatYAML, FormatJSON} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, exported, format); err != nil {
+			t.Fatalf("Encode %s failed: %v", format, err)
+		}
+		back, err := Decode(&buf, format)
+		if err != nil {
+			t.Fatalf("Decode %s failed: %v", format, err)
+		}
+		if len(back.Models) != 2 || *back.Models[0].CacheReadPerMillion != 0.5 || *back.Models[0].WebSearchPerThousand != 10 || !back.Models[1].Default || len(back.Profiles) != 2 {
+			t.Errorf("%s round trip lost data: %+v", format, back)
+		}
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":   "models:\n  - id: opus\n    input: 5\n",
+		"missing id":      "models:\n  - input_per_million: 5\n",
+		"negative rate":   "models:\n  - id: opus\n    input_per_million: -1\n",
+		"duplicate":       "models:\n  - id: opus\n  - id: opus\n",
+		"two defaults":    "profiles:\n  - name: a\n    default: true\n  - name: b\n    default: true\n",
+		"unnamed profile": "profiles:\n  - multiplier: 2\n",
+	}
+	for name, input := range tests {
+		if _, err := Decode(strings.NewReader(input), FormatYAML); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFormatForPath(t *testing.T) {
+	if f, err := FormatForPath("prices.YML"); err != nil || f != FormatYAML {
+		t.Errorf("FormatForPath(prices.YML) = %v, %v", f, err)
+	}
+	if f, err := FormatForPath("prices.json"); err != nil || f != FormatJSON {
+		t.Errorf("FormatForPath(prices.json) = %v, %v", f, err)
+	}
+	if _, err := FormatForPath("prices.csv"); err == nil {
+		t.Error("expected an error for a csv file")
+	}
+}
@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/alert"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+var alertCmd = &cobra.Command{
+	Use:   "alert",
+	Short: "Manage alert rules",
+	Long: `Alert rules are read from a JSON file (default: ~/.config/mclaude/alerts.json,
+or $MCLAUDE_ALERTS_FILE). Session rules are evaluated when a session is
+recorded; daily, weekly, monthly and experiment rules are evaluated after each
+session, by "mclaude alert watch" and by "mclaude serve". Each rule fires at
+most once per session, period or experiment.
+
+Example file:
+  {
+    "rules": [
+      {"name": "expensive-session", "when": "session.cost > 5",
+       "notify": [{"type": "desktop"}]},
+      {"name": "daily-80", "when": "daily.cost > budget * 0.8",
+       "notify": [{"type": "webhook", "url": "http://localhost:9000/alerts"}]},
+      {"name": "target-reached", "when": "experiment.sessions >= target",
+       "notify": [{"type": "command", "command": "echo \"$MCLAUDE_ALERT_MESSAGE\" >> ~/alerts.log"}]}
+    ]
+  }
+
+Channels: webhook (POSTs a JSON payload), command (run with sh, the payload on
+stdin and MCLAUDE_ALERT_* variables in the environment), stderr, desktop.
+
+Variables:
+  session.     cost, tokens, turns, error_count, tool_calls, duration_minutes, anomaly_score
+  daily.       cost, tokens, sessions, error_count, budget (the global daily budget)
+  weekly.      same as daily, with the weekly budget
+  monthly.     same as daily, with the monthly budget
+  experiment.  cost, tokens, sessions, error_count, plus numeric experiment variables`,
+}
+
+var alertRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List and validate alert rules",
+	RunE:  runAlertRules,
+}
+
+var alertCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Evaluate daily, weekly, monthly and experiment rules now",
+	RunE:  runAlertCheck,
+}
+
+var alertWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Evaluate daily, weekly, monthly and experiment rules on a timer",
+	Long: `Evaluate daily, weekly, monthly and experiment rules every interval until
+interrupted. The rules file is reloaded on every check.
+
+Examples:
+  mclaude alert watch
+  mclaude alert watch --interval 1m`,
+	RunE: runAlertWatch,
+}
+
+var alertHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show alerts that fired",
+	RunE:  runAlertHistory,
+}
+
+// Flags
+var (
+	alertFile     string
+	alertInterval time.Duration
+	alertLast     int
+)
+
+func init() {
+	rootCmd.AddCommand(alertCmd)
+	alertCmd.AddCommand(alertRulesCmd)
+	alertCmd.AddCommand(alertCheckCmd)
+	alertCmd.AddCommand(alertWatchCmd)
+	alertCmd.AddCommand(alertHistoryCmd)
+
+	alertCmd.PersistentFlags().StringVar(&alertFile, "file", "", "Alert rules file (default: ~/.config/mclaude/alerts.json)")
+	alertWatchCmd.Flags().DurationVar(&alertInterval, "interval", 5*time.Minute, "Time between checks")
+	alertHistoryCmd.Flags().IntVarP(&alertLast, "last", "n", 20, "Number of alerts to show")
+}
+
+func alertRulesPath() (string, error) {
+	if alertFile != "" {
+		return alertFile, nil
+	}
+	return alert.DefaultPath()
+}
+
+func loadAlertRules() ([]domain.AlertRule, error) {
+	path, err := alertRulesPath()
+	if err != nil {
+		return nil, err
+	}
+	return alert.LoadRules(path)
+}
+
+func newAlertEvaluator(db *sql.DB) *alert.Evaluator {
+	return alert.NewEvaluator(
+		turso.NewStatsRepository(db),
+		turso.NewBudgetRepository(db),
+		turso.NewExperimentRepository(db),
+		turso.NewExperimentVariableRepository(db),
+		turso.NewAlertRepository(db),
+		alert.NewDispatcher(),
+	)
+}
+
+// evaluateSessionAlerts runs the alert rules after a session is recorded.
+func evaluateSessionAlerts(ctx context.Context, db *sql.DB, sample domain.SessionSample, anomaly *domain.SessionAnomaly) error {
+	rules, err := loadAlertRules()
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	evaluator := newAlertEvaluator(db)
+	now := time.Now()
+	_, sessionErr := evaluator.EvaluateSession(ctx, rules, sample, anomaly, now)
+	_, periodicErr := evaluator.EvaluatePeriodic(ctx, rules, now)
+	return errors.Join(sessionErr, periodicErr)
+}
+
+// runAlertTimer evaluates the periodic rules every interval until ctx is done,
+// reloading the rules file each time.
+func runAlertTimer(ctx context.Context, db *sql.DB, interval time.Duration, report func(*domain.AlertFiring)) {
+	evaluator := newAlertEvaluator(db)
+	check := func() {
+		rules, err := loadAlertRules()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			return
+		}
+		fired, err := evaluator.EvaluatePeriodic(ctx, rules, time.Now())
+		for _, f := range fired {
+			report(f)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		}
+	}
+
+	check()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+func runAlertRules(cmd *cobra.Command, args []string) error {
+	path, err := alertRulesPath()
+	if err != nil {
+		return err
+	}
+	rules, err := alert.LoadRules(path)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		fmt.Printf("No alert rules in %s\n", path)
+		fmt.Println("\nSee 'mclaude alert --help' for the file format")
+		return nil
+	}
+
+	fmt.Printf("Rules from %s\n\n", path)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSCOPE\tWHEN\tNOTIFY")
+	fmt.Fprintln(w, "----\t-----\t----\t------")
+	for _, r := range rules {
+		channels := make([]string, len(r.Channels))
+		for i, ch := range r.Channels {
+			channels[i] = ch.Type
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, r.Condition.Scope, r.Condition, strings.Join(channels, ", "))
+	}
+	return w.Flush()
+}
+
+func runAlertCheck(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	rules, err := loadAlertRules()
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		fmt.Println("No alert rules configured")
+		return nil
+	}
+
+	fired, err := newAlertEvaluator(app.DB.DB).EvaluatePeriodic(ctx, rules, time.Now())
+	for _, f := range fired {
+		fmt.Printf("Fired %s\n", f.Message)
+	}
+	if err != nil {
+		return err
+	}
+	if len(fired) == 0 {
+		fmt.Println("No new alerts")
+	}
+	return nil
+}
+
+func runAlertWatch(cmd *cobra.Command, args []string) error {
+	if alertInterval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Checking alert rules every %s (Ctrl+C to stop)\n", alertInterval)
+	runAlertTimer(ctx, app.DB.DB, alertInterval, func(f *domain.AlertFiring) {
+		fmt.Printf("%s  Fired %s\n", f.FiredAt.Format("2006-01-02 15:04"), f.Message)
+	})
+	return nil
+}
+
+func runAlertHistory(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	firings, err := app.AlertRepo.ListRecent(ctx, alertLast)
+	if err != nil {
+		return err
+	}
+	if len(firings) == 0 {
+		fmt.Println("No alerts have fired")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIRED\tRULE\tMESSAGE")
+	fmt.Fprintln(w, "-----\t----\t-------")
+	for _, f := range firings {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.FiredAt.Local().Format("2006-01-02 15:04"), f.Rule, f.Message)
+	}
+	return w.Flush()
+}
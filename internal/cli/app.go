@@ -13,14 +13,27 @@ type AppContext struct {
 	SessionRepo     ports.SessionRepository
 	MetricsRepo     ports.SessionMetricsRepository
 	ToolRepo        ports.SessionToolRepository
+	ToolCallRepo    ports.SessionToolCallRepository
 	FileRepo        ports.SessionFileRepository
 	CommandRepo     ports.SessionCommandRepository
 	SubagentRepo    ports.SessionSubagentRepository
 	ExperimentRepo  ports.ExperimentRepository
 	ExpVariableRepo ports.ExperimentVariableRepository
+	TemplateRepo    ports.ExperimentTemplateRepository
+	NoteRepo        ports.ExperimentNoteRepository
+	RotationRepo    ports.RotationGroupRepository
 	ProjectRepo     ports.ProjectRepository
 	PricingRepo     ports.PricingRepository
 	StatsRepo       ports.StatsRepository
+	BudgetRepo      ports.BudgetRepository
+	PlanRepo        ports.PlanRepository
+	UsageRepo       ports.UsageRepository
+	AnomalyRepo     ports.AnomalyRepository
+	AlertRepo       ports.AlertRepository
+	PlanPriceRepo   ports.PlanPriceRepository
+	ProfileRepo     ports.PricingProfileRepository
+	BillingRepo     ports.BillingRepository
+	CommitRepo      ports.CommitRepository
 }
 
 // NewAppContext creates an AppContext with all dependencies initialized.
@@ -35,14 +48,27 @@ func NewAppContext() (*AppContext, error) {
 		SessionRepo:     turso.NewSessionRepository(db.DB),
 		MetricsRepo:     turso.NewSessionMetricsRepository(db.DB),
 		ToolRepo:        turso.NewSessionToolRepository(db.DB),
+		ToolCallRepo:    turso.NewSessionToolCallRepository(db.DB),
 		FileRepo:        turso.NewSessionFileRepository(db.DB),
 		CommandRepo:     turso.NewSessionCommandRepository(db.DB),
 		SubagentRepo:    turso.NewSessionSubagentRepository(db.DB),
 		ExperimentRepo:  turso.NewExperimentRepository(db.DB),
 		ExpVariableRepo: turso.NewExperimentVariableRepository(db.DB),
+		TemplateRepo:    turso.NewExperimentTemplateRepository(db.DB),
+		NoteRepo:        turso.NewExperimentNoteRepository(db.DB),
+		RotationRepo:    turso.NewRotationGroupRepository(db.DB),
 		ProjectRepo:     turso.NewProjectRepository(db.DB),
 		PricingRepo:     turso.NewPricingRepository(db.DB),
 		StatsRepo:       turso.NewStatsRepository(db.DB),
+		BudgetRepo:      turso.NewBudgetRepository(db.DB),
+		PlanRepo:        turso.NewPlanRepository(db.DB),
+		UsageRepo:       turso.NewUsageRepository(db.DB),
+		AnomalyRepo:     turso.NewAnomalyRepository(db.DB),
+		AlertRepo:       turso.NewAlertRepository(db.DB),
+		PlanPriceRepo:   turso.NewPlanPriceRepository(db.DB),
+		ProfileRepo:     turso.NewPricingProfileRepository(db.DB),
+		BillingRepo:     turso.NewBillingRepository(db.DB),
+		CommitRepo:      turso.NewCommitRepository(db.DB),
 	}, nil
 }
 
@@ -17,9 +17,20 @@ func TestAppContextFieldTypes(t *testing.T) {
 	var _ ports.SessionSubagentRepository = a.SubagentRepo       //nolint:staticcheck
 	var _ ports.ExperimentRepository = a.ExperimentRepo          //nolint:staticcheck
 	var _ ports.ExperimentVariableRepository = a.ExpVariableRepo //nolint:staticcheck
+	var _ ports.ExperimentTemplateRepository = a.TemplateRepo    //nolint:staticcheck
+	var _ ports.ExperimentNoteRepository = a.NoteRepo            //nolint:staticcheck
+	var _ ports.RotationGroupRepository = a.RotationRepo         //nolint:staticcheck
 	var _ ports.ProjectRepository = a.ProjectRepo                //nolint:staticcheck
 	var _ ports.PricingRepository = a.PricingRepo                //nolint:staticcheck
 	var _ ports.StatsRepository = a.StatsRepo                    //nolint:staticcheck
+	var _ ports.BudgetRepository = a.BudgetRepo                  //nolint:staticcheck
+	var _ ports.PlanRepository = a.PlanRepo                      //nolint:staticcheck
+	var _ ports.UsageRepository = a.UsageRepo                    //nolint:staticcheck
+	var _ ports.AnomalyRepository = a.AnomalyRepo                //nolint:staticcheck
+	var _ ports.AlertRepository = a.AlertRepo                    //nolint:staticcheck
+	var _ ports.PlanPriceRepository = a.PlanPriceRepo            //nolint:staticcheck
+	var _ ports.PricingProfileRepository = a.ProfileRepo         //nolint:staticcheck
+	var _ ports.BillingRepository = a.BillingRepo                //nolint:staticcheck
 }
 
 func TestAppContextClose_NilDB(t *testing.T) {
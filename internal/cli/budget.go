@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/budget"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Manage spend budgets",
+	Long: `Cap spend per day, week or month, globally or for a project or experiment.
+
+Budgets are checked by the UserPromptSubmit and PreToolUse hooks. Once a hard
+budget is exceeded, prompts and tool calls are blocked until the period resets;
+soft budgets only show a warning. Days start at local midnight and weeks on
+Monday.`,
+}
+
+var budgetSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set one or more budgets",
+	Long: `Set daily, weekly and/or monthly budgets in USD. Setting a budget again
+for the same period and scope replaces it.
+
+Examples:
+  mclaude budget set --daily 5 --monthly 100
+  mclaude budget set --weekly 20 --experiment "minimal-prompts" --hard
+  mclaude budget set --daily 2 --project <project-id> --hard`,
+	RunE: runBudgetSet,
+}
+
+var budgetStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show spend against each budget",
+	RunE:  runBudgetStatus,
+}
+
+var budgetRemoveCmd = &cobra.Command{
+	Use:   "remove <daily|weekly|monthly>",
+	Short: "Remove a budget",
+	Long: `Remove the budget for a period and scope.
+
+Examples:
+  mclaude budget remove daily
+  mclaude budget remove weekly --experiment "minimal-prompts"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBudgetRemove,
+}
+
+// Flags
+var (
+	budgetDaily      float64
+	budgetWeekly     float64
+	budgetMonthly    float64
+	budgetHard       bool
+	budgetProject    string
+	budgetExperiment string
+)
+
+func init() {
+	rootCmd.AddCommand(budgetCmd)
+	budgetCmd.AddCommand(budgetSetCmd)
+	budgetCmd.AddCommand(budgetStatusCmd)
+	budgetCmd.AddCommand(budgetRemoveCmd)
+
+	budgetSetCmd.Flags().Float64Var(&budgetDaily, "daily", 0, "Daily budget in USD")
+	budgetSetCmd.Flags().Float64Var(&budgetWeekly, "weekly", 0, "Weekly budget in USD")
+	budgetSetCmd.Flags().Float64Var(&budgetMonthly, "monthly", 0, "Monthly budget in USD")
+	budgetSetCmd.Flags().BoolVar(&budgetHard, "hard", false, "Block prompts and tool calls once exceeded (default: warn only)")
+
+	for _, cmd := range []*cobra.Command{budgetSetCmd, budgetRemoveCmd} {
+		cmd.Flags().StringVar(&budgetProject, "project", "", "Scope the budget to a project ID")
+		cmd.Flags().StringVar(&budgetExperiment, "experiment", "", "Scope the budget to an experiment")
+		cmd.MarkFlagsMutuallyExclusive("project", "experiment")
+	}
+}
+
+func runBudgetSet(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	amounts := map[domain.BudgetPeriod]float64{
+		domain.BudgetDaily:   budgetDaily,
+		domain.BudgetWeekly:  budgetWeekly,
+		domain.BudgetMonthly: budgetMonthly,
+	}
+	set := 0
+	for _, period := range domain.BudgetPeriods {
+		if cmd.Flags().Changed(string(period)) {
+			if amounts[period] <= 0 {
+				return fmt.Errorf("--%s must be greater than zero", period)
+			}
+			set++
+		}
+	}
+	if set == 0 {
+		return fmt.Errorf("at least one of --daily, --weekly or --monthly is required")
+	}
+
+	projectID, experimentID, scope, err := resolveBudgetScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	mode := "soft"
+	if budgetHard {
+		mode = "hard"
+	}
+	now := time.Now().UTC()
+	for _, period := range domain.BudgetPeriods {
+		if !cmd.Flags().Changed(string(period)) {
+			continue
+		}
+		b := &domain.Budget{
+			Period:       period,
+			AmountUsd:    amounts[period],
+			ProjectID:    projectID,
+			ExperimentID: experimentID,
+			Hard:         budgetHard,
+			CreatedAt:    now,
+		}
+		if err := app.BudgetRepo.Set(ctx, b); err != nil {
+			return err
+		}
+		fmt.Printf("Set %s %s budget for %s: $%.2f\n", mode, period, scope, b.AmountUsd)
+	}
+	return nil
+}
+
+func runBudgetStatus(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	now := time.Now()
+
+	checker := budget.NewChecker(app.BudgetRepo, app.StatsRepo, app.ProjectRepo, app.ExperimentRepo)
+	statuses, err := checker.Statuses(ctx, now)
+	if err != nil {
+		return err
+	}
+	if len(statuses) == 0 {
+		fmt.Println("No budgets set")
+		fmt.Println("\nUse 'mclaude budget set --daily <usd>' to add one")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println("  Budgets")
+	fmt.Println("  =======")
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  SCOPE\tPERIOD\tMODE\tSPENT\tLIMIT\tUSED\tELAPSED\tPROJECTED\tRESETS\tSTATUS")
+	_, _ = fmt.Fprintln(w, "  -----\t------\t----\t-----\t-----\t----\t-------\t---------\t------\t------")
+	for _, s := range statuses {
+		mode := "soft"
+		if s.Budget.Hard {
+			mode = "hard"
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t$%.2f\t$%.2f\t%s %3.0f%%\t%.0f%%\t$%.2f\t%s\t%s\n",
+			truncate(s.Label, 30),
+			s.Budget.Period,
+			mode,
+			s.SpentUsd,
+			s.Budget.AmountUsd,
+			budgetBar(s.UsedFraction()),
+			s.UsedFraction()*100,
+			s.ElapsedFraction(now)*100,
+			s.ProjectedUsd(now),
+			s.End.Format("2006-01-02 15:04"),
+			budgetState(s, now),
+		)
+	}
+	_ = w.Flush()
+	fmt.Println()
+	return nil
+}
+
+func runBudgetRemove(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	period := strings.ToLower(args[0])
+	if !domain.IsValidBudgetPeriod(period) {
+		return fmt.Errorf("invalid period %q (use daily, weekly or monthly)", args[0])
+	}
+
+	projectID, experimentID, scope, err := resolveBudgetScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	removed, err := app.BudgetRepo.Delete(ctx, domain.BudgetID(domain.BudgetPeriod(period), projectID, experimentID))
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("no %s budget set for %s", period, scope)
+	}
+
+	fmt.Printf("Removed %s budget for %s\n", period, scope)
+	return nil
+}
+
+// resolveBudgetScope validates the --project and --experiment flags and
+// returns the scope IDs along with a description of the scope.
+func resolveBudgetScope(ctx context.Context) (projectID, experimentID *string, scope string, err error) {
+	switch {
+	case budgetProject != "":
+		project, err := app.ProjectRepo.GetByID(ctx, budgetProject)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to get project: %w", err)
+		}
+		if project == nil {
+			return nil, nil, "", fmt.Errorf("project not found: %s", budgetProject)
+		}
+		return &project.ID, nil, "project " + project.Name, nil
+	case budgetExperiment != "":
+		exp, err := getExperimentByName(ctx, app.ExperimentRepo, budgetExperiment)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		return nil, &exp.ID, "experiment " + exp.Name, nil
+	default:
+		return nil, nil, domain.BudgetScopeGlobal + " spend", nil
+	}
+}
+
+// budgetBar draws spend as a 10-character bar, capped at full.
+func budgetBar(fraction float64) string {
+	filled := min(int(fraction*10+0.5), 10)
+	return strings.Repeat("█", filled) + strings.Repeat("░", 10-filled)
+}
+
+// budgetState summarizes whether spend is on pace to stay within the budget.
+func budgetState(s domain.BudgetStatus, now time.Time) string {
+	switch {
+	case s.Exceeded() && s.Budget.Hard:
+		return "BLOCKING"
+	case s.Exceeded():
+		return "exceeded"
+	case s.ProjectedUsd(now) > s.Budget.AmountUsd:
+		return "over pace"
+	default:
+		return "on track"
+	}
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+var commandsCmd = &cobra.Command{
+	Use:   "commands",
+	Short: "Analyze Bash commands run during sessions",
+	Long: `Report the Bash commands run during recorded sessions: how often each one
+ran, how often it failed, and how often it was re-run right after failing.
+
+Commands are normalized to their program and subcommand without arguments,
+so "go test ./... -run X" counts as "go test" and "cd web && npm run build"
+as "npm run build". They are grouped in categories: test, build, lint, git,
+package, filesystem, network and other. A retry is a run of the same command
+line as the session's previous command, after it failed.
+
+Examples:
+  mclaude commands                   # Commands from the last 30 days
+  mclaude commands --days 7 --last 30
+  mclaude commands --project <id>    # Commands of one project`,
+	RunE: runCommands,
+}
+
+// Flags
+var (
+	commandsDays    int
+	commandsLast    int
+	commandsProject string
+	commandsTop     int
+)
+
+func init() {
+	rootCmd.AddCommand(commandsCmd)
+	commandsCmd.Flags().IntVarP(&commandsDays, "days", "d", 30, "Number of days of sessions to analyze")
+	commandsCmd.Flags().IntVarP(&commandsLast, "last", "n", 20, "Number of commands to show")
+	commandsCmd.Flags().StringVar(&commandsProject, "project", "", "Filter by project ID")
+	commandsCmd.Flags().IntVar(&commandsTop, "top", 5, "Number of top commands to show per project")
+}
+
+func runCommands(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	opts := ports.ListCommandRunsOptions{
+		Since: time.Now().UTC().AddDate(0, 0, -commandsDays).Format(time.RFC3339),
+	}
+	if commandsProject != "" {
+		opts.ProjectID = &commandsProject
+	}
+	runs, err := app.CommandRepo.ListRuns(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		fmt.Printf("No commands in the last %d days\n", commandsDays)
+		return nil
+	}
+
+	stats := domain.AggregateCommands(runs)
+	fmt.Printf("%d command runs, %d distinct commands (last %d days)\n\n", len(runs), len(stats), commandsDays)
+
+	fmt.Println("By category:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CATEGORY\tCOMMANDS\tRUNS\tFAILED\tFAILURE RATE\tRETRIES")
+	_, _ = fmt.Fprintln(w, "--------\t--------\t----\t------\t------------\t-------")
+	for _, c := range domain.SummarizeCommandCategories(stats) {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.1f%%\t%d\n", c.Category, c.Commands, c.Runs, c.Failures, c.FailureRate()*100, c.Retries)
+	}
+	_ = w.Flush()
+
+	fmt.Println()
+	fmt.Println("Most run commands:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "COMMAND\tCATEGORY\tRUNS\tSESSIONS\tFAILED\tFAILURE RATE\tRETRIES")
+	_, _ = fmt.Fprintln(w, "-------\t--------\t----\t--------\t------\t------------\t-------")
+	for _, s := range stats[:min(commandsLast, len(stats))] {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%.1f%%\t%d\n",
+			truncate(s.Command, 40), s.Category, s.Runs, s.Sessions, s.Failures, s.FailureRate()*100, s.Retries)
+	}
+	_ = w.Flush()
+
+	if retried := domain.RetriedCommands(stats); len(retried) > 0 {
+		fmt.Println()
+		fmt.Println("Retry loops (re-run after failing):")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "COMMAND\tRETRIES\tRUNS\tFAILURE RATE")
+		_, _ = fmt.Fprintln(w, "-------\t-------\t----\t------------")
+		for _, s := range retried[:min(commandsLast, len(retried))] {
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\n", truncate(s.Command, 40), s.Retries, s.Runs, s.FailureRate()*100)
+		}
+		_ = w.Flush()
+	}
+
+	if commandsProject != "" {
+		return nil
+	}
+	projectNames := make(map[string]string)
+	if projects, err := app.ProjectRepo.List(ctx); err == nil {
+		for _, p := range projects {
+			projectNames[p.ID] = p.Name
+		}
+	}
+	fmt.Println()
+	fmt.Println("Top commands by project:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PROJECT\tRUNS\tTOP COMMANDS")
+	_, _ = fmt.Fprintln(w, "-------\t----\t------------")
+	for _, p := range domain.TopCommandsByProject(runs, commandsTop) {
+		name := projectNames[p.ProjectID]
+		if name == "" {
+			name = p.ProjectID[:min(8, len(p.ProjectID))]
+		}
+		top := make([]string, len(p.Commands))
+		for i, s := range p.Commands {
+			top[i] = fmt.Sprintf("%s (%d)", s.Command, s.Runs)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\n", truncate(name, 30), p.Runs, strings.Join(top, ", "))
+	}
+	_ = w.Flush()
+	return nil
+}
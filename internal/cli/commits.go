@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+var commitsCmd = &cobra.Command{
+	Use:   "commits",
+	Short: "List commits made during sessions",
+	Long: `List the commits made during recorded sessions, with the share of the
+session's cost each one took, and the commits per experiment.
+
+A session's commits are the new commits between the HEAD it started at and
+its end. When the start was not captured, the commits made on HEAD while the
+session was active are used if it ran 'git commit'. A session's cost is split
+evenly between its commits.
+
+Examples:
+  mclaude commits                     # Commits from the last 30 days
+  mclaude commits --last 50 --days 90
+  mclaude commits --experiment "exp"  # Commits made under an experiment`,
+	RunE: runCommits,
+}
+
+// Flags
+var (
+	commitsLast       int
+	commitsDays       int
+	commitsExperiment string
+)
+
+func init() {
+	rootCmd.AddCommand(commitsCmd)
+	commitsCmd.Flags().IntVarP(&commitsLast, "last", "n", 20, "Number of commits to show")
+	commitsCmd.Flags().IntVarP(&commitsDays, "days", "d", 30, "Number of days of commits to show")
+	commitsCmd.Flags().StringVarP(&commitsExperiment, "experiment", "e", "", "Filter by experiment name")
+}
+
+func runCommits(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	opts := ports.ListCommitsOptions{
+		Limit: commitsLast,
+		Since: time.Now().UTC().AddDate(0, 0, -commitsDays).Format(time.RFC3339),
+	}
+	var experiment *domain.Experiment
+	if commitsExperiment != "" {
+		exp, err := getExperimentByName(ctx, app.ExperimentRepo, commitsExperiment)
+		if err != nil {
+			return err
+		}
+		experiment = exp
+		opts.ExperimentID = &exp.ID
+	}
+
+	commits, err := app.CommitRepo.List(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		fmt.Printf("No commits in the last %d days\n", commitsDays)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "HASH\tDATE\tSESSION\tEXPERIMENT\tFILES\tLINES\tCOST\tMESSAGE")
+		_, _ = fmt.Fprintln(w, "----\t----\t-------\t----------\t-----\t-----\t----\t-------")
+		for _, c := range commits {
+			exp := "-"
+			if c.ExperimentName != nil {
+				exp = truncate(*c.ExperimentName, 20)
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t+%d -%d\t%s\t%s\n",
+				c.ShortHash(),
+				c.CommittedAt.Local().Format("2006-01-02 15:04"),
+				c.SessionID[:min(8, len(c.SessionID))],
+				exp,
+				c.FilesChanged,
+				c.LinesAdded,
+				c.LinesRemoved,
+				commitCostLabel(c.CostUsd()),
+				truncate(c.Subject(), 50),
+			)
+		}
+		_ = w.Flush()
+	}
+
+	stats, err := app.CommitRepo.ListStatsByExperiment(ctx)
+	if err != nil {
+		return err
+	}
+	if experiment != nil {
+		stats = filterCommitStats(stats, experiment.ID)
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println("Commits by experiment (all time):")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "EXPERIMENT\tSESSIONS\tWITH COMMITS\tCOMMITS\tCOMMITS/SESSION\tLINES\tCOST\tCOST/COMMIT")
+	_, _ = fmt.Fprintln(w, "----------\t--------\t------------\t-------\t---------------\t-----\t----\t-----------")
+	for _, s := range stats {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.2f\t+%d -%d\t$%.2f\t%s\n",
+			truncate(s.ExperimentName, 30),
+			s.Sessions,
+			s.SessionsWithCommits,
+			s.Commits,
+			s.CommitsPerSession(),
+			s.LinesAdded,
+			s.LinesRemoved,
+			s.CostUsd,
+			commitCostLabel(s.CostPerCommit()),
+		)
+	}
+	_ = w.Flush()
+	return nil
+}
+
+func commitCostLabel(cost *float64) string {
+	if cost == nil {
+		return "-"
+	}
+	return fmt.Sprintf("$%.4f", *cost)
+}
+
+func filterCommitStats(stats []domain.ExperimentCommitStats, experimentID string) []domain.ExperimentCommitStats {
+	for _, s := range stats {
+		if s.ExperimentID == experimentID {
+			return []domain.ExperimentCommitStats{s}
+		}
+	}
+	return nil
+}
@@ -69,6 +69,12 @@ func showDefaultModel(ctx context.Context) error {
 	if defaultModel.CacheWritePerMillion != nil {
 		fmt.Printf("  Cache Write: $%.2f / 1M tokens\n", *defaultModel.CacheWritePerMillion)
 	}
+	if defaultModel.CacheWrite1hPerMillion != nil {
+		fmt.Printf("  Cache Write (1h): $%.2f / 1M tokens\n", *defaultModel.CacheWrite1hPerMillion)
+	}
+	if defaultModel.WebSearchPerThousand != nil {
+		fmt.Printf("  Web Search:  $%.2f / 1K requests\n", *defaultModel.WebSearchPerThousand)
+	}
 
 	return nil
 }
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+var configTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage experiment templates",
+	Long: `Save reusable experiment metadata and variables as templates.
+
+Use a template with: mclaude experiment create <name> --template <template>`,
+}
+
+var configTemplateSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save an experiment template",
+	Long: `Save an experiment template from flags, or from an existing experiment.
+
+Saving over an existing template replaces it.
+
+Examples:
+  mclaude config template save opus-max --model claude-opus-4-6 --plan max_20x --var effort=high
+  mclaude config template save baseline --from "baseline"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigTemplateSave,
+}
+
+var configTemplateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List experiment templates",
+	RunE:  runConfigTemplateList,
+}
+
+var configTemplateDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete an experiment template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigTemplateDelete,
+}
+
+// Flags
+var templateFrom string
+
+func init() {
+	configCmd.AddCommand(configTemplateCmd)
+	configTemplateCmd.AddCommand(configTemplateSaveCmd)
+	configTemplateCmd.AddCommand(configTemplateListCmd)
+	configTemplateCmd.AddCommand(configTemplateDeleteCmd)
+
+	addExperimentMetadataFlags(configTemplateSaveCmd)
+	configTemplateSaveCmd.Flags().StringVar(&templateFrom, "from", "", "Copy metadata and variables from an existing experiment")
+}
+
+func runConfigTemplateSave(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name := args[0]
+
+	// Build the template on an experiment so the shared flag handling applies.
+	exp := &domain.Experiment{}
+	vars := map[string]string{}
+
+	if templateFrom != "" {
+		src, err := getExperimentByName(ctx, app.ExperimentRepo, templateFrom)
+		if err != nil {
+			return err
+		}
+		exp = src
+		srcVars, err := app.ExpVariableRepo.ListByExperimentID(ctx, src.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list variables: %w", err)
+		}
+		for _, v := range srcVars {
+			vars[v.Key] = v.Value
+		}
+	}
+
+	applyExperimentMetadataFlags(cmd, exp)
+	varSets, err := parseVarFlags(expVars)
+	if err != nil {
+		return err
+	}
+	maps.Copy(vars, varSets)
+
+	tmpl := &domain.ExperimentTemplate{
+		Name:        name,
+		Description: exp.Description,
+		Hypothesis:  exp.Hypothesis,
+		ModelID:     exp.ModelID,
+		PlanType:    exp.PlanType,
+		Notes:       exp.Notes,
+		Variables:   vars,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := app.TemplateRepo.Save(ctx, tmpl); err != nil {
+		return fmt.Errorf("failed to save template: %w", err)
+	}
+
+	fmt.Printf("Saved experiment template: %s\n", name)
+	return nil
+}
+
+func runConfigTemplateList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	templates, err := app.TemplateRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	if len(templates) == 0 {
+		fmt.Println("No experiment templates found")
+		fmt.Println("\nUse 'mclaude config template save <name>' to create one")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tMODEL\tPLAN\tVARIABLES\tDESCRIPTION")
+	_, _ = fmt.Fprintln(w, "----\t-----\t----\t---------\t-----------")
+
+	for _, t := range templates {
+		pairs := make([]string, 0, len(t.Variables))
+		for _, key := range slices.Sorted(maps.Keys(t.Variables)) {
+			pairs = append(pairs, key+"="+t.Variables[key])
+		}
+		vars := "-"
+		if len(pairs) > 0 {
+			vars = strings.Join(pairs, ", ")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			t.Name, valueOrDash(t.ModelID), valueOrDash(t.PlanType), vars, valueOrDash(t.Description))
+	}
+
+	_ = w.Flush()
+	return nil
+}
+
+func runConfigTemplateDelete(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name := args[0]
+
+	if _, err := getTemplateByName(ctx, name); err != nil {
+		return err
+	}
+	if err := app.TemplateRepo.Delete(ctx, name); err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	fmt.Printf("Deleted experiment template: %s\n", name)
+	return nil
+}
+
+func getTemplateByName(ctx context.Context, name string) (*domain.ExperimentTemplate, error) {
+	tmpl, err := app.TemplateRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	if tmpl == nil {
+		return nil, fmt.Errorf("experiment template %q not found", name)
+	}
+	return tmpl, nil
+}
+
+// applyTemplate copies a template's metadata onto an experiment.
+func applyTemplate(exp *domain.Experiment, tmpl *domain.ExperimentTemplate) {
+	exp.Description = tmpl.Description
+	exp.Hypothesis = tmpl.Hypothesis
+	exp.ModelID = tmpl.ModelID
+	exp.PlanType = tmpl.PlanType
+	exp.Notes = tmpl.Notes
+}
+
+func valueOrDash(s *string) string {
+	if s == nil || *s == "" {
+		return "-"
+	}
+	return *s
+}
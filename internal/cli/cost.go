@@ -27,11 +27,12 @@ var costListCmd = &cobra.Command{
 var costSetCmd = &cobra.Command{
 	Use:   "set <model-id>",
 	Short: "Set model pricing",
-	Long: `Set pricing for a model (USD per 1M tokens).
+	Long: `Set pricing for a model (USD per 1M tokens, and per 1K requests for the
+server-side web search and web fetch tools).
 
 Examples:
   mclaude cost set claude-sonnet-4-20250514 --input 3.00 --output 15.00
-  mclaude cost set claude-opus-4-6-20260115 --input 5.00 --output 25.00 --cache-read 0.50 --cache-write 6.25 --long-input 10.00 --long-output 37.50
+  mclaude cost set claude-opus-4-6-20260115 --input 5.00 --output 25.00 --cache-read 0.50 --cache-write 6.25 --cache-write-1h 10.00 --long-input 10.00 --long-output 37.50
   mclaude cost set claude-opus-4-20250514 --input 15.00 --output 75.00 --cache-read 1.50 --cache-write 18.75`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCostSet,
@@ -57,10 +58,13 @@ var (
 	costOutput        float64
 	costCacheRead     float64
 	costCacheWrite    float64
+	costCacheWrite1h  float64
 	costName          string
 	costLongInput     float64
 	costLongOutput    float64
 	costLongThreshold int64
+	costWebSearch     float64
+	costWebFetch      float64
 )
 
 func init() {
@@ -75,9 +79,12 @@ func init() {
 	costSetCmd.Flags().Float64Var(&costOutput, "output", 0, "Output tokens cost per 1M (required)")
 	costSetCmd.Flags().Float64Var(&costCacheRead, "cache-read", 0, "Cache read tokens cost per 1M")
 	costSetCmd.Flags().Float64Var(&costCacheWrite, "cache-write", 0, "Cache write tokens cost per 1M")
+	costSetCmd.Flags().Float64Var(&costCacheWrite1h, "cache-write-1h", 0, "1-hour cache write tokens cost per 1M (defaults to twice the input cost)")
 	costSetCmd.Flags().Float64Var(&costLongInput, "long-input", 0, "Long context input cost per 1M (>200K tokens)")
 	costSetCmd.Flags().Float64Var(&costLongOutput, "long-output", 0, "Long context output cost per 1M (>200K tokens)")
 	costSetCmd.Flags().Int64Var(&costLongThreshold, "long-threshold", 200000, "Input token threshold for long context pricing")
+	costSetCmd.Flags().Float64Var(&costWebSearch, "web-search", domain.DefaultWebSearchPerThousand, "Web search cost per 1K requests")
+	costSetCmd.Flags().Float64Var(&costWebFetch, "web-fetch", 0, "Web fetch cost per 1K requests")
 	costSetCmd.Flags().StringVar(&costName, "name", "", "Display name (defaults to model ID)")
 	_ = costSetCmd.MarkFlagRequired("input")
 	_ = costSetCmd.MarkFlagRequired("output")
@@ -98,8 +105,8 @@ func runCostList(cmd *cobra.Command, args []string) error {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(w, "MODEL ID\tNAME\tINPUT/1M\tOUTPUT/1M\tCACHE R/1M\tCACHE W/1M\tLONG IN/1M\tLONG OUT/1M\tDEFAULT")
-	_, _ = fmt.Fprintln(w, "--------\t----\t--------\t---------\t----------\t----------\t----------\t-----------\t-------")
+	_, _ = fmt.Fprintln(w, "MODEL ID\tNAME\tINPUT/1M\tOUTPUT/1M\tCACHE R/1M\tCACHE W/1M\tCACHE W 1H/1M\tLONG IN/1M\tLONG OUT/1M\tSEARCH/1K\tDEFAULT")
+	_, _ = fmt.Fprintln(w, "--------\t----\t--------\t---------\t----------\t----------\t-------------\t----------\t-----------\t---------\t-------")
 
 	for _, p := range pricing {
 		cacheRead := "-"
@@ -110,6 +117,10 @@ func runCostList(cmd *cobra.Command, args []string) error {
 		if p.CacheWritePerMillion != nil {
 			cacheWrite = fmt.Sprintf("$%.2f", *p.CacheWritePerMillion)
 		}
+		cacheWrite1h := "-"
+		if p.CacheWrite1hPerMillion != nil {
+			cacheWrite1h = fmt.Sprintf("$%.2f", *p.CacheWrite1hPerMillion)
+		}
 		longInput := "-"
 		if p.LongContextInputPerMillion != nil {
 			longInput = fmt.Sprintf("$%.2f", *p.LongContextInputPerMillion)
@@ -118,14 +129,18 @@ func runCostList(cmd *cobra.Command, args []string) error {
 		if p.LongContextOutputPerMillion != nil {
 			longOutput = fmt.Sprintf("$%.2f", *p.LongContextOutputPerMillion)
 		}
+		webSearch := "-"
+		if p.WebSearchPerThousand != nil {
+			webSearch = fmt.Sprintf("$%.2f", *p.WebSearchPerThousand)
+		}
 		isDefault := ""
 		if p.IsDefault {
 			isDefault = "*"
 		}
 
-		_, _ = fmt.Fprintf(w, "%s\t%s\t$%.2f\t$%.2f\t%s\t%s\t%s\t%s\t%s\n",
+		_, _ = fmt.Fprintf(w, "%s\t%s\t$%.2f\t$%.2f\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			p.ID, p.DisplayName, p.InputPerMillion, p.OutputPerMillion,
-			cacheRead, cacheWrite, longInput, longOutput, isDefault)
+			cacheRead, cacheWrite, cacheWrite1h, longInput, longOutput, webSearch, isDefault)
 	}
 
 	_ = w.Flush()
@@ -142,11 +157,13 @@ func runCostSet(cmd *cobra.Command, args []string) error {
 	}
 
 	pricing := &domain.ModelPricing{
-		ID:               modelID,
-		DisplayName:      displayName,
-		InputPerMillion:  costInput,
-		OutputPerMillion: costOutput,
-		CreatedAt:        time.Now().UTC(),
+		ID:                   modelID,
+		DisplayName:          displayName,
+		InputPerMillion:      costInput,
+		OutputPerMillion:     costOutput,
+		WebSearchPerThousand: &costWebSearch,
+		WebFetchPerThousand:  &costWebFetch,
+		CreatedAt:            time.Now().UTC(),
 	}
 
 	if costCacheRead > 0 {
@@ -155,6 +172,9 @@ func runCostSet(cmd *cobra.Command, args []string) error {
 	if costCacheWrite > 0 {
 		pricing.CacheWritePerMillion = &costCacheWrite
 	}
+	if costCacheWrite1h > 0 {
+		pricing.CacheWrite1hPerMillion = &costCacheWrite1h
+	}
 	if costLongInput > 0 {
 		pricing.LongContextInputPerMillion = &costLongInput
 	}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/catalog"
+)
+
+var costImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a pricing catalog from YAML or JSON",
+	Long: `Import model pricing and pricing profiles from a .yaml, .yml or .json file.
+
+Models and profiles in the file replace those with the same ID or name; the
+rest are kept. A profile's rates replace all of its existing rates.
+
+Example file:
+  models:
+    - id: claude-sonnet-4-5-20250929
+      display_name: Claude Sonnet 4.5
+      input_per_million: 3.00
+      output_per_million: 15.00
+      cache_read_per_million: 0.30
+      cache_write_per_million: 3.75
+      web_search_per_thousand: 10.00
+      default: true
+  profiles:
+    - name: enterprise-discount
+      multiplier: 0.8
+
+Examples:
+  mclaude cost import pricing.yaml
+  mclaude cost export team.json && mclaude cost import team.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCostImport,
+}
+
+var costExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export the pricing catalog as YAML or JSON",
+	Long: `Export model pricing and pricing profiles to a .yaml, .yml or .json file,
+or as YAML to stdout without a file.
+
+Examples:
+  mclaude cost export
+  mclaude cost export pricing.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCostExport,
+}
+
+func init() {
+	costCmd.AddCommand(costImportCmd)
+	costCmd.AddCommand(costExportCmd)
+}
+
+func runCostImport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	path := args[0]
+
+	format, err := catalog.FormatForPath(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open catalog: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	c, err := catalog.Decode(f, format)
+	if err != nil {
+		return err
+	}
+	result, err := catalog.NewService(app.PricingRepo, app.ProfileRepo).Import(ctx, c, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %s: %d models (%d new, %d updated), %d profiles (%d new, %d updated)\n",
+		path,
+		result.ModelsCreated+result.ModelsUpdated, result.ModelsCreated, result.ModelsUpdated,
+		result.ProfilesCreated+result.ProfilesUpdated, result.ProfilesCreated, result.ProfilesUpdated,
+	)
+	return nil
+}
+
+func runCostExport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	c, err := catalog.NewService(app.PricingRepo, app.ProfileRepo).Export(ctx)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return catalog.Encode(os.Stdout, c, catalog.FormatYAML)
+	}
+
+	path := args[0]
+	format, err := catalog.FormatForPath(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create catalog: %w", err)
+	}
+	if err := catalog.Encode(f, c, format); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write catalog: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write catalog: %w", err)
+	}
+
+	fmt.Printf("Exported %d models and %d profiles to %s\n", len(c.Models), len(c.Profiles), path)
+	return nil
+}
@@ -0,0 +1,374 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+var costProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage pricing profiles",
+	Long: `Pricing profiles adjust model pricing to what is actually paid through a
+provider or contract, such as anthropic-api, bedrock, vertex or an enterprise
+discount. A profile multiplies the catalog rates, and can replace the rates of
+specific models.
+
+Sessions are priced under their project's profile, or the default profile for
+projects without one. Profiles apply to sessions recorded from then on.`,
+}
+
+var costProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pricing profiles and project assignments",
+	RunE:  runCostProfileList,
+}
+
+var costProfileSetCmd = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Create or update a pricing profile",
+	Long: `Create or update a pricing profile. Existing model rates are kept.
+
+Examples:
+  mclaude cost profile set enterprise-discount --multiplier 0.8 --description "Negotiated 20% off"
+  mclaude cost profile set vertex-regional --multiplier 1.1`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCostProfileSet,
+}
+
+var costProfileRateCmd = &cobra.Command{
+	Use:   "rate <name> <model-id>",
+	Short: "Set a profile's pricing of a model (USD per 1M tokens)",
+	Long: `Set the pricing of a model under a profile, replacing the catalog rates
+instead of applying the profile's multiplier.
+
+Examples:
+  mclaude cost profile rate enterprise-discount claude-opus-4-6-20260206 --input 4.00 --output 20.00
+  mclaude cost profile rate enterprise-discount claude-opus-4-6-20260206 --remove`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCostProfileRate,
+}
+
+var costProfileDefaultCmd = &cobra.Command{
+	Use:   "default <name>",
+	Short: "Set the profile used by projects without one",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCostProfileDefault,
+}
+
+var costProfileAssignCmd = &cobra.Command{
+	Use:   "assign <name> --project <project-id>",
+	Short: "Price a project's sessions under a profile",
+	Long: `Price a project's sessions under a profile. Use 'default' as the name to
+return the project to the default profile.
+
+Examples:
+  mclaude cost profile assign bedrock --project <project-id>
+  mclaude cost profile assign default --project <project-id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCostProfileAssign,
+}
+
+var costProfileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a pricing profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCostProfileDelete,
+}
+
+// Flags
+var (
+	profileMultiplier  float64
+	profileDescription string
+	profileProject     string
+	profileRemoveRate  bool
+)
+
+func init() {
+	costCmd.AddCommand(costProfileCmd)
+
+	costProfileCmd.AddCommand(costProfileListCmd)
+	costProfileCmd.AddCommand(costProfileSetCmd)
+	costProfileCmd.AddCommand(costProfileRateCmd)
+	costProfileCmd.AddCommand(costProfileDefaultCmd)
+	costProfileCmd.AddCommand(costProfileAssignCmd)
+	costProfileCmd.AddCommand(costProfileDeleteCmd)
+
+	costProfileSetCmd.Flags().Float64Var(&profileMultiplier, "multiplier", 1, "Multiplier applied to the catalog rates")
+	costProfileSetCmd.Flags().StringVar(&profileDescription, "description", "", "Description of the profile")
+
+	costProfileRateCmd.Flags().Float64Var(&costInput, "input", 0, "Input tokens cost per 1M (required)")
+	costProfileRateCmd.Flags().Float64Var(&costOutput, "output", 0, "Output tokens cost per 1M (required)")
+	costProfileRateCmd.Flags().Float64Var(&costCacheRead, "cache-read", 0, "Cache read tokens cost per 1M")
+	costProfileRateCmd.Flags().Float64Var(&costCacheWrite, "cache-write", 0, "Cache write tokens cost per 1M")
+	costProfileRateCmd.Flags().Float64Var(&costCacheWrite1h, "cache-write-1h", 0, "1-hour cache write tokens cost per 1M (defaults to twice the input cost)")
+	costProfileRateCmd.Flags().Float64Var(&costLongInput, "long-input", 0, "Long context input cost per 1M (>200K tokens)")
+	costProfileRateCmd.Flags().Float64Var(&costLongOutput, "long-output", 0, "Long context output cost per 1M (>200K tokens)")
+	costProfileRateCmd.Flags().Int64Var(&costLongThreshold, "long-threshold", 200000, "Input token threshold for long context pricing")
+	costProfileRateCmd.Flags().Float64Var(&costWebSearch, "web-search", domain.DefaultWebSearchPerThousand, "Web search cost per 1K requests")
+	costProfileRateCmd.Flags().Float64Var(&costWebFetch, "web-fetch", 0, "Web fetch cost per 1K requests")
+	costProfileRateCmd.Flags().BoolVar(&profileRemoveRate, "remove", false, "Remove the model's rates from the profile")
+	costProfileRateCmd.MarkFlagsMutuallyExclusive("remove", "input")
+	costProfileRateCmd.MarkFlagsMutuallyExclusive("remove", "output")
+
+	costProfileAssignCmd.Flags().StringVar(&profileProject, "project", "", "Project ID (required)")
+	_ = costProfileAssignCmd.MarkFlagRequired("project")
+}
+
+func runCostProfileList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	profiles, err := app.ProfileRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	if len(profiles) == 0 {
+		fmt.Println("No pricing profiles configured")
+		fmt.Println("\nUse 'mclaude cost profile set' to add one")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tMULTIPLIER\tMODEL RATES\tDEFAULT\tDESCRIPTION")
+	_, _ = fmt.Fprintln(w, "----\t----------\t-----------\t-------\t-----------")
+	for _, p := range profiles {
+		isDefault := ""
+		if p.IsDefault {
+			isDefault = "*"
+		}
+		description := "-"
+		if p.Description != nil {
+			description = *p.Description
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%.2fx\t%d\t%s\t%s\n", p.Name, p.Multiplier, len(p.Rates), isDefault, truncate(description, 50))
+	}
+	_ = w.Flush()
+
+	for _, p := range profiles {
+		if len(p.Rates) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s rates:\n", p.Name)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  MODEL ID\tINPUT/1M\tOUTPUT/1M\tCACHE R/1M\tCACHE W/1M")
+		for _, r := range p.Rates {
+			_, _ = fmt.Fprintf(w, "  %s\t$%.2f\t$%.2f\t%s\t%s\n", r.ID, r.InputPerMillion, r.OutputPerMillion, formatRate(r.CacheReadPerMillion), formatRate(r.CacheWritePerMillion))
+		}
+		_ = w.Flush()
+	}
+
+	assigned, err := app.ProfileRepo.ListProjects(ctx)
+	if err != nil {
+		return err
+	}
+	if len(assigned) > 0 {
+		ids := make([]string, 0, len(assigned))
+		for id := range assigned {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		fmt.Println("\nProjects:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, id := range ids {
+			name := id
+			if p, _ := app.ProjectRepo.GetByID(ctx, id); p != nil {
+				name = p.Name
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\n", truncate(name, 30), id, assigned[id])
+		}
+		_ = w.Flush()
+	}
+	return nil
+}
+
+func runCostProfileSet(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name := args[0]
+
+	if name == "default" {
+		return fmt.Errorf(`"default" is reserved for 'mclaude cost profile assign'`)
+	}
+	if profileMultiplier <= 0 {
+		return fmt.Errorf("--multiplier must be positive")
+	}
+
+	profile, err := app.ProfileRepo.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	created := profile == nil
+	if created {
+		profile = &domain.PricingProfile{Name: name, Multiplier: 1, CreatedAt: time.Now().UTC()}
+	}
+	if created || cmd.Flags().Changed("multiplier") {
+		profile.Multiplier = profileMultiplier
+	}
+	if cmd.Flags().Changed("description") {
+		profile.Description = nil
+		if profileDescription != "" {
+			profile.Description = &profileDescription
+		}
+	}
+
+	if err := app.ProfileRepo.Set(ctx, profile); err != nil {
+		return err
+	}
+	if created {
+		fmt.Printf("Created pricing profile %s (%.2fx)\n", name, profile.Multiplier)
+	} else {
+		fmt.Printf("Updated pricing profile %s (%.2fx)\n", name, profile.Multiplier)
+	}
+	return nil
+}
+
+func runCostProfileRate(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name, modelID := args[0], domain.ResolveModelAlias(args[1])
+
+	profile, err := app.ProfileRepo.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return fmt.Errorf("pricing profile %q not found", name)
+	}
+
+	if profileRemoveRate {
+		removed, err := app.ProfileRepo.DeleteRate(ctx, name, modelID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return fmt.Errorf("profile %s has no rates for %s", name, modelID)
+		}
+		fmt.Printf("Removed %s rates from profile %s\n", modelID, name)
+		return nil
+	}
+
+	if !cmd.Flags().Changed("input") || !cmd.Flags().Changed("output") {
+		return fmt.Errorf("--input and --output are required")
+	}
+	rate := &domain.ModelPricing{
+		ID:                   modelID,
+		DisplayName:          modelID,
+		InputPerMillion:      costInput,
+		OutputPerMillion:     costOutput,
+		WebSearchPerThousand: &costWebSearch,
+		WebFetchPerThousand:  &costWebFetch,
+	}
+	if costCacheRead > 0 {
+		rate.CacheReadPerMillion = &costCacheRead
+	}
+	if costCacheWrite > 0 {
+		rate.CacheWritePerMillion = &costCacheWrite
+	}
+	if costCacheWrite1h > 0 {
+		rate.CacheWrite1hPerMillion = &costCacheWrite1h
+	}
+	if costLongInput > 0 {
+		rate.LongContextInputPerMillion = &costLongInput
+	}
+	if costLongOutput > 0 {
+		rate.LongContextOutputPerMillion = &costLongOutput
+	}
+	if costLongInput > 0 || costLongOutput > 0 {
+		rate.LongContextThreshold = &costLongThreshold
+	}
+
+	if err := app.ProfileRepo.SetRate(ctx, name, rate); err != nil {
+		return err
+	}
+	fmt.Printf("Set %s rates for profile %s\n", modelID, name)
+	return nil
+}
+
+func runCostProfileDefault(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name := args[0]
+
+	profile, err := app.ProfileRepo.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return fmt.Errorf("pricing profile %q not found", name)
+	}
+	if err := app.ProfileRepo.SetDefault(ctx, name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Set %s as the default pricing profile\n", name)
+	return nil
+}
+
+func runCostProfileAssign(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name := args[0]
+
+	project, err := app.ProjectRepo.GetByID(ctx, profileProject)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return fmt.Errorf("project not found: %s", profileProject)
+	}
+
+	if name == "default" {
+		if _, err := app.ProfileRepo.ClearProject(ctx, project.ID); err != nil {
+			return err
+		}
+		fmt.Printf("Project %s now uses the default pricing profile\n", project.Name)
+		return nil
+	}
+
+	profile, err := app.ProfileRepo.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return fmt.Errorf("pricing profile %q not found", name)
+	}
+	if err := app.ProfileRepo.SetProject(ctx, project.ID, name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Project %s now uses pricing profile %s\n", project.Name, name)
+	return nil
+}
+
+func runCostProfileDelete(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name := args[0]
+
+	profile, err := app.ProfileRepo.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return fmt.Errorf("pricing profile %q not found", name)
+	}
+	if profile.IsDefault {
+		return fmt.Errorf("%s is the default pricing profile; set another default first", name)
+	}
+	if _, err := app.ProfileRepo.Delete(ctx, name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted pricing profile %s\n", name)
+	return nil
+}
+
+func formatRate(rate *float64) string {
+	if rate == nil {
+		return "-"
+	}
+	return fmt.Sprintf("$%.2f", *rate)
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/simulate"
+)
+
+var costSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Re-price recorded usage under a what-if scenario",
+	Long: `Re-price the sessions recorded in a time range as if they had used another
+model, another sub-agent model or a different cache hit rate, and compare the
+result with the recorded cost per project and experiment.
+
+Models are configured model IDs or the aliases haiku, sonnet and opus. The
+cache hit rate is the fraction of input tokens read from cache; the remaining
+input keeps its recorded split between uncached input and cache writes.
+Without options, sessions are re-priced at the current pricing of the models
+they used.
+
+Examples:
+  mclaude cost simulate --model sonnet
+  mclaude cost simulate --subagent-model haiku --since 2026-03-01
+  mclaude cost simulate --cache-hit-rate 0.9 --since 7d`,
+	Args: cobra.NoArgs,
+	RunE: runCostSimulate,
+}
+
+// Flags
+var (
+	simulateModel         string
+	simulateSubagentModel string
+	simulateCacheHitRate  float64
+	simulateSince         string
+	simulateUntil         string
+)
+
+func init() {
+	costCmd.AddCommand(costSimulateCmd)
+
+	costSimulateCmd.Flags().StringVar(&simulateModel, "model", "", "Model for every session's main thread")
+	costSimulateCmd.Flags().StringVar(&simulateSubagentModel, "subagent-model", "", "Model for every sub-agent")
+	costSimulateCmd.Flags().Float64Var(&simulateCacheHitRate, "cache-hit-rate", 0, "Fraction of input tokens read from cache (0-1)")
+	costSimulateCmd.Flags().StringVar(&simulateSince, "since", "30d", "Sessions created at or after this time, or within this interval (e.g. 7d)")
+	costSimulateCmd.Flags().StringVar(&simulateUntil, "until", "", "Sessions created before this time (default: now)")
+}
+
+func runCostSimulate(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	since, err := parseSince(simulateSince, now)
+	if err != nil {
+		return err
+	}
+	until := now
+	if simulateUntil != "" {
+		if until, err = parseScheduleTime(simulateUntil); err != nil {
+			return err
+		}
+	}
+	if !until.After(since) {
+		return fmt.Errorf("--until must be after --since")
+	}
+
+	sim := simulate.NewSimulator(app.StatsRepo, app.PricingRepo, app.ProfileRepo, app.ProjectRepo, app.ExperimentRepo)
+	var scenario domain.CostScenario
+	if simulateModel != "" {
+		if scenario.Model, err = sim.Pricing(ctx, simulateModel); err != nil {
+			return err
+		}
+	}
+	if simulateSubagentModel != "" {
+		if scenario.SubagentModel, err = sim.Pricing(ctx, simulateSubagentModel); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("cache-hit-rate") {
+		if simulateCacheHitRate < 0 || simulateCacheHitRate > 1 {
+			return fmt.Errorf("--cache-hit-rate must be between 0 and 1")
+		}
+		scenario.CacheHitRate = &simulateCacheHitRate
+	}
+
+	result, err := sim.Run(ctx, scenario, since, until)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("  Cost Simulation\n")
+	fmt.Printf("  ---------------\n")
+	fmt.Printf("  Sessions:          %d (%s to %s)\n", result.Total.Sessions, since.Local().Format("2006-01-02 15:04"), until.Local().Format("2006-01-02 15:04"))
+	fmt.Printf("  Scenario:          %s\n", scenarioLabel(scenario))
+	fmt.Printf("  Actual:            $%.2f\n", result.Total.ActualUsd)
+	fmt.Printf("  Simulated:         $%.2f\n", result.Total.SimulatedUsd)
+	fmt.Printf("  Difference:        %s (%+.1f%%)\n", formatSignedUsd(result.Total.DeltaUsd()), result.Total.DeltaPercent())
+	fmt.Println()
+
+	if result.Total.Sessions == 0 {
+		fmt.Println("  No sessions in this range.")
+		fmt.Println()
+		return nil
+	}
+	printSimulationRows("PROJECT", result.Projects)
+	printSimulationRows("EXPERIMENT", result.Experiments)
+	return nil
+}
+
+// parseSince parses an interval back from now (e.g. "30d") or a time.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := parseInterval(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return parseScheduleTime(s)
+}
+
+func scenarioLabel(sc domain.CostScenario) string {
+	label := "recorded models"
+	if sc.Model != nil {
+		label = sc.Model.ID
+	}
+	if sc.SubagentModel != nil {
+		label += ", sub-agents on " + sc.SubagentModel.ID
+	}
+	if sc.CacheHitRate != nil {
+		label += fmt.Sprintf(", %.0f%% cache hits", *sc.CacheHitRate*100)
+	}
+	return label
+}
+
+func printSimulationRows(label string, rows []simulate.Row) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "  %s\tSESSIONS\tACTUAL\tSIMULATED\tDELTA\n", label)
+	_, _ = fmt.Fprintf(w, "  %s\t--------\t------\t---------\t-----\n", repeatChar('-', len(label)))
+	for _, r := range rows {
+		_, _ = fmt.Fprintf(w, "  %s\t%d\t$%.2f\t$%.2f\t%s (%+.1f%%)\n",
+			truncate(r.Name, 40),
+			r.Sessions,
+			r.ActualUsd,
+			r.SimulatedUsd,
+			formatSignedUsd(r.DeltaUsd()),
+			r.DeltaPercent(),
+		)
+	}
+	_ = w.Flush()
+	fmt.Println()
+}
@@ -3,8 +3,8 @@ package cli
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
-	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -26,8 +26,12 @@ var experimentCreateCmd = &cobra.Command{
 	Short: "Create a new experiment",
 	Long: `Create a new experiment and automatically activate it.
 
+With --start, the experiment is scheduled instead of activated: it becomes
+active for new sessions once its schedule window opens.
+
 Examples:
-  mclaude experiment create "minimal-prompts" --description "Testing shorter prompts" --hypothesis "Reduces token usage"`,
+  mclaude experiment create "minimal-prompts" --description "Testing shorter prompts" --hypothesis "Reduces token usage"
+  mclaude experiment create "weekend-run" --start 2026-03-07 --end 2026-03-09`,
 	Args: cobra.ExactArgs(1),
 	RunE: runExperimentCreate,
 }
@@ -101,6 +105,9 @@ var (
 	expPlan        string
 	expNotes       string
 	expVars        []string
+	expStart       string
+	expEnd         string
+	expTemplate    string
 )
 
 func init() {
@@ -116,12 +123,21 @@ func init() {
 	experimentCmd.AddCommand(experimentCompareCmd)
 
 	// Flags for create command
-	experimentCreateCmd.Flags().StringVarP(&expDescription, "description", "d", "", "Description of the experiment")
-	experimentCreateCmd.Flags().StringVarP(&expHypothesis, "hypothesis", "H", "", "Hypothesis to test")
-	experimentCreateCmd.Flags().StringVarP(&expModel, "model", "m", "", "Model ID used for this experiment (e.g. claude-opus-4-6)")
-	experimentCreateCmd.Flags().StringVarP(&expPlan, "plan", "p", "", "Plan type (e.g. pro, max_5x, max_20x)")
-	experimentCreateCmd.Flags().StringVarP(&expNotes, "notes", "n", "", "Free-form notes about methodology")
-	experimentCreateCmd.Flags().StringArrayVar(&expVars, "var", nil, "Variable key=value pair (can be repeated)")
+	addExperimentMetadataFlags(experimentCreateCmd)
+	experimentCreateCmd.Flags().StringVarP(&expTemplate, "template", "t", "", "Start from a saved experiment template")
+	experimentCreateCmd.Flags().StringVar(&expStart, "start", "", "Schedule the experiment to start at this time instead of activating it")
+	experimentCreateCmd.Flags().StringVar(&expEnd, "end", "", "End of the schedule window (requires --start)")
+}
+
+// addExperimentMetadataFlags registers the metadata flags shared by the
+// experiment create and update commands and template save.
+func addExperimentMetadataFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&expDescription, "description", "d", "", "Description of the experiment")
+	cmd.Flags().StringVarP(&expHypothesis, "hypothesis", "H", "", "Hypothesis to test")
+	cmd.Flags().StringVarP(&expModel, "model", "m", "", "Model ID used for this experiment (e.g. claude-opus-4-6)")
+	cmd.Flags().StringVarP(&expPlan, "plan", "p", "", "Plan type (e.g. pro, max_5x, max_20x)")
+	cmd.Flags().StringVarP(&expNotes, "notes", "n", "", "Free-form notes about methodology")
+	cmd.Flags().StringArrayVar(&expVars, "var", nil, "Variable key=value pair (can be repeated)")
 }
 
 func runExperimentCreate(cmd *cobra.Command, args []string) error {
@@ -136,8 +152,8 @@ func runExperimentCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("experiment with name %q already exists", name)
 	}
 
-	if err := app.ExperimentRepo.DeactivateAll(ctx); err != nil {
-		return fmt.Errorf("failed to deactivate experiments: %w", err)
+	if expEnd != "" && expStart == "" {
+		return fmt.Errorf("--end requires --start")
 	}
 
 	now := time.Now().UTC()
@@ -145,39 +161,51 @@ func runExperimentCreate(cmd *cobra.Command, args []string) error {
 		ID:        uuid.New().String(),
 		Name:      name,
 		StartedAt: now,
-		IsActive:  true,
+		IsActive:  expStart == "",
 		CreatedAt: now,
 	}
-	if expDescription != "" {
-		exp.Description = &expDescription
-	}
-	if expHypothesis != "" {
-		exp.Hypothesis = &expHypothesis
-	}
-	if expModel != "" {
-		exp.ModelID = &expModel
+
+	vars := map[string]string{}
+	if expTemplate != "" {
+		tmpl, err := getTemplateByName(ctx, expTemplate)
+		if err != nil {
+			return err
+		}
+		applyTemplate(exp, tmpl)
+		maps.Copy(vars, tmpl.Variables)
 	}
-	if expPlan != "" {
-		exp.PlanType = &expPlan
+
+	applyExperimentMetadataFlags(cmd, exp)
+	varSets, err := parseVarFlags(expVars)
+	if err != nil {
+		return err
 	}
-	if expNotes != "" {
-		exp.Notes = &expNotes
+	maps.Copy(vars, varSets)
+
+	if expStart != "" {
+		start, end, err := parseScheduleWindow(expStart, expEnd)
+		if err != nil {
+			return err
+		}
+		exp.StartedAt = start
+		exp.ScheduledStart = &start
+		exp.ScheduledEnd = end
+	} else if err := app.ExperimentRepo.DeactivateAll(ctx); err != nil {
+		return fmt.Errorf("failed to deactivate experiments: %w", err)
 	}
 
 	if err := app.ExperimentRepo.Create(ctx, exp); err != nil {
 		return fmt.Errorf("failed to create experiment: %w", err)
 	}
 
-	for _, v := range expVars {
-		key, value, ok := strings.Cut(v, "=")
-		if !ok {
-			return fmt.Errorf("invalid variable format %q, expected key=value", v)
-		}
-		if err := app.ExpVariableRepo.Set(ctx, exp.ID, key, value); err != nil {
-			return fmt.Errorf("failed to set variable %q: %w", key, err)
-		}
+	if err := setExperimentVariables(ctx, exp.ID, vars); err != nil {
+		return err
 	}
 
+	if exp.ScheduledStart != nil {
+		fmt.Printf("Created experiment %s, scheduled %s\n", name, formatScheduleWindow(*exp.ScheduledStart, exp.ScheduledEnd))
+		return nil
+	}
 	fmt.Printf("Created and activated experiment: %s\n", name)
 	return nil
 }
@@ -200,6 +228,18 @@ func runExperimentList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	timeline, err := parseInterval(timelineWindow)
+	if err != nil {
+		return err
+	}
+
+	groups, err := app.RotationRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list rotation groups: %w", err)
+	}
+	now := time.Now().UTC()
+	current, _ := domain.ResolveActiveExperiment(now, experiments, groups)
+
 	statsMap := make(map[string]domain.ExperimentStats)
 	for _, es := range expStats {
 		statsMap[es.ExperimentID] = es
@@ -211,10 +251,12 @@ func runExperimentList(cmd *cobra.Command, args []string) error {
 
 	for _, exp := range experiments {
 		status := "inactive"
-		if exp.IsActive {
+		if current != nil && current.ID == exp.ID {
 			status = "ACTIVE"
 		} else if exp.EndedAt != nil {
 			status = "ended"
+		} else if exp.ScheduledStart != nil && exp.ScheduledStart.After(now) {
+			status = "scheduled"
 		}
 
 		started := exp.StartedAt.Format("2006-01-02")
@@ -246,6 +288,8 @@ func runExperimentList(cmd *cobra.Command, args []string) error {
 	}
 
 	_ = w.Flush()
+
+	printExperimentTimeline(experiments, groups, timeline)
 	return nil
 }
 
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+var experimentUpdateCmd = &cobra.Command{
+	Use:   "update <name>",
+	Short: "Update an experiment",
+	Long: `Update an experiment's metadata, schedule, or variables.
+
+Only the flags that are passed are changed. Passing an empty value clears
+the field (e.g. --notes "").
+
+Examples:
+  mclaude experiment update "minimal-prompts" --hypothesis "Shorter prompts reduce tokens"
+  mclaude experiment update "minimal-prompts" --model claude-opus-4-6 --var effort=high
+  mclaude experiment update "minimal-prompts" --unset-var effort --rename "short-prompts"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExperimentUpdate,
+}
+
+var experimentCloneCmd = &cobra.Command{
+	Use:   "clone <source> <new-name>",
+	Short: "Clone an experiment",
+	Long: `Create a new experiment copying the metadata and variables of an existing one.
+
+The clone starts with no sessions and is not activated unless --activate is passed.
+
+Examples:
+  mclaude experiment clone "baseline" "baseline-v2"
+  mclaude experiment clone "baseline" "baseline-opus" --activate`,
+	Args: cobra.ExactArgs(2),
+	RunE: runExperimentClone,
+}
+
+// Flags
+var (
+	expRename     string
+	expUnsetVars  []string
+	cloneActivate bool
+)
+
+func init() {
+	experimentCmd.AddCommand(experimentUpdateCmd)
+	experimentCmd.AddCommand(experimentCloneCmd)
+
+	addExperimentMetadataFlags(experimentUpdateCmd)
+	experimentUpdateCmd.Flags().StringVar(&expRename, "rename", "", "New name for the experiment")
+	experimentUpdateCmd.Flags().StringArrayVar(&expUnsetVars, "unset-var", nil, "Variable key to remove (can be repeated)")
+	experimentUpdateCmd.Flags().StringVar(&expStart, "start", "", "Start of the schedule window")
+	experimentUpdateCmd.Flags().StringVar(&expEnd, "end", "", "End of the schedule window")
+
+	experimentCloneCmd.Flags().BoolVar(&cloneActivate, "activate", false, "Activate the clone after creating it")
+}
+
+func runExperimentUpdate(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name := args[0]
+
+	exp, err := getExperimentByName(ctx, app.ExperimentRepo, name)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("rename") && expRename != exp.Name {
+		if expRename == "" {
+			return fmt.Errorf("--rename cannot be empty")
+		}
+		existing, err := app.ExperimentRepo.GetByName(ctx, expRename)
+		if err != nil {
+			return fmt.Errorf("failed to check experiment: %w", err)
+		}
+		if existing != nil {
+			return fmt.Errorf("experiment with name %q already exists", expRename)
+		}
+		exp.Name = expRename
+	}
+
+	applyExperimentMetadataFlags(cmd, exp)
+
+	if cmd.Flags().Changed("start") || cmd.Flags().Changed("end") {
+		startStr := expStart
+		if startStr == "" {
+			if exp.ScheduledStart == nil {
+				return fmt.Errorf("--end requires --start when the experiment has no schedule")
+			}
+			startStr = exp.ScheduledStart.Format(time.RFC3339)
+		}
+		endStr := expEnd
+		if !cmd.Flags().Changed("end") && exp.ScheduledEnd != nil {
+			endStr = exp.ScheduledEnd.Format(time.RFC3339)
+		}
+		start, end, err := parseScheduleWindow(startStr, endStr)
+		if err != nil {
+			return err
+		}
+		exp.ScheduledStart = &start
+		exp.ScheduledEnd = end
+	}
+
+	vars, err := parseVarFlags(expVars)
+	if err != nil {
+		return err
+	}
+
+	if err := app.ExperimentRepo.Update(ctx, exp); err != nil {
+		return fmt.Errorf("failed to update experiment: %w", err)
+	}
+
+	if err := setExperimentVariables(ctx, exp.ID, vars); err != nil {
+		return err
+	}
+	for _, key := range expUnsetVars {
+		if err := app.ExpVariableRepo.Delete(ctx, exp.ID, key); err != nil {
+			return fmt.Errorf("failed to remove variable %q: %w", key, err)
+		}
+	}
+
+	fmt.Printf("Updated experiment: %s\n", exp.Name)
+	return nil
+}
+
+func runExperimentClone(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	srcName, newName := args[0], args[1]
+
+	src, err := getExperimentByName(ctx, app.ExperimentRepo, srcName)
+	if err != nil {
+		return err
+	}
+
+	existing, err := app.ExperimentRepo.GetByName(ctx, newName)
+	if err != nil {
+		return fmt.Errorf("failed to check experiment: %w", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("experiment with name %q already exists", newName)
+	}
+
+	srcVars, err := app.ExpVariableRepo.ListByExperimentID(ctx, src.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list variables: %w", err)
+	}
+
+	if cloneActivate {
+		if err := app.ExperimentRepo.DeactivateAll(ctx); err != nil {
+			return fmt.Errorf("failed to deactivate experiments: %w", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	clone := &domain.Experiment{
+		ID:          uuid.New().String(),
+		Name:        newName,
+		Description: src.Description,
+		Hypothesis:  src.Hypothesis,
+		StartedAt:   now,
+		IsActive:    cloneActivate,
+		CreatedAt:   now,
+		ModelID:     src.ModelID,
+		PlanType:    src.PlanType,
+		Notes:       src.Notes,
+	}
+	if err := app.ExperimentRepo.Create(ctx, clone); err != nil {
+		return fmt.Errorf("failed to create experiment: %w", err)
+	}
+
+	vars := make(map[string]string, len(srcVars))
+	for _, v := range srcVars {
+		vars[v.Key] = v.Value
+	}
+	if err := setExperimentVariables(ctx, clone.ID, vars); err != nil {
+		return err
+	}
+
+	if cloneActivate {
+		fmt.Printf("Cloned %s into %s and activated it\n", srcName, newName)
+		return nil
+	}
+	fmt.Printf("Cloned %s into %s\n", srcName, newName)
+	return nil
+}
+
+// applyExperimentMetadataFlags copies the metadata flags that were explicitly
+// passed onto the experiment. An empty value clears the field.
+func applyExperimentMetadataFlags(cmd *cobra.Command, exp *domain.Experiment) {
+	fields := []struct {
+		flag  string
+		value string
+		dst   **string
+	}{
+		{"description", expDescription, &exp.Description},
+		{"hypothesis", expHypothesis, &exp.Hypothesis},
+		{"model", expModel, &exp.ModelID},
+		{"plan", expPlan, &exp.PlanType},
+		{"notes", expNotes, &exp.Notes},
+	}
+	for _, f := range fields {
+		if !cmd.Flags().Changed(f.flag) {
+			continue
+		}
+		if f.value == "" {
+			*f.dst = nil
+			continue
+		}
+		v := f.value
+		*f.dst = &v
+	}
+}
+
+// parseVarFlags parses repeated key=value flags into a map.
+func parseVarFlags(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, v := range pairs {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid variable format %q, expected key=value", v)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+func setExperimentVariables(ctx context.Context, experimentID string, vars map[string]string) error {
+	for _, key := range slices.Sorted(maps.Keys(vars)) {
+		if err := app.ExpVariableRepo.Set(ctx, experimentID, key, vars[key]); err != nil {
+			return fmt.Errorf("failed to set variable %q: %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+var experimentNoteCmd = &cobra.Command{
+	Use:   "note <name> <text>",
+	Short: "Add a timestamped entry to an experiment's journal",
+	Long: `Append an observation to an experiment's journal.
+
+Entries are timestamped and shown as markers on the experiment's charts, so
+observations can be lined up with shifts in the metrics.
+
+Examples:
+  mclaude experiment note "minimal-prompts" "Switched to plan mode for refactors"
+  mclaude experiment note "minimal-prompts" Noticed more retries on Bash today`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runExperimentNote,
+}
+
+var experimentJournalCmd = &cobra.Command{
+	Use:   "journal <name>",
+	Short: "Show an experiment's journal",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runExperimentJournal,
+}
+
+func init() {
+	experimentCmd.AddCommand(experimentNoteCmd)
+	experimentCmd.AddCommand(experimentJournalCmd)
+}
+
+func runExperimentNote(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	exp, err := getExperimentByName(ctx, app.ExperimentRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	content := strings.TrimSpace(strings.Join(args[1:], " "))
+	if content == "" {
+		return fmt.Errorf("note cannot be empty")
+	}
+
+	note := &domain.ExperimentNote{
+		ExperimentID: exp.ID,
+		Content:      content,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := app.NoteRepo.Create(ctx, note); err != nil {
+		return fmt.Errorf("failed to add note: %w", err)
+	}
+
+	fmt.Printf("Added note to %s\n", exp.Name)
+	return nil
+}
+
+func runExperimentJournal(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	exp, err := getExperimentByName(ctx, app.ExperimentRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	notes, err := app.NoteRepo.ListByExperimentID(ctx, exp.ID)
+	if err != nil {
+		return err
+	}
+	if len(notes) == 0 {
+		fmt.Printf("No journal entries for %s\n", exp.Name)
+		fmt.Printf("\nUse 'mclaude experiment note %q \"...\"' to add one\n", exp.Name)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("  Journal: %s\n", exp.Name)
+	fmt.Printf("  =========%s\n", repeatChar('=', len(exp.Name)))
+	fmt.Println()
+	for _, n := range notes {
+		fmt.Printf("  %s  %s\n", n.CreatedAt.Local().Format("2006-01-02 15:04"), n.Content)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/report"
+)
+
+var experimentReportCmd = &cobra.Command{
+	Use:   "report <name>",
+	Short: "Generate a self-contained experiment report",
+	Long: `Generate a report with the experiment's metadata, variables, hypothesis,
+session totals, top tools, daily cost and verdict.
+
+With --baseline, per-session metrics are compared against another experiment,
+with 95% confidence intervals for each delta.
+
+Examples:
+  mclaude experiment report "minimal-prompts" --baseline "baseline"
+  mclaude experiment report "minimal-prompts" --format html -o report.html
+  mclaude experiment report "minimal-prompts" --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExperimentReport,
+}
+
+var experimentConcludeCmd = &cobra.Command{
+	Use:   "conclude <name>",
+	Short: "Record a verdict on an experiment's hypothesis",
+	Long: `Record whether the experiment confirmed or rejected its hypothesis.
+
+The verdict and conclusion are shown in reports and on the experiment page.
+Concluding again replaces the previous verdict.
+
+Examples:
+  mclaude experiment conclude "minimal-prompts" --verdict confirmed --conclusion "Tokens/session down 30%"
+  mclaude experiment conclude "minimal-prompts" --verdict inconclusive --end`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExperimentConclude,
+}
+
+// Flags
+var (
+	reportBaseline  string
+	reportFormat    string
+	reportOutput    string
+	concludeVerdict string
+	concludeNote    string
+	concludeAndEnd  bool
+)
+
+func init() {
+	experimentCmd.AddCommand(experimentReportCmd)
+	experimentCmd.AddCommand(experimentConcludeCmd)
+
+	experimentReportCmd.Flags().StringVarP(&reportBaseline, "baseline", "b", "", "Experiment to compare against")
+	experimentReportCmd.Flags().StringVarP(&reportFormat, "format", "f", report.FormatMarkdown, "Output format: md, html, or json")
+	experimentReportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Write the report to a file instead of stdout")
+
+	experimentConcludeCmd.Flags().StringVar(&concludeVerdict, "verdict", "", "Verdict: confirmed, rejected, or inconclusive (required)")
+	experimentConcludeCmd.Flags().StringVar(&concludeNote, "conclusion", "", "What was learned")
+	experimentConcludeCmd.Flags().BoolVar(&concludeAndEnd, "end", false, "Also end the experiment")
+	_ = experimentConcludeCmd.MarkFlagRequired("verdict")
+}
+
+func runExperimentReport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	format := strings.ToLower(reportFormat)
+	if !report.IsValidFormat(format) {
+		return fmt.Errorf("invalid format %q (use md, html or json)", reportFormat)
+	}
+
+	exp, err := getExperimentByName(ctx, app.ExperimentRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	var baseline *domain.Experiment
+	if reportBaseline != "" {
+		if baseline, err = getExperimentByName(ctx, app.ExperimentRepo, reportBaseline); err != nil {
+			return err
+		}
+		if baseline.ID == exp.ID {
+			return fmt.Errorf("--baseline must be a different experiment")
+		}
+	}
+
+	rep, err := report.NewBuilder(app.ExpVariableRepo, app.StatsRepo).Build(ctx, exp, baseline)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if reportOutput != "" {
+		f, err := os.Create(reportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+
+	if err := report.Render(w, rep, format); err != nil {
+		return err
+	}
+
+	if reportOutput != "" {
+		fmt.Printf("Wrote %s report to %s\n", format, reportOutput)
+	}
+	return nil
+}
+
+func runExperimentConclude(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	verdict := strings.ToLower(concludeVerdict)
+	if !domain.IsValidVerdict(verdict) {
+		return fmt.Errorf("invalid verdict %q (use %s, %s or %s)",
+			concludeVerdict, domain.VerdictConfirmed, domain.VerdictRejected, domain.VerdictInconclusive)
+	}
+
+	exp, err := getExperimentByName(ctx, app.ExperimentRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	var conclusion *string
+	if concludeNote != "" {
+		conclusion = &concludeNote
+	}
+	now := time.Now().UTC()
+	if err := app.ExperimentRepo.SetConclusion(ctx, exp.ID, &verdict, conclusion, &now); err != nil {
+		return fmt.Errorf("failed to conclude experiment: %w", err)
+	}
+
+	if concludeAndEnd && exp.EndedAt == nil {
+		exp.EndedAt = &now
+		exp.IsActive = false
+		if err := app.ExperimentRepo.Update(ctx, exp); err != nil {
+			return fmt.Errorf("failed to end experiment: %w", err)
+		}
+	}
+
+	fmt.Printf("Concluded experiment %s: %s\n", exp.Name, verdict)
+	return nil
+}
@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+var experimentScheduleCmd = &cobra.Command{
+	Use:   "schedule <name>",
+	Short: "Set or clear an experiment's schedule window",
+	Long: `Schedule an experiment to be active between a start and an optional end time.
+
+While inside its window, a scheduled experiment is used for new sessions
+regardless of which experiment is manually activated. Rotation groups take
+precedence over schedule windows.
+
+Examples:
+  mclaude experiment schedule "minimal-prompts" --start 2026-03-01 --end 2026-03-08
+  mclaude experiment schedule "minimal-prompts" --start "2026-03-01 09:00"
+  mclaude experiment schedule "minimal-prompts" --clear`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExperimentSchedule,
+}
+
+var experimentRotationCmd = &cobra.Command{
+	Use:   "rotation",
+	Short: "Manage experiment rotation groups",
+	Long: `Rotation groups alternate between experiments on a fixed interval,
+which makes crossover designs possible (e.g. A/B alternating daily to cancel
+out weekday effects). The active experiment is resolved at hook time.`,
+}
+
+var experimentRotationCreateCmd = &cobra.Command{
+	Use:   "create <group> <exp1> <exp2> [exp3...]",
+	Short: "Create a rotation group",
+	Long: `Create a rotation group that alternates between the given experiments.
+Listing an experiment more than once gives it more slots of the rotation.
+
+Examples:
+  mclaude experiment rotation create crossover baseline minimal-prompts --every 1d
+  mclaude experiment rotation create shifts a b c --every 8h --start "2026-03-01 09:00" --end 2026-03-15
+  mclaude experiment rotation create weighted a a b --every 1d   # a two days in three`,
+	Args: cobra.MinimumNArgs(3),
+	RunE: runExperimentRotationCreate,
+}
+
+var experimentRotationListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List rotation groups",
+	RunE:  runExperimentRotationList,
+}
+
+var experimentRotationDeleteCmd = &cobra.Command{
+	Use:   "delete <group>",
+	Short: "Delete a rotation group",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runExperimentRotationDelete,
+}
+
+// Flags
+var (
+	scheduleStart  string
+	scheduleEnd    string
+	scheduleClear  bool
+	rotationEvery  string
+	rotationStart  string
+	rotationEnd    string
+	timelineWindow string
+)
+
+func init() {
+	experimentCmd.AddCommand(experimentScheduleCmd)
+	experimentCmd.AddCommand(experimentRotationCmd)
+
+	experimentRotationCmd.AddCommand(experimentRotationCreateCmd)
+	experimentRotationCmd.AddCommand(experimentRotationListCmd)
+	experimentRotationCmd.AddCommand(experimentRotationDeleteCmd)
+
+	experimentScheduleCmd.Flags().StringVar(&scheduleStart, "start", "", "Start of the schedule window")
+	experimentScheduleCmd.Flags().StringVar(&scheduleEnd, "end", "", "End of the schedule window (optional)")
+	experimentScheduleCmd.Flags().BoolVar(&scheduleClear, "clear", false, "Remove the schedule window")
+
+	experimentRotationCreateCmd.Flags().StringVar(&rotationEvery, "every", "1d", "Rotation interval (e.g. 1d, 12h, 90m)")
+	experimentRotationCreateCmd.Flags().StringVar(&rotationStart, "start", "", "When the rotation begins (default: now)")
+	experimentRotationCreateCmd.Flags().StringVar(&rotationEnd, "end", "", "When the rotation stops (optional)")
+
+	experimentListCmd.Flags().StringVar(&timelineWindow, "timeline", "7d", "How far ahead to show the schedule timeline")
+}
+
+func runExperimentSchedule(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name := args[0]
+
+	exp, err := getExperimentByName(ctx, app.ExperimentRepo, name)
+	if err != nil {
+		return err
+	}
+
+	if scheduleClear {
+		exp.ScheduledStart = nil
+		exp.ScheduledEnd = nil
+		if err := app.ExperimentRepo.Update(ctx, exp); err != nil {
+			return fmt.Errorf("failed to update experiment: %w", err)
+		}
+		fmt.Printf("Cleared schedule for experiment: %s\n", name)
+		return nil
+	}
+
+	if scheduleStart == "" {
+		return fmt.Errorf("--start is required (or use --clear)")
+	}
+
+	start, end, err := parseScheduleWindow(scheduleStart, scheduleEnd)
+	if err != nil {
+		return err
+	}
+	if exp.EndedAt != nil {
+		return fmt.Errorf("experiment %q has already ended", name)
+	}
+
+	exp.ScheduledStart = &start
+	exp.ScheduledEnd = end
+	if err := app.ExperimentRepo.Update(ctx, exp); err != nil {
+		return fmt.Errorf("failed to update experiment: %w", err)
+	}
+
+	fmt.Printf("Scheduled experiment %s: %s\n", name, formatScheduleWindow(start, end))
+	return nil
+}
+
+func runExperimentRotationCreate(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	groupName := args[0]
+	expNames := args[1:]
+
+	existing, err := app.RotationRepo.GetByName(ctx, groupName)
+	if err != nil {
+		return fmt.Errorf("failed to check rotation group: %w", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("rotation group %q already exists", groupName)
+	}
+
+	interval, err := parseInterval(rotationEvery)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	anchor := now
+	var endsAt *time.Time
+	if rotationStart != "" || rotationEnd != "" {
+		startStr := rotationStart
+		if startStr == "" {
+			startStr = now.Format(time.RFC3339)
+		}
+		anchor, endsAt, err = parseScheduleWindow(startStr, rotationEnd)
+		if err != nil {
+			return err
+		}
+	}
+
+	group := &domain.RotationGroup{
+		ID:        uuid.New().String(),
+		Name:      groupName,
+		Interval:  interval,
+		AnchorAt:  anchor,
+		EndsAt:    endsAt,
+		CreatedAt: now,
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range expNames {
+		exp, err := getExperimentByName(ctx, app.ExperimentRepo, name)
+		if err != nil {
+			return err
+		}
+		seen[exp.ID] = true
+		group.ExperimentIDs = append(group.ExperimentIDs, exp.ID)
+	}
+	if len(seen) < 2 {
+		return fmt.Errorf("a rotation needs at least two different experiments")
+	}
+
+	if err := app.RotationRepo.Create(ctx, group); err != nil {
+		return fmt.Errorf("failed to create rotation group: %w", err)
+	}
+
+	fmt.Printf("Created rotation group %s: %s every %s, %s\n",
+		groupName, strings.Join(expNames, " → "), rotationEvery, formatScheduleWindow(anchor, endsAt))
+	return nil
+}
+
+func runExperimentRotationList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	groups, err := app.RotationRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list rotation groups: %w", err)
+	}
+	if len(groups) == 0 {
+		fmt.Println("No rotation groups found")
+		return nil
+	}
+
+	names, err := experimentNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "GROUP\tEVERY\tEXPERIMENTS\tSTARTS\tENDS\tCURRENT")
+	_, _ = fmt.Fprintln(w, "-----\t-----\t-----------\t------\t----\t-------")
+
+	now := time.Now().UTC()
+	for _, g := range groups {
+		members := make([]string, len(g.ExperimentIDs))
+		for i, id := range g.ExperimentIDs {
+			members[i] = names[id]
+		}
+		ends := "-"
+		if g.EndsAt != nil {
+			ends = g.EndsAt.Local().Format("2006-01-02 15:04")
+		}
+		current := "-"
+		if id := g.ExperimentAt(now); id != "" {
+			current = names[id]
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			g.Name, g.Interval, strings.Join(members, ", "),
+			g.AnchorAt.Local().Format("2006-01-02 15:04"), ends, current)
+	}
+
+	_ = w.Flush()
+	return nil
+}
+
+func runExperimentRotationDelete(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	name := args[0]
+
+	group, err := app.RotationRepo.GetByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get rotation group: %w", err)
+	}
+	if group == nil {
+		return fmt.Errorf("rotation group %q not found", name)
+	}
+
+	if err := app.RotationRepo.Delete(ctx, group.ID); err != nil {
+		return fmt.Errorf("failed to delete rotation group: %w", err)
+	}
+
+	fmt.Printf("Deleted rotation group: %s\n", name)
+	return nil
+}
+
+// printExperimentTimeline prints the upcoming sequence of active experiments.
+// Nothing is printed when no schedules or rotation groups apply in the window.
+func printExperimentTimeline(experiments []*domain.Experiment, groups []*domain.RotationGroup, window time.Duration) {
+	now := time.Now().UTC()
+	slots := domain.BuildTimeline(now, now.Add(window), experiments, groups)
+
+	hasSchedule := false
+	for _, slot := range slots {
+		if slot.Source != domain.ScheduleSourceManual {
+			hasSchedule = true
+			break
+		}
+	}
+	if !hasSchedule {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("Upcoming timeline:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FROM\tUNTIL\tEXPERIMENT\tSOURCE")
+	_, _ = fmt.Fprintln(w, "----\t-----\t----------\t------")
+	for _, slot := range slots {
+		until := slot.End.Local().Format("2006-01-02 15:04")
+		if !slot.End.Before(now.Add(window)) {
+			until = "…"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			slot.Start.Local().Format("2006-01-02 15:04"), until, slot.ExperimentName, slot.Source)
+	}
+	_ = w.Flush()
+}
+
+// parseScheduleWindow parses a start and optional end time, validating their order.
+func parseScheduleWindow(startStr, endStr string) (time.Time, *time.Time, error) {
+	start, err := parseScheduleTime(startStr)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if endStr == "" {
+		return start, nil, nil
+	}
+	end, err := parseScheduleTime(endStr)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if !end.After(start) {
+		return time.Time{}, nil, fmt.Errorf("end (%s) must be after start (%s)", endStr, startStr)
+	}
+	return start, &end, nil
+}
+
+func formatScheduleWindow(start time.Time, end *time.Time) string {
+	s := "from " + start.Local().Format("2006-01-02 15:04")
+	if end != nil {
+		s += " until " + end.Local().Format("2006-01-02 15:04")
+	}
+	return s
+}
+
+// experimentNames returns a map of experiment ID to name.
+func experimentNames(ctx context.Context) (map[string]string, error) {
+	experiments, err := app.ExperimentRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiments: %w", err)
+	}
+	names := make(map[string]string, len(experiments))
+	for _, e := range experiments {
+		names[e.ID] = e.Name
+	}
+	return names, nil
+}
@@ -27,12 +27,16 @@ type expData struct {
 	totalTokens  int64
 	tokensPerSes int64
 	costPerSes   float64
+	linesChanged int64
+	costPer100   float64
 	// Normalized behavior metrics
 	tokensPerTurn    float64
 	outputRatio      float64
 	cacheHitRate     float64
 	errorRate        float64
 	toolCallsPerTurn float64
+	withChecks       int64
+	greenRate        float64
 }
 
 func runExperimentStats(cmd *cobra.Command, args []string) error {
@@ -115,6 +119,8 @@ func runExperimentStats(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Estimated:         $%.4f\n", stats.TotalCostUsd)
 	fmt.Println()
 
+	printLinesChanged(stats)
+
 	if stats.SessionCount > 0 {
 		fmt.Printf("  Efficiency\n")
 		fmt.Printf("  ----------\n")
@@ -134,6 +140,9 @@ func runExperimentStats(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Cache hit rate:    %.1f%%\n", normalized.CacheHitRate*100)
 	fmt.Printf("  Error rate:        %.2f%%\n", normalized.ErrorRate*100)
 	fmt.Printf("  Tool calls/turn:   %.1f\n", normalized.ToolCallsPerTurn)
+	if stats.SessionsWithChecks > 0 {
+		fmt.Printf("  Ended green:       %.1f%% (%d of %d sessions with checks)\n", normalized.GreenRate*100, stats.SessionsEndedGreen, stats.SessionsWithChecks)
+	}
 	fmt.Println()
 
 	return nil
@@ -184,11 +193,15 @@ func runExperimentCompare(cmd *cobra.Command, args []string) error {
 			totalTokens:      totalTokens,
 			tokensPerSes:     tokensPerSes,
 			costPerSes:       costPerSes,
+			linesChanged:     stats.LinesChanged(),
+			costPer100:       stats.CostPer100Lines(),
 			tokensPerTurn:    normalized.TokensPerTurn,
 			outputRatio:      normalized.OutputRatio,
 			cacheHitRate:     normalized.CacheHitRate,
 			errorRate:        normalized.ErrorRate,
 			toolCallsPerTurn: normalized.ToolCallsPerTurn,
+			withChecks:       stats.SessionsWithChecks,
+			greenRate:        normalized.GreenRate,
 		})
 	}
 
@@ -225,12 +238,25 @@ func runExperimentCompare(cmd *cobra.Command, args []string) error {
 	printCompareRow(w, "Cost", experiments, func(e expData) string { return fmt.Sprintf("$%.2f", e.cost) })
 	printCompareRow(w, "Tokens/session", experiments, func(e expData) string { return util.FormatNumber(e.tokensPerSes) })
 	printCompareRow(w, "Cost/session", experiments, func(e expData) string { return fmt.Sprintf("$%.4f", e.costPerSes) })
+	printCompareRow(w, "Lines changed", experiments, func(e expData) string { return util.FormatNumber(e.linesChanged) })
+	printCompareRow(w, "Cost/100 lines", experiments, func(e expData) string {
+		if e.linesChanged == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("$%.4f", e.costPer100)
+	})
 	fmt.Fprintln(w)
 	printCompareRow(w, "Tokens/turn", experiments, func(e expData) string { return util.FormatNumber(int64(e.tokensPerTurn)) })
 	printCompareRow(w, "Output ratio", experiments, func(e expData) string { return fmt.Sprintf("%.2f", e.outputRatio) })
 	printCompareRow(w, "Cache hit rate", experiments, func(e expData) string { return fmt.Sprintf("%.1f%%", e.cacheHitRate*100) })
 	printCompareRow(w, "Error rate", experiments, func(e expData) string { return fmt.Sprintf("%.2f%%", e.errorRate*100) })
 	printCompareRow(w, "Tool calls/turn", experiments, func(e expData) string { return fmt.Sprintf("%.1f", e.toolCallsPerTurn) })
+	printCompareRow(w, "Ended green", experiments, func(e expData) string {
+		if e.withChecks == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", e.greenRate*100)
+	})
 
 	_ = w.Flush()
 	fmt.Println()
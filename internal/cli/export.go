@@ -58,6 +58,11 @@ type ExportSession struct {
 	EndedAt               string  `json:"ended_at,omitempty"`
 	DurationSeconds       int64   `json:"duration_seconds,omitempty"`
 	CreatedAt             string  `json:"created_at"`
+	GitRepoRoot           string  `json:"git_repo_root,omitempty"`
+	GitRemoteURL          string  `json:"git_remote_url,omitempty"`
+	GitBranch             string  `json:"git_branch,omitempty"`
+	GitStartCommit        string  `json:"git_start_commit,omitempty"`
+	GitEndCommit          string  `json:"git_end_commit,omitempty"`
 	MessageCountUser      int64   `json:"message_count_user"`
 	MessageCountAssistant int64   `json:"message_count_assistant"`
 	TurnCount             int64   `json:"turn_count"`
@@ -65,8 +70,15 @@ type ExportSession struct {
 	TokenOutput           int64   `json:"token_output"`
 	TokenCacheRead        int64   `json:"token_cache_read"`
 	TokenCacheWrite       int64   `json:"token_cache_write"`
+	TokenCacheWrite5m     int64   `json:"token_cache_write_5m"`
+	TokenCacheWrite1h     int64   `json:"token_cache_write_1h"`
+	WebSearchRequests     int64   `json:"web_search_requests"`
+	WebFetchRequests      int64   `json:"web_fetch_requests"`
+	ServerToolCostUsd     float64 `json:"server_tool_cost_usd,omitempty"`
 	CostEstimateUsd       float64 `json:"cost_estimate_usd,omitempty"`
 	ErrorCount            int64   `json:"error_count"`
+	LinesAdded            int64   `json:"lines_added"`
+	LinesRemoved          int64   `json:"lines_removed"`
 }
 
 func runExportSessions(cmd *cobra.Command, args []string) error {
@@ -112,6 +124,13 @@ func runExportSessions(cmd *cobra.Command, args []string) error {
 		if s.DurationSeconds != nil {
 			es.DurationSeconds = *s.DurationSeconds
 		}
+		if g := s.Git; g != nil {
+			es.GitRepoRoot = g.RepoRoot
+			es.GitRemoteURL = g.RemoteURL
+			es.GitBranch = g.Branch
+			es.GitStartCommit = g.StartCommit
+			es.GitEndCommit = g.EndCommit
+		}
 
 		m, err := app.MetricsRepo.GetBySessionID(ctx, s.ID)
 		if err == nil && m != nil {
@@ -122,7 +141,16 @@ func runExportSessions(cmd *cobra.Command, args []string) error {
 			es.TokenOutput = m.TokenOutput
 			es.TokenCacheRead = m.TokenCacheRead
 			es.TokenCacheWrite = m.TokenCacheWrite
+			es.TokenCacheWrite5m = m.TokenCacheWrite5m
+			es.TokenCacheWrite1h = m.TokenCacheWrite1h
+			es.WebSearchRequests = m.WebSearchRequests
+			es.WebFetchRequests = m.WebFetchRequests
 			es.ErrorCount = m.ErrorCount
+			es.LinesAdded = m.LinesAdded
+			es.LinesRemoved = m.LinesRemoved
+			if m.ServerToolCostUSD != nil {
+				es.ServerToolCostUsd = *m.ServerToolCostUSD
+			}
 			if m.CostEstimateUSD != nil {
 				es.CostEstimateUsd = *m.CostEstimateUSD
 			}
@@ -156,9 +184,12 @@ func runExportSessions(cmd *cobra.Command, args []string) error {
 		header := []string{
 			"id", "project_id", "experiment_id", "cwd", "permission_mode", "exit_reason",
 			"started_at", "ended_at", "duration_seconds", "created_at",
+			"git_repo_root", "git_remote_url", "git_branch", "git_start_commit", "git_end_commit",
 			"message_count_user", "message_count_assistant", "turn_count",
 			"token_input", "token_output", "token_cache_read", "token_cache_write",
-			"cost_estimate_usd", "error_count",
+			"token_cache_write_5m", "token_cache_write_1h",
+			"web_search_requests", "web_fetch_requests", "server_tool_cost_usd",
+			"cost_estimate_usd", "error_count", "lines_added", "lines_removed",
 		}
 		if err := writer.Write(header); err != nil {
 			return fmt.Errorf("failed to write CSV header: %w", err)
@@ -168,11 +199,15 @@ func runExportSessions(cmd *cobra.Command, args []string) error {
 			row := []string{
 				es.ID, es.ProjectID, es.ExperimentID, es.Cwd, es.PermissionMode, es.ExitReason,
 				es.StartedAt, es.EndedAt, fmt.Sprintf("%d", es.DurationSeconds), es.CreatedAt,
+				es.GitRepoRoot, es.GitRemoteURL, es.GitBranch, es.GitStartCommit, es.GitEndCommit,
 				fmt.Sprintf("%d", es.MessageCountUser), fmt.Sprintf("%d", es.MessageCountAssistant),
 				fmt.Sprintf("%d", es.TurnCount), fmt.Sprintf("%d", es.TokenInput),
 				fmt.Sprintf("%d", es.TokenOutput), fmt.Sprintf("%d", es.TokenCacheRead),
-				fmt.Sprintf("%d", es.TokenCacheWrite), fmt.Sprintf("%.6f", es.CostEstimateUsd),
-				fmt.Sprintf("%d", es.ErrorCount),
+				fmt.Sprintf("%d", es.TokenCacheWrite),
+				fmt.Sprintf("%d", es.TokenCacheWrite5m), fmt.Sprintf("%d", es.TokenCacheWrite1h),
+				fmt.Sprintf("%d", es.WebSearchRequests), fmt.Sprintf("%d", es.WebFetchRequests),
+				fmt.Sprintf("%.6f", es.ServerToolCostUsd), fmt.Sprintf("%.6f", es.CostEstimateUsd),
+				fmt.Sprintf("%d", es.ErrorCount), fmt.Sprintf("%d", es.LinesAdded), fmt.Sprintf("%d", es.LinesRemoved),
 			}
 			if err := writer.Write(row); err != nil {
 				return fmt.Errorf("failed to write CSV row: %w", err)
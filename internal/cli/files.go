@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+var filesCmd = &cobra.Command{
+	Use:   "files",
+	Short: "Show the files read and edited most across sessions",
+	Long: `Show the files sessions read, edited and wrote most, with how often the
+same file was re-read within a session and the cost of the sessions that
+touched it. Re-reads, reads of a file beyond the first in a session, are a
+sign of context churn.
+
+Paths under the project's directory are shown relative to it.
+
+Examples:
+  mclaude files --project <id>         # Hotspots of a project
+  mclaude files --project <id> --sort rereads
+  mclaude files --days 7 --last 50     # Across all projects`,
+	RunE: runFiles,
+}
+
+// Flags
+var (
+	filesProject string
+	filesDays    int
+	filesLast    int
+	filesSort    string
+)
+
+func init() {
+	rootCmd.AddCommand(filesCmd)
+	filesCmd.Flags().StringVar(&filesProject, "project", "", "Filter by project ID")
+	filesCmd.Flags().IntVarP(&filesDays, "days", "d", 30, "Number of days of sessions to analyze")
+	filesCmd.Flags().IntVarP(&filesLast, "last", "n", 20, "Number of files to show")
+	filesCmd.Flags().StringVar(&filesSort, "sort", domain.FileSortOperations, "Sort by: "+strings.Join(domain.FileSortKeys, ", "))
+}
+
+func runFiles(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if !slices.Contains(domain.FileSortKeys, filesSort) {
+		return fmt.Errorf("invalid sort %q: must be one of %s", filesSort, strings.Join(domain.FileSortKeys, ", "))
+	}
+
+	opts := ports.ListFileTouchesOptions{
+		Since: time.Now().UTC().AddDate(0, 0, -filesDays).Format(time.RFC3339),
+	}
+	root := ""
+	if filesProject != "" {
+		project, err := app.ProjectRepo.GetByID(ctx, filesProject)
+		if err != nil {
+			return fmt.Errorf("failed to get project: %w", err)
+		}
+		if project == nil {
+			return fmt.Errorf("project %q not found", filesProject)
+		}
+		opts.ProjectID = &project.ID
+		root = project.Path
+		fmt.Printf("Project: %s (%s)\n", project.Name, project.Path)
+	}
+
+	touches, err := app.FileRepo.ListTouches(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(touches) == 0 {
+		fmt.Printf("No file operations in the last %d days\n", filesDays)
+		return nil
+	}
+
+	hotspots := domain.AggregateFileHotspots(touches, root)
+	domain.SortFileHotspots(hotspots, filesSort)
+	fmt.Printf("%d files touched (last %d days, by %s)\n\n", len(hotspots), filesDays, filesSort)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FILE\tREADS\tEDITS\tWRITES\tSESSIONS\tREREADS\tREREAD RATE\tSESSION COST")
+	_, _ = fmt.Fprintln(w, "----\t-----\t-----\t------\t--------\t-------\t-----------\t------------")
+	for _, h := range hotspots[:min(filesLast, len(hotspots))] {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%.0f%%\t$%.2f\n",
+			truncateLeft(h.Path, 60), h.Reads, h.Edits, h.Writes, h.Sessions, h.Rereads, h.RereadRate()*100, h.SessionCostUsd)
+	}
+	_ = w.Flush()
+	return nil
+}
+
+// truncateLeft keeps the end of s, where a path's file name is.
+func truncateLeft(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return "..." + s[len(s)-maxLen+3:]
+}
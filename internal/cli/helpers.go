@@ -3,6 +3,9 @@ package cli
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
 	"github.com/emiliopalmerini/mclaude/internal/ports"
@@ -22,6 +25,52 @@ func getExperimentByName(ctx context.Context, repo ports.ExperimentRepository, n
 	return exp, nil
 }
 
+// resolveActiveExperiment determines which experiment is active at t, taking
+// rotation groups and schedule windows into account before the manual flag.
+func resolveActiveExperiment(ctx context.Context, expRepo ports.ExperimentRepository, rotationRepo ports.RotationGroupRepository, t time.Time) (*domain.Experiment, error) {
+	experiments, err := expRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiments: %w", err)
+	}
+	groups, err := rotationRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotation groups: %w", err)
+	}
+	exp, _ := domain.ResolveActiveExperiment(t, experiments, groups)
+	return exp, nil
+}
+
+// parseScheduleTime parses a user-supplied time as RFC3339, "YYYY-MM-DD HH:MM"
+// or "YYYY-MM-DD". Times without a zone are interpreted in the local timezone.
+func parseScheduleTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use RFC3339, YYYY-MM-DD HH:MM or YYYY-MM-DD)", s)
+}
+
+// parseInterval parses a duration that may use a "d" (days) suffix in addition
+// to the units accepted by time.ParseDuration (e.g. "2d", "12h", "90m").
+func parseInterval(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid interval %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid interval %q", s)
+	}
+	return d, nil
+}
+
 // truncate shortens a string to maxLen characters, appending "..." if truncated.
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
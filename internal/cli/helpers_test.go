@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
 )
@@ -26,10 +27,13 @@ func (m *mockExperimentRepo) GetActive(_ context.Context) (*domain.Experiment, e
 }
 func (m *mockExperimentRepo) List(_ context.Context) ([]*domain.Experiment, error) { return nil, nil }
 func (m *mockExperimentRepo) Update(_ context.Context, _ *domain.Experiment) error { return nil }
-func (m *mockExperimentRepo) Delete(_ context.Context, _ string) error             { return nil }
-func (m *mockExperimentRepo) Activate(_ context.Context, _ string) error           { return nil }
-func (m *mockExperimentRepo) Deactivate(_ context.Context, _ string) error         { return nil }
-func (m *mockExperimentRepo) DeactivateAll(_ context.Context) error                { return nil }
+func (m *mockExperimentRepo) SetConclusion(_ context.Context, _ string, _, _ *string, _ *time.Time) error {
+	return nil
+}
+func (m *mockExperimentRepo) Delete(_ context.Context, _ string) error     { return nil }
+func (m *mockExperimentRepo) Activate(_ context.Context, _ string) error   { return nil }
+func (m *mockExperimentRepo) Deactivate(_ context.Context, _ string) error { return nil }
+func (m *mockExperimentRepo) DeactivateAll(_ context.Context) error        { return nil }
 
 func TestGetExperimentByName_Found(t *testing.T) {
 	repo := &mockExperimentRepo{exp: &domain.Experiment{ID: "abc", Name: "test"}}
@@ -60,3 +64,57 @@ func TestGetExperimentByName_RepoError(t *testing.T) {
 		t.Fatal("expected error for repo failure")
 	}
 }
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"2d", 48 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"0d", 0, true},
+		{"-1h", 0, true},
+		{"daily", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseInterval(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseInterval(%q): expected error", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseInterval(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("parseInterval(%q) = %v, want %v", tt.in, got, tt.expected)
+		}
+	}
+}
+
+func TestParseScheduleTime(t *testing.T) {
+	got, err := parseScheduleTime("2026-03-01T09:00:00Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Format(time.RFC3339) != "2026-03-01T09:00:00Z" {
+		t.Errorf("unexpected time: %s", got.Format(time.RFC3339))
+	}
+
+	local, err := parseScheduleTime("2026-03-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local).UTC()
+	if !local.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, local)
+	}
+
+	if _, err := parseScheduleTime("next tuesday"); err == nil {
+		t.Error("expected error for invalid time")
+	}
+}
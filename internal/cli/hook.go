@@ -20,6 +20,7 @@ type HookResponse struct {
 	HookSpecificOutput json.RawMessage `json:"hookSpecificOutput,omitempty"`
 	Decision           string          `json:"decision,omitempty"`
 	Reason             string          `json:"reason,omitempty"`
+	SystemMessage      string          `json:"systemMessage,omitempty"`
 }
 
 var hookCmd = &cobra.Command{
@@ -33,13 +34,18 @@ your hooks to use "mclaude hook" for all event types:
   {
     "hooks": {
       "SessionStart": [{"type": "command", "command": "mclaude hook"}],
+      "UserPromptSubmit": [{"type": "command", "command": "mclaude hook"}],
+      "PreToolUse":   [{"type": "command", "command": "mclaude hook"}],
       "SessionEnd":   [{"type": "command", "command": "mclaude hook", "async": true}],
       "Stop":         [{"type": "command", "command": "mclaude hook", "async": true}],
       "PostToolUse":  [{"type": "command", "command": "mclaude hook", "async": true}],
       "SubagentStart":[{"type": "command", "command": "mclaude hook", "async": true}],
       "SubagentStop": [{"type": "command", "command": "mclaude hook", "async": true}]
     }
-  }`,
+  }
+
+UserPromptSubmit and PreToolUse enforce spend budgets (see "mclaude budget"),
+so they must not be async.`,
 	RunE: runHook,
 }
 
@@ -61,6 +67,10 @@ func runHook(cmd *cobra.Command, args []string) error {
 		return handleSessionStart(e)
 	case *domain.StopInput:
 		return handleStop(e)
+	case *domain.UserPromptSubmitInput:
+		return handleBudgetGate(&e.HookEventBase)
+	case *domain.PreToolUseInput:
+		return handleBudgetGate(&e.HookEventBase)
 	case *domain.PostToolUseInput:
 		return handlePostToolUse(e)
 	case *domain.SubagentStartInput:
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/budget"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+// handleBudgetGate checks the budgets covering the session before a prompt or
+// tool call. An exceeded hard budget blocks it; an exceeded soft budget only
+// shows a warning. The gate fails open: when budgets cannot be checked it
+// warns on stderr and lets the prompt or tool call through.
+func handleBudgetGate(event *domain.HookEventBase) error {
+	sqlDB, _, closeDB, err := hookDB()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: budget check skipped: %v\n", err)
+		return nil
+	}
+	defer closeDB()
+
+	ctx := context.Background()
+	now := time.Now()
+	// The experiment is resolved only when an experiment budget exists, as
+	// this runs before every tool call
+	experiment := func(ctx context.Context) (*domain.Experiment, error) {
+		return resolveActiveExperiment(ctx, turso.NewExperimentRepository(sqlDB), turso.NewRotationGroupRepository(sqlDB), now.UTC())
+	}
+	statuses, err := sessionBudgets(ctx, sqlDB, event.Cwd, experiment, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: budget check skipped: %v\n", err)
+		return nil
+	}
+
+	hard, soft := budget.Exceeded(statuses)
+	switch {
+	case len(hard) > 0:
+		return outputJSON(&HookResponse{
+			Decision: "block",
+			Reason:   budget.ExceededMessage("Hard", hard) + ". Raise it with 'mclaude budget set' or wait for the period to reset.",
+		})
+	case len(soft) > 0:
+		return outputJSON(&HookResponse{
+			SystemMessage: budget.ExceededMessage("Soft", soft),
+		})
+	}
+	return nil
+}
+
+// sessionBudgets returns the status of the budgets covering a session in cwd.
+// experiment resolves the experiment the session runs under, which may be
+// nil, and is only called when an experiment budget exists.
+func sessionBudgets(ctx context.Context, db *sql.DB, cwd string, experiment func(context.Context) (*domain.Experiment, error), now time.Time) ([]domain.BudgetStatus, error) {
+	checker := budget.NewChecker(
+		turso.NewBudgetRepository(db),
+		turso.NewStatsRepository(db),
+		turso.NewProjectRepository(db),
+		turso.NewExperimentRepository(db),
+	)
+	experimentID := func(ctx context.Context) (string, error) {
+		exp, err := experiment(ctx)
+		if err != nil || exp == nil {
+			return "", err
+		}
+		return exp.ID, nil
+	}
+	return checker.ForSession(ctx, turso.ProjectIDForPath(cwd), experimentID, now)
+}
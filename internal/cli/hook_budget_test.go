@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+// seedSpend records a session in cwd that cost costUsd, created now.
+func seedSpend(t *testing.T, db *sql.DB, cwd string, costUsd float64) {
+	t.Helper()
+	ctx := context.Background()
+
+	project, err := turso.NewProjectRepository(db).GetOrCreate(ctx, cwd)
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	queries := sqlc.New(db)
+	sessionID := "sess-spend-" + time.Now().Format("150405.000000000")
+	err = queries.CreateSession(ctx, sqlc.CreateSessionParams{
+		ID:             sessionID,
+		ProjectID:      project.ID,
+		TranscriptPath: "/tmp/transcript.jsonl",
+		Cwd:            cwd,
+		PermissionMode: "default",
+		ExitReason:     "exit",
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	err = queries.CreateSessionMetrics(ctx, sqlc.CreateSessionMetricsParams{
+		SessionID:       sessionID,
+		CostEstimateUsd: sql.NullFloat64{Float64: costUsd, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create session metrics: %v", err)
+	}
+}
+
+func setBudget(t *testing.T, db *sql.DB, b *domain.Budget) {
+	t.Helper()
+	b.CreatedAt = time.Now().UTC()
+	if err := turso.NewBudgetRepository(db).Set(context.Background(), b); err != nil {
+		t.Fatalf("Failed to set budget: %v", err)
+	}
+}
+
+func budgetGateInput(event string) map[string]string {
+	input := map[string]string{
+		"session_id":      "sess-budget",
+		"transcript_path": "/tmp/transcript.jsonl",
+		"cwd":             "/project",
+		"permission_mode": "default",
+		"hook_event_name": event,
+	}
+	if event == "PreToolUse" {
+		input["tool_name"] = "Bash"
+	} else {
+		input["prompt"] = "keep going"
+	}
+	return input
+}
+
+func TestHandleBudgetGate_NoBudgets(t *testing.T) {
+	db, cleanup := testDB(t)
+	defer cleanup()
+
+	testDBOverride = db
+	defer func() { testDBOverride = nil }()
+
+	seedSpend(t, db, "/project", 50)
+
+	output, err := runHookWithInput(t, budgetGateInput("PreToolUse"))
+	if err != nil {
+		t.Fatalf("PreToolUse handler failed: %v", err)
+	}
+	if output != "" {
+		t.Errorf("Expected no output without budgets, got: %s", output)
+	}
+}
+
+func TestHandleBudgetGate_FailsOpen(t *testing.T) {
+	db, cleanup := testDB(t)
+	cleanup()
+
+	// Budgets cannot be listed from a closed database
+	testDBOverride = db
+	defer func() { testDBOverride = nil }()
+
+	output, err := runHookWithInput(t, budgetGateInput("PreToolUse"))
+	if err != nil {
+		t.Fatalf("Expected the gate to let the tool call through, got: %v", err)
+	}
+	if output != "" {
+		t.Errorf("Expected no output when budgets cannot be checked, got: %s", output)
+	}
+}
+
+func TestHandleBudgetGate_HardBudgetBlocks(t *testing.T) {
+	db, cleanup := testDB(t)
+	defer cleanup()
+
+	testDBOverride = db
+	defer func() { testDBOverride = nil }()
+
+	seedSpend(t, db, "/project", 6)
+	setBudget(t, db, &domain.Budget{Period: domain.BudgetDaily, AmountUsd: 5, Hard: true})
+
+	for _, event := range []string{"PreToolUse", "UserPromptSubmit"} {
+		output, err := runHookWithInput(t, budgetGateInput(event))
+		if err != nil {
+			t.Fatalf("%s handler failed: %v", event, err)
+		}
+
+		var resp HookResponse
+		if err := json.Unmarshal([]byte(output), &resp); err != nil {
+			t.Fatalf("Failed to parse %s response: %v\nOutput: %s", event, err, output)
+		}
+		if resp.Decision != "block" {
+			t.Errorf("%s: expected block decision, got %q", event, resp.Decision)
+		}
+		if !strings.Contains(resp.Reason, "daily global: $6.00 of $5.00") {
+			t.Errorf("%s: expected reason to describe the budget, got: %s", event, resp.Reason)
+		}
+	}
+}
+
+func TestHandleBudgetGate_SoftBudgetWarns(t *testing.T) {
+	db, cleanup := testDB(t)
+	defer cleanup()
+
+	testDBOverride = db
+	defer func() { testDBOverride = nil }()
+
+	seedSpend(t, db, "/project", 6)
+	projectID := turso.ProjectIDForPath("/project")
+	setBudget(t, db, &domain.Budget{Period: domain.BudgetWeekly, AmountUsd: 5, ProjectID: &projectID})
+	// A hard budget for another project does not apply
+	otherID := turso.ProjectIDForPath("/other")
+	if _, err := turso.NewProjectRepository(db).GetOrCreate(context.Background(), "/other"); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	setBudget(t, db, &domain.Budget{Period: domain.BudgetDaily, AmountUsd: 1, ProjectID: &otherID, Hard: true})
+
+	output, err := runHookWithInput(t, budgetGateInput("UserPromptSubmit"))
+	if err != nil {
+		t.Fatalf("UserPromptSubmit handler failed: %v", err)
+	}
+
+	var resp HookResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v\nOutput: %s", err, output)
+	}
+	if resp.Decision != "" {
+		t.Errorf("Expected soft budget not to block, got decision %q", resp.Decision)
+	}
+	if !strings.Contains(resp.SystemMessage, "weekly project project") {
+		t.Errorf("Expected warning for the project budget, got: %s", resp.SystemMessage)
+	}
+}
+
+func TestHandleSessionStart_BudgetStatus(t *testing.T) {
+	db, cleanup := testDB(t)
+	defer cleanup()
+
+	testDBOverride = db
+	defer func() { testDBOverride = nil }()
+
+	queries := sqlc.New(db)
+	_ = queries.DeactivateAllExperiments(context.Background())
+
+	seedSpend(t, db, "/project", 2)
+	setBudget(t, db, &domain.Budget{Period: domain.BudgetMonthly, AmountUsd: 10})
+
+	output, err := runHookWithInput(t, map[string]string{
+		"session_id":      "sess-budget",
+		"transcript_path": "/tmp/transcript.jsonl",
+		"cwd":             "/project",
+		"permission_mode": "default",
+		"hook_event_name": "SessionStart",
+	})
+	if err != nil {
+		t.Fatalf("SessionStart handler failed: %v", err)
+	}
+
+	var resp HookResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v\nOutput: %s", err, output)
+	}
+	if want := "Budgets: monthly global: $2.00 of $10.00 (20%)"; resp.AdditionalContext != want {
+		t.Errorf("Expected additionalContext %q, got: %s", want, resp.AdditionalContext)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/emiliopalmerini/mclaude/internal/adapters/git"
 	"github.com/emiliopalmerini/mclaude/internal/adapters/storage"
 	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
 	"github.com/emiliopalmerini/mclaude/internal/domain"
@@ -29,27 +30,42 @@ type saveSessionOpts struct {
 func saveSessionData(ctx context.Context, sqlDB *sql.DB, sessionID, transcriptPath, cwd, permissionMode string, opts saveSessionOpts) error {
 	projectRepo := turso.NewProjectRepository(sqlDB)
 	experimentRepo := turso.NewExperimentRepository(sqlDB)
+	rotationRepo := turso.NewRotationGroupRepository(sqlDB)
 	sessionRepo := turso.NewSessionRepository(sqlDB)
 	metricsRepo := turso.NewSessionMetricsRepository(sqlDB)
 	toolRepo := turso.NewSessionToolRepository(sqlDB)
+	toolCallRepo := turso.NewSessionToolCallRepository(sqlDB)
 	fileRepo := turso.NewSessionFileRepository(sqlDB)
+	fileChangeRepo := turso.NewSessionFileChangeRepository(sqlDB)
 	commandRepo := turso.NewSessionCommandRepository(sqlDB)
+	commitRepo := turso.NewCommitRepository(sqlDB)
 	subagentRepo := turso.NewSessionSubagentRepository(sqlDB)
 	pricingRepo := turso.NewPricingRepository(sqlDB)
+	profileRepo := turso.NewPricingProfileRepository(sqlDB)
+	planRepo := turso.NewPlanRepository(sqlDB)
+	usageRepo := turso.NewUsageRepository(sqlDB)
+	statsRepo := turso.NewStatsRepository(sqlDB)
+	anomalyRepo := turso.NewAnomalyRepository(sqlDB)
+	gitStartRepo := turso.NewSessionGitStartRepository(sqlDB)
 
 	project, err := projectRepo.GetOrCreate(ctx, cwd)
 	if err != nil {
 		return fmt.Errorf("failed to get/create project: %w", err)
 	}
 
-	activeExperiment, err := experimentRepo.GetActive(ctx)
+	parsed, err := parser.ParseTranscript(sessionID, transcriptPath)
 	if err != nil {
-		return fmt.Errorf("failed to get active experiment: %w", err)
+		return fmt.Errorf("failed to parse transcript: %w", err)
 	}
 
-	parsed, err := parser.ParseTranscript(sessionID, transcriptPath)
+	// Resolve the experiment from schedules at the time the session started
+	experimentAt := time.Now().UTC()
+	if parsed.StartedAt != nil {
+		experimentAt = *parsed.StartedAt
+	}
+	activeExperiment, err := resolveActiveExperiment(ctx, experimentRepo, rotationRepo, experimentAt)
 	if err != nil {
-		return fmt.Errorf("failed to parse transcript: %w", err)
+		return fmt.Errorf("failed to get active experiment: %w", err)
 	}
 
 	// Store transcript copy (unless skipped)
@@ -68,19 +84,18 @@ func saveSessionData(ctx context.Context, sqlDB *sql.DB, sessionID, transcriptPa
 
 	parsed.Metrics.ModelID = parsed.ModelID
 
-	// Resolve pricing
+	// Resolve pricing under the project's pricing profile
+	profile, _ := profileRepo.GetForProject(ctx, project.ID)
 	defaultPricing, _ := pricingRepo.GetDefault(ctx)
-	pricing := resolvePricing(ctx, parsed.ModelID, pricingRepo, defaultPricing)
+	pricing := resolvePricing(ctx, parsed.ModelID, pricingRepo, profile, profile.Apply(defaultPricing))
 
 	var costEstimate *float64
 	if pricing != nil {
-		cost := pricing.CalculateCost(
-			parsed.Metrics.TokenInput,
-			parsed.Metrics.TokenOutput,
-			parsed.Metrics.TokenCacheRead,
-			parsed.Metrics.TokenCacheWrite,
-		)
+		usage := parsed.Metrics.TokenUsage()
+		cost := pricing.CalculateCost(usage)
 		costEstimate = &cost
+		serverToolCost := pricing.ServerToolCost(usage.ServerTools)
+		parsed.Metrics.ServerToolCostUSD = &serverToolCost
 
 		rates := pricing.ResolveRates(
 			parsed.Metrics.TokenInput,
@@ -92,6 +107,7 @@ func saveSessionData(ctx context.Context, sqlDB *sql.DB, sessionID, transcriptPa
 		parsed.Metrics.OutputRate = &rates.Output
 		parsed.Metrics.CacheReadRate = rates.CacheRead
 		parsed.Metrics.CacheWriteRate = rates.CacheWrite
+		parsed.Metrics.CacheWrite1hRate = rates.CacheWrite1h
 	}
 	parsed.Metrics.CostEstimateUSD = costEstimate
 
@@ -118,14 +134,35 @@ func saveSessionData(ctx context.Context, sqlDB *sql.DB, sessionID, transcriptPa
 	if storedPath != "" {
 		session.TranscriptStoredPath = &storedPath
 	}
+	// Git context is best-effort: a broken repository must not fail the hook
+	session.Git, err = sessionGit(ctx, sessionRepo, gitStartRepo, sessionID, cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
 	if activeExperiment != nil {
 		session.ExperimentID = &activeExperiment.ID
 	}
+	if err := countLinesFromGit(ctx, session.Git, parsed); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
 
 	if err := sessionRepo.Create(ctx, session); err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
 
+	// The session now holds the start, so the snapshot is no longer needed
+	if opts.ExitReason != "" {
+		if err := gitStartRepo.Delete(ctx, sessionID); err != nil {
+			return err
+		}
+	}
+
+	// The transcript is parsed whole every time, so replace what an earlier
+	// save recorded instead of adding to it
+	if err := sessionRepo.DeleteDetails(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to clear session details: %w", err)
+	}
+
 	if err := metricsRepo.Create(ctx, parsed.Metrics); err != nil {
 		return fmt.Errorf("failed to create session metrics: %w", err)
 	}
@@ -136,22 +173,43 @@ func saveSessionData(ctx context.Context, sqlDB *sql.DB, sessionID, transcriptPa
 		}
 	}
 
+	if len(parsed.ToolCalls) > 0 {
+		if err := toolCallRepo.CreateBatch(ctx, parsed.ToolCalls); err != nil {
+			return fmt.Errorf("failed to create session tool calls: %w", err)
+		}
+	}
+
 	if len(parsed.Files) > 0 {
 		if err := fileRepo.CreateBatch(ctx, parsed.Files); err != nil {
 			return fmt.Errorf("failed to create session files: %w", err)
 		}
 	}
 
+	if len(parsed.FileChanges) > 0 {
+		if err := fileChangeRepo.CreateBatch(ctx, parsed.FileChanges); err != nil {
+			return fmt.Errorf("failed to create session file changes: %w", err)
+		}
+	}
+
 	if len(parsed.Commands) > 0 {
 		if err := commandRepo.CreateBatch(ctx, parsed.Commands); err != nil {
 			return fmt.Errorf("failed to create session commands: %w", err)
 		}
 	}
 
+	// Commit detection is best-effort like the rest of the git context
+	commits, err := sessionCommits(ctx, session.Git, parsed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	} else if err := commitRepo.ReplaceForSession(ctx, sessionID, commits); err != nil {
+		return fmt.Errorf("failed to record session commits: %w", err)
+	}
+
 	if len(parsed.Subagents) > 0 {
 		for _, sa := range parsed.Subagents {
-			if p := resolvePricing(ctx, sa.Model, pricingRepo, pricing); p != nil {
-				cost := p.CalculateCost(sa.TokenInput, sa.TokenOutput, sa.TokenCacheRead, sa.TokenCacheWrite)
+			if p := resolvePricing(ctx, sa.Model, pricingRepo, profile, pricing); p != nil {
+				// Sub-agent server tool requests are priced with the session
+				cost := p.CalculateCost(sa.TokenUsage())
 				sa.CostEstimateUSD = &cost
 			}
 		}
@@ -160,6 +218,41 @@ func saveSessionData(ctx context.Context, sqlDB *sql.DB, sessionID, transcriptPa
 		}
 	}
 
+	if len(parsed.Requests) > 0 || len(parsed.RateLimitHits) > 0 {
+		// Tag hits with the current plan so learned limits can be scaled
+		// if the plan changes later
+		if plan, err := planRepo.Get(ctx); err == nil && plan != nil {
+			for i := range parsed.RateLimitHits {
+				parsed.RateLimitHits[i].PlanType = &plan.PlanType
+			}
+		}
+		if err := usageRepo.ReplaceForSession(ctx, sessionID, parsed.Requests, parsed.RateLimitHits); err != nil {
+			return fmt.Errorf("failed to record usage requests: %w", err)
+		}
+	}
+
+	sample := domain.SessionSample{
+		SessionID: sessionID,
+		Turns:     parsed.Metrics.TurnCount,
+		Tokens:    parsed.Metrics.TokenInput + parsed.Metrics.TokenOutput,
+		Errors:    parsed.Metrics.ErrorCount,
+	}
+	if costEstimate != nil {
+		sample.CostUsd = *costEstimate
+	}
+	if session.DurationSeconds != nil {
+		sample.DurationSeconds = *session.DurationSeconds
+	}
+	for _, tool := range parsed.Tools {
+		sample.ToolCalls += tool.InvocationCount
+	}
+	// Anomaly flags are derived from the saved data, so failing to compute
+	// them must not fail the hook
+	anomaly, err := scoreSessionAnomaly(ctx, statsRepo, anomalyRepo, session, sample)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
 	// Output success message
 	fmt.Printf("Session %s recorded: %d input tokens, %d output tokens",
 		sessionID[:min(8, len(sessionID))],
@@ -172,16 +265,147 @@ func saveSessionData(ctx context.Context, sqlDB *sql.DB, sessionID, transcriptPa
 	if len(parsed.Subagents) > 0 {
 		fmt.Printf(", %d sub-agents", len(parsed.Subagents))
 	}
+	if len(commits) > 0 {
+		fmt.Printf(", %d commits", len(commits))
+	}
+	if anomaly != nil {
+		fmt.Printf(", flagged as anomalous (%s)", anomaly.Summary())
+	}
 	fmt.Println()
 
+	// Alerts are best-effort: a bad rules file or failed delivery must not
+	// fail the hook
+	if err := evaluateSessionAlerts(ctx, sqlDB, sample, anomaly); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
 	return nil
 }
 
-// resolvePricing looks up model-specific pricing, falling back to the provided default.
-func resolvePricing(ctx context.Context, model *string, repo ports.PricingRepository, fallback *domain.ModelPricing) *domain.ModelPricing {
+// sessionGit combines the git state captured when the session started with
+// its current state. A session recorded before keeps the start it was first
+// recorded with, so a resumed session is not restarted at the resume.
+func sessionGit(ctx context.Context, sessions ports.SessionRepository, starts ports.SessionGitStartRepository, sessionID, cwd string) (*domain.SessionGit, error) {
+	prev, err := sessions.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	var start *domain.GitState
+	if prev != nil {
+		start = prev.Git.Start()
+	}
+	if start == nil {
+		if start, err = starts.Get(ctx, sessionID); err != nil {
+			return nil, err
+		}
+	}
+
+	end, err := git.NewInspector().State(ctx, cwd)
+	if err != nil {
+		return domain.NewSessionGit(start, nil), err
+	}
+	return domain.NewSessionGit(start, end), nil
+}
+
+// countLinesFromGit replaces the line counts taken from tool inputs with the
+// git diff since the commit the session started at, which also covers
+// changes made through Bash. It leaves them alone when the session did not
+// start from a clean working tree. Untracked files keep their tool counts.
+func countLinesFromGit(ctx context.Context, g *domain.SessionGit, parsed *parser.ParsedTranscript) error {
+	base := g.DiffBase()
+	if base == "" {
+		return nil
+	}
+	inspector := git.NewInspector()
+	tracked, err := inspector.DiffSince(ctx, g.RepoRoot, base)
+	if err != nil {
+		return err
+	}
+	untracked, err := inspector.Untracked(ctx, g.RepoRoot)
+	if err != nil {
+		return err
+	}
+
+	changes := domain.MergeGitFileChanges(tracked, parsed.FileChanges, untracked)
+	for _, c := range changes {
+		c.SessionID = parsed.Metrics.SessionID
+	}
+	parsed.FileChanges = changes
+	parsed.Metrics.LinesAdded, parsed.Metrics.LinesRemoved = domain.SumLineChanges(changes)
+	parsed.Metrics.LinesSource = domain.LinesSourceGit
+	return nil
+}
+
+// commitWindowSlack extends the session's time window past its last
+// transcript entry, for a commit made by the last command.
+const commitWindowSlack = time.Minute
+
+// sessionCommits finds the commits made during the session: the new commits
+// between the HEAD it started at and its end or, when the start is unknown
+// and the session ran `git commit`, the commits on HEAD made while it was
+// active. Commits older than the session, such as pulled ones, are left out.
+func sessionCommits(ctx context.Context, g *domain.SessionGit, parsed *parser.ParsedTranscript) ([]*domain.SessionCommit, error) {
+	if g == nil || g.EndCommit == "" || parsed.StartedAt == nil || parsed.EndedAt == nil {
+		return nil, nil
+	}
+	var from, detectedBy string
+	switch {
+	case g.StartCommit != "":
+		if !g.HeadMoved() {
+			return nil, nil
+		}
+		from, detectedBy = g.StartCommit, domain.CommitDetectedByHead
+	case domain.HasGitCommit(parsed.Commands):
+		detectedBy = domain.CommitDetectedByCommand
+	default:
+		return nil, nil
+	}
+
+	commits, err := git.NewInspector().Commits(ctx, g.RepoRoot, from, g.EndCommit, *parsed.StartedAt, parsed.EndedAt.Add(commitWindowSlack))
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range commits {
+		c.SessionID = parsed.Metrics.SessionID
+		c.DetectedBy = detectedBy
+	}
+	return commits, nil
+}
+
+// scoreSessionAnomaly compares the session against its project's recent
+// sessions and stores or clears its anomaly flag. It returns the anomaly, or
+// nil when the session looks normal.
+func scoreSessionAnomaly(ctx context.Context, stats ports.StatsRepository, anomalies ports.AnomalyRepository, session *domain.Session, sample domain.SessionSample) (*domain.SessionAnomaly, error) {
+	baseline, err := stats.ListSamplesByProject(ctx, session.ProjectID, session.ID, session.CreatedAt.Format(time.RFC3339), domain.AnomalyBaselineSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project baseline: %w", err)
+	}
+
+	anomaly := domain.DetectAnomaly(sample, baseline)
+	if anomaly == nil {
+		if err := anomalies.Clear(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	anomaly.DetectedAt = time.Now().UTC()
+	if err := anomalies.Set(ctx, anomaly); err != nil {
+		return nil, err
+	}
+	return anomaly, nil
+}
+
+// resolvePricing looks up model-specific pricing under the pricing profile,
+// falling back to the provided default.
+func resolvePricing(ctx context.Context, model *string, repo ports.PricingRepository, profile *domain.PricingProfile, fallback *domain.ModelPricing) *domain.ModelPricing {
 	if model != nil {
-		if p, _ := repo.GetByID(ctx, resolveModelAlias(*model)); p != nil {
-			return p
+		id := domain.ResolveModelAlias(*model)
+		if r := profile.Rate(id); r != nil {
+			return r
+		}
+		if p, _ := repo.GetByID(ctx, id); p != nil {
+			return profile.Apply(p)
 		}
 	}
 	return fallback
@@ -3,9 +3,15 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/emiliopalmerini/mclaude/internal/adapters/git"
 	"github.com/emiliopalmerini/mclaude/internal/adapters/turso"
+	"github.com/emiliopalmerini/mclaude/internal/budget"
 	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
 )
 
 func handleSessionStart(event *domain.SessionStartInput) error {
@@ -17,27 +23,56 @@ func handleSessionStart(event *domain.SessionStartInput) error {
 
 	ctx := context.Background()
 	experimentRepo := turso.NewExperimentRepository(sqlDB)
+	rotationRepo := turso.NewRotationGroupRepository(sqlDB)
 
-	activeExperiment, err := experimentRepo.GetActive(ctx)
+	// Git context is best-effort: a broken repository must not fail the hook
+	if err := captureGitStart(ctx, turso.NewSessionGitStartRepository(sqlDB), event.SessionID, event.Cwd, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
+	activeExperiment, err := resolveActiveExperiment(ctx, experimentRepo, rotationRepo, time.Now().UTC())
 	if err != nil {
 		return fmt.Errorf("failed to get active experiment: %w", err)
 	}
 
-	// No active experiment → no output needed
-	if activeExperiment == nil {
+	budgets, err := sessionBudgets(ctx, sqlDB, event.Cwd, func(context.Context) (*domain.Experiment, error) {
+		return activeExperiment, nil
+	}, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to check budgets: %w", err)
+	}
+
+	// No active experiment or budget → no output needed
+	if activeExperiment == nil && len(budgets) == 0 {
 		return nil
 	}
 
-	// Build context string with experiment info
-	contextStr := fmt.Sprintf("Active experiment: %s", activeExperiment.Name)
-	if activeExperiment.Hypothesis != nil {
-		contextStr += fmt.Sprintf("\nHypothesis: %s", *activeExperiment.Hypothesis)
+	// Build context string with experiment and budget info
+	var lines []string
+	if activeExperiment != nil {
+		lines = append(lines, fmt.Sprintf("Active experiment: %s", activeExperiment.Name))
+		if activeExperiment.Hypothesis != nil {
+			lines = append(lines, fmt.Sprintf("Hypothesis: %s", *activeExperiment.Hypothesis))
+		}
+		if activeExperiment.Description != nil {
+			lines = append(lines, fmt.Sprintf("Description: %s", *activeExperiment.Description))
+		}
 	}
-	if activeExperiment.Description != nil {
-		contextStr += fmt.Sprintf("\nDescription: %s", *activeExperiment.Description)
+	if len(budgets) > 0 {
+		lines = append(lines, budget.Summary(budgets))
 	}
 
 	return outputJSON(&HookResponse{
-		AdditionalContext: contextStr,
+		AdditionalContext: strings.Join(lines, "\n"),
 	})
 }
+
+// captureGitStart stores the git state of the session's working directory,
+// keeping the first one captured when a session is resumed.
+func captureGitStart(ctx context.Context, starts ports.SessionGitStartRepository, sessionID, cwd string, now time.Time) error {
+	state, err := git.NewInspector().State(ctx, cwd)
+	if err != nil || state == nil {
+		return err
+	}
+	return starts.Set(ctx, sessionID, state, now)
+}
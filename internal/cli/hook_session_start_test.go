@@ -180,3 +180,49 @@ func TestHandleSessionStart_OutputFormat(t *testing.T) {
 		t.Error("Expected 'additionalContext' key in response")
 	}
 }
+
+func TestHandleSessionStart_ScheduledExperiment(t *testing.T) {
+	db, cleanup := testDB(t)
+	defer cleanup()
+
+	testDBOverride = db
+	defer func() { testDBOverride = nil }()
+
+	ctx := context.Background()
+	queries := sqlc.New(db)
+	_ = queries.DeactivateAllExperiments(ctx)
+
+	now := time.Now().UTC()
+	err := queries.CreateExperiment(ctx, sqlc.CreateExperimentParams{
+		ID:             "exp-scheduled",
+		Name:           "scheduled-experiment",
+		StartedAt:      now.Format(time.RFC3339),
+		CreatedAt:      now.Format(time.RFC3339),
+		ScheduledStart: sql.NullString{String: now.Add(-time.Hour).Format(time.RFC3339), Valid: true},
+		ScheduledEnd:   sql.NullString{String: now.Add(time.Hour).Format(time.RFC3339), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create experiment: %v", err)
+	}
+
+	input := map[string]string{
+		"session_id":      "sess-sched",
+		"transcript_path": "/tmp/transcript.jsonl",
+		"cwd":             "/project",
+		"permission_mode": "default",
+		"hook_event_name": "SessionStart",
+	}
+
+	output, err := runHookWithInput(t, input)
+	if err != nil {
+		t.Fatalf("SessionStart handler failed: %v", err)
+	}
+
+	var resp HookResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Failed to parse response JSON: %v\nOutput: %s", err, output)
+	}
+	if !bytes.Contains([]byte(resp.AdditionalContext), []byte("scheduled-experiment")) {
+		t.Errorf("Expected scheduled experiment in additionalContext, got: %s", resp.AdditionalContext)
+	}
+}
@@ -153,3 +153,68 @@ func TestHandleStop_UpdatesExistingSession(t *testing.T) {
 		t.Error("Expected positive token input after stop update")
 	}
 }
+
+func TestHandleStop_SavingAgainKeepsCounts(t *testing.T) {
+	db, cleanup := testDB(t)
+	defer cleanup()
+
+	testDBOverride = db
+	defer func() { testDBOverride = nil }()
+
+	transcriptPath, err := filepath.Abs("testdata/transcript.jsonl")
+	if err != nil {
+		t.Fatalf("Failed to get transcript path: %v", err)
+	}
+
+	ctx := context.Background()
+	sessionID := "sess-stop-4-" + fmt.Sprintf("%d", time.Now().UnixNano())
+	input := map[string]any{
+		"session_id":       sessionID,
+		"transcript_path":  transcriptPath,
+		"cwd":              "/test/project",
+		"permission_mode":  "default",
+		"hook_event_name":  "Stop",
+		"stop_hook_active": false,
+	}
+
+	queries := sqlc.New(db)
+	counts := func() [6]int64 {
+		t.Helper()
+		var c [6]int64
+		tools, err := queries.ListSessionToolsBySessionID(ctx, sessionID)
+		if err != nil {
+			t.Fatalf("Failed to list tools: %v", err)
+		}
+		for _, tool := range tools {
+			c[0] += tool.InvocationCount
+		}
+		files, err := queries.ListSessionFilesBySessionID(ctx, sessionID)
+		if err != nil {
+			t.Fatalf("Failed to list files: %v", err)
+		}
+		for _, f := range files {
+			c[1] += f.OperationCount
+		}
+		for i, table := range []string{"session_commands", "session_subagents", "session_tool_calls", "session_file_changes"} {
+			if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE session_id = ?", sessionID).Scan(&c[2+i]); err != nil {
+				t.Fatalf("Failed to count %s: %v", table, err)
+			}
+		}
+		return c
+	}
+
+	// The Stop hook saves the session after every turn
+	if _, err := runHookWithInput(t, input); err != nil {
+		t.Fatalf("Stop handler failed: %v", err)
+	}
+	first := counts()
+	if first[0] == 0 || first[1] == 0 || first[2] == 0 {
+		t.Fatalf("Expected tools, files and commands, got %v", first)
+	}
+	if _, err := runHookWithInput(t, input); err != nil {
+		t.Fatalf("Stop handler failed: %v", err)
+	}
+	if again := counts(); again != first {
+		t.Errorf("Expected saving again to keep counts %v, got %v", first, again)
+	}
+}
@@ -85,22 +85,14 @@ func handleSubagentStop(event *domain.SubagentStopInput) error {
 	totalTokens := parsed.Metrics.TokenInput + parsed.Metrics.TokenOutput +
 		parsed.Metrics.TokenCacheRead + parsed.Metrics.TokenCacheWrite
 
-	// Calculate cost estimate
+	// Calculate cost estimate under the project's pricing profile
 	pricingRepo := turso.NewPricingRepository(sqlDB)
+	profile, _ := turso.NewPricingProfileRepository(sqlDB).GetForProject(ctx, turso.ProjectIDForPath(event.Cwd))
+	defaultPricing, _ := pricingRepo.GetDefault(ctx)
 	var costEstimate *float64
-
-	// Try to get pricing for the model
-	if parsed.ModelID != nil {
-		if p, _ := pricingRepo.GetByID(ctx, *parsed.ModelID); p != nil {
-			cost := p.CalculateCost(parsed.Metrics.TokenInput, parsed.Metrics.TokenOutput, parsed.Metrics.TokenCacheRead, parsed.Metrics.TokenCacheWrite)
-			costEstimate = &cost
-		}
-	}
-	if costEstimate == nil {
-		if p, _ := pricingRepo.GetDefault(ctx); p != nil {
-			cost := p.CalculateCost(parsed.Metrics.TokenInput, parsed.Metrics.TokenOutput, parsed.Metrics.TokenCacheRead, parsed.Metrics.TokenCacheWrite)
-			costEstimate = &cost
-		}
+	if p := resolvePricing(ctx, parsed.ModelID, pricingRepo, profile, profile.Apply(defaultPricing)); p != nil {
+		cost := p.CalculateCost(parsed.Metrics.TokenUsage())
+		costEstimate = &cost
 	}
 
 	// Save to session_subagents
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Show MCP server and tool analytics",
+	Long: `Show how sessions use the tools of MCP servers: calls, errors, the
+sessions that used each server and the size of their results.
+
+Tool names such as "mcp__github__create_issue" are split into the server
+("github") and the tool ("create_issue"). Result tokens are estimated from
+the size of the result text, at about 4 bytes per token; a server whose
+share of result tokens is well above its share of calls fills the context
+more than it is used.
+
+Examples:
+  mclaude mcp                        # Last 30 days
+  mclaude mcp --days 7 --tools       # With each server's tools
+  mclaude mcp -e baseline            # Sessions of an experiment`,
+	RunE: runMCP,
+}
+
+// Flags
+var (
+	mcpExperiment string
+	mcpProject    string
+	mcpDays       int
+	mcpTools      bool
+)
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+	mcpCmd.Flags().StringVarP(&mcpExperiment, "experiment", "e", "", "Filter by experiment name")
+	mcpCmd.Flags().StringVar(&mcpProject, "project", "", "Filter by project ID")
+	mcpCmd.Flags().IntVarP(&mcpDays, "days", "d", 30, "Number of days of sessions to analyze")
+	mcpCmd.Flags().BoolVar(&mcpTools, "tools", false, "Show the tools of each server")
+}
+
+func runMCP(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	opts := ports.ListToolCallsOptions{
+		Since: time.Now().UTC().AddDate(0, 0, -mcpDays).Format(time.RFC3339),
+	}
+	if mcpExperiment != "" {
+		exp, err := getExperimentByName(ctx, app.ExperimentRepo, mcpExperiment)
+		if err != nil {
+			return err
+		}
+		opts.ExperimentID = &exp.ID
+	}
+	if mcpProject != "" {
+		opts.ProjectID = &mcpProject
+	}
+
+	sequences, err := app.ToolCallRepo.ListSequences(ctx, opts)
+	if err != nil {
+		return err
+	}
+	b := domain.AggregateMCP(sequences)
+	if len(b.Servers) == 0 {
+		fmt.Printf("No MCP tool calls in the last %d days\n", mcpDays)
+		return nil
+	}
+
+	fmt.Printf("%s MCP calls of %s tool calls in %d sessions\n\n",
+		util.FormatNumber(b.MCPCalls()), util.FormatNumber(b.Calls), b.Sessions)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SERVER\tCALLS\tCALL SHARE\tSESSIONS\tERRORS\tRESULT TOKENS\tTOKENS/CALL\tRESULT SHARE")
+	_, _ = fmt.Fprintln(w, "------\t-----\t----------\t--------\t------\t-------------\t-----------\t------------")
+	for _, s := range b.Servers {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%.1f%%\t%d (%.0f%%)\t%d (%.0f%%)\t%s\t%.0f\t%.1f%%\n",
+			truncate(s.Server, 30), s.Calls, b.CallShare(s)*100, s.Sessions, b.SessionShare(s)*100,
+			s.Errors, s.ErrorRate()*100, util.FormatNumber(s.ResultTokens()), s.TokensPerCall(), b.ResultShare(s)*100)
+		if mcpTools {
+			for _, t := range s.Tools {
+				_, _ = fmt.Fprintf(w, "  %s\t%d\t\t\t%d\t%s\t\t\n",
+					truncate(t.Tool, 28), t.Calls, t.Errors, util.FormatNumber(domain.EstimateResultTokens(t.ResultBytes)))
+			}
+		}
+	}
+	_ = w.Flush()
+	return nil
+}
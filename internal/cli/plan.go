@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/usage"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+	"github.com/emiliopalmerini/mclaude/internal/value"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Track usage windows of a Pro or Max subscription",
+	Long: `Track token usage against the 5-hour and weekly windows of a Claude
+subscription.
+
+Usage is aggregated from the timestamp and tokens of every recorded request.
+Limits are not published, so they are learned from sessions that ran into a
+usage-limit error: the estimate is the median of the tokens used in the window
+when each limit was hit. Limits learned on another plan are scaled by the plan
+multiplier (Pro 1x, Max 5x, Max 20x) until the current plan hits its own.`,
+}
+
+var planSetCmd = &cobra.Command{
+	Use:   "set <pro|max_5x|max_20x>",
+	Short: "Set the subscription plan",
+	Long: `Set the subscription plan usage windows are tracked against.
+
+Without --weekly-reset the weekly window is the rolling last seven days.
+
+Examples:
+  mclaude plan set pro
+  mclaude plan set max_5x --weekly-reset "2026-03-02 09:00"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanSet,
+}
+
+var planStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show usage of the current windows",
+	RunE:  runPlanStatus,
+}
+
+var planClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Stop tracking plan usage windows",
+	RunE:  runPlanClear,
+}
+
+var planPriceCmd = &cobra.Command{
+	Use:   "price",
+	Short: "Manage the monthly price paid for the plan",
+	Long: `Record what the subscription costs per month, for comparing it with
+API pricing in 'mclaude value'. Each price applies from its month until the
+next price, so plan changes and price increases keep past months accurate.`,
+}
+
+var planPriceSetCmd = &cobra.Command{
+	Use:   "set [usd]",
+	Short: "Set the monthly plan price from a month onward",
+	Long: `Set the monthly plan price from a month onward. Without an amount the plan's
+list price is used (Pro $20, Max 5x $100, Max 20x $200).
+
+Examples:
+  mclaude plan price set                          # current plan, list price, this month
+  mclaude plan price set 100 --plan max_5x --from 2026-01
+  mclaude plan price set 200 --plan max_20x --from 2026-04`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPlanPriceSet,
+}
+
+var planPriceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List plan prices",
+	RunE:  runPlanPriceList,
+}
+
+var planPriceRemoveCmd = &cobra.Command{
+	Use:   "remove <YYYY-MM>",
+	Short: "Remove the price taking effect in a month",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPlanPriceRemove,
+}
+
+// Flags
+var (
+	planWeeklyReset string
+	planPricePlan   string
+	planPriceFrom   string
+)
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	planCmd.AddCommand(planSetCmd)
+	planCmd.AddCommand(planStatusCmd)
+	planCmd.AddCommand(planClearCmd)
+	planCmd.AddCommand(planPriceCmd)
+	planPriceCmd.AddCommand(planPriceSetCmd)
+	planPriceCmd.AddCommand(planPriceListCmd)
+	planPriceCmd.AddCommand(planPriceRemoveCmd)
+
+	planSetCmd.Flags().StringVar(&planWeeklyReset, "weekly-reset", "", "Any past or future weekly reset time (YYYY-MM-DD HH:MM, local time)")
+	planPriceSetCmd.Flags().StringVar(&planPricePlan, "plan", "", "Plan paid for (default: the current plan)")
+	planPriceSetCmd.Flags().StringVar(&planPriceFrom, "from", "", "First month the price applies (YYYY-MM, default: this month)")
+}
+
+func runPlanSet(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	planType := strings.ToLower(args[0])
+	if !domain.IsValidPlanType(planType) {
+		return fmt.Errorf("invalid plan %q (use pro, max_5x or max_20x)", args[0])
+	}
+
+	now := time.Now().UTC()
+	plan := &domain.PlanConfig{PlanType: planType, CreatedAt: now, UpdatedAt: now}
+	if planWeeklyReset != "" {
+		resetAt, err := time.ParseInLocation("2006-01-02 15:04", planWeeklyReset, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --weekly-reset %q (use YYYY-MM-DD HH:MM)", planWeeklyReset)
+		}
+		plan.WeeklyResetAt = &resetAt
+	}
+	if existing, err := app.PlanRepo.Get(ctx); err == nil && existing != nil {
+		plan.CreatedAt = existing.CreatedAt
+	}
+
+	if err := app.PlanRepo.Set(ctx, plan); err != nil {
+		return err
+	}
+	fmt.Printf("Plan set to %s\n", planType)
+	return nil
+}
+
+func runPlanStatus(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	status, err := usage.NewTracker(app.PlanRepo, app.UsageRepo).Status(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		fmt.Println("No plan set")
+		fmt.Println("\nUse 'mclaude plan set <pro|max_5x|max_20x>' to track usage windows")
+		return nil
+	}
+
+	fmt.Println()
+	printPlanUsage(status)
+	return nil
+}
+
+func runPlanClear(cmd *cobra.Command, args []string) error {
+	if err := app.PlanRepo.Clear(context.Background()); err != nil {
+		return err
+	}
+	fmt.Println("Plan cleared")
+	return nil
+}
+
+func runPlanPriceSet(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	planType := strings.ToLower(planPricePlan)
+	if planType == "" {
+		plan, err := app.PlanRepo.Get(ctx)
+		if err != nil {
+			return err
+		}
+		if plan == nil {
+			return fmt.Errorf("no plan set: use --plan or 'mclaude plan set'")
+		}
+		planType = plan.PlanType
+	}
+	if !domain.IsValidPlanType(planType) {
+		return fmt.Errorf("invalid plan %q (use pro, max_5x or max_20x)", planPricePlan)
+	}
+
+	amount := domain.PlanListPrices[planType]
+	if len(args) > 0 {
+		var err error
+		amount, err = strconv.ParseFloat(strings.TrimPrefix(args[0], "$"), 64)
+		if err != nil || amount <= 0 {
+			return fmt.Errorf("invalid amount %q", args[0])
+		}
+	}
+
+	from := domain.MonthStart(time.Now())
+	if planPriceFrom != "" {
+		var err error
+		if from, err = value.ParseMonth(planPriceFrom); err != nil {
+			return err
+		}
+	}
+
+	price := &domain.PlanPrice{
+		EffectiveFrom: from,
+		PlanType:      planType,
+		MonthlyUsd:    amount,
+		CreatedAt:     time.Now().UTC(),
+	}
+	if err := app.PlanPriceRepo.Set(ctx, price); err != nil {
+		return err
+	}
+	fmt.Printf("Plan price set: %s at $%.2f/month from %s\n", planType, amount, from.Format("2006-01"))
+	return nil
+}
+
+func runPlanPriceList(cmd *cobra.Command, args []string) error {
+	prices, err := app.PlanPriceRepo.List(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(prices) == 0 {
+		fmt.Println("No plan prices set")
+		fmt.Println("\nUse 'mclaude plan price set' to record what the plan costs")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FROM\tPLAN\tPRICE/MONTH")
+	fmt.Fprintln(w, "----\t----\t-----------")
+	for _, p := range prices {
+		fmt.Fprintf(w, "%s\t%s\t$%.2f\n", p.EffectiveFrom.Format("2006-01"), p.PlanType, p.MonthlyUsd)
+	}
+	return w.Flush()
+}
+
+func runPlanPriceRemove(cmd *cobra.Command, args []string) error {
+	from, err := value.ParseMonth(args[0])
+	if err != nil {
+		return err
+	}
+	removed, err := app.PlanPriceRepo.Delete(context.Background(), from)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("no plan price takes effect in %s", args[0])
+	}
+	fmt.Printf("Plan price from %s removed\n", args[0])
+	return nil
+}
+
+// printPlanUsage prints the current 5-hour and weekly windows as a stats
+// section.
+func printPlanUsage(status *domain.PlanUsage) {
+	fmt.Printf("  Plan Usage (%s)\n", status.Plan.PlanType)
+	fmt.Printf("  %s\n", repeatChar('-', len(status.Plan.PlanType)+13))
+	fmt.Printf("  5-hour window:     %s\n", formatWindowUsage(status.Session))
+	fmt.Printf("  Weekly window:     %s\n", formatWindowUsage(status.Weekly))
+	fmt.Println()
+}
+
+func formatWindowUsage(w domain.WindowUsage) string {
+	if !w.Active {
+		return "inactive (starts with the next request)"
+	}
+	resets := "resets " + w.End.Local().Format("Mon 15:04")
+	if w.Limit <= 0 {
+		return fmt.Sprintf("%s tokens, no limit learned yet, %s", util.FormatNumber(w.Tokens), resets)
+	}
+	return fmt.Sprintf("%s %3.0f%%  %s of ~%s tokens (%d hits), %s",
+		budgetBar(w.UsedFraction()),
+		w.UsedFraction()*100,
+		util.FormatNumber(w.Tokens),
+		util.FormatNumber(w.Limit),
+		w.LimitSamples,
+		resets,
+	)
+}
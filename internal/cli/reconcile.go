@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/reconcile"
+)
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile [console-export.csv]",
+	Short: "Compare estimated costs with Anthropic billing",
+	Long: `Compare the estimated costs of recorded sessions with the usage and cost
+billed by Anthropic, per day and model.
+
+With a file, first import a usage or cost report exported as CSV from the
+Anthropic console. Rows are stored per day, model, workspace and API key;
+re-importing a period replaces it. Without a file, compare the usage imported
+so far.
+
+When a model's tokens match the bill but its cost does not, its rates are off:
+the 'mclaude cost set' command that would have matched the bill is suggested.
+Days are in UTC. Discrepancies are also shown on the Billing page of
+'mclaude serve'.
+
+Examples:
+  mclaude reconcile usage-report.csv
+  mclaude reconcile --days 90 --tolerance 0.02`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReconcile,
+}
+
+// Flags
+var (
+	reconcileDays      int
+	reconcileTolerance float64
+)
+
+func init() {
+	rootCmd.AddCommand(reconcileCmd)
+	reconcileCmd.Flags().IntVarP(&reconcileDays, "days", "d", 30, "Number of days of billing data to compare")
+	reconcileCmd.Flags().Float64Var(&reconcileTolerance, "tolerance", reconcile.DefaultTolerance, "Relative difference accepted as a match (0.05 = 5%)")
+}
+
+func runReconcile(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	reconciler := reconcile.NewReconciler(app.BillingRepo, app.StatsRepo, app.PricingRepo)
+
+	if len(args) == 1 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open billing export: %w", err)
+		}
+		usage, err := reconcile.ParseCSV(f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		if err := reconciler.Import(ctx, usage, time.Now().UTC()); err != nil {
+			return err
+		}
+		fmt.Printf("Imported %d rows of billed usage from %s\n", len(usage), args[0])
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -reconcileDays)
+	report, err := reconciler.Report(ctx, since, reconcileTolerance)
+	if err != nil {
+		return err
+	}
+	if !report.HasData() {
+		fmt.Printf("No billed usage in the last %d days.\n", reconcileDays)
+		fmt.Println("Import a console usage or cost export with 'mclaude reconcile <file.csv>'.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("  Billing Reconciliation (%s to %s)\n", report.From.Format("2006-01-02"), report.To.Format("2006-01-02"))
+	fmt.Printf("  ---------------------------------------------\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  MODEL\tDAYS\tBILLED\tESTIMATED\tDELTA\tTOKENS Δ\tSTATUS")
+	_, _ = fmt.Fprintln(w, "  -----\t----\t------\t---------\t-----\t--------\t------")
+	for _, m := range report.Models {
+		_, _ = fmt.Fprintf(w, "  %s\t%d\t%s\t$%.2f\t%s\t%s\t%s\n",
+			truncate(m.Model, 40),
+			m.Days,
+			billedLabel(m.BillingDiscrepancy),
+			m.EstimatedUsd,
+			deltaLabel(m.BillingDiscrepancy),
+			tokenDeltaLabel(m.BillingDiscrepancy),
+			matchLabel(m.BillingDiscrepancy, report.Tolerance),
+		)
+	}
+	_, _ = fmt.Fprintf(w, "  Total\t\t%s\t$%.2f\t%s\t%s\t%s\n",
+		billedLabel(report.Total),
+		report.Total.EstimatedUsd,
+		deltaLabel(report.Total),
+		tokenDeltaLabel(report.Total),
+		matchLabel(report.Total, report.Tolerance),
+	)
+	_ = w.Flush()
+	fmt.Println()
+
+	mismatches := report.Mismatches()
+	if len(mismatches) > 0 {
+		fmt.Printf("  Days off by more than %.0f%%\n", report.Tolerance*100)
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  DATE\tMODEL\tBILLED\tESTIMATED\tDELTA\tTOKENS Δ")
+		_, _ = fmt.Fprintln(w, "  ----\t-----\t------\t---------\t-----\t--------")
+		for _, d := range mismatches {
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t$%.2f\t%s\t%s\n",
+				d.Date.Format("2006-01-02"),
+				truncate(d.Model, 40),
+				billedLabel(d.BillingDiscrepancy),
+				d.EstimatedUsd,
+				deltaLabel(d.BillingDiscrepancy),
+				tokenDeltaLabel(d.BillingDiscrepancy),
+			)
+		}
+		_ = w.Flush()
+		fmt.Println()
+	}
+
+	var suggested []reconcile.Model
+	for _, m := range report.Models {
+		if m.Suggested != nil {
+			suggested = append(suggested, m)
+		}
+	}
+	if len(suggested) > 0 {
+		fmt.Println("  Suggested pricing corrections (tokens match, rates are off):")
+		for _, m := range suggested {
+			fmt.Printf("    %s\n", reconcile.CostSetCommand(m.Suggested))
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func billedLabel(d domain.BillingDiscrepancy) string {
+	if !d.HasCost {
+		return "-"
+	}
+	return fmt.Sprintf("$%.2f", d.BilledUsd)
+}
+
+func deltaLabel(d domain.BillingDiscrepancy) string {
+	if !d.HasCost {
+		return "-"
+	}
+	if d.BilledUsd == 0 {
+		return formatSignedUsd(d.DeltaUsd())
+	}
+	return fmt.Sprintf("%s (%s)", formatSignedUsd(d.DeltaUsd()), formatPercent(d.DeltaPercent()))
+}
+
+func tokenDeltaLabel(d domain.BillingDiscrepancy) string {
+	if d.BilledTokens == 0 {
+		return "-"
+	}
+	return formatPercent(d.TokenDeltaPercent())
+}
+
+func matchLabel(d domain.BillingDiscrepancy, tolerance float64) string {
+	if d.CostMatches(tolerance) {
+		return "ok"
+	}
+	return "MISMATCH"
+}
+
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%+.1f%%", v)
+}
@@ -69,16 +69,3 @@ func processRecordInput(hookInput *domain.HookInput) error {
 		ExitReason: hookInput.Reason,
 	})
 }
-
-// resolveModelAlias maps short model aliases to full model IDs for pricing lookup.
-func resolveModelAlias(alias string) string {
-	aliases := map[string]string{
-		"haiku":  "claude-haiku-4-5-20251001",
-		"sonnet": "claude-sonnet-4-5-20250929",
-		"opus":   "claude-opus-4-6-20260206",
-	}
-	if id, ok := aliases[alias]; ok {
-		return id
-	}
-	return alias
-}
@@ -6,9 +6,11 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/emiliopalmerini/mclaude/internal/domain"
 	"github.com/emiliopalmerini/mclaude/internal/web"
 )
 
@@ -19,15 +21,23 @@ var serveCmd = &cobra.Command{
 
 Examples:
   mclaude serve              # Start on default port 8080
-  mclaude serve --port 3000  # Start on port 3000`,
+  mclaude serve --port 3000  # Start on port 3000
+
+While running, daily, weekly, monthly and experiment alert rules are checked
+every --alert-interval (0 disables the check).`,
 	RunE: runServe,
 }
 
-var servePort int
+// Flags
+var (
+	servePort          int
+	serveAlertInterval time.Duration
+)
 
 func init() {
 	rootCmd.AddCommand(serveCmd)
 	serveCmd.Flags().IntVarP(&servePort, "port", "p", 8080, "Port to listen on")
+	serveCmd.Flags().DurationVar(&serveAlertInterval, "alert-interval", 5*time.Minute, "Time between alert rule checks (0 disables)")
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
@@ -42,9 +52,17 @@ func runServe(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
+	if serveAlertInterval > 0 {
+		go runAlertTimer(ctx, app.DB.DB, serveAlertInterval, func(f *domain.AlertFiring) {
+			fmt.Printf("Alert fired: %s\n", f.Message)
+		})
+	}
+
 	server := web.NewServer(
 		app.DB.DB, servePort,
-		app.ExperimentRepo, app.ExpVariableRepo, app.PricingRepo, app.SessionRepo, app.MetricsRepo, app.StatsRepo, app.ProjectRepo,
+		app.ExperimentRepo, app.ExpVariableRepo, app.NoteRepo, app.PricingRepo, app.SessionRepo, app.MetricsRepo, app.StatsRepo, app.ProjectRepo, app.BudgetRepo,
+		app.PlanRepo, app.UsageRepo, app.PlanPriceRepo, app.ProfileRepo, app.BillingRepo, app.CommitRepo,
+		app.CommandRepo, app.FileRepo, app.ToolCallRepo,
 	)
 	return server.Start(ctx)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/emiliopalmerini/mclaude/internal/domain"
 	"github.com/emiliopalmerini/mclaude/internal/ports"
 )
 
@@ -26,7 +27,16 @@ Examples:
   mclaude sessions list                     # Last 10 sessions
   mclaude sessions list --last 20           # Last 20 sessions
   mclaude sessions list --experiment "exp"  # Sessions for experiment
-  mclaude sessions list --project <id>      # Sessions for project`,
+  mclaude sessions list --project <id>      # Sessions for project
+  mclaude sessions list --anomalous         # Only sessions flagged as anomalous
+  mclaude sessions list --branch main       # Sessions on a git branch
+  mclaude sessions list --repo mclaude      # Sessions in a matching repository
+  mclaude sessions list --outcome red       # Sessions whose last test, build or lint run failed
+
+Sessions flagged as anomalous against their project's baseline are marked
+with "!" and their reasons are listed below the table. The outcome is green
+when the last run of every kind of check a session ran (tests, builds and
+linters) passed, red when one failed, and "-" when it ran none.`,
 	RunE: runSessionsList,
 }
 
@@ -35,6 +45,10 @@ var (
 	sessionsLast       int
 	sessionsExperiment string
 	sessionsProject    string
+	sessionsAnomalous  bool
+	sessionsBranch     string
+	sessionsRepo       string
+	sessionsOutcome    string
 )
 
 func init() {
@@ -44,13 +58,27 @@ func init() {
 	sessionsListCmd.Flags().IntVarP(&sessionsLast, "last", "n", 10, "Number of sessions to show")
 	sessionsListCmd.Flags().StringVarP(&sessionsExperiment, "experiment", "e", "", "Filter by experiment name")
 	sessionsListCmd.Flags().StringVar(&sessionsProject, "project", "", "Filter by project ID")
+	sessionsListCmd.Flags().BoolVar(&sessionsAnomalous, "anomalous", false, "Only show sessions flagged as anomalous")
+	sessionsListCmd.Flags().StringVar(&sessionsBranch, "branch", "", "Filter by git branch")
+	sessionsListCmd.Flags().StringVar(&sessionsRepo, "repo", "", "Filter by git repository (part of its root path or remote URL)")
+	sessionsListCmd.Flags().StringVar(&sessionsOutcome, "outcome", "", "Filter by outcome: green, red or unchecked")
 }
 
 func runSessionsList(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	switch sessionsOutcome {
+	case "", domain.SessionOutcomeGreen, domain.SessionOutcomeRed, domain.SessionOutcomeUnchecked:
+	default:
+		return fmt.Errorf("invalid outcome %q: must be green, red or unchecked", sessionsOutcome)
+	}
+
 	opts := ports.ListSessionsOptions{
-		Limit: sessionsLast,
+		Limit:         sessionsLast,
+		AnomalousOnly: sessionsAnomalous,
+		Branch:        sessionsBranch,
+		Repository:    sessionsRepo,
+		Outcome:       sessionsOutcome,
 	}
 
 	if sessionsExperiment != "" {
@@ -74,14 +102,19 @@ func runSessionsList(cmd *cobra.Command, args []string) error {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "ID\tDATE\tMODEL\tTURNS\tTOKENS\tCOST\tDURATION\tREASON")
-	fmt.Fprintln(w, "--\t----\t-----\t-----\t------\t----\t--------\t------")
+	fmt.Fprintln(w, "ID\tDATE\tMODEL\tBRANCH\tTURNS\tTOKENS\tCOST\tDURATION\tOUTCOME\tREASON")
+	fmt.Fprintln(w, "--\t----\t-----\t------\t-----\t------\t----\t--------\t-------\t------")
 
+	var flagged []*domain.SessionListItem
 	for _, item := range items {
 		id := item.ID
 		if len(id) > 12 {
 			id = id[:12]
 		}
+		if item.Anomaly != nil {
+			id = "! " + id
+			flagged = append(flagged, item)
+		}
 
 		date := formatDateTimeCLI(item.CreatedAt)
 
@@ -90,6 +123,11 @@ func runSessionsList(cmd *cobra.Command, args []string) error {
 			model = shortModel(*item.ModelID)
 		}
 
+		branch := "-"
+		if item.GitBranch != nil {
+			branch = truncate(*item.GitBranch, 24)
+		}
+
 		turns := fmt.Sprintf("%d", item.TurnCount)
 		tokens := formatTokensCLI(item.TotalTokens)
 
@@ -103,11 +141,24 @@ func runSessionsList(cmd *cobra.Command, args []string) error {
 			duration = formatDurationCLI(*item.Duration)
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", id, date, model, turns, tokens, cost, duration, item.ExitReason)
+		outcome := "-"
+		if item.EndedGreen != nil {
+			outcome = domain.OutcomeOf(item.EndedGreen)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", id, date, model, branch, turns, tokens, cost, duration, outcome, item.ExitReason)
 	}
 
 	_ = w.Flush()
 
+	if len(flagged) > 0 {
+		fmt.Println()
+		fmt.Println("Anomalous sessions:")
+		for _, item := range flagged {
+			fmt.Printf("  ! %s  %s\n", item.ID[:min(12, len(item.ID))], item.Anomaly.Summary())
+		}
+	}
+
 	fmt.Printf("\nShowing %d session(s)\n", len(items))
 	return nil
 }
@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var sessionsAssignCmd = &cobra.Command{
+	Use:   "assign [session-ids...] <experiment>",
+	Short: "Retroactively assign sessions to an experiment",
+	Long: `Assign sessions to an experiment after they were recorded.
+
+Sessions can be selected by ID (or unique ID prefix), or by time range with
+--since/--until, optionally restricted to a project.
+
+Examples:
+  mclaude sessions assign 3f2a9c1b minimal-prompts
+  mclaude sessions assign --since 2026-03-01 --until 2026-03-08 minimal-prompts
+  mclaude sessions assign --since 2026-03-01 --project <id> baseline`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSessionsAssign,
+}
+
+var sessionsExcludeCmd = &cobra.Command{
+	Use:   "exclude [session-ids...]",
+	Short: "Exclude sessions from experiment stats",
+	Long: `Exclude outlier sessions from experiment stats and comparisons.
+
+Excluded sessions are kept, but left out of experiment aggregates, tool call
+totals and the compare pages. The reason is recorded with the session.
+
+Examples:
+  mclaude sessions exclude 3f2a9c1b --reason "3h debugging marathon"
+  mclaude sessions exclude --since "2026-03-02 14:00" --until "2026-03-02 18:00" --reason "outage"`,
+	RunE: runSessionsExclude,
+}
+
+var sessionsIncludeCmd = &cobra.Command{
+	Use:   "include [session-ids...]",
+	Short: "Include previously excluded sessions",
+	Long: `Include previously excluded sessions in experiment stats again.
+
+Examples:
+  mclaude sessions include 3f2a9c1b
+  mclaude sessions include --since 2026-03-02`,
+	RunE: runSessionsInclude,
+}
+
+var sessionsExcludedCmd = &cobra.Command{
+	Use:   "excluded",
+	Short: "List excluded sessions",
+	RunE:  runSessionsExcluded,
+}
+
+// Flags
+var (
+	selectSince   string
+	selectUntil   string
+	selectProject string
+	excludeReason string
+)
+
+func init() {
+	sessionsCmd.AddCommand(sessionsAssignCmd)
+	sessionsCmd.AddCommand(sessionsExcludeCmd)
+	sessionsCmd.AddCommand(sessionsIncludeCmd)
+	sessionsCmd.AddCommand(sessionsExcludedCmd)
+
+	for _, cmd := range []*cobra.Command{sessionsAssignCmd, sessionsExcludeCmd, sessionsIncludeCmd} {
+		cmd.Flags().StringVar(&selectSince, "since", "", "Select sessions created at or after this time")
+		cmd.Flags().StringVar(&selectUntil, "until", "", "Select sessions created before this time (default: now)")
+		cmd.Flags().StringVar(&selectProject, "project", "", "Restrict the time range to a project ID")
+	}
+
+	sessionsExcludeCmd.Flags().StringVar(&excludeReason, "reason", "", "Why the sessions are excluded (required)")
+	_ = sessionsExcludeCmd.MarkFlagRequired("reason")
+}
+
+func runSessionsAssign(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	expName := args[len(args)-1]
+
+	exp, err := getExperimentByName(ctx, app.ExperimentRepo, expName)
+	if err != nil {
+		return err
+	}
+
+	ids, err := selectSessions(ctx, args[:len(args)-1])
+	if err != nil {
+		return err
+	}
+
+	if err := app.SessionRepo.AssignExperiment(ctx, ids, exp.ID); err != nil {
+		return fmt.Errorf("failed to assign sessions: %w", err)
+	}
+
+	fmt.Printf("Assigned %d session(s) to experiment: %s\n", len(ids), exp.Name)
+	return nil
+}
+
+func runSessionsExclude(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	ids, err := selectSessions(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	if err := app.SessionRepo.SetExcluded(ctx, ids, true, &excludeReason); err != nil {
+		return fmt.Errorf("failed to exclude sessions: %w", err)
+	}
+
+	fmt.Printf("Excluded %d session(s): %s\n", len(ids), excludeReason)
+	return nil
+}
+
+func runSessionsInclude(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	ids, err := selectSessions(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	if err := app.SessionRepo.SetExcluded(ctx, ids, false, nil); err != nil {
+		return fmt.Errorf("failed to include sessions: %w", err)
+	}
+
+	fmt.Printf("Included %d session(s)\n", len(ids))
+	return nil
+}
+
+func runSessionsExcluded(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	sessions, err := app.SessionRepo.ListExcluded(ctx, 100)
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		fmt.Println("No excluded sessions")
+		return nil
+	}
+
+	names, err := experimentNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tDATE\tEXPERIMENT\tREASON")
+	_, _ = fmt.Fprintln(w, "--\t----\t----------\t------")
+
+	for _, s := range sessions {
+		id := s.ID
+		if len(id) > 12 {
+			id = id[:12]
+		}
+		experiment := "-"
+		if s.ExperimentID != nil {
+			experiment = names[*s.ExperimentID]
+		}
+		reason := "-"
+		if s.ExclusionReason != nil {
+			reason = *s.ExclusionReason
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			id, s.CreatedAt.Local().Format("2006-01-02 15:04"), experiment, reason)
+	}
+
+	_ = w.Flush()
+	return nil
+}
+
+// selectSessions resolves session IDs (or unique ID prefixes) given as
+// arguments, or the sessions created in the --since/--until range.
+func selectSessions(ctx context.Context, args []string) ([]string, error) {
+	hasRange := selectSince != "" || selectUntil != ""
+	if len(args) > 0 && hasRange {
+		return nil, fmt.Errorf("specify either session IDs or --since/--until, not both")
+	}
+	if selectProject != "" && !hasRange {
+		return nil, fmt.Errorf("--project requires --since or --until")
+	}
+
+	if hasRange {
+		return selectSessionsInRange(ctx)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no sessions selected: pass session IDs or --since/--until")
+	}
+
+	ids := make([]string, 0, len(args))
+	for _, arg := range args {
+		id, err := resolveSessionID(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func selectSessionsInRange(ctx context.Context) ([]string, error) {
+	since := time.Unix(0, 0).UTC()
+	until := time.Now().UTC()
+
+	var err error
+	if selectSince != "" {
+		if since, err = parseScheduleTime(selectSince); err != nil {
+			return nil, err
+		}
+	}
+	if selectUntil != "" {
+		if until, err = parseScheduleTime(selectUntil); err != nil {
+			return nil, err
+		}
+	}
+	if !until.After(since) {
+		return nil, fmt.Errorf("--until must be after --since")
+	}
+
+	var projectID *string
+	if selectProject != "" {
+		projectID = &selectProject
+	}
+
+	ids, err := app.SessionRepo.ListIDsCreatedBetween(ctx, since.Format(time.RFC3339), until.Format(time.RFC3339), projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no sessions found in range")
+	}
+	return ids, nil
+}
+
+// resolveSessionID returns the full session ID for an exact ID or a unique prefix.
+func resolveSessionID(ctx context.Context, idOrPrefix string) (string, error) {
+	session, err := app.SessionRepo.GetByID(ctx, idOrPrefix)
+	if err != nil {
+		return "", err
+	}
+	if session != nil {
+		return session.ID, nil
+	}
+
+	ids, err := app.SessionRepo.FindIDsByPrefix(ctx, idOrPrefix)
+	if err != nil {
+		return "", err
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("session not found: %s", idOrPrefix)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("session prefix %q is ambiguous (%d matches)", idOrPrefix, len(ids))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/usage"
 	"github.com/emiliopalmerini/mclaude/internal/util"
 )
 
@@ -21,7 +22,22 @@ Examples:
   mclaude stats --period today           # Today's stats
   mclaude stats --period week            # This week's stats
   mclaude stats --experiment "baseline"  # Stats for an experiment
-  mclaude stats --project <id>           # Stats for a project`,
+  mclaude stats --project <id>           # Stats for a project
+  mclaude stats --forecast               # Add a month-end spend forecast
+  mclaude stats --by language            # File operations by language
+  mclaude stats --by server              # Tool calls by MCP server
+
+The forecast projects daily cost to the end of the month (UTC), overall and
+per project, with a 95% range. The seasonal method adds weekday effects to a
+linear trend once two weeks of history are available. It also predicts when
+each budget runs out.
+
+With --by language, the files sessions read, edited and wrote are grouped by
+language and by kind: source, test, generated or vendored. Each session's
+cost is split across its files in proportion to their operations.
+
+With --by server, tool invocations are grouped by the MCP server that
+provides each tool, with built-in tools together.`,
 	RunE: runStats,
 }
 
@@ -30,6 +46,9 @@ var (
 	statsPeriod     string
 	statsExperiment string
 	statsProject    string
+	statsForecast   bool
+	statsMethod     string
+	statsBy         string
 )
 
 func init() {
@@ -38,12 +57,28 @@ func init() {
 	statsCmd.Flags().StringVarP(&statsPeriod, "period", "p", "all", "Time period: today, week, month, all")
 	statsCmd.Flags().StringVarP(&statsExperiment, "experiment", "e", "", "Filter by experiment name")
 	statsCmd.Flags().StringVar(&statsProject, "project", "", "Filter by project ID")
+	statsCmd.Flags().BoolVar(&statsForecast, "forecast", false, "Show a month-end spend forecast")
+	statsCmd.Flags().StringVar(&statsMethod, "forecast-method", domain.ForecastSeasonal, "Forecast method: linear, seasonal")
+	statsCmd.Flags().StringVar(&statsBy, "by", "", "Break usage down by: language, server")
 }
 
 func runStats(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if statsForecast && !domain.IsValidForecastMethod(statsMethod) {
+		return fmt.Errorf("invalid forecast method %q (use linear or seasonal)", statsMethod)
+	}
+	if statsBy != "" && statsBy != "language" && statsBy != "server" {
+		return fmt.Errorf("invalid breakdown %q (use language or server)", statsBy)
+	}
+
 	startDate := getStartDate(statsPeriod)
+	if statsBy == "language" {
+		return runStatsByLanguage(ctx, startDate)
+	}
+	if statsBy == "server" {
+		return runStatsByServer(ctx, startDate)
+	}
 
 	var stats *domain.AggregateStats
 	var filterLabel string
@@ -77,7 +112,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 
 	// Get active experiment
 	activeExpName := "-"
-	activeExp, _ := app.ExperimentRepo.GetActive(ctx)
+	activeExp, _ := resolveActiveExperiment(ctx, app.ExperimentRepo, app.RotationRepo, time.Now().UTC())
 	if activeExp != nil {
 		activeExpName = activeExp.Name
 	}
@@ -85,8 +120,14 @@ func runStats(cmd *cobra.Command, args []string) error {
 	// Get top tools
 	tools, _ := app.StatsRepo.GetTopTools(ctx, startDate, 5)
 
-	printStats(stats, filterLabel, statsPeriod, activeExpName, tools)
+	// Get plan usage windows, when a plan is set
+	planUsage, _ := usage.NewTracker(app.PlanRepo, app.UsageRepo).Status(ctx, time.Now())
+
+	printStats(stats, filterLabel, statsPeriod, activeExpName, tools, planUsage)
 
+	if statsForecast {
+		return printForecast(ctx, statsProject, statsMethod, time.Now())
+	}
 	return nil
 }
 
@@ -112,7 +153,7 @@ func getStartDate(period string) string {
 	return start.Format(time.RFC3339)
 }
 
-func printStats(stats *domain.AggregateStats, filterLabel, period, activeExp string, tools []domain.ToolUsageStats) {
+func printStats(stats *domain.AggregateStats, filterLabel, period, activeExp string, tools []domain.ToolUsageStats, planUsage *domain.PlanUsage) {
 	periodLabel := "All time"
 	switch period {
 	case "today":
@@ -157,6 +198,8 @@ func printStats(stats *domain.AggregateStats, filterLabel, period, activeExp str
 	fmt.Printf("  Estimated:         $%.4f\n", stats.TotalCostUsd)
 	fmt.Println()
 
+	printLinesChanged(stats)
+
 	if len(tools) > 0 {
 		fmt.Printf("  Top Tools\n")
 		fmt.Printf("  ---------\n")
@@ -165,4 +208,25 @@ func printStats(stats *domain.AggregateStats, filterLabel, period, activeExp str
 		}
 		fmt.Println()
 	}
+
+	if planUsage != nil {
+		printPlanUsage(planUsage)
+	}
+}
+
+// printLinesChanged prints the lines the sessions changed and what they cost,
+// when any were recorded.
+func printLinesChanged(stats *domain.AggregateStats) {
+	if stats.LinesChanged() == 0 {
+		return
+	}
+	fmt.Printf("  Code\n")
+	fmt.Printf("  ----\n")
+	fmt.Printf("  Lines changed:     %s (+%s / -%s)\n",
+		util.FormatNumber(stats.LinesChanged()),
+		util.FormatNumber(stats.TotalLinesAdded),
+		util.FormatNumber(stats.TotalLinesRemoved),
+	)
+	fmt.Printf("  Cost/100 lines:    $%.4f\n", stats.CostPer100Lines())
+	fmt.Println()
 }
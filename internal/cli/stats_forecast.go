@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/budget"
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/forecast"
+)
+
+// printForecast prints the month-end spend projection, overall or for
+// projectID, followed by when each budget is projected to run out.
+func printForecast(ctx context.Context, projectID, method string, now time.Time) error {
+	forecaster := forecast.NewForecaster(app.StatsRepo, app.ProjectRepo)
+	report, err := forecaster.MonthEnd(ctx, now, method)
+	if err != nil {
+		return err
+	}
+
+	overall := report.Overall
+	projects := report.Projects
+	if projectID != "" {
+		overall = domain.ForecastCost(nil, report.MonthStart, method, 0, 0, report.MonthEnd)
+		projects = nil
+		for _, p := range report.Projects {
+			if p.ProjectID == projectID {
+				overall = p.Forecast
+			}
+		}
+	}
+
+	title := fmt.Sprintf("Forecast to %s (%s)", report.MonthEnd.AddDate(0, 0, -1).Format("Jan 2"), overall.Method)
+	fmt.Printf("  %s\n", title)
+	fmt.Printf("  %s\n", repeatChar('-', len(title)))
+	fmt.Printf("  Spent so far:      $%.2f\n", overall.SpentUsd)
+	fmt.Printf("  Projected:         $%.2f%s\n", overall.ProjectedUsd, forecastRange(overall))
+	fmt.Printf("  Fitted on:         %d days\n", overall.HistoryDays)
+	fmt.Println()
+
+	if len(projects) > 1 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  PROJECT\tSPENT\tPROJECTED\t95% RANGE")
+		_, _ = fmt.Fprintln(w, "  -------\t-----\t---------\t---------")
+		for _, p := range projects {
+			rng := "-"
+			if p.Forecast.HasInterval {
+				rng = fmt.Sprintf("$%.2f - $%.2f", p.Forecast.LowUsd, p.Forecast.HighUsd)
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t$%.2f\t$%.2f\t%s\n",
+				truncate(p.Name, 30),
+				p.Forecast.SpentUsd,
+				p.Forecast.ProjectedUsd,
+				rng,
+			)
+		}
+		_ = w.Flush()
+		fmt.Println()
+	}
+
+	statuses, err := budget.NewChecker(app.BudgetRepo, app.StatsRepo, app.ProjectRepo, app.ExperimentRepo).Statuses(ctx, now)
+	if err != nil {
+		return err
+	}
+	budgets, err := forecaster.Budgets(ctx, statuses, now, method)
+	if err != nil {
+		return err
+	}
+	if len(budgets) > 0 {
+		fmt.Printf("  Budget Exhaustion\n")
+		fmt.Printf("  -----------------\n")
+		for _, b := range budgets {
+			fmt.Printf("  %-30s %s\n", truncate(string(b.Status.Budget.Period)+" "+b.Status.Label, 30), budgetOutlook(b))
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func forecastRange(f domain.CostForecast) string {
+	if !f.HasInterval {
+		return ""
+	}
+	return fmt.Sprintf("  (95%%: $%.2f - $%.2f)", f.LowUsd, f.HighUsd)
+}
+
+func budgetOutlook(b forecast.BudgetForecast) string {
+	switch {
+	case b.Status.Exceeded():
+		return fmt.Sprintf("exceeded ($%.2f of $%.2f)", b.Status.SpentUsd, b.Status.Budget.AmountUsd)
+	case b.ExhaustsAt != nil:
+		return fmt.Sprintf("runs out %s, before reset %s", b.ExhaustsAt.Local().Format("Mon Jan 2"), b.Status.End.Format("Mon Jan 2 15:04"))
+	default:
+		return fmt.Sprintf("lasts until reset %s", b.Status.End.Format("Mon Jan 2 15:04"))
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// runStatsByLanguage prints the file operations and session cost of the
+// sessions since startDate by language and file kind, and the language mix
+// of each experiment.
+func runStatsByLanguage(ctx context.Context, startDate string) error {
+	opts := ports.ListFileTouchesOptions{Since: startDate}
+	filterLabel := "All sessions"
+	if statsExperiment != "" {
+		exp, err := getExperimentByName(ctx, app.ExperimentRepo, statsExperiment)
+		if err != nil {
+			return err
+		}
+		opts.ExperimentID = &exp.ID
+		filterLabel = fmt.Sprintf("Experiment: %s", statsExperiment)
+	} else if statsProject != "" {
+		opts.ProjectID = &statsProject
+		filterLabel = fmt.Sprintf("Project: %s", truncate(statsProject, 16))
+	}
+
+	touches, err := app.FileRepo.ListTouches(ctx, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Printf("  Languages (%s)\n", filterLabel)
+	fmt.Printf("  =========\n")
+	fmt.Println()
+	if len(touches) == 0 {
+		fmt.Println("  No file operations recorded")
+		return nil
+	}
+
+	breakdown := domain.AggregateLanguages(touches)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  LANGUAGE\tFILES\tOPS\tSHARE\tREADS\tEDITS\tWRITES\tTEST OPS\tSESSIONS\tSESSION COST")
+	_, _ = fmt.Fprintln(w, "  --------\t-----\t---\t-----\t-----\t-----\t------\t--------\t--------\t------------")
+	for _, s := range breakdown.Languages {
+		_, _ = fmt.Fprintf(w, "  %s\t%d\t%d\t%.1f%%\t%d\t%d\t%d\t%.0f%%\t%d\t$%.2f\n",
+			s.Language, s.Files, s.Operations(), breakdown.Share(s)*100, s.Reads, s.Edits, s.Writes,
+			s.KindShare(domain.FileKindTest)*100, s.Sessions, s.SessionCostUsd)
+	}
+	_ = w.Flush()
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  KIND\tFILES\tOPS\tSHARE\tSESSION COST")
+	_, _ = fmt.Fprintln(w, "  ----\t-----\t---\t-----\t------------")
+	for _, k := range breakdown.Kinds {
+		share := float64(k.Operations) / float64(breakdown.Operations)
+		_, _ = fmt.Fprintf(w, "  %s\t%d\t%d\t%.1f%%\t$%.2f\n", k.Kind, k.Files, k.Operations, share*100, k.SessionCostUsd)
+	}
+	_ = w.Flush()
+
+	if statsExperiment != "" {
+		return nil
+	}
+	byExperiment := domain.AggregateLanguagesByExperiment(touches)
+	if len(byExperiment) < 2 {
+		return nil
+	}
+	experimentNames := make(map[string]string)
+	if experiments, err := app.ExperimentRepo.List(ctx); err == nil {
+		for _, e := range experiments {
+			experimentNames[e.ID] = e.Name
+		}
+	}
+	fmt.Println()
+	fmt.Println("  By experiment:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  EXPERIMENT\tOPS\tSESSION COST\tLANGUAGES")
+	_, _ = fmt.Fprintln(w, "  ----------\t---\t------------\t---------")
+	for _, e := range byExperiment {
+		name := experimentNames[e.ExperimentID]
+		if e.ExperimentID == "" {
+			name = "(none)"
+		} else if name == "" {
+			name = truncate(e.ExperimentID, 16)
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t%d\t$%.2f\t%s\n", truncate(name, 30), e.Operations, e.SessionCostUsd, e.MixLabel(4))
+	}
+	_ = w.Flush()
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
+)
+
+// statsServerToolLimit bounds the tools grouped by server, high enough to
+// cover every tool in practice.
+const statsServerToolLimit = 10000
+
+// runStatsByServer prints the tool invocations and errors of the sessions
+// since startDate by the MCP server that provides each tool.
+func runStatsByServer(ctx context.Context, startDate string) error {
+	if statsProject != "" {
+		return fmt.Errorf("--by server does not filter by project (use mclaude mcp --project)")
+	}
+
+	var tools []domain.ToolUsageStats
+	var err error
+	filterLabel := "All sessions"
+	if statsExperiment != "" {
+		exp, expErr := getExperimentByName(ctx, app.ExperimentRepo, statsExperiment)
+		if expErr != nil {
+			return expErr
+		}
+		// Experiment tool usage covers the whole experiment, not the period
+		tools, err = app.StatsRepo.GetTopToolsByExperiment(ctx, exp.ID, statsServerToolLimit)
+		filterLabel = fmt.Sprintf("Experiment: %s", statsExperiment)
+	} else {
+		tools, err = app.StatsRepo.GetTopTools(ctx, startDate, statsServerToolLimit)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("  Tool Servers (%s)\n", filterLabel)
+	fmt.Printf("  ============\n")
+	fmt.Println()
+	if len(tools) == 0 {
+		fmt.Println("  No tool usage recorded")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  SERVER\tTOOLS\tCALLS\tERRORS\tERROR RATE")
+	_, _ = fmt.Fprintln(w, "  ------\t-----\t-----\t------\t----------")
+	for _, s := range domain.GroupToolUsageByServer(tools) {
+		rate := 0.0
+		if s.TotalInvocations > 0 {
+			rate = float64(s.TotalErrors) / float64(s.TotalInvocations)
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t%d\t%s\t%d\t%.1f%%\n",
+			truncate(s.Server, 30), s.Tools, util.FormatNumber(s.TotalInvocations), s.TotalErrors, rate*100)
+	}
+	_ = w.Flush()
+	fmt.Println()
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,7 +20,9 @@ import (
 func testDB(t *testing.T) (*sql.DB, func()) {
 	t.Helper()
 
-	db, err := sql.Open("libsql", "file::memory:?cache=shared")
+	// Name the database after the test so a connection still closing from a
+	// previous test cannot leak its data into this one
+	db, err := sql.Open("libsql", fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_")))
 	if err != nil {
 		t.Fatalf("Failed to open in-memory database: %v", err)
 	}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/value"
+)
+
+var valueCmd = &cobra.Command{
+	Use:   "value",
+	Short: "Compare the subscription price with API pricing",
+	Long: `Compare what the recorded usage would have cost at API list prices with the
+monthly plan price, per month, model and project.
+
+A month's value is its API-equivalent cost per dollar paid for the plan: above
+1x the subscription is cheaper than pay-as-you-go. The break-even point is the
+API spend at which the plan pays for itself. Plan prices are set with
+'mclaude plan price set'. Months are in UTC.
+
+Examples:
+  mclaude value
+  mclaude value --months 12`,
+	RunE: runValue,
+}
+
+// Flags
+var (
+	valueMonths int
+	valueTop    int
+)
+
+func init() {
+	rootCmd.AddCommand(valueCmd)
+	valueCmd.Flags().IntVarP(&valueMonths, "months", "m", 6, "Number of months to compare, including the current one")
+	valueCmd.Flags().IntVar(&valueTop, "top", 10, "Number of models and projects to list")
+}
+
+func runValue(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	report, err := value.NewAnalyzer(app.StatsRepo, app.PlanPriceRepo, app.ProjectRepo).Report(ctx, time.Now(), valueMonths)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("  Subscription Value\n")
+	fmt.Printf("  ------------------\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  MONTH\tPLAN\tPRICE\tAPI COST\tVALUE\tSAVED\tBREAK-EVEN")
+	_, _ = fmt.Fprintln(w, "  -----\t----\t-----\t--------\t-----\t-----\t----------")
+	for _, m := range report.Months {
+		month := m.Month.Format("2006-01")
+		if m.Partial {
+			month += "*"
+		}
+		if m.Price == nil {
+			_, _ = fmt.Fprintf(w, "  %s\t-\t-\t$%.2f\t-\t-\t-\n", month, m.APICostUsd)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t$%.2f\t$%.2f\t%.2fx\t%s\t%s\n",
+			month,
+			m.Price.PlanType,
+			m.Price.MonthlyUsd,
+			m.APICostUsd,
+			m.ValueRatio(),
+			formatSignedUsd(m.SavingsUsd()),
+			breakEvenLabel(m),
+		)
+	}
+	if report.HasPrices() {
+		_, _ = fmt.Fprintf(w, "  Total\t\t$%.2f\t$%.2f\t%.2fx\t%s\n",
+			report.PriceUsd,
+			report.APICostUsd,
+			report.ValueRatio(),
+			formatSignedUsd(report.SavingsUsd()),
+		)
+	}
+	_ = w.Flush()
+	fmt.Println()
+
+	current := report.Current()
+	if current.Price == nil {
+		fmt.Println("  No plan price for this month.")
+		fmt.Println("  Use 'mclaude plan price set' to compare the subscription with API pricing.")
+		fmt.Println()
+	} else {
+		fmt.Printf("  Break-even:        $%.2f/month of API usage ($%.2f/day)\n", current.PriceUsd(), current.BreakEvenDailyUsd())
+		if current.Partial {
+			fmt.Printf("  This month:        $%.2f so far (%.0f%%), $%.2f at the current pace (%.2fx)\n",
+				current.APICostUsd,
+				current.ValueRatio()*100,
+				current.ProjectedAPICostUsd,
+				current.ProjectedAPICostUsd/current.PriceUsd(),
+			)
+		}
+		fmt.Println()
+	}
+
+	printCostShares("MODEL", report.ByModel)
+	printCostShares("PROJECT", report.ByProject)
+	fmt.Println("  * month in progress")
+	return nil
+}
+
+func printCostShares(label string, shares []value.CostShare) {
+	if len(shares) == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "  %s\tSESSIONS\tAPI COST\tSHARE\n", label)
+	_, _ = fmt.Fprintf(w, "  %s\t--------\t--------\t-----\n", repeatChar('-', len(label)))
+	for i, s := range shares {
+		if i >= valueTop {
+			break
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t%d\t$%.2f\t%.0f%%\n", truncate(s.Name, 40), s.Sessions, s.APICostUsd, s.Fraction*100)
+	}
+	_ = w.Flush()
+	fmt.Println()
+}
+
+func breakEvenLabel(m domain.MonthValue) string {
+	if m.BreakEvenAt != nil {
+		return "reached " + m.BreakEvenAt.Format("Jan 2")
+	}
+	if m.Partial {
+		return "not yet"
+	}
+	return "not reached"
+}
+
+func formatSignedUsd(v float64) string {
+	if v < 0 {
+		return fmt.Sprintf("-$%.2f", -v)
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+var workflowsCmd = &cobra.Command{
+	Use:   "workflows",
+	Short: "Compare tool call patterns between experiments",
+	Long: `Mine the patterns of consecutive tool calls sessions make, such as
+"Grep → Read → Edit → Bash(test)", and compare how often each occurs per
+session between experiments. Bash calls are labeled with the category of
+their command, and repeated calls of the same tool count once.
+
+Loops are runs of two or more iterations of editing files and then running
+a Bash command that fails, without a passing run in between.
+
+Examples:
+  mclaude workflows                           # Every experiment, last 30 days
+  mclaude workflows -e baseline -e terse      # Two experiments
+  mclaude workflows --length 4 --last 20      # Longer patterns`,
+	RunE: runWorkflows,
+}
+
+// Flags
+var (
+	workflowsExperiments []string
+	workflowsProject     string
+	workflowsDays        int
+	workflowsLength      int
+	workflowsLast        int
+)
+
+func init() {
+	rootCmd.AddCommand(workflowsCmd)
+	workflowsCmd.Flags().StringSliceVarP(&workflowsExperiments, "experiment", "e", nil, "Experiments to compare (repeatable, default all)")
+	workflowsCmd.Flags().StringVar(&workflowsProject, "project", "", "Filter by project ID")
+	workflowsCmd.Flags().IntVarP(&workflowsDays, "days", "d", 30, "Number of days of sessions to analyze")
+	workflowsCmd.Flags().IntVar(&workflowsLength, "length", 3, "Number of consecutive tool calls in a pattern")
+	workflowsCmd.Flags().IntVarP(&workflowsLast, "last", "n", 10, "Number of top patterns of each experiment to compare")
+}
+
+// workflowGroup is the sessions of an experiment, or of none.
+type workflowGroup struct {
+	name      string
+	sequences []domain.ToolSequence
+}
+
+func runWorkflows(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if workflowsLength < 1 {
+		return fmt.Errorf("invalid length %d: must be at least 1", workflowsLength)
+	}
+
+	opts := ports.ListToolCallsOptions{
+		Since: time.Now().UTC().AddDate(0, 0, -workflowsDays).Format(time.RFC3339),
+	}
+	if workflowsProject != "" {
+		opts.ProjectID = &workflowsProject
+	}
+	groups, err := workflowGroups(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		fmt.Printf("No tool calls in the last %d days\n", workflowsDays)
+		return nil
+	}
+
+	summaries := make([]domain.WorkflowSummary, len(groups))
+	for i, g := range groups {
+		summaries[i] = domain.SummarizeWorkflows(g.sequences, workflowsLength)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "EXPERIMENT\tSESSIONS\tCALLS\tCALLS/SESSION\tLOOPS\tSESSIONS WITH LOOPS\tAVG ITERATIONS")
+	_, _ = fmt.Fprintln(w, "----------\t--------\t-----\t-------------\t-----\t-------------------\t--------------")
+	for i, g := range groups {
+		s := summaries[i]
+		iterations := 0.0
+		if s.Loops > 0 {
+			iterations = float64(s.LoopIterations) / float64(s.Loops)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\t%d\t%d (%.0f%%)\t%.1f\n",
+			truncate(g.name, 30), s.Sessions, s.Calls, float64(s.Calls)/float64(s.Sessions),
+			s.Loops, s.LoopSessions, s.LoopSessionRate()*100, iterations)
+	}
+	_ = w.Flush()
+
+	fmt.Println()
+	fmt.Printf("Patterns of %d calls, occurrences per session:\n", workflowsLength)
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{"PATTERN"}
+	rule := []string{"-------"}
+	for _, g := range groups {
+		name := strings.ToUpper(truncate(g.name, 20))
+		header = append(header, name)
+		rule = append(rule, strings.Repeat("-", len(name)))
+	}
+	_, _ = fmt.Fprintln(w, strings.Join(header, "\t"))
+	_, _ = fmt.Fprintln(w, strings.Join(rule, "\t"))
+	for _, row := range domain.CompareWorkflows(summaries, workflowsLast) {
+		cells := []string{row.Pattern}
+		for _, rate := range row.Rates {
+			cells = append(cells, fmt.Sprintf("%.2f", rate))
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	_ = w.Flush()
+	return nil
+}
+
+// workflowGroups returns the tool call sequences of the selected
+// experiments, or of every experiment and then the sessions outside any.
+// Sessions excluded from experiment stats are left out.
+func workflowGroups(ctx context.Context, opts ports.ListToolCallsOptions) ([]workflowGroup, error) {
+	if len(workflowsExperiments) > 0 {
+		var groups []workflowGroup
+		for _, name := range workflowsExperiments {
+			exp, err := getExperimentByName(ctx, app.ExperimentRepo, name)
+			if err != nil {
+				return nil, err
+			}
+			opts.ExperimentID = &exp.ID
+			sequences, err := app.ToolCallRepo.ListSequences(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			if len(sequences) > 0 {
+				groups = append(groups, workflowGroup{name: exp.Name, sequences: sequences})
+			}
+		}
+		return groups, nil
+	}
+
+	sequences, err := app.ToolCallRepo.ListSequences(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	experimentNames := make(map[string]string)
+	if experiments, err := app.ExperimentRepo.List(ctx); err == nil {
+		for _, e := range experiments {
+			experimentNames[e.ID] = e.Name
+		}
+	}
+	byExperiment := make(map[string]int)
+	var groups []workflowGroup
+	var none []domain.ToolSequence
+	for _, seq := range sequences {
+		if seq.Excluded {
+			continue
+		}
+		if seq.ExperimentID == nil {
+			none = append(none, seq)
+			continue
+		}
+		i, ok := byExperiment[*seq.ExperimentID]
+		if !ok {
+			name := experimentNames[*seq.ExperimentID]
+			if name == "" {
+				name = truncate(*seq.ExperimentID, 16)
+			}
+			i = len(groups)
+			byExperiment[*seq.ExperimentID] = i
+			groups = append(groups, workflowGroup{name: name})
+		}
+		groups[i].sequences = append(groups[i].sequences, seq)
+	}
+	if len(none) > 0 {
+		groups = append(groups, workflowGroup{name: "(none)", sequences: none})
+	}
+	return groups, nil
+}
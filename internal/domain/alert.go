@@ -0,0 +1,398 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AlertScope is what an alert rule is evaluated against. Period scopes use the
+// global budget of the same period as their budget variable.
+type AlertScope string
+
+const (
+	AlertScopeSession    AlertScope = "session"
+	AlertScopeDaily      AlertScope = "daily"
+	AlertScopeWeekly     AlertScope = "weekly"
+	AlertScopeMonthly    AlertScope = "monthly"
+	AlertScopeExperiment AlertScope = "experiment"
+)
+
+// alertVariables lists the variables available in each scope. Experiment rules
+// may also reference the experiment's numeric variables, so they are not
+// checked when parsing.
+var alertVariables = map[AlertScope][]string{
+	AlertScopeSession:    {"cost", "tokens", "turns", "error_count", "tool_calls", "duration_minutes", "anomaly_score"},
+	AlertScopeDaily:      {"cost", "tokens", "sessions", "error_count", "budget"},
+	AlertScopeWeekly:     {"cost", "tokens", "sessions", "error_count", "budget"},
+	AlertScopeMonthly:    {"cost", "tokens", "sessions", "error_count", "budget"},
+	AlertScopeExperiment: {"cost", "tokens", "sessions", "error_count"},
+}
+
+// AlertVariables returns the variables available in a scope.
+func AlertVariables(scope AlertScope) []string {
+	return alertVariables[scope]
+}
+
+// Alert delivery channel types.
+const (
+	AlertChannelWebhook = "webhook"
+	AlertChannelCommand = "command"
+	AlertChannelStderr  = "stderr"
+	AlertChannelDesktop = "desktop"
+)
+
+// AlertChannel is where a fired alert is delivered. URL is set for webhooks
+// and Command for shell commands.
+type AlertChannel struct {
+	Type    string
+	URL     string
+	Command string
+}
+
+// AlertRule fires its channels when its condition holds. A rule fires at most
+// once per key: the session, the period or the experiment it matched.
+type AlertRule struct {
+	Name      string
+	Condition *AlertCondition
+	Channels  []AlertChannel
+}
+
+// AlertFiring records an alert that fired. Key identifies what the rule fired
+// for: a session ID, an experiment ID, or a period such as "daily 2026-03-04".
+type AlertFiring struct {
+	Rule      string
+	Scope     AlertScope
+	Key       string
+	Value     float64
+	Threshold float64
+	Message   string
+	FiredAt   time.Time
+}
+
+// ErrAlertVariableUndefined is returned when a condition references a variable
+// that has no value, such as the budget of a period without one.
+var ErrAlertVariableUndefined = errors.New("undefined alert variable")
+
+// AlertCondition compares two arithmetic expressions over the variables of a
+// single scope, e.g. "daily.cost > budget * 0.8". Unqualified names refer to
+// the same scope as the qualified ones.
+type AlertCondition struct {
+	Scope  AlertScope
+	Op     string
+	Left   string
+	Right  string
+	source string
+	left   alertExpr
+	right  alertExpr
+}
+
+func (c *AlertCondition) String() string {
+	return c.source
+}
+
+// Eval evaluates the condition. It also returns the values of both sides.
+func (c *AlertCondition) Eval(vars map[string]float64) (bool, float64, float64, error) {
+	l, err := c.left.eval(vars)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	r, err := c.right.eval(vars)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	var ok bool
+	switch c.Op {
+	case ">":
+		ok = l > r
+	case ">=":
+		ok = l >= r
+	case "<":
+		ok = l < r
+	case "<=":
+		ok = l <= r
+	case "==":
+		ok = l == r
+	case "!=":
+		ok = l != r
+	}
+	return ok, l, r, nil
+}
+
+// ParseAlertCondition parses a condition such as "session.cost > 5".
+func ParseAlertCondition(s string) (*AlertCondition, error) {
+	tokens, err := tokenizeAlert(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &alertParser{tokens: tokens}
+
+	cond := &AlertCondition{source: strings.TrimSpace(s)}
+	leftStart := p.pos
+	if cond.left, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	cond.Left = joinAlertTokens(tokens[leftStart:p.pos])
+	op := p.next()
+	if op.kind != alertTokenCompare {
+		return nil, fmt.Errorf("expected a comparison (>, >=, <, <=, ==, !=) in %q", s)
+	}
+	cond.Op = op.text
+	rightStart := p.pos
+	if cond.right, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	cond.Right = joinAlertTokens(tokens[rightStart:p.pos])
+	if p.pos != len(tokens) {
+		return nil, fmt.Errorf("unexpected %q in %q", p.tokens[p.pos].text, s)
+	}
+
+	if err := cond.resolveScope(p.idents); err != nil {
+		return nil, fmt.Errorf("%w in %q", err, s)
+	}
+	return cond, nil
+}
+
+// resolveScope checks that every qualified name uses the same scope, strips
+// the scope from the names and validates them.
+func (c *AlertCondition) resolveScope(idents []*alertVar) error {
+	for _, v := range idents {
+		scope, name, ok := strings.Cut(v.name, ".")
+		if !ok {
+			continue
+		}
+		if _, known := alertVariables[AlertScope(scope)]; !known {
+			return fmt.Errorf("unknown scope %q", scope)
+		}
+		if c.Scope != "" && c.Scope != AlertScope(scope) {
+			return fmt.Errorf("cannot mix %s and %s", c.Scope, scope)
+		}
+		c.Scope = AlertScope(scope)
+		v.name = name
+	}
+	if c.Scope == "" {
+		return errors.New("condition must reference a scope, e.g. session.cost")
+	}
+	if c.Scope == AlertScopeExperiment {
+		return nil
+	}
+	for _, v := range idents {
+		if !slices.Contains(alertVariables[c.Scope], v.name) {
+			return fmt.Errorf("unknown %s variable %q (available: %s)", c.Scope, v.name, strings.Join(alertVariables[c.Scope], ", "))
+		}
+	}
+	return nil
+}
+
+type alertExpr interface {
+	eval(vars map[string]float64) (float64, error)
+}
+
+type alertNumber float64
+
+func (n alertNumber) eval(map[string]float64) (float64, error) {
+	return float64(n), nil
+}
+
+type alertVar struct {
+	name string
+}
+
+func (v *alertVar) eval(vars map[string]float64) (float64, error) {
+	value, ok := vars[v.name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrAlertVariableUndefined, v.name)
+	}
+	return value, nil
+}
+
+type alertBinary struct {
+	op          byte
+	left, right alertExpr
+}
+
+func (b *alertBinary) eval(vars map[string]float64) (float64, error) {
+	l, err := b.left.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	r, err := b.right.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	switch b.op {
+	case '+':
+		return l + r, nil
+	case '-':
+		return l - r, nil
+	case '*':
+		return l * r, nil
+	default:
+		if r == 0 {
+			return 0, nil
+		}
+		return l / r, nil
+	}
+}
+
+type alertTokenKind int
+
+const (
+	alertTokenNumber alertTokenKind = iota
+	alertTokenIdent
+	alertTokenOp
+	alertTokenCompare
+	alertTokenEOF
+)
+
+type alertToken struct {
+	kind alertTokenKind
+	text string
+}
+
+func tokenizeAlert(s string) ([]alertToken, error) {
+	var tokens []alertToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c >= '0' && c <= '9' || c == '.':
+			j := i
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, alertToken{alertTokenNumber, s[i:j]})
+			i = j
+		case isAlertIdentByte(c) && !(c >= '0' && c <= '9'):
+			j := i
+			for j < len(s) && (isAlertIdentByte(s[j]) || s[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, alertToken{alertTokenIdent, s[i:j]})
+			i = j
+		case strings.IndexByte("+-*/()", c) >= 0:
+			tokens = append(tokens, alertToken{alertTokenOp, string(c)})
+			i++
+		case strings.IndexByte("<>=!", c) >= 0:
+			j := i + 1
+			if j < len(s) && s[j] == '=' {
+				j++
+			}
+			op := s[i:j]
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("invalid operator %q in %q", op, s)
+			}
+			tokens = append(tokens, alertToken{alertTokenCompare, op})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", c, s)
+		}
+	}
+	return tokens, nil
+}
+
+func isAlertIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func joinAlertTokens(tokens []alertToken) string {
+	parts := make([]string, len(tokens))
+	for i, t := range tokens {
+		parts[i] = t.text
+	}
+	return strings.NewReplacer("( ", "(", " )", ")").Replace(strings.Join(parts, " "))
+}
+
+type alertParser struct {
+	tokens []alertToken
+	pos    int
+	idents []*alertVar
+}
+
+func (p *alertParser) peek() alertToken {
+	if p.pos >= len(p.tokens) {
+		return alertToken{kind: alertTokenEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *alertParser) next() alertToken {
+	t := p.peek()
+	if t.kind != alertTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// parseExpr parses sums and differences of terms.
+func (p *alertParser) parseExpr() (alertExpr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == alertTokenOp && (t.text == "+" || t.text == "-"); t = p.peek() {
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &alertBinary{op: t.text[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseTerm parses products and quotients of factors.
+func (p *alertParser) parseTerm() (alertExpr, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == alertTokenOp && (t.text == "*" || t.text == "/"); t = p.peek() {
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &alertBinary{op: t.text[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *alertParser) parseFactor() (alertExpr, error) {
+	t := p.next()
+	switch {
+	case t.kind == alertTokenNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", t.text)
+		}
+		return alertNumber(n), nil
+	case t.kind == alertTokenIdent:
+		v := &alertVar{name: t.text}
+		p.idents = append(p.idents, v)
+		return v, nil
+	case t.kind == alertTokenOp && t.text == "-":
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &alertBinary{op: '-', left: alertNumber(0), right: operand}, nil
+	case t.kind == alertTokenOp && t.text == "(":
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.text != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		return inner, nil
+	case t.kind == alertTokenEOF:
+		return nil, errors.New("unexpected end of condition")
+	default:
+		return nil, fmt.Errorf("unexpected %q", t.text)
+	}
+}
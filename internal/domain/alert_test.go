@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAlertCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		vars      map[string]float64
+		wantScope AlertScope
+		wantFire  bool
+		wantRight float64
+	}{
+		{"session.cost > 5", map[string]float64{"cost": 6}, AlertScopeSession, true, 5},
+		{"session.cost > 5", map[string]float64{"cost": 5}, AlertScopeSession, false, 5},
+		{"daily.cost > budget*0.8", map[string]float64{"cost": 8.5, "budget": 10}, AlertScopeDaily, true, 8},
+		{"session.error_count >= 20", map[string]float64{"error_count": 20}, AlertScopeSession, true, 20},
+		{"experiment.sessions == target", map[string]float64{"sessions": 30, "target": 30}, AlertScopeExperiment, true, 30},
+		{"weekly.cost - 2 * (weekly.budget / 4) != 0", map[string]float64{"cost": 5, "budget": 10}, AlertScopeWeekly, false, 0},
+		{"monthly.cost / monthly.sessions < -1", map[string]float64{"cost": 1, "sessions": 0}, AlertScopeMonthly, false, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			cond, err := ParseAlertCondition(tt.condition)
+			if err != nil {
+				t.Fatalf("ParseAlertCondition() error = %v", err)
+			}
+			assertEqual(t, "scope", tt.wantScope, cond.Scope)
+			fire, _, right, err := cond.Eval(tt.vars)
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+			assertEqual(t, "fire", tt.wantFire, fire)
+			if !floatEquals(right, tt.wantRight) {
+				t.Errorf("right = %v, want %v", right, tt.wantRight)
+			}
+		})
+	}
+}
+
+func TestParseAlertCondition_Errors(t *testing.T) {
+	for _, condition := range []string{
+		"",
+		"session.cost",
+		"session.cost = 5",
+		"cost > 5",
+		"session.cost > daily.cost",
+		"hourly.cost > 5",
+		"session.sessions > 5",
+		"session.cost > (5",
+		"session.cost > 5 5",
+		"session.cost > $5",
+	} {
+		if _, err := ParseAlertCondition(condition); err == nil {
+			t.Errorf("ParseAlertCondition(%q) expected error", condition)
+		}
+	}
+}
+
+func TestAlertCondition_UndefinedVariable(t *testing.T) {
+	cond, err := ParseAlertCondition("daily.cost > budget * 0.8")
+	if err != nil {
+		t.Fatalf("ParseAlertCondition() error = %v", err)
+	}
+	assertEqual(t, "left", "daily.cost", cond.Left)
+	assertEqual(t, "right", "budget * 0.8", cond.Right)
+
+	_, _, _, err = cond.Eval(map[string]float64{"cost": 3})
+	if !errors.Is(err, ErrAlertVariableUndefined) {
+		t.Errorf("Eval() error = %v, want ErrAlertVariableUndefined", err)
+	}
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A session is scored against the AnomalyBaselineSize most recent sessions of
+// its project, and only once at least AnomalyMinBaseline of them exist.
+// Metrics whose robust z-score reaches AnomalyThreshold are flagged.
+const (
+	AnomalyBaselineSize = 50
+	AnomalyMinBaseline  = 10
+	AnomalyThreshold    = 3.5
+)
+
+// madScale converts a median absolute deviation into a standard deviation
+// estimate for normally distributed values.
+const madScale = 1.4826
+
+// AnomalyMetric names a per-session value checked for anomalies. MinSpread is
+// the smallest spread assumed for the baseline, so a project whose sessions
+// never vary (e.g. no errors at all) does not flag every small change.
+type AnomalyMetric struct {
+	Name      string
+	MinSpread float64
+	Value     func(SessionSample) float64
+}
+
+// AnomalyMetrics lists the metrics scored for every session.
+var AnomalyMetrics = []AnomalyMetric{
+	{"cost", 0.05, func(s SessionSample) float64 { return s.CostUsd }},
+	{"tokens/turn", 1000, func(s SessionSample) float64 {
+		if s.Turns == 0 {
+			return 0
+		}
+		return float64(s.Tokens) / float64(s.Turns)
+	}},
+	{"error rate", 0.05, func(s SessionSample) float64 {
+		if s.ToolCalls == 0 {
+			return 0
+		}
+		return float64(s.Errors) / float64(s.ToolCalls)
+	}},
+	{"tool calls", 5, func(s SessionSample) float64 { return float64(s.ToolCalls) }},
+}
+
+// AnomalyReason describes one metric that was unusually high for a session.
+type AnomalyReason struct {
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	Median float64 `json:"median"`
+	Score  float64 `json:"score"`
+}
+
+func (r AnomalyReason) String() string {
+	return fmt.Sprintf("%s %s vs median %s (z=%.1f)",
+		r.Metric, formatAnomalyValue(r.Metric, r.Value), formatAnomalyValue(r.Metric, r.Median), r.Score)
+}
+
+func formatAnomalyValue(metric string, v float64) string {
+	switch metric {
+	case "cost":
+		return fmt.Sprintf("$%.2f", v)
+	case "error rate":
+		return fmt.Sprintf("%.0f%%", v*100)
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
+
+// SessionAnomaly records why a session was flagged. Score is the highest
+// robust z-score among the flagged metrics.
+type SessionAnomaly struct {
+	SessionID  string
+	Score      float64
+	Reasons    []AnomalyReason
+	DetectedAt time.Time
+}
+
+// Summary joins the reasons into a single line.
+func (a *SessionAnomaly) Summary() string {
+	parts := make([]string, len(a.Reasons))
+	for i, r := range a.Reasons {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// DetectAnomaly scores a session against its project baseline using the
+// robust z-score (x - median) / (1.4826 * MAD). Only unusually high values are
+// flagged. It returns nil when the baseline is too small or nothing stands out.
+func DetectAnomaly(session SessionSample, baseline []SessionSample) *SessionAnomaly {
+	if len(baseline) < AnomalyMinBaseline {
+		return nil
+	}
+
+	var reasons []AnomalyReason
+	var score float64
+	for _, m := range AnomalyMetrics {
+		values := make([]float64, len(baseline))
+		for i, s := range baseline {
+			values[i] = m.Value(s)
+		}
+		med := median(values)
+		deviations := make([]float64, len(values))
+		for i, v := range values {
+			deviations[i] = math.Abs(v - med)
+		}
+		spread := math.Max(madScale*median(deviations), m.MinSpread)
+
+		value := m.Value(session)
+		z := (value - med) / spread
+		if z < AnomalyThreshold {
+			continue
+		}
+		reasons = append(reasons, AnomalyReason{Metric: m.Name, Value: value, Median: med, Score: z})
+		score = math.Max(score, z)
+	}
+
+	if len(reasons) == 0 {
+		return nil
+	}
+	return &SessionAnomaly{SessionID: session.SessionID, Score: score, Reasons: reasons}
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,91 @@
+package domain
+
+import "testing"
+
+func anomalyBaseline(n int) []SessionSample {
+	baseline := make([]SessionSample, n)
+	for i := range baseline {
+		baseline[i] = SessionSample{
+			Turns:     10,
+			Tokens:    int64(20000 + i*100),
+			CostUsd:   0.40 + float64(i%5)*0.05,
+			ToolCalls: int64(20 + i%5),
+		}
+	}
+	return baseline
+}
+
+func TestDetectAnomaly(t *testing.T) {
+	tests := []struct {
+		name        string
+		session     SessionSample
+		baseline    []SessionSample
+		wantMetrics []string
+	}{
+		{
+			name:     "typical session",
+			session:  SessionSample{Turns: 10, Tokens: 21000, CostUsd: 0.50, ToolCalls: 22},
+			baseline: anomalyBaseline(20),
+		},
+		{
+			name:        "runaway cost",
+			session:     SessionSample{Turns: 10, Tokens: 21000, CostUsd: 6.00, ToolCalls: 22},
+			baseline:    anomalyBaseline(20),
+			wantMetrics: []string{"cost"},
+		},
+		{
+			name:        "errors against an error-free baseline",
+			session:     SessionSample{Turns: 10, Tokens: 21000, CostUsd: 0.50, ToolCalls: 22, Errors: 11},
+			baseline:    anomalyBaseline(20),
+			wantMetrics: []string{"error rate"},
+		},
+		{
+			name:        "tool call loop",
+			session:     SessionSample{Turns: 10, Tokens: 21000, CostUsd: 0.50, ToolCalls: 200},
+			baseline:    anomalyBaseline(20),
+			wantMetrics: []string{"tool calls"},
+		},
+		{
+			name:     "unusually cheap session is not flagged",
+			session:  SessionSample{Turns: 10, Tokens: 21000, CostUsd: 0.01, ToolCalls: 22},
+			baseline: anomalyBaseline(20),
+		},
+		{
+			name:     "baseline too small",
+			session:  SessionSample{Turns: 10, Tokens: 21000, CostUsd: 6.00, ToolCalls: 22},
+			baseline: anomalyBaseline(AnomalyMinBaseline - 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectAnomaly(tt.session, tt.baseline)
+			if len(tt.wantMetrics) == 0 {
+				if got != nil {
+					t.Fatalf("DetectAnomaly() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("DetectAnomaly() = nil, want anomaly")
+			}
+			if len(got.Reasons) != len(tt.wantMetrics) {
+				t.Fatalf("reasons = %+v, want metrics %v", got.Reasons, tt.wantMetrics)
+			}
+			for i, r := range got.Reasons {
+				assertEqual(t, "metric", tt.wantMetrics[i], r.Metric)
+				if r.Score < AnomalyThreshold || r.Score > got.Score {
+					t.Errorf("reason score = %v, anomaly score = %v", r.Score, got.Score)
+				}
+			}
+		})
+	}
+}
+
+func TestAnomalyReasonString(t *testing.T) {
+	a := &SessionAnomaly{Reasons: []AnomalyReason{
+		{Metric: "cost", Value: 4.2, Median: 0.35, Score: 12.34},
+		{Metric: "error rate", Value: 0.5, Median: 0, Score: 10},
+	}}
+	assertEqual(t, "summary", "cost $4.20 vs median $0.35 (z=12.3); error rate 50% vs median 0% (z=10.0)", a.Summary())
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// BillingUsage is the usage and cost Anthropic billed for a model on a UTC
+// day, imported from a console usage or cost export. Workspace and APIKey are
+// empty when the export has no such breakdown; CostUsd is nil when it has no
+// cost.
+type BillingUsage struct {
+	Date       time.Time
+	Model      string
+	Workspace  string
+	APIKey     string
+	Tokens     TokenUsage
+	CostUsd    *float64
+	ImportedAt time.Time
+}
+
+// DailyModelUsage is the recorded usage and estimated cost of a model on a
+// UTC day, counting main threads and sub-agents by the model they used.
+type DailyModelUsage struct {
+	Date    time.Time
+	Model   string
+	Tokens  TokenUsage
+	CostUsd float64
+}
+
+// BillingDiscrepancy compares what was billed for a model with what was
+// estimated. HasCost is false when the billing export had no costs, leaving
+// only the tokens to compare.
+type BillingDiscrepancy struct {
+	Model           string
+	BilledTokens    int64
+	EstimatedTokens int64
+	BilledUsd       float64
+	EstimatedUsd    float64
+	HasCost         bool
+}
+
+// DeltaUsd returns the estimated cost minus the billed cost; negative when
+// the estimate is short.
+func (d BillingDiscrepancy) DeltaUsd() float64 {
+	return d.EstimatedUsd - d.BilledUsd
+}
+
+// DeltaPercent returns the estimate's error relative to the billed cost in
+// percent, or 0 without a billed cost.
+func (d BillingDiscrepancy) DeltaPercent() float64 {
+	if d.BilledUsd == 0 {
+		return 0
+	}
+	return d.DeltaUsd() / d.BilledUsd * 100
+}
+
+// TokenDeltaPercent returns the recorded tokens' error relative to the
+// billed tokens in percent, or 0 without billed tokens.
+func (d BillingDiscrepancy) TokenDeltaPercent() float64 {
+	if d.BilledTokens == 0 {
+		return 0
+	}
+	return float64(d.EstimatedTokens-d.BilledTokens) / float64(d.BilledTokens) * 100
+}
+
+// CostMatches reports whether the estimate is within tolerance (a fraction)
+// of the billed cost. Without costs only the tokens are compared.
+func (d BillingDiscrepancy) CostMatches(tolerance float64) bool {
+	if !d.HasCost {
+		return d.TokensMatch(tolerance)
+	}
+	return math.Abs(d.DeltaPercent()) <= tolerance*100 && (d.BilledUsd > 0 || d.EstimatedUsd == 0)
+}
+
+// TokensMatch reports whether the recorded tokens are within tolerance (a
+// fraction) of the billed tokens. Exports without token counts always match.
+func (d BillingDiscrepancy) TokensMatch(tolerance float64) bool {
+	if d.BilledTokens == 0 {
+		return true
+	}
+	return math.Abs(d.TokenDeltaPercent()) <= tolerance*100
+}
+
+// PriceCorrection returns the factor to scale a model's rates by so that the
+// estimate matches the billed cost. It is only meaningful when the tokens
+// match; without costs, tokens or an estimate it returns 0.
+func (d BillingDiscrepancy) PriceCorrection() float64 {
+	if !d.HasCost || d.EstimatedUsd <= 0 || d.BilledUsd <= 0 {
+		return 0
+	}
+	return d.BilledUsd / d.EstimatedUsd
+}
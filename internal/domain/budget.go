@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"time"
+)
+
+// BudgetPeriod is the window a budget's spend is measured over.
+type BudgetPeriod string
+
+const (
+	BudgetDaily   BudgetPeriod = "daily"
+	BudgetWeekly  BudgetPeriod = "weekly"
+	BudgetMonthly BudgetPeriod = "monthly"
+)
+
+// Budget scopes.
+const (
+	BudgetScopeGlobal     = "global"
+	BudgetScopeProject    = "project"
+	BudgetScopeExperiment = "experiment"
+)
+
+// BudgetPeriods lists the supported periods, shortest first.
+var BudgetPeriods = []BudgetPeriod{BudgetDaily, BudgetWeekly, BudgetMonthly}
+
+// IsValidBudgetPeriod reports whether p is a supported budget period.
+func IsValidBudgetPeriod(p string) bool {
+	switch BudgetPeriod(p) {
+	case BudgetDaily, BudgetWeekly, BudgetMonthly:
+		return true
+	}
+	return false
+}
+
+// Bounds returns the start and end of the period containing t, in t's
+// location. Weeks start on Monday.
+func (p BudgetPeriod) Bounds(t time.Time) (time.Time, time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch p {
+	case BudgetWeekly:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	case BudgetMonthly:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return day, day.AddDate(0, 0, 1)
+	}
+}
+
+// Budget caps spend over a period, either globally or for a single project or
+// experiment. Hard budgets block further prompts and tool calls once exceeded;
+// soft budgets only warn.
+type Budget struct {
+	ID           string
+	Period       BudgetPeriod
+	AmountUsd    float64
+	ProjectID    *string
+	ExperimentID *string
+	Hard         bool
+	CreatedAt    time.Time
+}
+
+// BudgetID returns the ID of the budget for a period and scope. There is at
+// most one budget per period and scope, so setting it again replaces it.
+func BudgetID(period BudgetPeriod, projectID, experimentID *string) string {
+	switch {
+	case projectID != nil:
+		return string(period) + ":" + BudgetScopeProject + ":" + *projectID
+	case experimentID != nil:
+		return string(period) + ":" + BudgetScopeExperiment + ":" + *experimentID
+	default:
+		return string(period)
+	}
+}
+
+// Scope returns the budget's scope: global, project or experiment.
+func (b *Budget) Scope() string {
+	switch {
+	case b.ProjectID != nil:
+		return BudgetScopeProject
+	case b.ExperimentID != nil:
+		return BudgetScopeExperiment
+	default:
+		return BudgetScopeGlobal
+	}
+}
+
+// AppliesTo reports whether the budget covers a session in projectID running
+// under experimentID. Either may be empty.
+func (b *Budget) AppliesTo(projectID, experimentID string) bool {
+	switch b.Scope() {
+	case BudgetScopeProject:
+		return *b.ProjectID == projectID
+	case BudgetScopeExperiment:
+		return experimentID != "" && *b.ExperimentID == experimentID
+	default:
+		return true
+	}
+}
+
+// BudgetStatus is a budget's spend within its current period.
+type BudgetStatus struct {
+	Budget   *Budget
+	Label    string
+	Start    time.Time
+	End      time.Time
+	SpentUsd float64
+}
+
+// RemainingUsd returns the amount left in the period, never below zero.
+func (s BudgetStatus) RemainingUsd() float64 {
+	return max(s.Budget.AmountUsd-s.SpentUsd, 0)
+}
+
+// UsedFraction returns spend as a fraction of the budget.
+func (s BudgetStatus) UsedFraction() float64 {
+	if s.Budget.AmountUsd <= 0 {
+		return 1
+	}
+	return s.SpentUsd / s.Budget.AmountUsd
+}
+
+// Exceeded reports whether spend has reached the budget.
+func (s BudgetStatus) Exceeded() bool {
+	return s.SpentUsd >= s.Budget.AmountUsd
+}
+
+// ElapsedFraction returns how much of the period has passed at now.
+func (s BudgetStatus) ElapsedFraction(now time.Time) float64 {
+	total := s.End.Sub(s.Start)
+	if total <= 0 {
+		return 1
+	}
+	return min(max(float64(now.Sub(s.Start))/float64(total), 0), 1)
+}
+
+// ProjectedUsd extrapolates the spend so far to the end of the period at a
+// constant burn rate.
+func (s BudgetStatus) ProjectedUsd(now time.Time) float64 {
+	elapsed := s.ElapsedFraction(now)
+	if elapsed <= 0 {
+		return s.SpentUsd
+	}
+	return s.SpentUsd / elapsed
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBudgetPeriod_Bounds(t *testing.T) {
+	at := scheduleTime("2026-03-05T15:30:00Z") // Thursday
+
+	tests := []struct {
+		period BudgetPeriod
+		start  string
+		end    string
+	}{
+		{BudgetDaily, "2026-03-05T00:00:00Z", "2026-03-06T00:00:00Z"},
+		{BudgetWeekly, "2026-03-02T00:00:00Z", "2026-03-09T00:00:00Z"},
+		{BudgetMonthly, "2026-03-01T00:00:00Z", "2026-04-01T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		start, end := tt.period.Bounds(at)
+		assertEqual(t, string(tt.period)+" start", tt.start, start.Format(time.RFC3339))
+		assertEqual(t, string(tt.period)+" end", tt.end, end.Format(time.RFC3339))
+	}
+
+	// A Sunday belongs to the week that started the previous Monday.
+	start, _ := BudgetWeekly.Bounds(scheduleTime("2026-03-08T23:00:00Z"))
+	assertEqual(t, "sunday week start", "2026-03-02T00:00:00Z", start.Format(time.RFC3339))
+}
+
+func TestBudget_AppliesTo(t *testing.T) {
+	project, experiment := "p1", "e1"
+	global := &Budget{Period: BudgetDaily}
+	byProject := &Budget{Period: BudgetDaily, ProjectID: &project}
+	byExperiment := &Budget{Period: BudgetDaily, ExperimentID: &experiment}
+
+	assertEqual(t, "global id", "daily", BudgetID(global.Period, nil, nil))
+	assertEqual(t, "project id", "daily:project:p1", BudgetID(byProject.Period, &project, nil))
+	assertEqual(t, "experiment id", "daily:experiment:e1", BudgetID(byExperiment.Period, nil, &experiment))
+
+	tests := []struct {
+		name       string
+		budget     *Budget
+		project    string
+		experiment string
+		expected   bool
+	}{
+		{"global", global, "p2", "", true},
+		{"same project", byProject, "p1", "", true},
+		{"other project", byProject, "p2", "e1", false},
+		{"same experiment", byExperiment, "p2", "e1", true},
+		{"no experiment", byExperiment, "p1", "", false},
+	}
+	for _, tt := range tests {
+		if got := tt.budget.AppliesTo(tt.project, tt.experiment); got != tt.expected {
+			t.Errorf("%s: AppliesTo = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestBudgetStatus(t *testing.T) {
+	start := scheduleTime("2026-03-05T00:00:00Z")
+	s := BudgetStatus{
+		Budget:   &Budget{Period: BudgetDaily, AmountUsd: 10},
+		Start:    start,
+		End:      start.Add(24 * time.Hour),
+		SpentUsd: 4,
+	}
+	now := start.Add(6 * time.Hour)
+
+	if !floatEquals(s.RemainingUsd(), 6) {
+		t.Errorf("RemainingUsd = %f, want 6", s.RemainingUsd())
+	}
+	if !floatEquals(s.UsedFraction(), 0.4) {
+		t.Errorf("UsedFraction = %f, want 0.4", s.UsedFraction())
+	}
+	if !floatEquals(s.ElapsedFraction(now), 0.25) {
+		t.Errorf("ElapsedFraction = %f, want 0.25", s.ElapsedFraction(now))
+	}
+	if !floatEquals(s.ProjectedUsd(now), 16) {
+		t.Errorf("ProjectedUsd = %f, want 16", s.ProjectedUsd(now))
+	}
+	if s.Exceeded() {
+		t.Error("expected budget not to be exceeded")
+	}
+
+	s.SpentUsd = 12
+	if !s.Exceeded() {
+		t.Error("expected budget to be exceeded")
+	}
+	if s.RemainingUsd() != 0 {
+		t.Errorf("RemainingUsd = %f, want 0", s.RemainingUsd())
+	}
+}
@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"path"
+	"strings"
+)
+
+// Categories of commands whose result says whether the work is in a good
+// state.
+const (
+	CommandCategoryTest  = "test"
+	CommandCategoryBuild = "build"
+	CommandCategoryLint  = "lint"
+)
+
+// CommandCategories lists the categories in display order.
+var CommandCategories = []string{CommandCategoryTest, CommandCategoryBuild, CommandCategoryLint}
+
+// programCategories maps programs and subcommands to their category. Keys
+// are the program alone or "program subcommand".
+var programCategories = map[string]string{
+	// Tests
+	"go test": CommandCategoryTest, "cargo test": CommandCategoryTest, "cargo nextest": CommandCategoryTest,
+	"pytest": CommandCategoryTest, "py.test": CommandCategoryTest, "tox": CommandCategoryTest, "nox": CommandCategoryTest,
+	"jest": CommandCategoryTest, "vitest": CommandCategoryTest, "mocha": CommandCategoryTest, "playwright test": CommandCategoryTest,
+	"rspec": CommandCategoryTest, "rake test": CommandCategoryTest, "mix test": CommandCategoryTest, "ctest": CommandCategoryTest,
+	"dotnet test": CommandCategoryTest, "mvn test": CommandCategoryTest, "mvn verify": CommandCategoryTest,
+	"gradle test": CommandCategoryTest, "gradlew test": CommandCategoryTest, "phpunit": CommandCategoryTest,
+	"swift test": CommandCategoryTest, "zig test": CommandCategoryTest, "deno test": CommandCategoryTest,
+	// Builds
+	"go build": CommandCategoryBuild, "go install": CommandCategoryBuild, "cargo build": CommandCategoryBuild, "cargo check": CommandCategoryBuild,
+	"tsc": CommandCategoryBuild, "vite build": CommandCategoryBuild, "next build": CommandCategoryBuild, "webpack": CommandCategoryBuild,
+	"dotnet build": CommandCategoryBuild, "mvn compile": CommandCategoryBuild, "mvn package": CommandCategoryBuild, "mvn install": CommandCategoryBuild,
+	"gradle build": CommandCategoryBuild, "gradlew build": CommandCategoryBuild, "gradle assemble": CommandCategoryBuild, "gradlew assemble": CommandCategoryBuild,
+	"swift build": CommandCategoryBuild, "zig build": CommandCategoryBuild, "nix build": CommandCategoryBuild, "bazel build": CommandCategoryBuild,
+	"cmake --build": CommandCategoryBuild, "ninja": CommandCategoryBuild, "templ generate": CommandCategoryBuild,
+	// Linters and type checkers
+	"go vet": CommandCategoryLint, "golangci-lint": CommandCategoryLint, "staticcheck": CommandCategoryLint, "cargo clippy": CommandCategoryLint,
+	"eslint": CommandCategoryLint, "biome": CommandCategoryLint, "prettier": CommandCategoryLint, "stylelint": CommandCategoryLint,
+	"ruff": CommandCategoryLint, "flake8": CommandCategoryLint, "pylint": CommandCategoryLint, "mypy": CommandCategoryLint, "pyright": CommandCategoryLint,
+	"black": CommandCategoryLint, "rubocop": CommandCategoryLint, "shellcheck": CommandCategoryLint, "hadolint": CommandCategoryLint,
+	"swiftlint": CommandCategoryLint, "ktlint": CommandCategoryLint, "deno lint": CommandCategoryLint,
+}
+
+// wrappers are programs that run another command given as their arguments,
+// with the number of their own words to drop.
+var wrappers = map[string]int{
+	"sudo": 1, "time": 1, "nice": 1, "env": 1, "npx": 1, "bunx": 1, "pnpx": 1,
+	"uv run": 2, "poetry run": 2, "pipenv run": 2, "pdm run": 2, "hatch run": 2, "bundle exec": 2,
+	"pnpm exec": 2, "yarn exec": 2, "npm exec": 2, "python -m": 2, "python3 -m": 2,
+}
+
+// ClassifyCommand returns the categories of the commands in a shell command
+// line, such as test and build for "go build ./... && go test ./...", or
+// nil when it runs none of them.
+func ClassifyCommand(command string) []string {
+	var categories []string
+	for _, fields := range shellCommands(command) {
+		category := classifyFields(unwrap(fields))
+		if category == "" {
+			continue
+		}
+		seen := false
+		for _, c := range categories {
+			seen = seen || c == category
+		}
+		if !seen {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+func classifyFields(fields []string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	program := path.Base(fields[0])
+	switch program {
+	case "npm", "yarn", "pnpm", "bun":
+		return classifyScript(packageScript(program, fields[1:]))
+	case "make", "just", "task":
+		// The first target, or the default one, which usually builds
+		for _, arg := range fields[1:] {
+			if !strings.HasPrefix(arg, "-") && !strings.Contains(arg, "=") {
+				return classifyScript(arg)
+			}
+		}
+		return CommandCategoryBuild
+	}
+	if len(fields) > 1 {
+		if category, ok := programCategories[program+" "+fields[1]]; ok {
+			return category
+		}
+	}
+	return programCategories[program]
+}
+
+// packageScript returns the script a package manager runs, as in
+// "npm run lint", "npm test" or "yarn build".
+func packageScript(program string, args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	if (args[0] == "run" || args[0] == "run-script") && len(args) > 1 {
+		return args[1]
+	}
+	if program == "npm" && args[0] != "test" && args[0] != "t" {
+		// npm only runs scripts through "run", besides test
+		return ""
+	}
+	return args[0]
+}
+
+// classifyScript classifies a package script or make target by its name,
+// such as "test:unit", "lint" or "build".
+func classifyScript(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "t" || strings.Contains(name, "test") || name == "check" || name == "spec":
+		return CommandCategoryTest
+	case strings.Contains(name, "lint") || strings.Contains(name, "typecheck") || strings.Contains(name, "vet"):
+		return CommandCategoryLint
+	case strings.Contains(name, "build") || name == "all" || name == "compile":
+		return CommandCategoryBuild
+	}
+	return ""
+}
+
+// unwrap drops wrapper programs, so "uv run pytest" is classified as pytest.
+func unwrap(fields []string) []string {
+	for len(fields) > 0 {
+		name := path.Base(fields[0])
+		n, ok := 0, false
+		if len(fields) > 1 {
+			n, ok = wrappers[name+" "+fields[1]]
+		}
+		if !ok {
+			n, ok = wrappers[name]
+		}
+		if !ok || n > len(fields) {
+			return fields
+		}
+		fields = fields[n:]
+		// Options and assignments of the wrapper, as in "npx -y" or "env A=1"
+		for len(fields) > 0 && (strings.HasPrefix(fields[0], "-") || strings.Contains(fields[0], "=")) {
+			fields = fields[1:]
+		}
+	}
+	return fields
+}
+
+// shellCommands splits a shell command line into the words of each command
+// it runs, split on &&, ||, ;, pipes and subshells, without the environment
+// assignments before each one. Quoting is not interpreted.
+func shellCommands(command string) [][]string {
+	separators := strings.NewReplacer("&&", "\n", "||", "\n", ";", "\n", "|", "\n", "(", "\n", ")", "\n", "`", "\n")
+	var commands [][]string
+	for _, segment := range strings.Split(separators.Replace(command), "\n") {
+		fields := strings.Fields(segment)
+		// Skip environment assignments such as CGO_ENABLED=0
+		for len(fields) > 0 && strings.Contains(fields[0], "=") {
+			fields = fields[1:]
+		}
+		if len(fields) > 0 {
+			commands = append(commands, fields)
+		}
+	}
+	return commands
+}
+
+// Session outcomes, for filtering sessions by how they ended.
+const (
+	SessionOutcomeGreen     = "green"     // the last runs of every category passed
+	SessionOutcomeRed       = "red"       // the last run of some category failed
+	SessionOutcomeUnchecked = "unchecked" // no tests, builds or linters ran
+)
+
+// OutcomeOf returns the session outcome for an EndedGreen value.
+func OutcomeOf(endedGreen *bool) string {
+	switch {
+	case endedGreen == nil:
+		return SessionOutcomeUnchecked
+	case *endedGreen:
+		return SessionOutcomeGreen
+	default:
+		return SessionOutcomeRed
+	}
+}
+
+// SessionOutcome records whether the last run of each category of command
+// in a session passed. A category is nil when the session never ran it, or
+// its exit code is unknown.
+type SessionOutcome struct {
+	TestsPassed *bool
+	BuildPassed *bool
+	LintPassed  *bool
+}
+
+// NewSessionOutcome classifies the commands of a session, in the order they
+// ran. A command line that runs several categories counts for each of them
+// with its one exit code.
+func NewSessionOutcome(commands []*SessionCommand) SessionOutcome {
+	var o SessionOutcome
+	for _, cmd := range commands {
+		if cmd.ExitCode == nil {
+			continue
+		}
+		passed := *cmd.ExitCode == 0
+		for _, category := range ClassifyCommand(cmd.Command) {
+			*o.field(category) = &passed
+		}
+	}
+	return o
+}
+
+func (o *SessionOutcome) field(category string) **bool {
+	switch category {
+	case CommandCategoryTest:
+		return &o.TestsPassed
+	case CommandCategoryBuild:
+		return &o.BuildPassed
+	default:
+		return &o.LintPassed
+	}
+}
+
+// Passed returns whether the last run of a category passed, or nil when
+// it did not run.
+func (o SessionOutcome) Passed(category string) *bool {
+	return *o.field(category)
+}
+
+// EndedGreen reports whether the last run of every category the session
+// ran passed, or nil when it ran none.
+func (o SessionOutcome) EndedGreen() *bool {
+	var green *bool
+	for _, passed := range []*bool{o.TestsPassed, o.BuildPassed, o.LintPassed} {
+		if passed == nil {
+			continue
+		}
+		ok := *passed && (green == nil || *green)
+		green = &ok
+	}
+	return green
+}
+
+// Summary describes the last run of each category, such as "tests passed,
+// lint failed", or returns "" when none ran.
+func (o SessionOutcome) Summary() string {
+	names := map[string]string{CommandCategoryTest: "tests", CommandCategoryBuild: "build", CommandCategoryLint: "lint"}
+	var parts []string
+	for _, category := range CommandCategories {
+		passed := o.Passed(category)
+		if passed == nil {
+			continue
+		}
+		result := "failed"
+		if *passed {
+			result = "passed"
+		}
+		parts = append(parts, names[category]+" "+result)
+	}
+	return strings.Join(parts, ", ")
+}
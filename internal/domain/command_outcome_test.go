@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClassifyCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"go test ./...", []string{CommandCategoryTest}},
+		{"go build ./... && go vet ./... && go test ./...", []string{CommandCategoryBuild, CommandCategoryLint, CommandCategoryTest}},
+		{"cd web && npm test", []string{CommandCategoryTest}},
+		{"npm run test:unit -- --watch=false", []string{CommandCategoryTest}},
+		{"npm run lint", []string{CommandCategoryLint}},
+		{"npm install", nil},
+		{"yarn build", []string{CommandCategoryBuild}},
+		{"uv run pytest -x tests/", []string{CommandCategoryTest}},
+		{"python3 -m pytest", []string{CommandCategoryTest}},
+		{"npx -y eslint src", []string{CommandCategoryLint}},
+		{"CGO_ENABLED=0 env GOOS=linux go build -o bin/app", []string{CommandCategoryBuild}},
+		{"cargo clippy -- -D warnings", []string{CommandCategoryLint}},
+		{"make", []string{CommandCategoryBuild}},
+		{"make -j4 test", []string{CommandCategoryTest}},
+		{"make install-deps", nil},
+		{"go test ./... 2>&1 | tail -20", []string{CommandCategoryTest}},
+		{"git status", nil},
+		{"ls tests/", nil},
+	}
+	for _, tt := range tests {
+		if got := ClassifyCommand(tt.command); !slices.Equal(got, tt.want) {
+			t.Errorf("ClassifyCommand(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestNewSessionOutcome(t *testing.T) {
+	exit := func(code int) *int { return &code }
+
+	o := NewSessionOutcome([]*SessionCommand{
+		{Command: "go test ./...", ExitCode: exit(1)},
+		{Command: "go build ./...", ExitCode: exit(0)},
+		{Command: "golangci-lint run", ExitCode: exit(1)},
+		{Command: "go test ./...", ExitCode: exit(0)},
+		{Command: "go vet ./...", ExitCode: nil}, // unknown result
+	})
+	if o.TestsPassed == nil || !*o.TestsPassed || o.BuildPassed == nil || !*o.BuildPassed {
+		t.Errorf("expected the last test and build runs to pass, got %+v", o)
+	}
+	if o.LintPassed == nil || *o.LintPassed {
+		t.Errorf("expected the last lint run to fail, got %+v", o)
+	}
+	if green := o.EndedGreen(); green == nil || *green || OutcomeOf(green) != SessionOutcomeRed {
+		t.Errorf("expected the session not to end green, got %v", green)
+	}
+	assertEqual(t, "summary", "tests passed, build passed, lint failed", o.Summary())
+
+	o = NewSessionOutcome([]*SessionCommand{
+		{Command: "npm test", ExitCode: exit(1)},
+		{Command: "npm run build && npm test", ExitCode: exit(0)},
+	})
+	if green := o.EndedGreen(); green == nil || !*green || o.Passed(CommandCategoryLint) != nil {
+		t.Errorf("expected a green session without lint runs, got %+v", o)
+	}
+
+	if green := NewSessionOutcome([]*SessionCommand{{Command: "ls", ExitCode: exit(0)}}).EndedGreen(); green != nil {
+		t.Errorf("expected no outcome without checks, got %v", *green)
+	}
+	assertEqual(t, "outcome without checks", SessionOutcomeUnchecked, OutcomeOf(nil))
+}
@@ -0,0 +1,311 @@
+package domain
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Command categories beyond the checks, for command analytics.
+const (
+	CommandCategoryGit        = "git"
+	CommandCategoryPackage    = "package"
+	CommandCategoryFilesystem = "filesystem"
+	CommandCategoryNetwork    = "network"
+	CommandCategoryOther      = "other"
+)
+
+// navigationPrograms only set up the shell for the command that follows,
+// as in "cd web && npm test".
+var navigationPrograms = map[string]bool{
+	"cd": true, "pushd": true, "popd": true, "export": true, "source": true, ".": true, "set": true,
+}
+
+// subcommandPrograms are normalized with their subcommand, such as "go test"
+// or "docker compose".
+var subcommandPrograms = map[string]bool{
+	"go": true, "cargo": true, "npm": true, "yarn": true, "pnpm": true, "bun": true, "deno": true,
+	"pip": true, "pip3": true, "uv": true, "poetry": true, "pipenv": true, "gem": true, "bundle": true, "composer": true,
+	"make": true, "just": true, "task": true, "mvn": true, "gradle": true, "gradlew": true, "dotnet": true,
+	"swift": true, "zig": true, "mix": true, "rake": true, "nix": true, "brew": true, "apt": true, "apt-get": true,
+	"docker": true, "podman": true, "kubectl": true, "helm": true, "terraform": true, "gh": true,
+	"systemctl": true, "templ": true, "sqlc": true,
+}
+
+// subcommandPattern matches words that can be subcommands or scripts, and
+// not paths, flags or quoted arguments.
+var subcommandPattern = regexp.MustCompile(`^[a-z][a-z0-9:_-]*$`)
+
+// packageManagers install and run dependencies; anything they run that is
+// not a check counts as package management.
+var packageManagers = map[string]bool{
+	"npm": true, "yarn": true, "pnpm": true, "bun": true, "pip": true, "pip3": true, "uv": true,
+	"poetry": true, "pipenv": true, "gem": true, "bundle": true, "composer": true, "brew": true,
+	"apt": true, "apt-get": true,
+}
+
+// packageSubcommands are the dependency subcommands of toolchains that also
+// build and test.
+var packageSubcommands = map[string]bool{
+	"go get": true, "go mod": true, "cargo add": true, "cargo install": true, "cargo update": true,
+	"cargo remove": true, "deno add": true, "deno install": true, "mix deps.get": true, "dotnet add": true,
+}
+
+var filesystemPrograms = map[string]bool{
+	"ls": true, "cat": true, "head": true, "tail": true, "less": true, "find": true, "fd": true, "grep": true,
+	"rg": true, "ag": true, "sed": true, "awk": true, "wc": true, "sort": true, "uniq": true, "cut": true,
+	"tree": true, "du": true, "df": true, "stat": true, "file": true, "diff": true, "touch": true,
+	"mkdir": true, "rmdir": true, "rm": true, "cp": true, "mv": true, "ln": true, "chmod": true, "chown": true,
+	"tar": true, "zip": true, "unzip": true, "realpath": true, "readlink": true, "pwd": true, "xargs": true,
+}
+
+var networkPrograms = map[string]bool{
+	"curl": true, "wget": true, "http": true, "ssh": true, "scp": true, "rsync": true, "ping": true,
+	"nc": true, "dig": true, "nslookup": true, "telnet": true, "ftp": true, "sftp": true,
+}
+
+// NormalizeCommand reduces a shell command line to the program it runs and
+// its subcommand, without arguments: "go test ./... -run X" becomes
+// "go test" and "cd web && npm run build" becomes "npm run build". Leading
+// navigation such as cd is skipped; wrappers such as sudo or npx are dropped.
+func NormalizeCommand(command string) string {
+	first := ""
+	for _, fields := range shellCommands(command) {
+		fields = unwrap(fields)
+		if len(fields) == 0 {
+			continue
+		}
+		program := path.Base(fields[0])
+		if navigationPrograms[program] {
+			if first == "" {
+				first = program
+			}
+			continue
+		}
+		return normalizeFields(program, fields[1:])
+	}
+	return first
+}
+
+func normalizeFields(program string, args []string) string {
+	switch {
+	case program == "git":
+		if sub := gitSubcommand(args); subcommandPattern.MatchString(sub) {
+			return "git " + sub
+		}
+		return program
+	case !subcommandPrograms[program]:
+		return program
+	}
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") {
+			continue
+		}
+		if !subcommandPattern.MatchString(arg) {
+			return program
+		}
+		// Scripts run by package managers are as telling as subcommands
+		if (arg == "run" || arg == "run-script") && packageManagers[program] && i+1 < len(args) && subcommandPattern.MatchString(args[i+1]) {
+			return program + " run " + args[i+1]
+		}
+		return program + " " + arg
+	}
+	return program
+}
+
+// CategorizeCommand returns the category of a normalized command: test,
+// build or lint for checks, then git, package, filesystem, network, or
+// other.
+func CategorizeCommand(normalized string) string {
+	fields := strings.Fields(normalized)
+	if len(fields) == 0 {
+		return CommandCategoryOther
+	}
+	if category := classifyFields(fields); category != "" {
+		return category
+	}
+	program := fields[0]
+	switch {
+	case program == "git" || program == "gh":
+		return CommandCategoryGit
+	case packageManagers[program] || packageSubcommands[normalized]:
+		return CommandCategoryPackage
+	case filesystemPrograms[program] || navigationPrograms[program]:
+		return CommandCategoryFilesystem
+	case networkPrograms[program]:
+		return CommandCategoryNetwork
+	}
+	return CommandCategoryOther
+}
+
+// CommandRun is a command a session ran, for command analytics.
+type CommandRun struct {
+	SessionID string
+	ProjectID string
+	Command   string
+	ExitCode  *int
+}
+
+// CommandStats aggregates the runs of a normalized command.
+type CommandStats struct {
+	Command   string
+	Category  string
+	Runs      int64
+	Completed int64 // Runs with a known exit code
+	Failures  int64
+	Retries   int64 // Runs repeating the session's previous command after it failed
+	Sessions  int64
+}
+
+// FailureRate returns the share of the runs with a known exit code that
+// failed.
+func (s CommandStats) FailureRate() float64 {
+	if s.Completed == 0 {
+		return 0
+	}
+	return float64(s.Failures) / float64(s.Completed)
+}
+
+// AggregateCommands groups runs by normalized command, most run first. Runs
+// must be grouped by session, in the order they ran, for retries to be
+// counted.
+func AggregateCommands(runs []CommandRun) []CommandStats {
+	byCommand := make(map[string]*CommandStats)
+	sessions := make(map[string]map[string]bool)
+	for i, run := range runs {
+		normalized := NormalizeCommand(run.Command)
+		if normalized == "" {
+			continue
+		}
+		s, ok := byCommand[normalized]
+		if !ok {
+			s = &CommandStats{Command: normalized, Category: CategorizeCommand(normalized)}
+			byCommand[normalized] = s
+			sessions[normalized] = make(map[string]bool)
+		}
+		s.Runs++
+		if run.ExitCode != nil {
+			s.Completed++
+			if *run.ExitCode != 0 {
+				s.Failures++
+			}
+		}
+		if i > 0 {
+			prev := runs[i-1]
+			if prev.SessionID == run.SessionID && prev.Command == run.Command && prev.ExitCode != nil && *prev.ExitCode != 0 {
+				s.Retries++
+			}
+		}
+		sessions[normalized][run.SessionID] = true
+	}
+
+	stats := make([]CommandStats, 0, len(byCommand))
+	for command, s := range byCommand {
+		s.Sessions = int64(len(sessions[command]))
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Runs != stats[j].Runs {
+			return stats[i].Runs > stats[j].Runs
+		}
+		return stats[i].Command < stats[j].Command
+	})
+	return stats
+}
+
+// CommandCategoryStats sums the commands of a category.
+type CommandCategoryStats struct {
+	Category  string
+	Commands  int64 // Distinct normalized commands
+	Runs      int64
+	Completed int64
+	Failures  int64
+	Retries   int64
+}
+
+// FailureRate returns the share of the runs with a known exit code that
+// failed.
+func (s CommandCategoryStats) FailureRate() float64 {
+	if s.Completed == 0 {
+		return 0
+	}
+	return float64(s.Failures) / float64(s.Completed)
+}
+
+// SummarizeCommandCategories sums command stats per category, most run
+// first.
+func SummarizeCommandCategories(stats []CommandStats) []CommandCategoryStats {
+	byCategory := make(map[string]*CommandCategoryStats)
+	var categories []CommandCategoryStats
+	for _, s := range stats {
+		c, ok := byCategory[s.Category]
+		if !ok {
+			c = &CommandCategoryStats{Category: s.Category}
+			byCategory[s.Category] = c
+		}
+		c.Commands++
+		c.Runs += s.Runs
+		c.Completed += s.Completed
+		c.Failures += s.Failures
+		c.Retries += s.Retries
+	}
+	for _, c := range byCategory {
+		categories = append(categories, *c)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Runs != categories[j].Runs {
+			return categories[i].Runs > categories[j].Runs
+		}
+		return categories[i].Category < categories[j].Category
+	})
+	return categories
+}
+
+// RetriedCommands returns the commands that were re-run after failing, most
+// retried first.
+func RetriedCommands(stats []CommandStats) []CommandStats {
+	var retried []CommandStats
+	for _, s := range stats {
+		if s.Retries > 0 {
+			retried = append(retried, s)
+		}
+	}
+	sort.SliceStable(retried, func(i, j int) bool { return retried[i].Retries > retried[j].Retries })
+	return retried
+}
+
+// ProjectCommands holds a project's most run commands.
+type ProjectCommands struct {
+	ProjectID string
+	Runs      int64
+	Commands  []CommandStats
+}
+
+// TopCommandsByProject aggregates runs per project, keeping each project's
+// limit most run commands, projects with the most runs first.
+func TopCommandsByProject(runs []CommandRun, limit int) []ProjectCommands {
+	var projects []ProjectCommands
+	byProject := make(map[string][]CommandRun)
+	var order []string
+	for _, run := range runs {
+		if _, ok := byProject[run.ProjectID]; !ok {
+			order = append(order, run.ProjectID)
+		}
+		byProject[run.ProjectID] = append(byProject[run.ProjectID], run)
+	}
+	for _, projectID := range order {
+		stats := AggregateCommands(byProject[projectID])
+		p := ProjectCommands{ProjectID: projectID}
+		for _, s := range stats {
+			p.Runs += s.Runs
+		}
+		if len(stats) > limit {
+			stats = stats[:limit]
+		}
+		p.Commands = stats
+		projects = append(projects, p)
+	}
+	sort.SliceStable(projects, func(i, j int) bool { return projects[i].Runs > projects[j].Runs })
+	return projects
+}
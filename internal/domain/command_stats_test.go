@@ -0,0 +1,70 @@
+package domain
+
+import "testing"
+
+func TestNormalizeCommand(t *testing.T) {
+	tests := []struct {
+		command    string
+		normalized string
+		category   string
+	}{
+		{`go test ./... -run TestX`, "go test", CommandCategoryTest},
+		{`cd web && npm run build`, "npm run build", CommandCategoryBuild},
+		{`npm install --save-dev vitest`, "npm install", CommandCategoryPackage},
+		{`git -C repo commit -m "fix"`, "git commit", CommandCategoryGit},
+		{`gh pr create --fill`, "gh pr", CommandCategoryGit},
+		{`sudo apt-get install -y jq`, "apt-get install", CommandCategoryPackage},
+		{`go mod tidy`, "go mod", CommandCategoryPackage},
+		{`ls -la /tmp | head`, "ls", CommandCategoryFilesystem},
+		{`curl -s https://example.com`, "curl", CommandCategoryNetwork},
+		{`uv run pytest -x`, "pytest", CommandCategoryTest},
+		{`go run ./cmd/mclaude`, "go run", CommandCategoryOther},
+		{`python script.py`, "python", CommandCategoryOther},
+		{`cd /tmp`, "cd", CommandCategoryFilesystem},
+		{``, "", CommandCategoryOther},
+	}
+	for _, tt := range tests {
+		got := NormalizeCommand(tt.command)
+		if got != tt.normalized {
+			t.Errorf("NormalizeCommand(%q) = %q, want %q", tt.command, got, tt.normalized)
+		}
+		if category := CategorizeCommand(got); category != tt.category {
+			t.Errorf("CategorizeCommand(%q) = %q, want %q", got, category, tt.category)
+		}
+	}
+}
+
+func TestAggregateCommands(t *testing.T) {
+	exit := func(code int) *int { return &code }
+	runs := []CommandRun{
+		{SessionID: "s1", ProjectID: "p1", Command: "go test ./...", ExitCode: exit(1)},
+		{SessionID: "s1", ProjectID: "p1", Command: "go test ./...", ExitCode: exit(1)}, // retry
+		{SessionID: "s1", ProjectID: "p1", Command: "go test ./...", ExitCode: exit(0)}, // retry
+		{SessionID: "s1", ProjectID: "p1", Command: "git status", ExitCode: exit(0)},
+		{SessionID: "s2", ProjectID: "p2", Command: "go test ./...", ExitCode: exit(0)}, // another session
+		{SessionID: "s2", ProjectID: "p2", Command: "go test -run X ./...", ExitCode: nil},
+	}
+
+	stats := AggregateCommands(runs)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 commands, got %+v", stats)
+	}
+	goTest := stats[0]
+	if goTest.Command != "go test" || goTest.Runs != 5 || goTest.Completed != 4 || goTest.Failures != 2 || goTest.Retries != 2 || goTest.Sessions != 2 {
+		t.Errorf("unexpected go test stats: %+v", goTest)
+	}
+	assertFloatNear(t, "failure rate", 0.5, goTest.FailureRate())
+
+	categories := SummarizeCommandCategories(stats)
+	if len(categories) != 2 || categories[0].Category != CommandCategoryTest || categories[1].Runs != 1 {
+		t.Errorf("unexpected categories: %+v", categories)
+	}
+	if retried := RetriedCommands(stats); len(retried) != 1 || retried[0].Command != "go test" {
+		t.Errorf("expected go test to be retried, got %+v", retried)
+	}
+
+	projects := TopCommandsByProject(runs, 1)
+	if len(projects) != 2 || projects[0].ProjectID != "p1" || projects[0].Runs != 4 || len(projects[0].Commands) != 1 {
+		t.Errorf("unexpected top commands by project: %+v", projects)
+	}
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// How a session's commits were found.
+const (
+	CommitDetectedByHead    = "head"    // new commits between the start and end HEAD
+	CommitDetectedByCommand = "command" // commits on HEAD while a session that ran `git commit` was active
+)
+
+// SessionCommit is a commit made during a session.
+type SessionCommit struct {
+	SessionID    string
+	Hash         string
+	Message      string
+	Author       string
+	CommittedAt  time.Time
+	FilesChanged int64
+	LinesAdded   int64
+	LinesRemoved int64
+	DetectedBy   string
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c *SessionCommit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// Subject returns the first line of the commit message.
+func (c *SessionCommit) Subject() string {
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// CommitListItem is a commit with the session that made it and its cost.
+type CommitListItem struct {
+	SessionCommit
+	ExperimentID   *string
+	ExperimentName *string
+	SessionCostUsd *float64
+	SessionCommits int64
+}
+
+// CostUsd returns the commit's share of its session's cost, split evenly
+// between the commits the session made, or nil when the cost is unknown.
+func (c *CommitListItem) CostUsd() *float64 {
+	if c.SessionCostUsd == nil || c.SessionCommits == 0 {
+		return nil
+	}
+	cost := *c.SessionCostUsd / float64(c.SessionCommits)
+	return &cost
+}
+
+// ExperimentCommitStats summarizes the commits made by an experiment's
+// sessions.
+type ExperimentCommitStats struct {
+	ExperimentID        string
+	ExperimentName      string
+	Sessions            int64
+	SessionsWithCommits int64
+	Commits             int64
+	LinesAdded          int64
+	LinesRemoved        int64
+	CostUsd             float64
+}
+
+// CommitsPerSession returns the average number of commits per session.
+func (s ExperimentCommitStats) CommitsPerSession() float64 {
+	if s.Sessions == 0 {
+		return 0
+	}
+	return float64(s.Commits) / float64(s.Sessions)
+}
+
+// CostPerCommit returns the cost of all the experiment's sessions divided by
+// the commits they made, or nil without commits.
+func (s ExperimentCommitStats) CostPerCommit() *float64 {
+	if s.Commits == 0 {
+		return nil
+	}
+	cost := s.CostUsd / float64(s.Commits)
+	return &cost
+}
+
+// HasGitCommit reports whether any of the commands runs `git commit`.
+func HasGitCommit(commands []*SessionCommand) bool {
+	for _, cmd := range commands {
+		if RunsGitCommit(cmd.Command) {
+			return true
+		}
+	}
+	return false
+}
+
+// RunsGitCommit reports whether a shell command line runs `git commit`,
+// alone or chained with other commands, with or without global git options
+// such as -C.
+func RunsGitCommit(command string) bool {
+	for _, fields := range shellCommands(command) {
+		if path.Base(fields[0]) == "git" && gitSubcommand(fields[1:]) == "commit" {
+			return true
+		}
+	}
+	return false
+}
+
+// gitSubcommand returns the first argument after git's global options.
+func gitSubcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-C" || arg == "-c" || arg == "--git-dir" || arg == "--work-tree" || arg == "--namespace":
+			i++ // the option's value
+		case strings.HasPrefix(arg, "-"):
+		default:
+			return arg
+		}
+	}
+	return ""
+}
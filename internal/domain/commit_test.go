@@ -0,0 +1,57 @@
+package domain
+
+import "testing"
+
+func TestRunsGitCommit(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{`git commit -m "fix"`, true},
+		{`git add -A && git commit -m "fix; again"`, true},
+		{`cd repo; git -C sub -c user.name=x commit --amend`, true},
+		{`GIT_AUTHOR_NAME=x /usr/bin/git commit -q`, true},
+		{`go test ./... && git status | head`, false},
+		{`git log --grep commit`, false},
+		{`echo git commit`, false},
+		{`git commit-tree HEAD^{tree}`, false},
+	}
+	for _, tt := range tests {
+		if got := RunsGitCommit(tt.command); got != tt.want {
+			t.Errorf("RunsGitCommit(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+
+	commands := []*SessionCommand{{Command: "git status"}, {Command: "git commit -m x"}}
+	if !HasGitCommit(commands) || HasGitCommit(commands[:1]) {
+		t.Error("expected only the second command to commit")
+	}
+}
+
+func TestSessionCommit(t *testing.T) {
+	c := SessionCommit{Hash: "0123456789abcdef", Message: "Add parser\n\nWith tests."}
+	assertEqual(t, "short hash", "0123456", c.ShortHash())
+	assertEqual(t, "subject", "Add parser", c.Subject())
+
+	cost := 3.0
+	item := CommitListItem{SessionCostUsd: &cost, SessionCommits: 2}
+	if got := item.CostUsd(); got == nil || *got != 1.5 {
+		t.Errorf("expected the session cost split between 2 commits, got %v", got)
+	}
+	if (&CommitListItem{SessionCommits: 2}).CostUsd() != nil {
+		t.Error("expected no cost without a session cost")
+	}
+}
+
+func TestExperimentCommitStats(t *testing.T) {
+	s := ExperimentCommitStats{Sessions: 4, Commits: 6, CostUsd: 3}
+	if got := s.CostPerCommit(); got == nil || *got != 0.5 {
+		t.Errorf("expected $0.50 per commit, got %v", got)
+	}
+	if got := s.CommitsPerSession(); got != 1.5 {
+		t.Errorf("expected 1.5 commits per session, got %v", got)
+	}
+	if (ExperimentCommitStats{Sessions: 2, CostUsd: 1}).CostPerCommit() != nil {
+		t.Error("expected no cost per commit without commits")
+	}
+}
@@ -14,6 +14,38 @@ type Experiment struct {
 	ModelID     *string
 	PlanType    *string
 	Notes       *string
+	// Optional schedule window. When set, the experiment is considered
+	// active between ScheduledStart and ScheduledEnd regardless of IsActive.
+	ScheduledStart *time.Time
+	ScheduledEnd   *time.Time
+	// Outcome recorded when the experiment is concluded.
+	Verdict     *string
+	Conclusion  *string
+	ConcludedAt *time.Time
+}
+
+// Hypothesis verdicts recorded when concluding an experiment.
+const (
+	VerdictConfirmed    = "confirmed"
+	VerdictRejected     = "rejected"
+	VerdictInconclusive = "inconclusive"
+)
+
+// IsValidVerdict reports whether v is a known hypothesis verdict.
+func IsValidVerdict(v string) bool {
+	switch v {
+	case VerdictConfirmed, VerdictRejected, VerdictInconclusive:
+		return true
+	}
+	return false
+}
+
+// ExperimentNote is a timestamped entry in an experiment's journal.
+type ExperimentNote struct {
+	ID           int64
+	ExperimentID string
+	Content      string
+	CreatedAt    time.Time
 }
 
 type ExperimentVariable struct {
@@ -22,3 +54,15 @@ type ExperimentVariable struct {
 	Key          string
 	Value        string
 }
+
+// ExperimentTemplate holds reusable experiment metadata and variables.
+type ExperimentTemplate struct {
+	Name        string
+	Description *string
+	Hypothesis  *string
+	ModelID     *string
+	PlanType    *string
+	Notes       *string
+	Variables   map[string]string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// FileTouch is a session's operations of one kind on a file, with the cost
+// of the session.
+type FileTouch struct {
+	SessionID      string
+	ProjectID      string
+	ExperimentID   *string
+	Excluded       bool // Excluded from the experiment's stats
+	FilePath       string
+	Operation      string // "read", "write", "edit"
+	Count          int64
+	SessionCostUsd *float64
+}
+
+// FileHotspot aggregates the operations on a file across sessions.
+type FileHotspot struct {
+	Path     string
+	Reads    int64
+	Edits    int64
+	Writes   int64
+	Sessions int64
+	// Rereads counts the reads of the file beyond the first in each session,
+	// a sign of context churn; RereadSessions the sessions with any.
+	Rereads        int64
+	RereadSessions int64
+	// SessionCostUsd sums the cost of the sessions that touched the file.
+	SessionCostUsd float64
+}
+
+// Operations returns the reads, edits and writes of the file.
+func (h FileHotspot) Operations() int64 {
+	return h.Reads + h.Edits + h.Writes
+}
+
+// RereadRate returns the share of the reads that re-read the file in the
+// same session.
+func (h FileHotspot) RereadRate() float64 {
+	if h.Reads == 0 {
+		return 0
+	}
+	return float64(h.Rereads) / float64(h.Reads)
+}
+
+// Sort keys for file hotspots.
+const (
+	FileSortOperations = "operations"
+	FileSortReads      = "reads"
+	FileSortEdits      = "edits"
+	FileSortRereads    = "rereads"
+	FileSortSessions   = "sessions"
+	FileSortCost       = "cost"
+	FileSortPath       = "path"
+)
+
+// FileSortKeys lists the sort keys in display order.
+var FileSortKeys = []string{FileSortOperations, FileSortReads, FileSortEdits, FileSortRereads, FileSortSessions, FileSortCost, FileSortPath}
+
+// AggregateFileHotspots groups touches by file, with paths under root made
+// relative to it, sorted by operations.
+func AggregateFileHotspots(touches []FileTouch, root string) []FileHotspot {
+	byPath := make(map[string]*FileHotspot)
+	sessions := make(map[string]map[string]bool)
+	rereadSessions := make(map[string]map[string]bool)
+	for _, t := range touches {
+		p := relativePath(t.FilePath, root)
+		h, ok := byPath[p]
+		if !ok {
+			h = &FileHotspot{Path: p}
+			byPath[p] = h
+			sessions[p] = make(map[string]bool)
+			rereadSessions[p] = make(map[string]bool)
+		}
+		switch t.Operation {
+		case "read":
+			h.Reads += t.Count
+			if t.Count > 1 {
+				h.Rereads += t.Count - 1
+				rereadSessions[p][t.SessionID] = true
+			}
+		case "edit":
+			h.Edits += t.Count
+		case "write":
+			h.Writes += t.Count
+		}
+		if !sessions[p][t.SessionID] {
+			sessions[p][t.SessionID] = true
+			if t.SessionCostUsd != nil {
+				h.SessionCostUsd += *t.SessionCostUsd
+			}
+		}
+	}
+
+	hotspots := make([]FileHotspot, 0, len(byPath))
+	for p, h := range byPath {
+		h.Sessions = int64(len(sessions[p]))
+		h.RereadSessions = int64(len(rereadSessions[p]))
+		hotspots = append(hotspots, *h)
+	}
+	SortFileHotspots(hotspots, FileSortOperations)
+	return hotspots
+}
+
+// SortFileHotspots sorts hotspots by a FileSort key, largest first, or by
+// path; unknown keys sort by operations.
+func SortFileHotspots(hotspots []FileHotspot, key string) {
+	value := func(h FileHotspot) float64 {
+		switch key {
+		case FileSortReads:
+			return float64(h.Reads)
+		case FileSortEdits:
+			return float64(h.Edits + h.Writes)
+		case FileSortRereads:
+			return float64(h.Rereads)
+		case FileSortSessions:
+			return float64(h.Sessions)
+		case FileSortCost:
+			return h.SessionCostUsd
+		}
+		return float64(h.Operations())
+	}
+	sort.Slice(hotspots, func(i, j int) bool {
+		if key != FileSortPath {
+			if vi, vj := value(hotspots[i]), value(hotspots[j]); vi != vj {
+				return vi > vj
+			}
+		}
+		return hotspots[i].Path < hotspots[j].Path
+	})
+}
+
+// relativePath returns p relative to root when it is under it.
+func relativePath(p, root string) string {
+	if root == "" || root == "/" {
+		return p
+	}
+	if rel, ok := strings.CutPrefix(p, strings.TrimSuffix(root, "/")+"/"); ok {
+		return rel
+	}
+	return p
+}
+
+// FileTreeNode is a directory or file in a tree of hotspots, sized by
+// operations.
+type FileTreeNode struct {
+	Name     string
+	Path     string
+	Value    int64
+	Children []*FileTreeNode
+}
+
+// BuildFileTree nests hotspots by directory, with each directory sized by
+// the operations below it. Directories with a single child directory are
+// merged into it, as in "internal/web".
+func BuildFileTree(hotspots []FileHotspot) *FileTreeNode {
+	root := &FileTreeNode{}
+	for _, h := range hotspots {
+		node := root
+		parts := strings.Split(strings.TrimPrefix(h.Path, "/"), "/")
+		for i, part := range parts {
+			node.Value += h.Operations()
+			var child *FileTreeNode
+			for _, c := range node.Children {
+				if c.Name == part {
+					child = c
+					break
+				}
+			}
+			if child == nil {
+				child = &FileTreeNode{Name: part, Path: path.Join(node.Path, part)}
+				if strings.HasPrefix(h.Path, "/") && i == 0 {
+					child.Path = "/" + part
+				}
+				node.Children = append(node.Children, child)
+			}
+			node = child
+		}
+		node.Value += h.Operations()
+	}
+	compactFileTree(root)
+	return root
+}
+
+func compactFileTree(node *FileTreeNode) {
+	for _, c := range node.Children {
+		for len(c.Children) == 1 && len(c.Children[0].Children) > 0 {
+			only := c.Children[0]
+			c.Name = c.Name + "/" + only.Name
+			c.Path = only.Path
+			c.Children = only.Children
+		}
+		compactFileTree(c)
+	}
+	sort.Slice(node.Children, func(i, j int) bool { return node.Children[i].Value > node.Children[j].Value })
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "testing"
+
+func TestAggregateFileHotspots(t *testing.T) {
+	cost1, cost2 := 1.0, 2.0
+	touches := []FileTouch{
+		{SessionID: "s1", FilePath: "/repo/internal/web/server.go", Operation: "read", Count: 3, SessionCostUsd: &cost1},
+		{SessionID: "s1", FilePath: "/repo/internal/web/server.go", Operation: "edit", Count: 2, SessionCostUsd: &cost1},
+		{SessionID: "s2", FilePath: "/repo/internal/web/server.go", Operation: "read", Count: 1, SessionCostUsd: &cost2},
+		{SessionID: "s2", FilePath: "/repo/README.md", Operation: "write", Count: 1, SessionCostUsd: &cost2},
+		{SessionID: "s2", FilePath: "/etc/hosts", Operation: "read", Count: 1, SessionCostUsd: &cost2},
+	}
+
+	hotspots := AggregateFileHotspots(touches, "/repo/")
+	if len(hotspots) != 3 {
+		t.Fatalf("expected 3 files, got %+v", hotspots)
+	}
+	h := hotspots[0]
+	if h.Path != "internal/web/server.go" || h.Reads != 4 || h.Edits != 2 || h.Sessions != 2 {
+		t.Errorf("unexpected hotspot: %+v", h)
+	}
+	if h.Rereads != 2 || h.RereadSessions != 1 || !floatEquals(h.RereadRate(), 0.5) {
+		t.Errorf("expected 2 rereads in 1 session, got %+v", h)
+	}
+	if !floatEquals(h.SessionCostUsd, 3) {
+		t.Errorf("expected each session's cost counted once, got %f", h.SessionCostUsd)
+	}
+
+	SortFileHotspots(hotspots, FileSortPath)
+	assertEqual(t, "first by path", "/etc/hosts", hotspots[0].Path)
+	SortFileHotspots(hotspots, FileSortCost)
+	assertEqual(t, "first by cost", "internal/web/server.go", hotspots[0].Path)
+}
+
+func TestBuildFileTree(t *testing.T) {
+	tree := BuildFileTree([]FileHotspot{
+		{Path: "internal/web/server.go", Reads: 4},
+		{Path: "internal/web/helpers.go", Edits: 2},
+		{Path: "README.md", Writes: 1},
+	})
+	assertEqual(t, "root value", int64(7), tree.Value)
+	if len(tree.Children) != 2 {
+		t.Fatalf("expected 2 top-level entries, got %+v", tree.Children)
+	}
+	dir := tree.Children[0]
+	assertEqual(t, "merged directory", "internal/web", dir.Name)
+	assertEqual(t, "merged directory path", "internal/web", dir.Path)
+	assertEqual(t, "directory value", int64(6), dir.Value)
+	if len(dir.Children) != 2 || dir.Children[0].Name != "server.go" {
+		t.Errorf("expected the directory's files by operations, got %+v", dir.Children)
+	}
+}
@@ -0,0 +1,337 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Kinds of files, by what they hold rather than their language.
+const (
+	FileKindSource    = "source"
+	FileKindTest      = "test"
+	FileKindGenerated = "generated"
+	FileKindVendored  = "vendored"
+)
+
+// FileKinds lists the file kinds in display order.
+var FileKinds = []string{FileKindSource, FileKindTest, FileKindGenerated, FileKindVendored}
+
+// LanguageOther groups the files of unrecognized languages.
+const LanguageOther = "Other"
+
+var languageExtensions = map[string]string{
+	".go":    "Go",
+	".templ": "templ",
+	".sql":   "SQL",
+	".py":    "Python",
+	".pyi":   "Python",
+	".js":    "JavaScript",
+	".mjs":   "JavaScript",
+	".cjs":   "JavaScript",
+	".jsx":   "JavaScript",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+	".rs":    "Rust",
+	".java":  "Java",
+	".kt":    "Kotlin",
+	".rb":    "Ruby",
+	".php":   "PHP",
+	".cs":    "C#",
+	".c":     "C",
+	".h":     "C",
+	".cc":    "C++",
+	".cpp":   "C++",
+	".hpp":   "C++",
+	".swift": "Swift",
+	".lua":   "Lua",
+	".sh":    "Shell",
+	".bash":  "Shell",
+	".zsh":   "Shell",
+	".nix":   "Nix",
+	".html":  "HTML",
+	".css":   "CSS",
+	".scss":  "CSS",
+	".yaml":  "YAML",
+	".yml":   "YAML",
+	".json":  "JSON",
+	".jsonl": "JSON",
+	".toml":  "TOML",
+	".md":    "Markdown",
+	".mdx":   "Markdown",
+	".proto": "Protobuf",
+}
+
+// languageFileNames maps files whose name, not extension, gives their
+// language.
+var languageFileNames = map[string]string{
+	"go.mod":       "Go",
+	"go.sum":       "Go",
+	"Makefile":     "Make",
+	"Dockerfile":   "Docker",
+	"Justfile":     "Just",
+	"flake.lock":   "Nix",
+	"Cargo.lock":   "Rust",
+	"Gemfile":      "Ruby",
+	"Gemfile.lock": "Ruby",
+}
+
+// vendoredDirs are directories holding third-party code.
+var vendoredDirs = map[string]bool{
+	"vendor":        true,
+	"node_modules":  true,
+	"third_party":   true,
+	".venv":         true,
+	"site-packages": true,
+}
+
+// generatedDirs are directories holding generated code.
+var generatedDirs = map[string]bool{
+	"generated": true,
+	"gen":       true,
+	"dist":      true,
+}
+
+// generatedSuffixes mark generated files by the end of their name.
+var generatedSuffixes = []string{
+	"_templ.go", ".pb.go", "_gen.go", ".gen.go", "_generated.go", "_string.go",
+	".min.js", ".min.css", ".lock", "-lock.json", "go.sum",
+}
+
+// LanguageOf returns the language of a file from its name or extension, or
+// LanguageOther.
+func LanguageOf(filePath string) string {
+	base := path.Base(filePath)
+	if lang, ok := languageFileNames[base]; ok {
+		return lang
+	}
+	if lang, ok := languageExtensions[strings.ToLower(path.Ext(base))]; ok {
+		return lang
+	}
+	return LanguageOther
+}
+
+// FileKindOf classifies a file as vendored, generated, test or source, in
+// that order of precedence.
+func FileKindOf(filePath string) string {
+	dirs := strings.Split(path.Dir(filePath), "/")
+	for _, d := range dirs {
+		if vendoredDirs[d] {
+			return FileKindVendored
+		}
+	}
+	base := path.Base(filePath)
+	for _, d := range dirs {
+		if generatedDirs[d] {
+			return FileKindGenerated
+		}
+	}
+	for _, suffix := range generatedSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return FileKindGenerated
+		}
+	}
+	if isTestFile(base, dirs) {
+		return FileKindTest
+	}
+	return FileKindSource
+}
+
+func isTestFile(base string, dirs []string) bool {
+	for _, d := range dirs {
+		if d == "testdata" || d == "tests" || d == "test" || d == "__tests__" || d == "spec" {
+			return true
+		}
+	}
+	name := strings.TrimSuffix(base, path.Ext(base))
+	return strings.HasSuffix(name, "_test") ||
+		strings.HasSuffix(name, ".test") ||
+		strings.HasSuffix(name, ".spec") ||
+		strings.HasPrefix(name, "test_")
+}
+
+// LanguageStats aggregates the operations on the files of a language.
+type LanguageStats struct {
+	Language string
+	Files    int64
+	Reads    int64
+	Edits    int64
+	Writes   int64
+	Sessions int64
+	// KindOperations splits the operations by FileKind.
+	KindOperations map[string]int64
+	// SessionCostUsd is the language's share of the cost of the sessions
+	// that touched it, split by each session's operations, so the shares
+	// of all languages add up to the sessions' cost.
+	SessionCostUsd float64
+}
+
+// Operations returns the reads, edits and writes of the language's files.
+func (s LanguageStats) Operations() int64 {
+	return s.Reads + s.Edits + s.Writes
+}
+
+// KindShare returns the share of the operations on files of a kind.
+func (s LanguageStats) KindShare(kind string) float64 {
+	if s.Operations() == 0 {
+		return 0
+	}
+	return float64(s.KindOperations[kind]) / float64(s.Operations())
+}
+
+// FileKindStats aggregates the operations on files of a kind.
+type FileKindStats struct {
+	Kind           string
+	Files          int64
+	Operations     int64
+	SessionCostUsd float64
+}
+
+// LanguageBreakdown attributes the operations and session cost of file
+// touches to languages and file kinds.
+type LanguageBreakdown struct {
+	Operations     int64
+	SessionCostUsd float64
+	Languages      []LanguageStats // By operations, most first
+	Kinds          []FileKindStats // In FileKinds order, kinds with operations only
+}
+
+// Share returns the share of all operations on files of a language.
+func (b LanguageBreakdown) Share(s LanguageStats) float64 {
+	if b.Operations == 0 {
+		return 0
+	}
+	return float64(s.Operations()) / float64(b.Operations)
+}
+
+// AggregateLanguages attributes touches to the language and kind of their
+// file. A session's cost is split across the files it touched in proportion
+// to their operations.
+func AggregateLanguages(touches []FileTouch) LanguageBreakdown {
+	sessionOps := make(map[string]int64)
+	for _, t := range touches {
+		if isFileOperation(t.Operation) {
+			sessionOps[t.SessionID] += t.Count
+		}
+	}
+
+	var b LanguageBreakdown
+	byLanguage := make(map[string]*LanguageStats)
+	kinds := make(map[string]*FileKindStats)
+	languageFiles := make(map[string]map[string]bool)
+	languageSessions := make(map[string]map[string]bool)
+	kindFiles := make(map[string]map[string]bool)
+	for _, t := range touches {
+		if !isFileOperation(t.Operation) {
+			continue
+		}
+		lang, kind := LanguageOf(t.FilePath), FileKindOf(t.FilePath)
+		s, ok := byLanguage[lang]
+		if !ok {
+			s = &LanguageStats{Language: lang, KindOperations: make(map[string]int64)}
+			byLanguage[lang] = s
+			languageFiles[lang] = make(map[string]bool)
+			languageSessions[lang] = make(map[string]bool)
+		}
+		k, ok := kinds[kind]
+		if !ok {
+			k = &FileKindStats{Kind: kind}
+			kinds[kind] = k
+			kindFiles[kind] = make(map[string]bool)
+		}
+
+		switch t.Operation {
+		case "read":
+			s.Reads += t.Count
+		case "edit":
+			s.Edits += t.Count
+		case "write":
+			s.Writes += t.Count
+		}
+		s.KindOperations[kind] += t.Count
+		k.Operations += t.Count
+		b.Operations += t.Count
+		languageFiles[lang][t.FilePath] = true
+		languageSessions[lang][t.SessionID] = true
+		kindFiles[kind][t.FilePath] = true
+
+		if t.SessionCostUsd != nil && sessionOps[t.SessionID] > 0 {
+			cost := *t.SessionCostUsd * float64(t.Count) / float64(sessionOps[t.SessionID])
+			s.SessionCostUsd += cost
+			k.SessionCostUsd += cost
+			b.SessionCostUsd += cost
+		}
+	}
+
+	for lang, s := range byLanguage {
+		s.Files = int64(len(languageFiles[lang]))
+		s.Sessions = int64(len(languageSessions[lang]))
+		b.Languages = append(b.Languages, *s)
+	}
+	sort.Slice(b.Languages, func(i, j int) bool {
+		if oi, oj := b.Languages[i].Operations(), b.Languages[j].Operations(); oi != oj {
+			return oi > oj
+		}
+		return b.Languages[i].Language < b.Languages[j].Language
+	})
+	for _, kind := range FileKinds {
+		if k, ok := kinds[kind]; ok {
+			k.Files = int64(len(kindFiles[kind]))
+			b.Kinds = append(b.Kinds, *k)
+		}
+	}
+	return b
+}
+
+// MixLabel summarizes the share of operations of the n largest languages,
+// as in "Go 62%, SQL 20%".
+func (b LanguageBreakdown) MixLabel(n int) string {
+	parts := make([]string, 0, n)
+	for _, s := range b.Languages[:min(n, len(b.Languages))] {
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", s.Language, b.Share(s)*100))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func isFileOperation(op string) bool {
+	return op == "read" || op == "edit" || op == "write"
+}
+
+// ExperimentLanguages is the language breakdown of an experiment's sessions.
+type ExperimentLanguages struct {
+	ExperimentID string // Empty for sessions outside experiments
+	LanguageBreakdown
+}
+
+// AggregateLanguagesByExperiment breaks touches down by experiment and then
+// by language, ordered by operations, with sessions outside experiments
+// last. Sessions excluded from experiment stats are left out.
+func AggregateLanguagesByExperiment(touches []FileTouch) []ExperimentLanguages {
+	byExperiment := make(map[string][]FileTouch)
+	for _, t := range touches {
+		if t.Excluded {
+			continue
+		}
+		id := ""
+		if t.ExperimentID != nil {
+			id = *t.ExperimentID
+		}
+		byExperiment[id] = append(byExperiment[id], t)
+	}
+
+	result := make([]ExperimentLanguages, 0, len(byExperiment))
+	for id, ts := range byExperiment {
+		result = append(result, ExperimentLanguages{ExperimentID: id, LanguageBreakdown: AggregateLanguages(ts)})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].ExperimentID == "") != (result[j].ExperimentID == "") {
+			return result[j].ExperimentID == ""
+		}
+		if result[i].Operations != result[j].Operations {
+			return result[i].Operations > result[j].Operations
+		}
+		return result[i].ExperimentID < result[j].ExperimentID
+	})
+	return result
+}
@@ -0,0 +1,74 @@
+package domain
+
+import "testing"
+
+func TestLanguageOf(t *testing.T) {
+	for path, want := range map[string]string{
+		"/repo/internal/cli/stats.go":          "Go",
+		"/repo/internal/web/templates/x.templ": "templ",
+		"sqlc/queries/metrics.sql":             "SQL",
+		".github/workflows/ci.yml":             "YAML",
+		"go.mod":                               "Go",
+		"Makefile":                             "Make",
+		"LICENSE":                              LanguageOther,
+	} {
+		assertEqual(t, path, want, LanguageOf(path))
+	}
+}
+
+func TestFileKindOf(t *testing.T) {
+	for path, want := range map[string]string{
+		"/repo/internal/cli/stats.go":              FileKindSource,
+		"/repo/internal/cli/stats_test.go":         FileKindTest,
+		"/repo/web/src/app.spec.ts":                FileKindTest,
+		"/repo/tests/test_parser.py":               FileKindTest,
+		"/repo/internal/web/templates/x_templ.go":  FileKindGenerated,
+		"/repo/sqlc/generated/metrics.sql.go":      FileKindGenerated,
+		"/repo/vendor/github.com/x/y/y.go":         FileKindVendored,
+		"/repo/web/node_modules/lib/index.test.js": FileKindVendored,
+	} {
+		assertEqual(t, path, want, FileKindOf(path))
+	}
+}
+
+func TestAggregateLanguages(t *testing.T) {
+	cost := 4.0
+	touches := []FileTouch{
+		{SessionID: "s1", FilePath: "/repo/a.go", Operation: "read", Count: 2, SessionCostUsd: &cost},
+		{SessionID: "s1", FilePath: "/repo/a_test.go", Operation: "edit", Count: 1, SessionCostUsd: &cost},
+		{SessionID: "s1", FilePath: "/repo/q.sql", Operation: "write", Count: 1, SessionCostUsd: &cost},
+		{SessionID: "s2", FilePath: "/repo/a.go", Operation: "read", Count: 1},
+	}
+
+	b := AggregateLanguages(touches)
+	assertEqual(t, "operations", int64(5), b.Operations)
+	assertFloatNear(t, "session cost", 4, b.SessionCostUsd)
+	if len(b.Languages) != 2 {
+		t.Fatalf("expected 2 languages, got %+v", b.Languages)
+	}
+	goStats := b.Languages[0]
+	assertEqual(t, "first language", "Go", goStats.Language)
+	assertEqual(t, "go files", int64(2), goStats.Files)
+	assertEqual(t, "go sessions", int64(2), goStats.Sessions)
+	assertFloatNear(t, "go test share", 0.25, goStats.KindShare(FileKindTest))
+	assertFloatNear(t, "go session cost", 3, goStats.SessionCostUsd)
+	assertEqual(t, "mix", "Go 80%, SQL 20%", b.MixLabel(3))
+	if len(b.Kinds) != 2 || b.Kinds[0].Kind != FileKindSource || b.Kinds[1].Operations != 1 {
+		t.Errorf("unexpected kinds: %+v", b.Kinds)
+	}
+}
+
+func TestAggregateLanguagesByExperiment(t *testing.T) {
+	exp := "exp-1"
+	breakdowns := AggregateLanguagesByExperiment([]FileTouch{
+		{SessionID: "s1", FilePath: "a.go", Operation: "read", Count: 1},
+		{SessionID: "s2", ExperimentID: &exp, FilePath: "b.sql", Operation: "read", Count: 1},
+		{SessionID: "s3", ExperimentID: &exp, Excluded: true, FilePath: "c.py", Operation: "edit", Count: 5},
+	})
+	if len(breakdowns) != 2 {
+		t.Fatalf("expected 2 groups, got %+v", breakdowns)
+	}
+	assertEqual(t, "experiment first", "exp-1", breakdowns[0].ExperimentID)
+	assertEqual(t, "experiment mix", "SQL 100%", breakdowns[0].MixLabel(2))
+	assertEqual(t, "no experiment last", "", breakdowns[1].ExperimentID)
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Forecast methods.
+const (
+	ForecastLinear   = "linear"
+	ForecastSeasonal = "seasonal"
+)
+
+// ForecastHistoryDays is the number of complete days forecasts are fitted on.
+const ForecastHistoryDays = 28
+
+// SeasonalMinHistoryDays is the history needed to estimate weekday effects.
+// With less, seasonal forecasts fall back to linear.
+const SeasonalMinHistoryDays = 14
+
+// forecastZ is the normal quantile of a 95% interval.
+const forecastZ = 1.96
+
+// IsValidForecastMethod reports whether method is a supported forecast method.
+func IsValidForecastMethod(method string) bool {
+	return method == ForecastLinear || method == ForecastSeasonal
+}
+
+// DailyCost is the cost of a project's sessions on a UTC day.
+type DailyCost struct {
+	Date      time.Time
+	ProjectID string
+	CostUsd   float64
+}
+
+// ForecastPoint is the projected cost of a single day with its 95% interval.
+type ForecastPoint struct {
+	Date    time.Time
+	CostUsd float64
+	Low     float64
+	High    float64
+}
+
+// CostForecast projects daily cost up to the end of a period. The first day
+// is today, projected net of what has already been spent today. Totals
+// include SpentUsd, the spend of the period so far.
+type CostForecast struct {
+	Method       string
+	HistoryDays  int
+	HasInterval  bool
+	SpentUsd     float64
+	Days         []ForecastPoint
+	ProjectedUsd float64
+	LowUsd       float64
+	HighUsd      float64
+}
+
+// ForecastCost fits a model to history, the daily cost of the days from
+// start up to yesterday, and projects it from today until end. spentUsd is
+// the spend of the period so far and todayUsd the part of it spent today.
+//
+// The linear model is a least-squares trend. The seasonal model adds the
+// mean residual of each weekday to the trend. Intervals assume independent
+// normal residuals, so the total's interval grows with the square root of
+// the days left; they are omitted with fewer than three days of history.
+func ForecastCost(history []float64, start time.Time, method string, spentUsd, todayUsd float64, end time.Time) CostForecast {
+	if method == ForecastSeasonal && len(history) < SeasonalMinHistoryDays {
+		method = ForecastLinear
+	}
+	m := fitCostModel(history, start, method)
+
+	f := CostForecast{
+		Method:       method,
+		HistoryDays:  len(history),
+		HasInterval:  m.hasInterval,
+		SpentUsd:     spentUsd,
+		Days:         []ForecastPoint{},
+		ProjectedUsd: spentUsd,
+	}
+	var sum float64
+	for t, day := len(history), start.AddDate(0, 0, len(history)); day.Before(end); t, day = t+1, day.AddDate(0, 0, 1) {
+		pred := m.predict(t, day)
+		if t == len(history) {
+			pred = math.Max(pred-todayUsd, 0)
+		}
+		margin := forecastZ * m.stddev
+		f.Days = append(f.Days, ForecastPoint{
+			Date:    day,
+			CostUsd: pred,
+			Low:     math.Max(pred-margin, 0),
+			High:    pred + margin,
+		})
+		sum += pred
+	}
+
+	margin := forecastZ * m.stddev * math.Sqrt(float64(len(f.Days)))
+	f.ProjectedUsd = spentUsd + sum
+	f.LowUsd = spentUsd + math.Max(sum-margin, 0)
+	f.HighUsd = spentUsd + sum + margin
+	return f
+}
+
+// Exhaustion returns the first projected day on which spending, starting
+// from spentUsd, reaches amountUsd before end.
+func (f CostForecast) Exhaustion(spentUsd, amountUsd float64, end time.Time) (time.Time, bool) {
+	total := spentUsd
+	for _, d := range f.Days {
+		if !d.Date.Before(end) {
+			break
+		}
+		total += d.CostUsd
+		if total >= amountUsd {
+			return d.Date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+type costModel struct {
+	intercept   float64
+	slope       float64
+	weekday     [7]float64
+	stddev      float64
+	hasInterval bool
+}
+
+func (m costModel) predict(t int, day time.Time) float64 {
+	return math.Max(m.intercept+m.slope*float64(t)+m.weekday[day.Weekday()], 0)
+}
+
+func fitCostModel(history []float64, start time.Time, method string) costModel {
+	n := len(history)
+	switch {
+	case n == 0:
+		return costModel{}
+	case n < 3:
+		return costModel{intercept: Summarize(history).Mean}
+	}
+
+	m := costModel{hasInterval: true}
+	weekdayOf := func(t int) time.Weekday { return start.AddDate(0, 0, t).Weekday() }
+	fitted := func(t int) float64 { return m.intercept + m.slope*float64(t) + m.weekday[weekdayOf(t)] }
+
+	params, rounds := 2, 1
+	if method == ForecastSeasonal {
+		// Alternate between the trend and the weekday effects until they
+		// settle, which converges to a joint least-squares fit
+		params, rounds = 8, 20
+	}
+	adjusted := make([]float64, n)
+	for range rounds {
+		for t, y := range history {
+			adjusted[t] = y - m.weekday[weekdayOf(t)]
+		}
+		m.intercept, m.slope = fitTrend(adjusted)
+		if method != ForecastSeasonal {
+			break
+		}
+
+		var sums [7]float64
+		var counts [7]int
+		for t, y := range history {
+			wd := weekdayOf(t)
+			sums[wd] += y - (m.intercept + m.slope*float64(t))
+			counts[wd]++
+		}
+		for wd := range m.weekday {
+			if counts[wd] > 0 {
+				m.weekday[wd] = sums[wd] / float64(counts[wd])
+			}
+		}
+	}
+
+	var sse float64
+	for t, y := range history {
+		r := y - fitted(t)
+		sse += r * r
+	}
+	m.stddev = math.Sqrt(sse / float64(max(n-params, 1)))
+	return m
+}
+
+// fitTrend fits a least-squares line to values indexed by day.
+func fitTrend(values []float64) (intercept, slope float64) {
+	n := float64(len(values))
+	var tMean, yMean float64
+	for t, y := range values {
+		tMean += float64(t)
+		yMean += y
+	}
+	tMean /= n
+	yMean /= n
+	var sxx, sxy float64
+	for t, y := range values {
+		dt := float64(t) - tMean
+		sxx += dt * dt
+		sxy += dt * (y - yMean)
+	}
+	slope = sxy / sxx
+	return yMean - slope*tMean, slope
+}
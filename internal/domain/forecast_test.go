@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestForecastCost_Linear(t *testing.T) {
+	start := scheduleTime("2026-03-01T00:00:00Z")
+	// A perfect trend: 1, 2, ..., 10
+	history := make([]float64, 10)
+	for i := range history {
+		history[i] = float64(i + 1)
+	}
+	end := scheduleTime("2026-03-14T00:00:00Z")
+
+	f := ForecastCost(history, start, ForecastLinear, 60, 4, end)
+	assertEqual(t, "method", ForecastLinear, f.Method)
+	assertEqual(t, "days", 3, len(f.Days))
+	assertEqual(t, "first day", "2026-03-11", f.Days[0].Date.Format(time.DateOnly))
+	// Today is projected at 11, of which 4 is already spent
+	if !floatEquals(f.Days[0].CostUsd, 7) {
+		t.Errorf("today = %v, want 7", f.Days[0].CostUsd)
+	}
+	if !floatEquals(f.Days[2].CostUsd, 13) {
+		t.Errorf("last day = %v, want 13", f.Days[2].CostUsd)
+	}
+	// 60 spent + 7 + 12 + 13
+	if !floatEquals(f.ProjectedUsd, 92) {
+		t.Errorf("projected = %v, want 92", f.ProjectedUsd)
+	}
+	// No residuals, so the interval collapses onto the projection
+	if !f.HasInterval || !floatEquals(f.LowUsd, f.ProjectedUsd) || !floatEquals(f.HighUsd, f.ProjectedUsd) {
+		t.Errorf("expected a zero-width interval, got %v - %v", f.LowUsd, f.HighUsd)
+	}
+}
+
+func TestForecastCost_Seasonal(t *testing.T) {
+	start := scheduleTime("2026-03-02T00:00:00Z") // Monday
+	// Three weeks of $10 weekdays and $0 weekends
+	history := make([]float64, 21)
+	for i := range history {
+		if wd := start.AddDate(0, 0, i).Weekday(); wd != time.Saturday && wd != time.Sunday {
+			history[i] = 10
+		}
+	}
+	end := scheduleTime("2026-03-30T00:00:00Z")
+
+	f := ForecastCost(history, start, ForecastSeasonal, 0, 0, end)
+	assertEqual(t, "method", ForecastSeasonal, f.Method)
+	for _, d := range f.Days {
+		want := 10.0
+		if d.Date.Weekday() == time.Saturday || d.Date.Weekday() == time.Sunday {
+			want = 0
+		}
+		if math.Abs(d.CostUsd-want) > 1e-6 {
+			t.Errorf("%s = %v, want %v", d.Date.Format("Mon"), d.CostUsd, want)
+		}
+	}
+
+	linear := ForecastCost(history, start, ForecastLinear, 0, 0, end)
+	if linear.HighUsd-linear.LowUsd <= f.HighUsd-f.LowUsd {
+		t.Errorf("expected weekday effects to narrow the interval: linear %v, seasonal %v",
+			linear.HighUsd-linear.LowUsd, f.HighUsd-f.LowUsd)
+	}
+
+	// Too little history falls back to linear
+	short := ForecastCost(history[:7], start, ForecastSeasonal, 0, 0, end)
+	assertEqual(t, "short method", ForecastLinear, short.Method)
+}
+
+func TestForecastCost_SparseHistory(t *testing.T) {
+	start := scheduleTime("2026-03-01T00:00:00Z")
+	end := scheduleTime("2026-03-05T00:00:00Z")
+
+	f := ForecastCost(nil, start, ForecastLinear, 0, 0, end)
+	assertEqual(t, "empty interval", false, f.HasInterval)
+	assertEqual(t, "empty projection", 0.0, f.ProjectedUsd)
+
+	f = ForecastCost([]float64{2, 4}, start, ForecastLinear, 6, 0, end)
+	assertEqual(t, "sparse interval", false, f.HasInterval)
+	// Flat at the mean of 3 for the remaining 2 days
+	if !floatEquals(f.ProjectedUsd, 12) {
+		t.Errorf("projected = %v, want 12", f.ProjectedUsd)
+	}
+}
+
+func TestCostForecast_Exhaustion(t *testing.T) {
+	start := scheduleTime("2026-03-01T00:00:00Z")
+	f := ForecastCost([]float64{5, 5, 5, 5}, start, ForecastLinear, 0, 0, scheduleTime("2026-03-12T00:00:00Z"))
+
+	at, ok := f.Exhaustion(10, 22, scheduleTime("2026-03-12T00:00:00Z"))
+	if !ok {
+		t.Fatal("expected the budget to run out")
+	}
+	// 10 + 5 (Mar 5) + 5 (Mar 6) + 5 (Mar 7) = 25 >= 22
+	assertEqual(t, "exhaustion", "2026-03-07", at.Format(time.DateOnly))
+
+	if _, ok := f.Exhaustion(10, 22, scheduleTime("2026-03-07T00:00:00Z")); ok {
+		t.Error("expected the budget to last until its reset")
+	}
+}
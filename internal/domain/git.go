@@ -0,0 +1,86 @@
+package domain
+
+// GitState is the state of a git working tree at one point in time.
+// RemoteURL is the origin remote without credentials, Branch is empty on a
+// detached HEAD and Commit is empty before the first commit.
+type GitState struct {
+	RepoRoot  string
+	RemoteURL string
+	Branch    string
+	Commit    string
+	Dirty     bool
+}
+
+// SessionGit is the git context of a session's working directory: the
+// repository it ran in and how HEAD moved between the start and the end of
+// the session. The start is unknown (empty commit, nil dirty state) when the
+// SessionStart hook did not capture it.
+type SessionGit struct {
+	RepoRoot     string
+	RemoteURL    string
+	Branch       string
+	StartCommit  string
+	EndCommit    string
+	DirtyAtStart *bool
+	DirtyAtEnd   *bool
+}
+
+// NewSessionGit combines the states captured at the start and the end of a
+// session. The repository and branch are taken from the end, when HEAD was
+// last seen. It returns nil when neither state is known.
+func NewSessionGit(start, end *GitState) *SessionGit {
+	if start == nil && end == nil {
+		return nil
+	}
+	g := &SessionGit{}
+	if start != nil {
+		g.RepoRoot, g.RemoteURL, g.Branch = start.RepoRoot, start.RemoteURL, start.Branch
+		g.StartCommit = start.Commit
+		g.DirtyAtStart = &start.Dirty
+	}
+	if end != nil {
+		g.RepoRoot, g.RemoteURL, g.Branch = end.RepoRoot, end.RemoteURL, end.Branch
+		g.EndCommit = end.Commit
+		g.DirtyAtEnd = &end.Dirty
+	}
+	return g
+}
+
+// Start returns the state captured at the start of the session, or nil when
+// it is unknown.
+func (g *SessionGit) Start() *GitState {
+	if g == nil || g.DirtyAtStart == nil {
+		return nil
+	}
+	return &GitState{
+		RepoRoot:  g.RepoRoot,
+		RemoteURL: g.RemoteURL,
+		Branch:    g.Branch,
+		Commit:    g.StartCommit,
+		Dirty:     *g.DirtyAtStart,
+	}
+}
+
+// HeadMoved reports whether commits were made or checked out during the
+// session.
+func (g *SessionGit) HeadMoved() bool {
+	return g != nil && g.StartCommit != "" && g.EndCommit != "" && g.StartCommit != g.EndCommit
+}
+
+// DiffBase returns the commit to diff the end of the session against, or
+// an empty string when the session did not start from a clean working tree
+// at a known commit, so a diff would include changes made before it.
+func (g *SessionGit) DiffBase() string {
+	if g == nil || g.DirtyAtStart == nil || *g.DirtyAtStart || g.DirtyAtEnd == nil {
+		return ""
+	}
+	return g.StartCommit
+}
+
+// Repository names the repository by its remote, or its root without one.
+func (g *SessionGit) Repository() string {
+	if g.RemoteURL != "" {
+		return g.RemoteURL
+	}
+	return g.RepoRoot
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestNewSessionGit(t *testing.T) {
+	if g := NewSessionGit(nil, nil); g != nil {
+		t.Errorf("expected nil outside a repository, got %+v", g)
+	}
+
+	start := &GitState{RepoRoot: "/repo", Branch: "main", Commit: "aaa", Dirty: true}
+	end := &GitState{RepoRoot: "/repo", RemoteURL: "https://github.com/o/r.git", Branch: "feature", Commit: "bbb"}
+	g := NewSessionGit(start, end)
+	assertEqual(t, "branch", "feature", g.Branch)
+	assertEqual(t, "start commit", "aaa", g.StartCommit)
+	assertEqual(t, "end commit", "bbb", g.EndCommit)
+	assertEqual(t, "repository", "https://github.com/o/r.git", g.Repository())
+	if g.DirtyAtStart == nil || !*g.DirtyAtStart || g.DirtyAtEnd == nil || *g.DirtyAtEnd {
+		t.Errorf("expected dirty at start only, got %v %v", g.DirtyAtStart, g.DirtyAtEnd)
+	}
+	if !g.HeadMoved() {
+		t.Error("expected HEAD to have moved")
+	}
+	if s := g.Start(); s == nil || s.Commit != "aaa" || !s.Dirty {
+		t.Errorf("expected the start state back, got %+v", s)
+	}
+
+	// Without a SessionStart capture the start is unknown
+	g = NewSessionGit(nil, end)
+	if g.Start() != nil || g.HeadMoved() {
+		t.Errorf("expected an unknown start, got %+v", g)
+	}
+	assertEqual(t, "repository without remote", "/repo", NewSessionGit(start, nil).Repository())
+}
+
+func TestSessionGit_DiffBase(t *testing.T) {
+	clean := &GitState{RepoRoot: "/repo", Commit: "aaa"}
+	dirty := &GitState{RepoRoot: "/repo", Commit: "aaa", Dirty: true}
+	end := &GitState{RepoRoot: "/repo", Commit: "bbb", Dirty: true}
+
+	assertEqual(t, "clean start", "aaa", NewSessionGit(clean, end).DiffBase())
+	assertEqual(t, "dirty start", "", NewSessionGit(dirty, end).DiffBase())
+	assertEqual(t, "unknown start", "", NewSessionGit(nil, end).DiffBase())
+	assertEqual(t, "unknown end", "", NewSessionGit(clean, nil).DiffBase())
+	assertEqual(t, "no repository", "", (*SessionGit)(nil).DiffBase())
+}
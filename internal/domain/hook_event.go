@@ -28,6 +28,20 @@ type SessionStartInput struct {
 	AgentType string `json:"agent_type"`
 }
 
+// PreToolUseInput is sent before a tool is used in a session.
+type PreToolUseInput struct {
+	HookEventBase
+	ToolName  string          `json:"tool_name"`
+	ToolInput json.RawMessage `json:"tool_input"`
+	ToolUseID string          `json:"tool_use_id"`
+}
+
+// UserPromptSubmitInput is sent when the user submits a prompt.
+type UserPromptSubmitInput struct {
+	HookEventBase
+	Prompt string `json:"prompt"`
+}
+
 // PostToolUseInput is sent after a tool is used in a session.
 type PostToolUseInput struct {
 	HookEventBase
@@ -85,6 +99,20 @@ func ParseHookEvent(data []byte) (any, error) {
 		}
 		return &event, nil
 
+	case "PreToolUse":
+		var event PreToolUseInput
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("failed to parse PreToolUse event: %w", err)
+		}
+		return &event, nil
+
+	case "UserPromptSubmit":
+		var event UserPromptSubmitInput
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("failed to parse UserPromptSubmit event: %w", err)
+		}
+		return &event, nil
+
 	case "PostToolUse":
 		var event PostToolUseInput
 		if err := json.Unmarshal(data, &event); err != nil {
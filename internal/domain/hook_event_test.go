@@ -97,6 +97,63 @@ func TestParseHookEvent_PostToolUse(t *testing.T) {
 	}
 }
 
+func TestParseHookEvent_PreToolUse(t *testing.T) {
+	input := []byte(`{
+		"session_id": "abc123",
+		"transcript_path": "/tmp/transcript.jsonl",
+		"cwd": "/home/user/project",
+		"permission_mode": "default",
+		"hook_event_name": "PreToolUse",
+		"tool_name": "Write",
+		"tool_input": {"file_path": "main.go"},
+		"tool_use_id": "tool_456"
+	}`)
+
+	event, err := ParseHookEvent(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ptu, ok := event.(*PreToolUseInput)
+	if !ok {
+		t.Fatalf("expected *PreToolUseInput, got %T", event)
+	}
+
+	assertEqual(t, "Cwd", "/home/user/project", ptu.Cwd)
+	assertEqual(t, "HookEventName", "PreToolUse", ptu.HookEventName)
+	assertEqual(t, "ToolName", "Write", ptu.ToolName)
+	assertEqual(t, "ToolUseID", "tool_456", ptu.ToolUseID)
+
+	if ptu.ToolInput == nil {
+		t.Fatal("expected ToolInput to be set")
+	}
+}
+
+func TestParseHookEvent_UserPromptSubmit(t *testing.T) {
+	input := []byte(`{
+		"session_id": "abc123",
+		"transcript_path": "/tmp/transcript.jsonl",
+		"cwd": "/home/user/project",
+		"permission_mode": "default",
+		"hook_event_name": "UserPromptSubmit",
+		"prompt": "Fix the failing test"
+	}`)
+
+	event, err := ParseHookEvent(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ups, ok := event.(*UserPromptSubmitInput)
+	if !ok {
+		t.Fatalf("expected *UserPromptSubmitInput, got %T", event)
+	}
+
+	assertEqual(t, "SessionID", "abc123", ups.SessionID)
+	assertEqual(t, "HookEventName", "UserPromptSubmit", ups.HookEventName)
+	assertEqual(t, "Prompt", "Fix the failing test", ups.Prompt)
+}
+
 func TestParseHookEvent_Stop(t *testing.T) {
 	input := []byte(`{
 		"session_id": "abc123",
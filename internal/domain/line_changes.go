@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// Sources of a session's line counts.
+const (
+	LinesSourceTools = "tools" // Edit, MultiEdit and Write tool inputs
+	LinesSourceGit   = "git"   // git diff since the commit the session started at
+)
+
+// maxDiffCells bounds the table used to diff two texts line by line. Larger
+// edits are counted as replacing every line, which is what they usually are.
+const maxDiffCells = 4_000_000
+
+// CountLineChanges returns the lines added and removed to turn before into
+// after: the lines outside their longest common subsequence.
+func CountLineChanges(before, after string) (added, removed int64) {
+	a, b := splitLines(before), splitLines(after)
+
+	// Lines shared at the start and end need no table
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	if len(a) == 0 || len(b) == 0 || len(a)*len(b) > maxDiffCells {
+		return int64(len(b)), int64(len(a))
+	}
+
+	// Longest common subsequence, one row at a time
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				curr[j+1] = prev[j] + 1
+			case prev[j+1] >= curr[j]:
+				curr[j+1] = prev[j+1]
+			default:
+				curr[j+1] = curr[j]
+			}
+		}
+		prev, curr = curr, prev
+	}
+	common := prev[len(b)]
+	return int64(len(b) - common), int64(len(a) - common)
+}
+
+// splitLines splits text into lines, ignoring a trailing newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// MergeGitFileChanges combines the changes git reports for tracked files
+// with the changes counted from tool inputs for untracked files, which git
+// diff does not see. Both must be keyed by absolute path.
+func MergeGitFileChanges(tracked, fromTools []*SessionFileChange, untracked []string) []*SessionFileChange {
+	isUntracked := make(map[string]bool, len(untracked))
+	for _, path := range untracked {
+		isUntracked[path] = true
+	}
+	merged := append([]*SessionFileChange{}, tracked...)
+	for _, c := range fromTools {
+		if isUntracked[c.FilePath] {
+			merged = append(merged, c)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].FilePath < merged[j].FilePath })
+	return merged
+}
+
+// SumLineChanges returns the lines added and removed across files.
+func SumLineChanges(changes []*SessionFileChange) (added, removed int64) {
+	for _, c := range changes {
+		added += c.LinesAdded
+		removed += c.LinesRemoved
+	}
+	return added, removed
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "testing"
+
+func TestCountLineChanges(t *testing.T) {
+	tests := []struct {
+		name           string
+		before, after  string
+		added, removed int64
+	}{
+		{"empty", "", "", 0, 0},
+		{"new file", "", "a\nb\n", 2, 0},
+		{"deleted", "a\nb", "", 0, 2},
+		{"unchanged", "a\nb\n", "a\nb", 0, 0},
+		{"one line replaced", "a\nb\nc", "a\nB\nc", 1, 1},
+		{"insertion", "a\nc", "a\nb\nc", 1, 0},
+		{"moved line", "a\nb\nc", "b\nc\na", 1, 1},
+	}
+	for _, tt := range tests {
+		added, removed := CountLineChanges(tt.before, tt.after)
+		if added != tt.added || removed != tt.removed {
+			t.Errorf("%s: expected +%d -%d, got +%d -%d", tt.name, tt.added, tt.removed, added, removed)
+		}
+	}
+}
+
+func TestMergeGitFileChanges(t *testing.T) {
+	tracked := []*SessionFileChange{{FilePath: "/r/b.go", LinesAdded: 10, LinesRemoved: 2}}
+	fromTools := []*SessionFileChange{
+		{FilePath: "/r/b.go", LinesAdded: 3},
+		{FilePath: "/r/a.go", LinesAdded: 5},
+		{FilePath: "/r/ignored.log", LinesAdded: 7},
+	}
+
+	merged := MergeGitFileChanges(tracked, fromTools, []string{"/r/a.go"})
+	if len(merged) != 2 || merged[0].FilePath != "/r/a.go" || merged[1].LinesAdded != 10 {
+		t.Fatalf("expected git's b.go and the untracked a.go, got %+v", merged)
+	}
+	added, removed := SumLineChanges(merged)
+	assertEqual(t, "added", int64(15), added)
+	assertEqual(t, "removed", int64(2), removed)
+}
@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// MCPToolPrefix starts the names of the tools MCP servers provide, as in
+// "mcp__github__create_issue".
+const MCPToolPrefix = "mcp__"
+
+// BuiltinToolServer groups the tools no MCP server provides.
+const BuiltinToolServer = "built-in"
+
+// ParseMCPToolName splits an MCP tool name into its server and tool. ok is
+// false for built-in tools.
+func ParseMCPToolName(name string) (server, tool string, ok bool) {
+	rest, found := strings.CutPrefix(name, MCPToolPrefix)
+	if !found {
+		return "", "", false
+	}
+	server, tool, found = strings.Cut(rest, "__")
+	if !found || server == "" || tool == "" {
+		return "", "", false
+	}
+	return server, tool, true
+}
+
+// ToolServer returns the MCP server that provides a tool, or
+// BuiltinToolServer.
+func ToolServer(toolName string) string {
+	if server, _, ok := ParseMCPToolName(toolName); ok {
+		return server
+	}
+	return BuiltinToolServer
+}
+
+// bytesPerToken approximates how many bytes of tool result text make up a
+// token of context.
+const bytesPerToken = 4
+
+// EstimateResultTokens estimates the context tokens of a tool result of the
+// given size.
+func EstimateResultTokens(bytes int64) int64 {
+	return (bytes + bytesPerToken - 1) / bytesPerToken
+}
+
+// Server returns the MCP server that provides the tool, or
+// BuiltinToolServer.
+func (t ToolUsageStats) Server() string {
+	return ToolServer(t.ToolName)
+}
+
+// ToolServerUsage aggregates the usage of the tools of a server.
+type ToolServerUsage struct {
+	Server           string
+	Tools            int64
+	TotalInvocations int64
+	TotalErrors      int64
+}
+
+// GroupToolUsageByServer sums tool usage by the server that provides each
+// tool, most invoked first.
+func GroupToolUsageByServer(tools []ToolUsageStats) []ToolServerUsage {
+	byServer := make(map[string]*ToolServerUsage)
+	for _, t := range tools {
+		server := t.Server()
+		s, ok := byServer[server]
+		if !ok {
+			s = &ToolServerUsage{Server: server}
+			byServer[server] = s
+		}
+		s.Tools++
+		s.TotalInvocations += t.TotalInvocations
+		s.TotalErrors += t.TotalErrors
+	}
+
+	result := make([]ToolServerUsage, 0, len(byServer))
+	for _, s := range byServer {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalInvocations != result[j].TotalInvocations {
+			return result[i].TotalInvocations > result[j].TotalInvocations
+		}
+		return result[i].Server < result[j].Server
+	})
+	return result
+}
+
+// MCPToolStats aggregates the calls of one tool of an MCP server.
+type MCPToolStats struct {
+	Tool        string
+	Calls       int64
+	Errors      int64
+	ResultBytes int64
+}
+
+// MCPServerStats aggregates the calls of the tools of an MCP server.
+type MCPServerStats struct {
+	Server      string
+	Calls       int64
+	Errors      int64
+	Sessions    int64
+	ResultBytes int64
+	Tools       []MCPToolStats // Most called first
+}
+
+// ErrorRate returns the share of the server's calls that failed.
+func (s MCPServerStats) ErrorRate() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Calls)
+}
+
+// ResultTokens estimates the context tokens the server's results took.
+func (s MCPServerStats) ResultTokens() int64 {
+	return EstimateResultTokens(s.ResultBytes)
+}
+
+// TokensPerCall estimates the context tokens of an average result.
+func (s MCPServerStats) TokensPerCall() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.ResultTokens()) / float64(s.Calls)
+}
+
+// MCPBreakdown attributes tool calls and the size of their results to MCP
+// servers, against the totals of all tools, built-in ones included.
+type MCPBreakdown struct {
+	Sessions    int64 // Sessions with tool calls
+	Calls       int64
+	ResultBytes int64
+	Servers     []MCPServerStats // Most called first
+}
+
+// MCPCalls returns the calls of MCP tools.
+func (b MCPBreakdown) MCPCalls() int64 {
+	var calls int64
+	for _, s := range b.Servers {
+		calls += s.Calls
+	}
+	return calls
+}
+
+// CallShare returns the share of all tool calls a server's tools made.
+func (b MCPBreakdown) CallShare(s MCPServerStats) float64 {
+	if b.Calls == 0 {
+		return 0
+	}
+	return float64(s.Calls) / float64(b.Calls)
+}
+
+// ResultShare returns the share of all tool result bytes a server's results
+// took. A server whose result share is well above its call share fills the
+// context more than it is used.
+func (b MCPBreakdown) ResultShare(s MCPServerStats) float64 {
+	if b.ResultBytes == 0 {
+		return 0
+	}
+	return float64(s.ResultBytes) / float64(b.ResultBytes)
+}
+
+// SessionShare returns the share of sessions with tool calls that used a
+// server.
+func (b MCPBreakdown) SessionShare(s MCPServerStats) float64 {
+	if b.Sessions == 0 {
+		return 0
+	}
+	return float64(s.Sessions) / float64(b.Sessions)
+}
+
+// AggregateMCP sums the calls, errors and result sizes of the MCP tools in
+// the sequences by server and tool.
+func AggregateMCP(sequences []ToolSequence) MCPBreakdown {
+	var b MCPBreakdown
+	servers := make(map[string]*MCPServerStats)
+	tools := make(map[string]map[string]*MCPToolStats)
+	for _, seq := range sequences {
+		if len(seq.Calls) == 0 {
+			continue
+		}
+		b.Sessions++
+		used := make(map[string]bool)
+		for _, c := range seq.Calls {
+			b.Calls++
+			b.ResultBytes += c.ResultBytes
+			server, tool, ok := ParseMCPToolName(c.ToolName)
+			if !ok {
+				continue
+			}
+			s, found := servers[server]
+			if !found {
+				s = &MCPServerStats{Server: server}
+				servers[server] = s
+				tools[server] = make(map[string]*MCPToolStats)
+			}
+			t, found := tools[server][tool]
+			if !found {
+				t = &MCPToolStats{Tool: tool}
+				tools[server][tool] = t
+			}
+			s.Calls++
+			t.Calls++
+			s.ResultBytes += c.ResultBytes
+			t.ResultBytes += c.ResultBytes
+			if c.IsError {
+				s.Errors++
+				t.Errors++
+			}
+			if !used[server] {
+				used[server] = true
+				s.Sessions++
+			}
+		}
+	}
+
+	for server, s := range servers {
+		for _, t := range tools[server] {
+			s.Tools = append(s.Tools, *t)
+		}
+		sort.Slice(s.Tools, func(i, j int) bool {
+			if s.Tools[i].Calls != s.Tools[j].Calls {
+				return s.Tools[i].Calls > s.Tools[j].Calls
+			}
+			return s.Tools[i].Tool < s.Tools[j].Tool
+		})
+		b.Servers = append(b.Servers, *s)
+	}
+	sort.Slice(b.Servers, func(i, j int) bool {
+		if b.Servers[i].Calls != b.Servers[j].Calls {
+			return b.Servers[i].Calls > b.Servers[j].Calls
+		}
+		return b.Servers[i].Server < b.Servers[j].Server
+	})
+	return b
+}
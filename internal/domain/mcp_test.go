@@ -0,0 +1,72 @@
+package domain
+
+import "testing"
+
+func TestParseMCPToolName(t *testing.T) {
+	server, tool, ok := ParseMCPToolName("mcp__github__create_issue")
+	if !ok {
+		t.Fatal("expected an MCP tool")
+	}
+	assertEqual(t, "server", "github", server)
+	assertEqual(t, "tool", "create_issue", tool)
+
+	server, tool, _ = ParseMCPToolName("mcp__claude_ai_Linear__list__issues")
+	assertEqual(t, "server with underscores", "claude_ai_Linear", server)
+	assertEqual(t, "tool with separator", "list__issues", tool)
+
+	for _, name := range []string{"Bash", "mcp__github", "mcp____tool"} {
+		if _, _, ok := ParseMCPToolName(name); ok {
+			t.Errorf("expected %q not to be an MCP tool", name)
+		}
+	}
+	assertEqual(t, "built-in server", BuiltinToolServer, ToolServer("Read"))
+}
+
+func TestGroupToolUsageByServer(t *testing.T) {
+	groups := GroupToolUsageByServer([]ToolUsageStats{
+		{ToolName: "Read", TotalInvocations: 10},
+		{ToolName: "mcp__github__get_pr", TotalInvocations: 8, TotalErrors: 1},
+		{ToolName: "mcp__github__list_prs", TotalInvocations: 7, TotalErrors: 2},
+		{ToolName: "Bash", TotalInvocations: 3},
+	})
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 servers, got %+v", groups)
+	}
+	assertEqual(t, "first server", "github", groups[0].Server)
+	assertEqual(t, "tools", int64(2), groups[0].Tools)
+	assertEqual(t, "invocations", int64(15), groups[0].TotalInvocations)
+	assertEqual(t, "errors", int64(3), groups[0].TotalErrors)
+	assertEqual(t, "built-in invocations", int64(13), groups[1].TotalInvocations)
+}
+
+func TestAggregateMCP(t *testing.T) {
+	b := AggregateMCP([]ToolSequence{
+		{SessionID: "s1", Calls: []SessionToolCall{
+			{ToolName: "Read", ResultBytes: 400},
+			{ToolName: "mcp__docs__search", ResultBytes: 3000},
+			{ToolName: "mcp__docs__search", ResultBytes: 1000, IsError: true},
+		}},
+		{SessionID: "s2", Calls: []SessionToolCall{
+			{ToolName: "mcp__docs__fetch", ResultBytes: 600},
+			{ToolName: "mcp__git__status", ResultBytes: 200},
+		}},
+	})
+	assertEqual(t, "sessions", int64(2), b.Sessions)
+	assertEqual(t, "calls", int64(5), b.Calls)
+	assertEqual(t, "MCP calls", int64(4), b.MCPCalls())
+	if len(b.Servers) != 2 {
+		t.Fatalf("expected 2 servers, got %+v", b.Servers)
+	}
+
+	docs := b.Servers[0]
+	assertEqual(t, "server", "docs", docs.Server)
+	assertEqual(t, "server calls", int64(3), docs.Calls)
+	assertEqual(t, "server sessions", int64(2), docs.Sessions)
+	assertEqual(t, "result tokens", int64(1150), docs.ResultTokens())
+	assertEqual(t, "top tool", "search", docs.Tools[0].Tool)
+	assertEqual(t, "top tool errors", int64(1), docs.Tools[0].Errors)
+	assertFloatNear(t, "error rate", 1.0/3, docs.ErrorRate())
+	assertFloatNear(t, "call share", 0.6, b.CallShare(docs))
+	assertFloatNear(t, "result share", 4600.0/5200, b.ResultShare(docs))
+	assertFloatNear(t, "session share", 1, b.SessionShare(docs))
+}
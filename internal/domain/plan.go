@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Subscription plans. Usage limits scale with the plan's multiplier.
+const (
+	PlanPro    = "pro"
+	PlanMax5x  = "max_5x"
+	PlanMax20x = "max_20x"
+)
+
+// Rate-limit windows.
+const (
+	WindowSession = "5h"
+	WindowWeekly  = "weekly"
+)
+
+// Window lengths of subscription usage limits.
+const (
+	SessionWindowLength = 5 * time.Hour
+	WeeklyWindowLength  = 7 * 24 * time.Hour
+)
+
+// SessionWindowLookback bounds how far before a point in time requests are
+// considered when rebuilding the 5-hour window that contains it.
+const SessionWindowLookback = 24 * time.Hour
+
+var planMultipliers = map[string]float64{
+	PlanPro:    1,
+	PlanMax5x:  5,
+	PlanMax20x: 20,
+}
+
+// IsValidPlanType reports whether plan is a supported subscription plan.
+func IsValidPlanType(plan string) bool {
+	_, ok := planMultipliers[plan]
+	return ok
+}
+
+// PlanConfig is the subscription plan usage windows are tracked against.
+// WeeklyResetAt is any known weekly reset time; when nil, the weekly window
+// is the rolling last seven days.
+type PlanConfig struct {
+	PlanType      string
+	WeeklyResetAt *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// UsageRequest is the token usage of a single API request within a session.
+type UsageRequest struct {
+	SessionID       string
+	Timestamp       time.Time
+	Model           *string
+	TokenInput      int64
+	TokenOutput     int64
+	TokenCacheRead  int64
+	TokenCacheWrite int64
+}
+
+// WindowTokens returns the tokens the request counts toward usage limits.
+// Cache reads are excluded: they are a small fraction of the cost and would
+// otherwise dominate the totals.
+func (r UsageRequest) WindowTokens() int64 {
+	return r.TokenInput + r.TokenOutput + r.TokenCacheWrite
+}
+
+// RateLimitHit records a session running into a usage limit.
+type RateLimitHit struct {
+	SessionID string
+	HitAt     time.Time
+	Window    string
+	PlanType  *string
+}
+
+// UsageWindow aggregates the requests made within a rate-limit window.
+type UsageWindow struct {
+	Start    time.Time
+	End      time.Time
+	Tokens   int64
+	Requests int64
+}
+
+// SessionWindows groups requests into consecutive 5-hour windows. A window
+// opens at the hour of the first request made after the previous window
+// closed. Requests must be sorted by timestamp.
+func SessionWindows(requests []UsageRequest) []UsageWindow {
+	windows := []UsageWindow{}
+	for _, r := range requests {
+		n := len(windows)
+		if n == 0 || !r.Timestamp.Before(windows[n-1].End) {
+			start := r.Timestamp.Truncate(time.Hour)
+			windows = append(windows, UsageWindow{Start: start, End: start.Add(SessionWindowLength)})
+			n++
+		}
+		windows[n-1].Tokens += r.WindowTokens()
+		windows[n-1].Requests++
+	}
+	return windows
+}
+
+// WeeklyBounds returns the weekly window containing t. With a known reset
+// time windows repeat every seven days from it; otherwise the window is the
+// seven days ending at t.
+func WeeklyBounds(resetAt *time.Time, t time.Time) (time.Time, time.Time) {
+	if resetAt == nil {
+		return t.Add(-WeeklyWindowLength), t
+	}
+	periods := t.Sub(*resetAt) / WeeklyWindowLength
+	start := resetAt.Add(periods * WeeklyWindowLength)
+	if start.After(t) {
+		start = start.Add(-WeeklyWindowLength)
+	}
+	return start, start.Add(WeeklyWindowLength)
+}
+
+// WindowUsage is the usage of the current window against its limit.
+// Limit is zero when no limit has been learned yet.
+type WindowUsage struct {
+	Start        time.Time
+	End          time.Time
+	Active       bool
+	Tokens       int64
+	Limit        int64
+	LimitSamples int
+}
+
+// UsedFraction returns usage as a fraction of the learned limit, or 0 when
+// the limit is unknown.
+func (w WindowUsage) UsedFraction() float64 {
+	if w.Limit <= 0 {
+		return 0
+	}
+	return float64(w.Tokens) / float64(w.Limit)
+}
+
+// PlanUsage is the usage of the current 5-hour and weekly windows.
+type PlanUsage struct {
+	Plan    *PlanConfig
+	Session WindowUsage
+	Weekly  WindowUsage
+}
+
+// CurrentSessionWindow returns the 5-hour window active at now. Requests
+// must be sorted and cover at least SessionWindowLookback before now.
+func CurrentSessionWindow(requests []UsageRequest, now time.Time) WindowUsage {
+	var past []UsageRequest
+	for _, r := range requests {
+		if r.Timestamp.After(now) {
+			break
+		}
+		past = append(past, r)
+	}
+	windows := SessionWindows(past)
+	if n := len(windows); n > 0 && now.Before(windows[n-1].End) {
+		w := windows[n-1]
+		return WindowUsage{Start: w.Start, End: w.End, Active: true, Tokens: w.Tokens}
+	}
+	return WindowUsage{}
+}
+
+// CurrentWeeklyWindow returns the weekly window containing now.
+func CurrentWeeklyWindow(requests []UsageRequest, resetAt *time.Time, now time.Time) WindowUsage {
+	start, end := WeeklyBounds(resetAt, now)
+	w := WindowUsage{Start: start, End: end, Active: true}
+	for _, r := range requests {
+		if !r.Timestamp.Before(start) && !r.Timestamp.After(now) {
+			w.Tokens += r.WindowTokens()
+		}
+	}
+	return w
+}
+
+// TokensAtHit returns the tokens used in the window that contained the hit,
+// up to the moment of the hit. Requests must be sorted and cover the
+// lookback before the hit (SessionWindowLookback or WeeklyWindowLength).
+func TokensAtHit(hit RateLimitHit, requests []UsageRequest, resetAt *time.Time) int64 {
+	if hit.Window == WindowWeekly {
+		return CurrentWeeklyWindow(requests, resetAt, hit.HitAt).Tokens
+	}
+	return CurrentSessionWindow(requests, hit.HitAt).Tokens
+}
+
+// LearnLimit estimates a window's token limit for plan from the usage
+// observed when limits were hit: the median of the samples, with samples
+// from other plans scaled by the ratio of plan multipliers. Returns 0 when
+// there are no samples.
+func LearnLimit(plan string, samples []float64, samplePlans []string) int64 {
+	target, ok := planMultipliers[plan]
+	if !ok || len(samples) == 0 {
+		return 0
+	}
+	var same, scaled []float64
+	for i, s := range samples {
+		if samplePlans[i] == plan {
+			same = append(same, s)
+			continue
+		}
+		if m, ok := planMultipliers[samplePlans[i]]; ok {
+			scaled = append(scaled, s*target/m)
+		}
+	}
+	// Samples from the configured plan are authoritative
+	if len(same) == 0 {
+		same = scaled
+	}
+	if len(same) == 0 {
+		return 0
+	}
+	slices.Sort(same)
+	mid := len(same) / 2
+	if len(same)%2 == 0 {
+		return int64((same[mid-1] + same[mid]) / 2)
+	}
+	return int64(same[mid])
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func usageAt(ts string, tokens int64) UsageRequest {
+	return UsageRequest{Timestamp: scheduleTime(ts), TokenInput: tokens}
+}
+
+func TestSessionWindows(t *testing.T) {
+	requests := []UsageRequest{
+		usageAt("2026-03-05T09:20:00Z", 100),
+		usageAt("2026-03-05T13:59:00Z", 50),
+		// Opens a new window at the hour of the first request after 14:00
+		usageAt("2026-03-05T14:10:00Z", 30),
+		usageAt("2026-03-05T22:45:00Z", 5),
+	}
+
+	windows := SessionWindows(requests)
+	if len(windows) != 3 {
+		t.Fatalf("expected 3 windows, got %d", len(windows))
+	}
+	assertEqual(t, "w0 start", "2026-03-05T09:00:00Z", windows[0].Start.Format(time.RFC3339))
+	assertEqual(t, "w0 end", "2026-03-05T14:00:00Z", windows[0].End.Format(time.RFC3339))
+	assertEqual(t, "w0 tokens", int64(150), windows[0].Tokens)
+	assertEqual(t, "w0 requests", int64(2), windows[0].Requests)
+	assertEqual(t, "w1 start", "2026-03-05T14:00:00Z", windows[1].Start.Format(time.RFC3339))
+	assertEqual(t, "w1 tokens", int64(30), windows[1].Tokens)
+	assertEqual(t, "w2 start", "2026-03-05T22:00:00Z", windows[2].Start.Format(time.RFC3339))
+}
+
+func TestCurrentSessionWindow(t *testing.T) {
+	requests := []UsageRequest{
+		usageAt("2026-03-05T09:20:00Z", 100),
+		usageAt("2026-03-05T10:00:00Z", 50),
+	}
+
+	w := CurrentSessionWindow(requests, scheduleTime("2026-03-05T11:00:00Z"))
+	assertEqual(t, "active", true, w.Active)
+	assertEqual(t, "tokens", int64(150), w.Tokens)
+
+	// Requests after now are ignored
+	w = CurrentSessionWindow(requests, scheduleTime("2026-03-05T09:30:00Z"))
+	assertEqual(t, "tokens before second request", int64(100), w.Tokens)
+
+	w = CurrentSessionWindow(requests, scheduleTime("2026-03-05T14:00:00Z"))
+	assertEqual(t, "expired window", false, w.Active)
+}
+
+func TestWeeklyBounds(t *testing.T) {
+	now := scheduleTime("2026-03-05T15:00:00Z")
+
+	start, end := WeeklyBounds(nil, now)
+	assertEqual(t, "rolling start", "2026-02-26T15:00:00Z", start.Format(time.RFC3339))
+	assertEqual(t, "rolling end", "2026-03-05T15:00:00Z", end.Format(time.RFC3339))
+
+	for _, reset := range []string{"2026-03-02T09:00:00Z", "2026-01-05T09:00:00Z", "2026-04-06T09:00:00Z"} {
+		r := scheduleTime(reset)
+		start, end = WeeklyBounds(&r, now)
+		assertEqual(t, reset+" start", "2026-03-02T09:00:00Z", start.Format(time.RFC3339))
+		assertEqual(t, reset+" end", "2026-03-09T09:00:00Z", end.Format(time.RFC3339))
+	}
+}
+
+func TestTokensAtHit(t *testing.T) {
+	requests := []UsageRequest{
+		usageAt("2026-03-01T10:00:00Z", 1000),
+		usageAt("2026-03-05T09:20:00Z", 100),
+		usageAt("2026-03-05T10:00:00Z", 50),
+		usageAt("2026-03-05T12:00:00Z", 25),
+	}
+
+	hit := RateLimitHit{HitAt: scheduleTime("2026-03-05T11:00:00Z"), Window: WindowSession}
+	assertEqual(t, "session hit", int64(150), TokensAtHit(hit, requests, nil))
+
+	hit.Window = WindowWeekly
+	assertEqual(t, "weekly hit", int64(1150), TokensAtHit(hit, requests, nil))
+}
+
+func TestLearnLimit(t *testing.T) {
+	assertEqual(t, "no samples", int64(0), LearnLimit(PlanPro, nil, nil))
+	assertEqual(t, "unknown plan", int64(0), LearnLimit("free", []float64{10}, []string{PlanPro}))
+
+	// Median of samples from the same plan
+	assertEqual(t, "odd median", int64(200),
+		LearnLimit(PlanPro, []float64{300, 100, 200}, []string{PlanPro, PlanPro, PlanPro}))
+	assertEqual(t, "even median", int64(150),
+		LearnLimit(PlanPro, []float64{100, 200}, []string{PlanPro, PlanPro}))
+
+	// Same-plan samples take precedence over scaled ones
+	assertEqual(t, "same plan wins", int64(100),
+		LearnLimit(PlanPro, []float64{100, 5000}, []string{PlanPro, PlanMax5x}))
+
+	// Samples from another plan are scaled by the multiplier ratio
+	assertEqual(t, "scaled up", int64(4000),
+		LearnLimit(PlanMax20x, []float64{1000}, []string{PlanMax5x}))
+}
+
+func TestWindowUsage_UsedFraction(t *testing.T) {
+	assertEqual(t, "no limit", 0.0, WindowUsage{Tokens: 50}.UsedFraction())
+	assertEqual(t, "half", 0.5, WindowUsage{Tokens: 50, Limit: 100}.UsedFraction())
+}
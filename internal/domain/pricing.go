@@ -8,26 +8,106 @@ type ModelPricing struct {
 	InputPerMillion             float64
 	OutputPerMillion            float64
 	CacheReadPerMillion         *float64
-	CacheWritePerMillion        *float64
+	CacheWritePerMillion        *float64 // 5-minute cache tier
+	CacheWrite1hPerMillion      *float64 // 1-hour cache tier
 	LongContextInputPerMillion  *float64 // Premium pricing for >threshold input tokens
 	LongContextOutputPerMillion *float64
-	LongContextThreshold        *int64 // Input token threshold (default 200K)
+	LongContextThreshold        *int64   // Input token threshold (default 200K)
+	WebSearchPerThousand        *float64 // Server-side tool rates, per 1,000 requests
+	WebFetchPerThousand         *float64
 	IsDefault                   bool
 	CreatedAt                   time.Time
 }
 
+// ResolveModelAlias maps short model aliases to full model IDs for pricing lookup.
+func ResolveModelAlias(alias string) string {
+	aliases := map[string]string{
+		"haiku":  "claude-haiku-4-5-20251001",
+		"sonnet": "claude-sonnet-4-5-20250929",
+		"opus":   "claude-opus-4-6-20260206",
+	}
+	if id, ok := aliases[alias]; ok {
+		return id
+	}
+	return alias
+}
+
+// TokenUsage is a set of token counts as priced by ModelPricing, with the
+// server-side tool requests billed alongside them. CacheWrite counts both
+// cache tiers; CacheWrite1h is the part of it written to the 1-hour cache.
+type TokenUsage struct {
+	Input        int64
+	Output       int64
+	CacheRead    int64
+	CacheWrite   int64
+	CacheWrite1h int64
+	ServerTools  ServerToolUsage
+}
+
+// TotalInput returns the input tokens including cache reads and writes.
+func (u TokenUsage) TotalInput() int64 {
+	return u.Input + u.CacheRead + u.CacheWrite
+}
+
+// Total returns the input, cache and output tokens.
+func (u TokenUsage) Total() int64 {
+	return u.TotalInput() + u.Output
+}
+
+// Add returns the sum of both usages.
+func (u TokenUsage) Add(o TokenUsage) TokenUsage {
+	return TokenUsage{
+		Input:        u.Input + o.Input,
+		Output:       u.Output + o.Output,
+		CacheRead:    u.CacheRead + o.CacheRead,
+		CacheWrite:   u.CacheWrite + o.CacheWrite,
+		CacheWrite1h: u.CacheWrite1h + o.CacheWrite1h,
+		ServerTools: ServerToolUsage{
+			WebSearchRequests: u.ServerTools.WebSearchRequests + o.ServerTools.WebSearchRequests,
+			WebFetchRequests:  u.ServerTools.WebFetchRequests + o.ServerTools.WebFetchRequests,
+		},
+	}
+}
+
+// Sub returns the usage left after removing o, never below zero. Server tool
+// requests are kept.
+func (u TokenUsage) Sub(o TokenUsage) TokenUsage {
+	return TokenUsage{
+		Input:        max(u.Input-o.Input, 0),
+		Output:       max(u.Output-o.Output, 0),
+		CacheRead:    max(u.CacheRead-o.CacheRead, 0),
+		CacheWrite:   max(u.CacheWrite-o.CacheWrite, 0),
+		CacheWrite1h: max(u.CacheWrite1h-o.CacheWrite1h, 0),
+		ServerTools:  u.ServerTools,
+	}
+}
+
+// DefaultWebSearchPerThousand is Anthropic's list price for server-side web
+// search, in USD per 1,000 searches.
+const DefaultWebSearchPerThousand = 10.00
+
+// ServerToolUsage counts the requests made to server-side tools, which are
+// billed per request on top of tokens.
+type ServerToolUsage struct {
+	WebSearchRequests int64
+	WebFetchRequests  int64
+}
+
 // EffectiveRates holds the resolved per-million-token rates after applying
 // long-context thresholds and deriving cache rates.
 type EffectiveRates struct {
-	Input      float64
-	Output     float64
-	CacheRead  *float64
-	CacheWrite *float64
+	Input        float64
+	Output       float64
+	CacheRead    *float64
+	CacheWrite   *float64
+	CacheWrite1h *float64
 }
 
 // ResolveRates determines the effective per-million-token rates given the
 // token counts. Long-context thresholds and derived cache rates (0.1x read,
-// 1.25x write) are applied here so callers can store the resolved rates.
+// 1.25x 5-minute write, 2x 1-hour write) are applied here so callers can
+// store the resolved rates. Without a 1-hour rate, 1-hour cache writes are
+// priced at twice the input rate, as long as the model has a cache write rate.
 func (p *ModelPricing) ResolveRates(input, output, cacheRead, cacheWrite int64) EffectiveRates {
 	totalInputTokens := input + cacheRead + cacheWrite
 
@@ -59,21 +139,72 @@ func (p *ModelPricing) ResolveRates(input, output, cacheRead, cacheWrite int64)
 		}
 		rates.CacheWrite = &cw
 	}
+	switch {
+	case p.CacheWrite1hPerMillion != nil:
+		cw := *p.CacheWrite1hPerMillion
+		if useLongContext {
+			cw = rates.Input * 2
+		}
+		rates.CacheWrite1h = &cw
+	case rates.CacheWrite != nil:
+		cw := rates.Input * 2
+		rates.CacheWrite1h = &cw
+	}
 
 	return rates
 }
 
-func (p *ModelPricing) CalculateCost(input, output, cacheRead, cacheWrite int64) float64 {
-	rates := p.ResolveRates(input, output, cacheRead, cacheWrite)
+// CalculateCost returns the cost of the usage in USD.
+func (p *ModelPricing) CalculateCost(u TokenUsage) float64 {
+	rates := p.ResolveRates(u.Input, u.Output, u.CacheRead, u.CacheWrite)
 
-	cost := float64(input) * rates.Input / 1_000_000
-	cost += float64(output) * rates.Output / 1_000_000
+	cost := float64(u.Input) * rates.Input / 1_000_000
+	cost += float64(u.Output) * rates.Output / 1_000_000
 	if rates.CacheRead != nil {
-		cost += float64(cacheRead) * *rates.CacheRead / 1_000_000
+		cost += float64(u.CacheRead) * *rates.CacheRead / 1_000_000
 	}
 	if rates.CacheWrite != nil {
-		cost += float64(cacheWrite) * *rates.CacheWrite / 1_000_000
+		cost += float64(u.CacheWrite-u.CacheWrite1h) * *rates.CacheWrite / 1_000_000
 	}
+	if rates.CacheWrite1h != nil {
+		cost += float64(u.CacheWrite1h) * *rates.CacheWrite1h / 1_000_000
+	}
+
+	return cost + p.ServerToolCost(u.ServerTools)
+}
 
+// ServerToolCost returns the per-request charges for server-side tool use.
+// Tools without a rate are free.
+func (p *ModelPricing) ServerToolCost(tools ServerToolUsage) float64 {
+	var cost float64
+	if p.WebSearchPerThousand != nil {
+		cost += float64(tools.WebSearchRequests) * *p.WebSearchPerThousand / 1_000
+	}
+	if p.WebFetchPerThousand != nil {
+		cost += float64(tools.WebFetchRequests) * *p.WebFetchPerThousand / 1_000
+	}
 	return cost
 }
+
+// Scale returns a copy of the pricing with every rate multiplied by factor.
+func (p *ModelPricing) Scale(factor float64) *ModelPricing {
+	scaled := *p
+	scaled.InputPerMillion *= factor
+	scaled.OutputPerMillion *= factor
+	scaled.CacheReadPerMillion = scaleRate(p.CacheReadPerMillion, factor)
+	scaled.CacheWritePerMillion = scaleRate(p.CacheWritePerMillion, factor)
+	scaled.CacheWrite1hPerMillion = scaleRate(p.CacheWrite1hPerMillion, factor)
+	scaled.LongContextInputPerMillion = scaleRate(p.LongContextInputPerMillion, factor)
+	scaled.LongContextOutputPerMillion = scaleRate(p.LongContextOutputPerMillion, factor)
+	scaled.WebSearchPerThousand = scaleRate(p.WebSearchPerThousand, factor)
+	scaled.WebFetchPerThousand = scaleRate(p.WebFetchPerThousand, factor)
+	return &scaled
+}
+
+func scaleRate(rate *float64, factor float64) *float64 {
+	if rate == nil {
+		return nil
+	}
+	scaled := *rate * factor
+	return &scaled
+}
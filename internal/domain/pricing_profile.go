@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// DefaultPricingProfile is the profile at Anthropic API list prices.
+const DefaultPricingProfile = "anthropic-api"
+
+// PricingProfile adjusts the model pricing catalog to what is actually paid
+// through a provider or contract. Rates replace the catalog pricing of
+// specific models (ID is the model ID); every other model is priced at its
+// catalog rates times Multiplier.
+type PricingProfile struct {
+	Name        string
+	Description *string
+	Multiplier  float64
+	IsDefault   bool
+	Rates       []*ModelPricing
+	CreatedAt   time.Time
+}
+
+// Rate returns the profile's own pricing of a model, or nil.
+func (p *PricingProfile) Rate(modelID string) *ModelPricing {
+	if p == nil {
+		return nil
+	}
+	for _, r := range p.Rates {
+		if r.ID == modelID {
+			return r
+		}
+	}
+	return nil
+}
+
+// Apply returns the pricing of a catalog model under the profile. A nil
+// profile or pricing is returned unchanged.
+func (p *PricingProfile) Apply(pricing *ModelPricing) *ModelPricing {
+	if p == nil || pricing == nil {
+		return pricing
+	}
+	if r := p.Rate(pricing.ID); r != nil {
+		return r
+	}
+	if p.Multiplier == 1 {
+		return pricing
+	}
+	return pricing.Scale(p.Multiplier)
+}
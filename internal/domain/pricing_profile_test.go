@@ -0,0 +1,33 @@
+package domain
+
+import "testing"
+
+func TestPricingProfile_Apply(t *testing.T) {
+	cacheRead := 0.5
+	opus := &ModelPricing{ID: "opus", InputPerMillion: 5, OutputPerMillion: 25, CacheReadPerMillion: &cacheRead}
+	sonnet := &ModelPricing{ID: "sonnet", InputPerMillion: 3, OutputPerMillion: 15}
+	negotiated := &ModelPricing{ID: "sonnet", InputPerMillion: 2, OutputPerMillion: 10}
+
+	profile := &PricingProfile{Name: "enterprise", Multiplier: 0.8, Rates: []*ModelPricing{negotiated}}
+
+	scaled := profile.Apply(opus)
+	assertEqual(t, "input", 4.0, scaled.InputPerMillion)
+	assertEqual(t, "output", 20.0, scaled.OutputPerMillion)
+	assertEqual(t, "cache read", 0.4, *scaled.CacheReadPerMillion)
+	// The catalog pricing is left untouched
+	assertEqual(t, "catalog input", 5.0, opus.InputPerMillion)
+	assertEqual(t, "catalog cache read", 0.5, *opus.CacheReadPerMillion)
+
+	// Model rates replace the catalog pricing instead of scaling it
+	if got := profile.Apply(sonnet); got != negotiated {
+		t.Errorf("expected the profile's sonnet rates, got %+v", got)
+	}
+
+	var none *PricingProfile
+	if got := none.Apply(opus); got != opus {
+		t.Errorf("expected no profile to keep the catalog pricing, got %+v", got)
+	}
+	if got := (&PricingProfile{Multiplier: 1}).Apply(opus); got != opus {
+		t.Errorf("expected a 1x profile to keep the catalog pricing, got %+v", got)
+	}
+}
@@ -14,7 +14,7 @@ func TestModelPricing_CalculateCost_StandardPricing(t *testing.T) {
 	}
 
 	// 1000 input, 500 output = $0.003 + $0.0075 = $0.0105
-	cost := pricing.CalculateCost(1000, 500, 0, 0)
+	cost := pricing.CalculateCost(TokenUsage{Input: 1000, Output: 500})
 	expected := 0.0105
 
 	if !floatEquals(cost, expected) {
@@ -36,7 +36,7 @@ func TestModelPricing_CalculateCost_WithCache(t *testing.T) {
 
 	// 1000 input, 500 output, 100 cache read, 50 cache write
 	// $0.003 + $0.0075 + $0.00003 + $0.0001875 = $0.0107175
-	cost := pricing.CalculateCost(1000, 500, 100, 50)
+	cost := pricing.CalculateCost(TokenUsage{Input: 1000, Output: 500, CacheRead: 100, CacheWrite: 50})
 	expected := 0.0107175
 
 	if !floatEquals(cost, expected) {
@@ -65,7 +65,7 @@ func TestModelPricing_CalculateCost_LongContext_UnderThreshold(t *testing.T) {
 
 	// 100K tokens = under threshold, use standard pricing
 	// 100000 input, 10000 output = $0.30 + $0.15 = $0.45
-	cost := pricing.CalculateCost(100000, 10000, 0, 0)
+	cost := pricing.CalculateCost(TokenUsage{Input: 100000, Output: 10000})
 	expected := 0.45
 
 	if !floatEquals(cost, expected) {
@@ -94,7 +94,7 @@ func TestModelPricing_CalculateCost_LongContext_OverThreshold(t *testing.T) {
 
 	// 250K tokens = over threshold, use long context pricing
 	// 250000 input, 10000 output = $1.50 + $0.225 = $1.725
-	cost := pricing.CalculateCost(250000, 10000, 0, 0)
+	cost := pricing.CalculateCost(TokenUsage{Input: 250000, Output: 10000})
 	expected := 1.725
 
 	if !floatEquals(cost, expected) {
@@ -129,7 +129,7 @@ func TestModelPricing_CalculateCost_LongContext_CacheCountsTowardThreshold(t *te
 	// 30000 cache read * $0.60/MTok = $0.018
 	// 25000 cache write * $7.50/MTok = $0.1875
 	// Total = $1.3305
-	cost := pricing.CalculateCost(150000, 10000, 30000, 25000)
+	cost := pricing.CalculateCost(TokenUsage{Input: 150000, Output: 10000, CacheRead: 30000, CacheWrite: 25000})
 	expected := 1.3305
 
 	if !floatEquals(cost, expected) {
@@ -160,7 +160,7 @@ func TestModelPricing_CalculateCost_Opus46(t *testing.T) {
 	// 50000 input * $5/MTok = $0.25
 	// 5000 output * $25/MTok = $0.125
 	// Total = $0.375
-	cost := pricing.CalculateCost(50000, 5000, 0, 0)
+	cost := pricing.CalculateCost(TokenUsage{Input: 50000, Output: 5000})
 	expected := 0.375
 
 	if !floatEquals(cost, expected) {
@@ -171,7 +171,7 @@ func TestModelPricing_CalculateCost_Opus46(t *testing.T) {
 	// 250000 input * $10/MTok = $2.50
 	// 5000 output * $37.50/MTok = $0.1875
 	// Total = $2.6875
-	cost2 := pricing.CalculateCost(250000, 5000, 0, 0)
+	cost2 := pricing.CalculateCost(TokenUsage{Input: 250000, Output: 5000})
 	expected2 := 2.6875
 
 	if !floatEquals(cost2, expected2) {
@@ -179,6 +179,67 @@ func TestModelPricing_CalculateCost_Opus46(t *testing.T) {
 	}
 }
 
+func TestModelPricing_CalculateCost_CacheWriteTiers(t *testing.T) {
+	cacheWrite := 3.75
+	cacheWrite1h := 6.00
+	pricing := &ModelPricing{
+		ID:                     "claude-sonnet-4-20250514",
+		InputPerMillion:        3.00,
+		OutputPerMillion:       15.00,
+		CacheWritePerMillion:   &cacheWrite,
+		CacheWrite1hPerMillion: &cacheWrite1h,
+	}
+
+	// 100K cache writes, 40K of them to the 1-hour cache
+	// 60000 * $3.75/MTok + 40000 * $6/MTok = $0.225 + $0.24 = $0.465
+	cost := pricing.CalculateCost(TokenUsage{CacheWrite: 100000, CacheWrite1h: 40000})
+	if !floatEquals(cost, 0.465) {
+		t.Errorf("Expected cost 0.465, got %.6f", cost)
+	}
+
+	// Without a 1-hour rate, 1-hour writes cost twice the input rate
+	pricing.CacheWrite1hPerMillion = nil
+	cost = pricing.CalculateCost(TokenUsage{CacheWrite: 100000, CacheWrite1h: 40000})
+	if !floatEquals(cost, 0.465) {
+		t.Errorf("Expected cost 0.465 without a 1-hour rate, got %.6f", cost)
+	}
+
+	// Including the long context input rate
+	threshold := int64(200000)
+	longInput, longOutput := 6.00, 22.50
+	pricing.LongContextThreshold = &threshold
+	pricing.LongContextInputPerMillion = &longInput
+	pricing.LongContextOutputPerMillion = &longOutput
+	rates := pricing.ResolveRates(150000, 0, 0, 100000)
+	if rates.CacheWrite1h == nil || !floatEquals(*rates.CacheWrite1h, 12.00) {
+		t.Errorf("Expected a derived long context 1-hour rate of 12.00, got %v", rates.CacheWrite1h)
+	}
+}
+
+func TestModelPricing_CalculateCost_ServerTools(t *testing.T) {
+	webSearch := 10.00
+	pricing := &ModelPricing{
+		ID:                   "claude-sonnet-4-20250514",
+		InputPerMillion:      3.00,
+		OutputPerMillion:     15.00,
+		WebSearchPerThousand: &webSearch,
+	}
+
+	// 1000 input, 500 output = $0.0105, plus 3 searches at $0.01 each.
+	// Web fetch has no rate, so fetches are free.
+	cost := pricing.CalculateCost(TokenUsage{Input: 1000, Output: 500, ServerTools: ServerToolUsage{WebSearchRequests: 3, WebFetchRequests: 5}})
+	expected := 0.0405
+
+	if !floatEquals(cost, expected) {
+		t.Errorf("Expected cost %.6f, got %.6f", expected, cost)
+	}
+
+	scaled := pricing.Scale(0.5).ServerToolCost(ServerToolUsage{WebSearchRequests: 3})
+	if !floatEquals(scaled, 0.015) {
+		t.Errorf("Expected scaled server tool cost 0.015, got %.6f", scaled)
+	}
+}
+
 func TestModelPricing_ResolveRates_Standard(t *testing.T) {
 	pricing := &ModelPricing{
 		ID:               "claude-sonnet-4-20250514",
@@ -293,7 +354,7 @@ func TestModelPricing_ResolveRates_ConsistentWithCalculateCost(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cost := pricing.CalculateCost(tt.input, tt.output, tt.cacheRead, tt.cacheWrite)
+			cost := pricing.CalculateCost(TokenUsage{Input: tt.input, Output: tt.output, CacheRead: tt.cacheRead, CacheWrite: tt.cacheWrite})
 			rates := pricing.ResolveRates(tt.input, tt.output, tt.cacheRead, tt.cacheWrite)
 
 			// Reconstruct cost from rates
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// Sources that can make an experiment active at a given time.
+const (
+	ScheduleSourceRotation = "rotation"
+	ScheduleSourceSchedule = "schedule"
+	ScheduleSourceManual   = "manual"
+)
+
+// maxRotationSlots bounds the number of rotation boundaries expanded when building a timeline.
+const maxRotationSlots = 1000
+
+// RotationGroup alternates a fixed list of experiments every Interval, starting
+// at AnchorAt. It is used for crossover designs (A, B, A, B, ...) that cancel out
+// time-of-day or weekday effects.
+type RotationGroup struct {
+	ID            string
+	Name          string
+	Interval      time.Duration
+	AnchorAt      time.Time
+	EndsAt        *time.Time
+	ExperimentIDs []string
+	CreatedAt     time.Time
+}
+
+// ExperimentAt returns the ID of the experiment scheduled at t, or "" when t
+// falls outside the rotation.
+func (g *RotationGroup) ExperimentAt(t time.Time) string {
+	if len(g.ExperimentIDs) == 0 || g.Interval <= 0 {
+		return ""
+	}
+	if t.Before(g.AnchorAt) {
+		return ""
+	}
+	if g.EndsAt != nil && !t.Before(*g.EndsAt) {
+		return ""
+	}
+	slot := int64(t.Sub(g.AnchorAt) / g.Interval)
+	return g.ExperimentIDs[slot%int64(len(g.ExperimentIDs))]
+}
+
+// IsScheduledAt reports whether the experiment's schedule window contains t.
+// Experiments without a scheduled start, or ended before t, are never scheduled.
+func (e *Experiment) IsScheduledAt(t time.Time) bool {
+	if e.ScheduledStart == nil || t.Before(*e.ScheduledStart) {
+		return false
+	}
+	if e.ScheduledEnd != nil && !t.Before(*e.ScheduledEnd) {
+		return false
+	}
+	return !e.endedBy(t)
+}
+
+func (e *Experiment) endedBy(t time.Time) bool {
+	return e.EndedAt != nil && !t.Before(*e.EndedAt)
+}
+
+// ResolveActiveExperiment determines which experiment is active at t.
+// Rotation groups take precedence, then schedule windows (latest start wins),
+// and finally the manually activated experiment. Returns nil and "" when no
+// experiment applies.
+func ResolveActiveExperiment(t time.Time, experiments []*Experiment, groups []*RotationGroup) (*Experiment, string) {
+	byID := make(map[string]*Experiment, len(experiments))
+	for _, e := range experiments {
+		byID[e.ID] = e
+	}
+
+	for _, g := range groups {
+		if e, ok := byID[g.ExperimentAt(t)]; ok && !e.endedBy(t) {
+			return e, ScheduleSourceRotation
+		}
+	}
+
+	var scheduled *Experiment
+	for _, e := range experiments {
+		if !e.IsScheduledAt(t) {
+			continue
+		}
+		if scheduled == nil || e.ScheduledStart.After(*scheduled.ScheduledStart) {
+			scheduled = e
+		}
+	}
+	if scheduled != nil {
+		return scheduled, ScheduleSourceSchedule
+	}
+
+	for _, e := range experiments {
+		if e.IsActive {
+			return e, ScheduleSourceManual
+		}
+	}
+	return nil, ""
+}
+
+// TimelineSlot is a contiguous period during which one experiment is active.
+type TimelineSlot struct {
+	ExperimentID   string
+	ExperimentName string
+	Start          time.Time
+	End            time.Time
+	Source         string
+}
+
+// BuildTimeline expands schedules and rotation groups into the sequence of
+// active experiments between from and until. Adjacent slots for the same
+// experiment and source are merged; periods with no active experiment are omitted.
+func BuildTimeline(from, until time.Time, experiments []*Experiment, groups []*RotationGroup) []TimelineSlot {
+	if !until.After(from) {
+		return nil
+	}
+
+	points := []time.Time{from, until}
+	addPoint := func(t *time.Time) {
+		if t != nil && t.After(from) && t.Before(until) {
+			points = append(points, *t)
+		}
+	}
+
+	for _, e := range experiments {
+		addPoint(e.ScheduledStart)
+		addPoint(e.ScheduledEnd)
+		addPoint(e.EndedAt)
+	}
+	for _, g := range groups {
+		addPoint(&g.AnchorAt)
+		addPoint(g.EndsAt)
+		if g.Interval <= 0 {
+			continue
+		}
+		boundary := g.AnchorAt
+		if boundary.Before(from) {
+			boundary = boundary.Add(from.Sub(boundary) / g.Interval * g.Interval)
+		}
+		for i := 0; i < maxRotationSlots && boundary.Before(until); i++ {
+			addPoint(&boundary)
+			boundary = boundary.Add(g.Interval)
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i].Before(points[j]) })
+
+	var slots []TimelineSlot
+	for i := 0; i < len(points)-1; i++ {
+		start, end := points[i], points[i+1]
+		if !end.After(start) {
+			continue
+		}
+		exp, source := ResolveActiveExperiment(start, experiments, groups)
+		if exp == nil {
+			continue
+		}
+		if n := len(slots); n > 0 && slots[n-1].ExperimentID == exp.ID &&
+			slots[n-1].Source == source && slots[n-1].End.Equal(start) {
+			slots[n-1].End = end
+			continue
+		}
+		slots = append(slots, TimelineSlot{
+			ExperimentID:   exp.ID,
+			ExperimentName: exp.Name,
+			Start:          start,
+			End:            end,
+			Source:         source,
+		})
+	}
+	return slots
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func scheduleTime(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+func TestRotationGroup_ExperimentAt(t *testing.T) {
+	ends := scheduleTime("2026-03-05T00:00:00Z")
+	g := &RotationGroup{
+		Interval:      24 * time.Hour,
+		AnchorAt:      scheduleTime("2026-03-01T00:00:00Z"),
+		EndsAt:        &ends,
+		ExperimentIDs: []string{"a", "b"},
+	}
+
+	tests := []struct {
+		at       string
+		expected string
+	}{
+		{"2026-02-28T23:59:59Z", ""},
+		{"2026-03-01T00:00:00Z", "a"},
+		{"2026-03-01T23:00:00Z", "a"},
+		{"2026-03-02T00:00:00Z", "b"},
+		{"2026-03-03T12:00:00Z", "a"},
+		{"2026-03-04T12:00:00Z", "b"},
+		{"2026-03-05T00:00:00Z", ""},
+	}
+	for _, tt := range tests {
+		assertEqual(t, tt.at, tt.expected, g.ExperimentAt(scheduleTime(tt.at)))
+	}
+}
+
+func TestResolveActiveExperiment_Precedence(t *testing.T) {
+	start := scheduleTime("2026-03-01T00:00:00Z")
+	end := scheduleTime("2026-03-10T00:00:00Z")
+
+	manual := &Experiment{ID: "manual", Name: "manual", IsActive: true}
+	scheduled := &Experiment{ID: "sched", Name: "sched", ScheduledStart: &start, ScheduledEnd: &end}
+	rotA := &Experiment{ID: "rot-a", Name: "rot-a"}
+	rotB := &Experiment{ID: "rot-b", Name: "rot-b"}
+	experiments := []*Experiment{manual, scheduled, rotA, rotB}
+
+	groups := []*RotationGroup{{
+		Interval:      12 * time.Hour,
+		AnchorAt:      scheduleTime("2026-03-05T00:00:00Z"),
+		ExperimentIDs: []string{"rot-a", "rot-b"},
+	}}
+
+	tests := []struct {
+		name     string
+		at       string
+		expected string
+		source   string
+	}{
+		{"before any schedule falls back to manual", "2026-02-20T00:00:00Z", "manual", ScheduleSourceManual},
+		{"schedule window beats manual", "2026-03-02T00:00:00Z", "sched", ScheduleSourceSchedule},
+		{"rotation beats schedule", "2026-03-05T06:00:00Z", "rot-a", ScheduleSourceRotation},
+		{"rotation alternates", "2026-03-05T18:00:00Z", "rot-b", ScheduleSourceRotation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp, source := ResolveActiveExperiment(scheduleTime(tt.at), experiments, groups)
+			if exp == nil {
+				t.Fatal("expected an experiment, got nil")
+			}
+			assertEqual(t, "experiment", tt.expected, exp.ID)
+			assertEqual(t, "source", tt.source, source)
+		})
+	}
+}
+
+func TestResolveActiveExperiment_EndedExperimentsAreSkipped(t *testing.T) {
+	start := scheduleTime("2026-03-01T00:00:00Z")
+	ended := scheduleTime("2026-03-02T00:00:00Z")
+	exp := &Experiment{ID: "a", ScheduledStart: &start, EndedAt: &ended}
+
+	got, _ := ResolveActiveExperiment(scheduleTime("2026-03-01T12:00:00Z"), []*Experiment{exp}, nil)
+	if got == nil || got.ID != "a" {
+		t.Fatalf("expected experiment before end, got %v", got)
+	}
+
+	got, source := ResolveActiveExperiment(scheduleTime("2026-03-03T00:00:00Z"), []*Experiment{exp}, nil)
+	if got != nil {
+		t.Errorf("expected nil after end, got %q (%s)", got.ID, source)
+	}
+}
+
+func TestBuildTimeline_MergesAndAlternates(t *testing.T) {
+	a := &Experiment{ID: "a", Name: "A"}
+	b := &Experiment{ID: "b", Name: "B"}
+	groups := []*RotationGroup{{
+		Interval:      24 * time.Hour,
+		AnchorAt:      scheduleTime("2026-03-01T00:00:00Z"),
+		ExperimentIDs: []string{"a", "b"},
+	}}
+
+	slots := BuildTimeline(
+		scheduleTime("2026-03-01T12:00:00Z"),
+		scheduleTime("2026-03-04T00:00:00Z"),
+		[]*Experiment{a, b}, groups,
+	)
+
+	expected := []struct {
+		id    string
+		start string
+		end   string
+	}{
+		{"a", "2026-03-01T12:00:00Z", "2026-03-02T00:00:00Z"},
+		{"b", "2026-03-02T00:00:00Z", "2026-03-03T00:00:00Z"},
+		{"a", "2026-03-03T00:00:00Z", "2026-03-04T00:00:00Z"},
+	}
+	if len(slots) != len(expected) {
+		t.Fatalf("expected %d slots, got %d: %+v", len(expected), len(slots), slots)
+	}
+	for i, e := range expected {
+		assertEqual(t, "id", e.id, slots[i].ExperimentID)
+		assertEqual(t, "start", e.start, slots[i].Start.Format(time.RFC3339))
+		assertEqual(t, "end", e.end, slots[i].End.Format(time.RFC3339))
+		assertEqual(t, "source", ScheduleSourceRotation, slots[i].Source)
+	}
+}
+
+func TestBuildTimeline_EmptyRange(t *testing.T) {
+	now := scheduleTime("2026-03-01T00:00:00Z")
+	if slots := BuildTimeline(now, now, nil, nil); slots != nil {
+		t.Errorf("expected nil timeline, got %+v", slots)
+	}
+}
@@ -15,6 +15,11 @@ type Session struct {
 	EndedAt              *time.Time
 	DurationSeconds      *int64
 	CreatedAt            time.Time
+	// Excluded sessions are left out of experiment stats and comparisons.
+	Excluded        bool
+	ExclusionReason *string
+	// Git is nil when the session did not run in a git repository.
+	Git *SessionGit
 }
 
 type SessionMetrics struct {
@@ -26,13 +31,35 @@ type SessionMetrics struct {
 	TokenInput            int64
 	TokenOutput           int64
 	TokenCacheRead        int64
-	TokenCacheWrite       int64
+	TokenCacheWrite       int64 // Both cache tiers
+	TokenCacheWrite5m     int64
+	TokenCacheWrite1h     int64
 	CostEstimateUSD       *float64
 	ErrorCount            int64
 	InputRate             *float64
 	OutputRate            *float64
 	CacheReadRate         *float64
 	CacheWriteRate        *float64
+	CacheWrite1hRate      *float64
+	WebSearchRequests     int64
+	WebFetchRequests      int64
+	ServerToolCostUSD     *float64 // Included in CostEstimateUSD
+	LinesAdded            int64
+	LinesRemoved          int64
+	LinesSource           string // LinesSourceTools or LinesSourceGit, empty when no lines were counted
+	Outcome               SessionOutcome
+}
+
+// TokenUsage returns the session's usage as priced by ModelPricing.
+func (m *SessionMetrics) TokenUsage() TokenUsage {
+	return TokenUsage{
+		Input:        m.TokenInput,
+		Output:       m.TokenOutput,
+		CacheRead:    m.TokenCacheRead,
+		CacheWrite:   m.TokenCacheWrite,
+		CacheWrite1h: m.TokenCacheWrite1h,
+		ServerTools:  ServerToolUsage{WebSearchRequests: m.WebSearchRequests, WebFetchRequests: m.WebFetchRequests},
+	}
 }
 
 type SessionTool struct {
@@ -52,6 +79,15 @@ type SessionFile struct {
 	OperationCount int64
 }
 
+// SessionFileChange holds the lines a session added to and removed from a
+// file.
+type SessionFileChange struct {
+	SessionID    string
+	FilePath     string
+	LinesAdded   int64
+	LinesRemoved int64
+}
+
 type SessionCommand struct {
 	ID         int64
 	SessionID  string
@@ -72,22 +108,38 @@ type SessionListItem struct {
 	TotalTokens   int64
 	Cost          *float64
 	ModelID       *string
+	GitBranch     *string
 	SubagentCount int64
+	Anomaly       *SessionAnomaly
+	EndedGreen    *bool // nil when no tests, builds or linters ran
 }
 
 type SessionSubagent struct {
-	ID              int64
-	SessionID       string
-	AgentType       string  // subagent_type for Task (e.g. "Explore", "Bash"), skill name for Skill (e.g. "commit")
-	AgentKind       string  // "task" or "skill"
-	Description     *string // short description from Task input
-	Model           *string // model alias (e.g. "haiku", "sonnet") or nil
-	TotalTokens     int64
-	TokenInput      int64
-	TokenOutput     int64
-	TokenCacheRead  int64
-	TokenCacheWrite int64
-	TotalDurationMs *int64
-	ToolUseCount    int64
-	CostEstimateUSD *float64
+	ID                int64
+	SessionID         string
+	AgentType         string  // subagent_type for Task (e.g. "Explore", "Bash"), skill name for Skill (e.g. "commit")
+	AgentKind         string  // "task" or "skill"
+	Description       *string // short description from Task input
+	Model             *string // model alias (e.g. "haiku", "sonnet") or nil
+	TotalTokens       int64
+	TokenInput        int64
+	TokenOutput       int64
+	TokenCacheRead    int64
+	TokenCacheWrite   int64 // Both cache tiers
+	TokenCacheWrite5m int64
+	TokenCacheWrite1h int64
+	TotalDurationMs   *int64
+	ToolUseCount      int64
+	CostEstimateUSD   *float64
+}
+
+// TokenUsage returns the sub-agent's token usage as priced by ModelPricing.
+func (s *SessionSubagent) TokenUsage() TokenUsage {
+	return TokenUsage{
+		Input:        s.TokenInput,
+		Output:       s.TokenOutput,
+		CacheRead:    s.TokenCacheRead,
+		CacheWrite:   s.TokenCacheWrite,
+		CacheWrite1h: s.TokenCacheWrite1h,
+	}
 }
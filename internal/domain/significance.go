@@ -0,0 +1,139 @@
+package domain
+
+import "math"
+
+// SampleSummary holds the size, mean and sample standard deviation of a set of values.
+type SampleSummary struct {
+	N      int
+	Mean   float64
+	StdDev float64
+}
+
+// Summarize computes the summary of a set of values.
+func Summarize(values []float64) SampleSummary {
+	s := SampleSummary{N: len(values)}
+	if s.N == 0 {
+		return s
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	s.Mean = sum / float64(s.N)
+
+	if s.N > 1 {
+		var sq float64
+		for _, v := range values {
+			sq += (v - s.Mean) * (v - s.Mean)
+		}
+		s.StdDev = math.Sqrt(sq / float64(s.N-1))
+	}
+	return s
+}
+
+// MetricDelta compares a per-session metric between a baseline and a treatment.
+// CILow and CIHigh bound the 95% confidence interval of Treatment.Mean - Baseline.Mean
+// and are only set when HasInterval is true.
+type MetricDelta struct {
+	Metric      string
+	Baseline    SampleSummary
+	Treatment   SampleSummary
+	Delta       float64
+	DeltaPct    float64
+	HasInterval bool
+	CILow       float64
+	CIHigh      float64
+	Significant bool
+}
+
+// CompareSamples computes the difference of means with a Welch 95% confidence
+// interval. No interval is computed when either side has fewer than two values.
+func CompareSamples(metric string, baseline, treatment []float64) MetricDelta {
+	d := MetricDelta{
+		Metric:    metric,
+		Baseline:  Summarize(baseline),
+		Treatment: Summarize(treatment),
+	}
+	d.Delta = d.Treatment.Mean - d.Baseline.Mean
+	if d.Baseline.Mean != 0 {
+		d.DeltaPct = d.Delta / d.Baseline.Mean * 100
+	}
+
+	if d.Baseline.N < 2 || d.Treatment.N < 2 {
+		return d
+	}
+
+	d.HasInterval = true
+	vb := d.Baseline.StdDev * d.Baseline.StdDev / float64(d.Baseline.N)
+	vt := d.Treatment.StdDev * d.Treatment.StdDev / float64(d.Treatment.N)
+	se := math.Sqrt(vb + vt)
+	if se == 0 {
+		d.CILow, d.CIHigh = d.Delta, d.Delta
+		d.Significant = d.Delta != 0
+		return d
+	}
+
+	// Welch–Satterthwaite degrees of freedom.
+	df := (vb + vt) * (vb + vt) /
+		(vb*vb/float64(d.Baseline.N-1) + vt*vt/float64(d.Treatment.N-1))
+	margin := tCritical95(df) * se
+
+	d.CILow = d.Delta - margin
+	d.CIHigh = d.Delta + margin
+	d.Significant = d.CILow > 0 || d.CIHigh < 0
+	return d
+}
+
+// tTable95 holds two-sided 95% critical values of Student's t for 1..30 degrees of freedom.
+var tTable95 = [...]float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tCritical95 returns the two-sided 95% critical value for df degrees of freedom,
+// rounding df down (conservative) and using the normal value beyond the table.
+func tCritical95(df float64) float64 {
+	i := int(math.Floor(df))
+	if i < 1 {
+		i = 1
+	}
+	if i > len(tTable95) {
+		return 1.96
+	}
+	return tTable95[i-1]
+}
+
+// SampleMetric names a per-session value extracted from a SessionSample.
+type SampleMetric struct {
+	Name  string
+	Value func(SessionSample) float64
+}
+
+// ComparedMetrics are the per-session metrics compared in experiment reports.
+var ComparedMetrics = []SampleMetric{
+	{"Tokens/session", func(s SessionSample) float64 { return float64(s.Tokens) }},
+	{"Cost/session", func(s SessionSample) float64 { return s.CostUsd }},
+	{"Turns/session", func(s SessionSample) float64 { return float64(s.Turns) }},
+	{"Tool calls/session", func(s SessionSample) float64 { return float64(s.ToolCalls) }},
+	{"Errors/session", func(s SessionSample) float64 { return float64(s.Errors) }},
+	{"Duration (min)", func(s SessionSample) float64 { return float64(s.DurationSeconds) / 60 }},
+}
+
+// CompareSessionSamples compares every ComparedMetrics entry between two sets of sessions.
+func CompareSessionSamples(baseline, treatment []SessionSample) []MetricDelta {
+	deltas := make([]MetricDelta, len(ComparedMetrics))
+	for i, m := range ComparedMetrics {
+		deltas[i] = CompareSamples(m.Name, sampleValues(baseline, m.Value), sampleValues(treatment, m.Value))
+	}
+	return deltas
+}
+
+func sampleValues(samples []SessionSample, value func(SessionSample) float64) []float64 {
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = value(s)
+	}
+	return values
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   SampleSummary
+	}{
+		{"empty", nil, SampleSummary{}},
+		{"single value", []float64{4}, SampleSummary{N: 1, Mean: 4}},
+		{"several values", []float64{2, 4, 4, 4, 5, 5, 7, 9}, SampleSummary{N: 8, Mean: 5, StdDev: math.Sqrt(32.0 / 7)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize(tt.values)
+			if got.N != tt.want.N || !floatEquals(got.Mean, tt.want.Mean) || !floatEquals(got.StdDev, tt.want.StdDev) {
+				t.Errorf("Summarize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSamples(t *testing.T) {
+	tests := []struct {
+		name            string
+		baseline        []float64
+		treatment       []float64
+		wantDelta       float64
+		wantDeltaPct    float64
+		wantSignificant bool
+		wantNoInterval  bool
+	}{
+		{
+			name:            "clear reduction",
+			baseline:        []float64{100, 102, 98, 101, 99},
+			treatment:       []float64{80, 82, 78, 81, 79},
+			wantDelta:       -20,
+			wantDeltaPct:    -20,
+			wantSignificant: true,
+		},
+		{
+			name:            "overlapping samples",
+			baseline:        []float64{100, 140, 60, 120, 80},
+			treatment:       []float64{110, 150, 70, 130, 90},
+			wantDelta:       10,
+			wantDeltaPct:    10,
+			wantSignificant: false,
+		},
+		{
+			name:            "too few sessions",
+			baseline:        []float64{100},
+			treatment:       []float64{50, 60},
+			wantDelta:       -45,
+			wantDeltaPct:    -45,
+			wantSignificant: false,
+			wantNoInterval:  true,
+		},
+		{
+			name:            "zero baseline mean",
+			baseline:        []float64{0, 0},
+			treatment:       []float64{1, 3},
+			wantDelta:       2,
+			wantDeltaPct:    0,
+			wantSignificant: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompareSamples("metric", tt.baseline, tt.treatment)
+			if !floatEquals(got.Delta, tt.wantDelta) {
+				t.Errorf("Delta = %v, want %v", got.Delta, tt.wantDelta)
+			}
+			if !floatEquals(got.DeltaPct, tt.wantDeltaPct) {
+				t.Errorf("DeltaPct = %v, want %v", got.DeltaPct, tt.wantDeltaPct)
+			}
+			if got.Significant != tt.wantSignificant {
+				t.Errorf("Significant = %v, want %v (CI %v..%v)", got.Significant, tt.wantSignificant, got.CILow, got.CIHigh)
+			}
+			if got.HasInterval == tt.wantNoInterval {
+				t.Errorf("HasInterval = %v, want %v", got.HasInterval, !tt.wantNoInterval)
+			}
+			if got.HasInterval && (got.CILow > got.Delta || got.CIHigh < got.Delta) {
+				t.Errorf("CI %v..%v does not contain delta %v", got.CILow, got.CIHigh, got.Delta)
+			}
+		})
+	}
+}
+
+func TestTCritical95(t *testing.T) {
+	tests := []struct {
+		df   float64
+		want float64
+	}{
+		{0.5, 12.706},
+		{1, 12.706},
+		{4.8, 2.776},
+		{30, 2.042},
+		{200, 1.96},
+	}
+
+	for _, tt := range tests {
+		if got := tCritical95(tt.df); got != tt.want {
+			t.Errorf("tCritical95(%v) = %v, want %v", tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestCompareSessionSamples(t *testing.T) {
+	baseline := []SessionSample{{Tokens: 1000, CostUsd: 1, DurationSeconds: 600}}
+	treatment := []SessionSample{{Tokens: 500, CostUsd: 0.5, DurationSeconds: 300}}
+
+	deltas := CompareSessionSamples(baseline, treatment)
+	if len(deltas) != len(ComparedMetrics) {
+		t.Fatalf("got %d deltas, want %d", len(deltas), len(ComparedMetrics))
+	}
+	if deltas[0].Metric != "Tokens/session" || !floatEquals(deltas[0].DeltaPct, -50) {
+		t.Errorf("tokens delta = %+v", deltas[0])
+	}
+	last := deltas[len(deltas)-1]
+	if !floatEquals(last.Baseline.Mean, 10) || !floatEquals(last.Treatment.Mean, 5) {
+		t.Errorf("duration delta = %+v", last)
+	}
+}
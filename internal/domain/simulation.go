@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// CacheHitRate returns the fraction of input tokens read from cache.
+func (u TokenUsage) CacheHitRate() float64 {
+	total := u.TotalInput()
+	if total == 0 {
+		return 0
+	}
+	return float64(u.CacheRead) / float64(total)
+}
+
+// WithCacheHitRate returns the usage with rate of its input tokens read from
+// cache. The total input is unchanged; the remainder is split between
+// uncached input and cache writes in their original proportion.
+func (u TokenUsage) WithCacheHitRate(rate float64) TokenUsage {
+	total := u.TotalInput()
+	misses := total - int64(math.Round(float64(total)*rate))
+
+	out := TokenUsage{Output: u.Output, CacheRead: total - misses, ServerTools: u.ServerTools}
+	if uncached := u.Input + u.CacheWrite; uncached > 0 {
+		out.CacheWrite = int64(math.Round(float64(misses) * float64(u.CacheWrite) / float64(uncached)))
+	}
+	if u.CacheWrite > 0 {
+		out.CacheWrite1h = int64(math.Round(float64(out.CacheWrite) * float64(u.CacheWrite1h) / float64(u.CacheWrite)))
+	}
+	out.Input = misses - out.CacheWrite
+	return out
+}
+
+// SubagentUsage is the recorded usage of a single sub-agent run. Model is
+// empty when unknown.
+type SubagentUsage struct {
+	Model   string
+	Tokens  TokenUsage
+	CostUsd float64
+}
+
+// SessionUsage is the recorded usage of a session's main thread and its
+// sub-agents, with the costs estimated when it was recorded. Tokens and
+// CostUsd are the main thread's alone, without its sub-agents'.
+type SessionUsage struct {
+	SessionID    string
+	ProjectID    string
+	ExperimentID *string
+	CreatedAt    time.Time
+	Model        string
+	Tokens       TokenUsage
+	CostUsd      float64
+	Subagents    []SubagentUsage
+}
+
+// ActualCostUsd returns the recorded cost of the main thread and sub-agents.
+func (s SessionUsage) ActualCostUsd() float64 {
+	cost := s.CostUsd
+	for _, sa := range s.Subagents {
+		cost += sa.CostUsd
+	}
+	return cost
+}
+
+// CostScenario re-prices recorded usage. Model replaces the pricing of each
+// session's main thread and SubagentModel that of its sub-agents; when
+// CacheHitRate is set, every session is priced as if that fraction of its
+// input had been read from cache. Nil fields keep what was recorded.
+type CostScenario struct {
+	Model         *ModelPricing
+	SubagentModel *ModelPricing
+	CacheHitRate  *float64
+}
+
+// SimulateCost returns the session's cost under the scenario. pricing looks
+// up the pricing of a recorded model ID and returns nil when it is unknown.
+// As when sessions are recorded, a main thread without known pricing uses
+// fallback and sub-agents without known pricing use the main thread's; usage
+// that cannot be priced keeps its recorded cost.
+func (sc CostScenario) SimulateCost(s SessionUsage, pricing func(model string) *ModelPricing, fallback *ModelPricing) float64 {
+	main := sc.Model
+	if main == nil {
+		main = pricing(s.Model)
+	}
+	if main == nil {
+		main = fallback
+	}
+	cost := sc.price(s.Tokens, main, s.CostUsd)
+
+	for _, sa := range s.Subagents {
+		p := sc.SubagentModel
+		if p == nil {
+			p = pricing(sa.Model)
+		}
+		if p == nil {
+			p = main
+		}
+		cost += sc.price(sa.Tokens, p, sa.CostUsd)
+	}
+	return cost
+}
+
+func (sc CostScenario) price(tokens TokenUsage, p *ModelPricing, recorded float64) float64 {
+	if p == nil {
+		return recorded
+	}
+	if sc.CacheHitRate != nil {
+		tokens = tokens.WithCacheHitRate(*sc.CacheHitRate)
+	}
+	return p.CalculateCost(tokens)
+}
@@ -0,0 +1,83 @@
+package domain
+
+import "testing"
+
+func TestTokenUsage_WithCacheHitRate(t *testing.T) {
+	u := TokenUsage{Input: 300, Output: 50, CacheRead: 600, CacheWrite: 100}
+	assertEqual(t, "hit rate", 0.6, u.CacheHitRate())
+
+	sim := u.WithCacheHitRate(0.8)
+	assertEqual(t, "total input", u.TotalInput(), sim.TotalInput())
+	assertEqual(t, "cache read", int64(800), sim.CacheRead)
+	// The 200 misses keep the 3:1 split of input to cache writes
+	assertEqual(t, "input", int64(150), sim.Input)
+	assertEqual(t, "cache write", int64(50), sim.CacheWrite)
+	assertEqual(t, "output", int64(50), sim.Output)
+
+	// Without uncached input every miss is plain input
+	sim = TokenUsage{CacheRead: 100}.WithCacheHitRate(0.25)
+	assertEqual(t, "all-cached input", int64(75), sim.Input)
+	assertEqual(t, "all-cached write", int64(0), sim.CacheWrite)
+}
+
+func TestCostScenario_SimulateCost(t *testing.T) {
+	cacheRead, cacheWrite := 0.5, 6.25
+	opus := &ModelPricing{ID: "opus", InputPerMillion: 5, OutputPerMillion: 25, CacheReadPerMillion: &cacheRead, CacheWritePerMillion: &cacheWrite}
+	sonnetRead, sonnetWrite := 0.3, 3.75
+	sonnet := &ModelPricing{ID: "sonnet", InputPerMillion: 3, OutputPerMillion: 15, CacheReadPerMillion: &sonnetRead, CacheWritePerMillion: &sonnetWrite}
+	pricing := func(model string) *ModelPricing {
+		switch model {
+		case "opus":
+			return opus
+		case "sonnet":
+			return sonnet
+		}
+		return nil
+	}
+
+	million := TokenUsage{Input: 1_000_000, Output: 1_000_000}
+	session := SessionUsage{
+		Model:   "opus",
+		Tokens:  million,
+		CostUsd: 30,
+		Subagents: []SubagentUsage{
+			{Model: "sonnet", Tokens: million, CostUsd: 18},
+			{Model: "", Tokens: million, CostUsd: 30},
+			{Model: "mystery", Tokens: million, CostUsd: 1},
+		},
+	}
+	assertEqual(t, "actual", 79.0, session.ActualCostUsd())
+
+	// Unchanged scenario re-prices at the recorded models; unknown sub-agent
+	// models fall back to the main thread's pricing
+	if got := (CostScenario{}).SimulateCost(session, pricing, nil); !floatEquals(got, 30+18+30+30) {
+		t.Errorf("baseline = %.2f, want 108", got)
+	}
+
+	// Everything on Sonnet
+	if got := (CostScenario{Model: sonnet}).SimulateCost(session, pricing, nil); !floatEquals(got, 18+18+18+18) {
+		t.Errorf("sonnet main = %.2f, want 72", got)
+	}
+
+	// Sub-agents on Sonnet only
+	if got := (CostScenario{SubagentModel: sonnet}).SimulateCost(session, pricing, nil); !floatEquals(got, 30+18*3) {
+		t.Errorf("sonnet sub-agents = %.2f, want 84", got)
+	}
+
+	// Unpriceable usage keeps its recorded cost
+	unknown := SessionUsage{Model: "mystery", Tokens: million, CostUsd: 2}
+	if got := (CostScenario{}).SimulateCost(unknown, pricing, nil); got != 2 {
+		t.Errorf("unknown model = %.2f, want 2", got)
+	}
+	// unless there is a fallback, like the default model
+	if got := (CostScenario{}).SimulateCost(unknown, pricing, sonnet); !floatEquals(got, 18) {
+		t.Errorf("unknown model with fallback = %.2f, want 18", got)
+	}
+
+	// 80% cache hits: 200K input at $5, 800K cache reads at $0.50, 1M output at $25
+	rate := 0.8
+	cached := SessionUsage{Model: "opus", Tokens: million}
+	if got := (CostScenario{CacheHitRate: &rate}).SimulateCost(cached, pricing, nil); !floatEquals(got, 1+0.4+25) {
+		t.Errorf("cache scenario = %.4f, want 26.4", got)
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // AggregateStats holds summary statistics across sessions.
 type AggregateStats struct {
 	SessionCount           int64
@@ -10,8 +12,30 @@ type AggregateStats struct {
 	TotalTokenOutput       int64
 	TotalTokenCacheRead    int64
 	TotalTokenCacheWrite   int64
+	TotalTokenCacheWrite1h int64
 	TotalCostUsd           float64
 	TotalErrors            int64
+	TotalWebSearchRequests int64
+	TotalWebFetchRequests  int64
+	TotalServerToolCostUsd float64 // Included in TotalCostUsd
+	TotalLinesAdded        int64
+	TotalLinesRemoved      int64
+	SessionsWithChecks     int64 // Sessions that ran tests, builds or linters
+	SessionsEndedGreen     int64 // Sessions whose last runs of them all passed
+}
+
+// LinesChanged returns the lines added and removed across the sessions.
+func (a *AggregateStats) LinesChanged() int64 {
+	return a.TotalLinesAdded + a.TotalLinesRemoved
+}
+
+// CostPer100Lines returns the estimated cost of 100 changed lines, or 0
+// when no lines were changed.
+func (a *AggregateStats) CostPer100Lines() float64 {
+	if a.LinesChanged() == 0 {
+		return 0
+	}
+	return a.TotalCostUsd / float64(a.LinesChanged()) * 100
 }
 
 // ToolUsageStats holds usage data for a single tool.
@@ -35,6 +59,7 @@ type NormalizedMetrics struct {
 	CacheHitRate     float64
 	ErrorRate        float64
 	ToolCallsPerTurn float64
+	GreenRate        float64 // Share of the sessions with checks that ended green
 }
 
 // ComputeNormalized derives behavioral metrics from aggregate stats.
@@ -57,6 +82,10 @@ func (a *AggregateStats) ComputeNormalized(totalToolCalls int64) NormalizedMetri
 		m.CacheHitRate = float64(a.TotalTokenCacheRead) / float64(contextTotal)
 	}
 
+	if a.SessionsWithChecks > 0 {
+		m.GreenRate = float64(a.SessionsEndedGreen) / float64(a.SessionsWithChecks)
+	}
+
 	return m
 }
 
@@ -65,3 +94,40 @@ type TranscriptPathInfo struct {
 	ID             string
 	TranscriptPath string
 }
+
+// DailyTotal holds the sessions, tokens and cost of a single UTC day.
+type DailyTotal struct {
+	Date     string
+	Sessions int64
+	Tokens   int64
+	CostUsd  float64
+}
+
+// GroupSamplesByDay sums samples per UTC day. Samples must be in
+// chronological order; days without sessions are omitted.
+func GroupSamplesByDay(samples []SessionSample) []DailyTotal {
+	days := []DailyTotal{}
+	for _, s := range samples {
+		date := s.CreatedAt.UTC().Format("2006-01-02")
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			days = append(days, DailyTotal{Date: date})
+		}
+		d := &days[len(days)-1]
+		d.Sessions++
+		d.Tokens += s.Tokens
+		d.CostUsd += s.CostUsd
+	}
+	return days
+}
+
+// SessionSample holds the per-session values used for experiment comparisons.
+type SessionSample struct {
+	SessionID       string
+	CreatedAt       time.Time
+	DurationSeconds int64
+	Turns           int64
+	Tokens          int64
+	CostUsd         float64
+	Errors          int64
+	ToolCalls       int64
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"math"
 	"testing"
+	"time"
 )
 
 func TestAggregateStats_ComputeNormalized(t *testing.T) {
@@ -22,6 +23,8 @@ func TestAggregateStats_ComputeNormalized(t *testing.T) {
 				TotalTokenCacheRead:  50000,
 				TotalTokenCacheWrite: 10000,
 				TotalErrors:          5,
+				SessionsWithChecks:   8,
+				SessionsEndedGreen:   6,
 			},
 			totalToolCalls: 200,
 			expected: NormalizedMetrics{
@@ -30,6 +33,7 @@ func TestAggregateStats_ComputeNormalized(t *testing.T) {
 				CacheHitRate:     50000.0 / (100000.0 + 50000.0 + 10000.0), // 50000/160000
 				ErrorRate:        0.1,                                      // 5/50
 				ToolCallsPerTurn: 4.0,                                      // 200/50
+				GreenRate:        0.75,                                     // 6/8
 			},
 		},
 		{
@@ -95,6 +99,7 @@ func TestAggregateStats_ComputeNormalized(t *testing.T) {
 			assertFloatNear(t, "CacheHitRate", tt.expected.CacheHitRate, got.CacheHitRate)
 			assertFloatNear(t, "ErrorRate", tt.expected.ErrorRate, got.ErrorRate)
 			assertFloatNear(t, "ToolCallsPerTurn", tt.expected.ToolCallsPerTurn, got.ToolCallsPerTurn)
+			assertFloatNear(t, "GreenRate", tt.expected.GreenRate, got.GreenRate)
 		})
 	}
 }
@@ -105,3 +110,35 @@ func assertFloatNear(t *testing.T, name string, expected, actual float64) {
 		t.Errorf("%s: expected %.6f, got %.6f", name, expected, actual)
 	}
 }
+
+func TestGroupSamplesByDay(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+	samples := []SessionSample{
+		{CreatedAt: day(1, 9), Tokens: 100, CostUsd: 0.1},
+		{CreatedAt: day(1, 17), Tokens: 200, CostUsd: 0.2},
+		{CreatedAt: day(3, 10), Tokens: 50, CostUsd: 0.05},
+	}
+
+	got := GroupSamplesByDay(samples)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(got))
+	}
+	if got[0].Date != "2026-03-01" || got[0].Sessions != 2 || got[0].Tokens != 300 {
+		t.Errorf("unexpected first day: %+v", got[0])
+	}
+	if got[1].Date != "2026-03-03" || got[1].Sessions != 1 {
+		t.Errorf("unexpected second day: %+v", got[1])
+	}
+}
+
+func TestAggregateStats_CostPer100Lines(t *testing.T) {
+	a := &AggregateStats{TotalCostUsd: 3}
+	if a.CostPer100Lines() != 0 {
+		t.Errorf("expected 0 without changed lines, got %f", a.CostPer100Lines())
+	}
+	a.TotalLinesAdded, a.TotalLinesRemoved = 250, 50
+	assertEqual(t, "lines changed", int64(300), a.LinesChanged())
+	if !floatEquals(a.CostPer100Lines(), 1) {
+		t.Errorf("expected $1 per 100 lines, got %f", a.CostPer100Lines())
+	}
+}
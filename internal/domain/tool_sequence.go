@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// SessionToolCall is a tool call of a session, in the order it was made.
+// Label is the tool name, with Bash calls qualified by the category of their
+// command, as in "Bash(test)".
+type SessionToolCall struct {
+	SessionID   string
+	Sequence    int64
+	ToolName    string
+	Label       string
+	IsError     bool
+	ResultBytes int64 // Size of the result's text
+}
+
+// ToolCallLabel returns the label of a tool call for workflow mining.
+func ToolCallLabel(toolName, command string) string {
+	if toolName == "Bash" && command != "" {
+		return "Bash(" + CategorizeCommand(NormalizeCommand(command)) + ")"
+	}
+	return toolName
+}
+
+// editTools change files; an Edit→Bash failure loop starts with one of them.
+var editTools = map[string]bool{
+	"Edit": true, "MultiEdit": true, "Write": true, "NotebookEdit": true,
+}
+
+// ToolSequence is the tool calls of a session, in order.
+type ToolSequence struct {
+	SessionID    string
+	ExperimentID *string
+	Excluded     bool // Excluded from the experiment's stats
+	Calls        []SessionToolCall
+}
+
+// WorkflowSeparator joins the labels of a workflow pattern.
+const WorkflowSeparator = " → "
+
+// ToolNGrams returns the patterns of n consecutive calls, after collapsing
+// runs of the same label so "Read, Read, Edit" counts as "Read → Edit".
+func ToolNGrams(calls []SessionToolCall, n int) []string {
+	var labels []string
+	for _, c := range calls {
+		if len(labels) == 0 || labels[len(labels)-1] != c.Label {
+			labels = append(labels, c.Label)
+		}
+	}
+	if n <= 0 || len(labels) < n {
+		return nil
+	}
+	grams := make([]string, 0, len(labels)-n+1)
+	for i := 0; i+n <= len(labels); i++ {
+		grams = append(grams, strings.Join(labels[i:i+n], WorkflowSeparator))
+	}
+	return grams
+}
+
+// ToolLoop is a run of consecutive iterations of editing files and then
+// running a Bash command that fails.
+type ToolLoop struct {
+	SessionID  string
+	Start      int64 // Sequence of the loop's first edit
+	Iterations int
+}
+
+// DetectEditFailLoops finds the runs of two or more Edit→failed Bash
+// iterations in a session's calls. Calls other than edits and Bash, such as
+// reads and searches between them, are ignored. A Bash command that passes,
+// or that fails again without an edit in between, ends the run.
+func DetectEditFailLoops(calls []SessionToolCall) []ToolLoop {
+	var loops []ToolLoop
+	var current ToolLoop
+	edited := false
+	closeLoop := func() {
+		if current.Iterations >= 2 {
+			loops = append(loops, current)
+		}
+		current = ToolLoop{}
+	}
+	for _, c := range calls {
+		switch {
+		case editTools[c.ToolName]:
+			if !edited && current.Iterations == 0 {
+				current = ToolLoop{SessionID: c.SessionID, Start: c.Sequence}
+			}
+			edited = true
+		case c.ToolName == "Bash":
+			if edited && c.IsError {
+				current.Iterations++
+			} else {
+				closeLoop()
+			}
+			edited = false
+		}
+	}
+	closeLoop()
+	return loops
+}
+
+// WorkflowPattern counts a pattern of consecutive tool calls.
+type WorkflowPattern struct {
+	Pattern  string
+	Count    int64 // Occurrences
+	Sessions int64 // Sessions it occurred in
+}
+
+// WorkflowSummary mines the tool call patterns and Edit→Bash failure loops
+// of a group of sessions.
+type WorkflowSummary struct {
+	Sessions       int64
+	Calls          int64
+	Patterns       []WorkflowPattern // Most frequent first
+	Loops          int64
+	LoopSessions   int64
+	LoopIterations int64
+}
+
+// PatternRate returns the occurrences of a pattern per session, zero when
+// it did not occur.
+func (s WorkflowSummary) PatternRate(pattern string) float64 {
+	if s.Sessions == 0 {
+		return 0
+	}
+	for _, p := range s.Patterns {
+		if p.Pattern == pattern {
+			return float64(p.Count) / float64(s.Sessions)
+		}
+	}
+	return 0
+}
+
+// LoopSessionRate returns the share of sessions with a failure loop.
+func (s WorkflowSummary) LoopSessionRate() float64 {
+	if s.Sessions == 0 {
+		return 0
+	}
+	return float64(s.LoopSessions) / float64(s.Sessions)
+}
+
+// SummarizeWorkflows counts the patterns of n consecutive calls and the
+// failure loops of sessions.
+func SummarizeWorkflows(sequences []ToolSequence, n int) WorkflowSummary {
+	var s WorkflowSummary
+	byPattern := make(map[string]*WorkflowPattern)
+	for _, seq := range sequences {
+		s.Sessions++
+		s.Calls += int64(len(seq.Calls))
+		seen := make(map[string]bool)
+		for _, gram := range ToolNGrams(seq.Calls, n) {
+			p, ok := byPattern[gram]
+			if !ok {
+				p = &WorkflowPattern{Pattern: gram}
+				byPattern[gram] = p
+			}
+			p.Count++
+			if !seen[gram] {
+				seen[gram] = true
+				p.Sessions++
+			}
+		}
+		loops := DetectEditFailLoops(seq.Calls)
+		if len(loops) > 0 {
+			s.LoopSessions++
+		}
+		for _, l := range loops {
+			s.Loops++
+			s.LoopIterations += int64(l.Iterations)
+		}
+	}
+
+	for _, p := range byPattern {
+		s.Patterns = append(s.Patterns, *p)
+	}
+	sort.Slice(s.Patterns, func(i, j int) bool {
+		if s.Patterns[i].Count != s.Patterns[j].Count {
+			return s.Patterns[i].Count > s.Patterns[j].Count
+		}
+		return s.Patterns[i].Pattern < s.Patterns[j].Pattern
+	})
+	return s
+}
+
+// WorkflowComparison is a pattern's occurrences per session in each of the
+// compared summaries.
+type WorkflowComparison struct {
+	Pattern string
+	Rates   []float64
+}
+
+// CompareWorkflows lines up the limit most frequent patterns of each
+// summary, ordered by their highest rate.
+func CompareWorkflows(summaries []WorkflowSummary, limit int) []WorkflowComparison {
+	seen := make(map[string]bool)
+	var rows []WorkflowComparison
+	for _, s := range summaries {
+		for _, p := range s.Patterns[:min(limit, len(s.Patterns))] {
+			if seen[p.Pattern] {
+				continue
+			}
+			seen[p.Pattern] = true
+			row := WorkflowComparison{Pattern: p.Pattern, Rates: make([]float64, len(summaries))}
+			for i, other := range summaries {
+				row.Rates[i] = other.PatternRate(p.Pattern)
+			}
+			rows = append(rows, row)
+		}
+	}
+	highest := func(r WorkflowComparison) float64 {
+		m := 0.0
+		for _, v := range r.Rates {
+			m = max(m, v)
+		}
+		return m
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return highest(rows[i]) > highest(rows[j]) })
+	return rows
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "testing"
+
+func toolCalls(sessionID string, labels ...string) []SessionToolCall {
+	calls := make([]SessionToolCall, len(labels))
+	for i, label := range labels {
+		c := SessionToolCall{SessionID: sessionID, Sequence: int64(i + 1), ToolName: label, Label: label}
+		if label == "Bash!" {
+			c.ToolName, c.Label, c.IsError = "Bash", "Bash(test)", true
+		} else if label == "Bash" {
+			c.Label = "Bash(test)"
+		}
+		calls[i] = c
+	}
+	return calls
+}
+
+func TestToolCallLabel(t *testing.T) {
+	assertEqual(t, "test command", "Bash(test)", ToolCallLabel("Bash", "go test ./..."))
+	assertEqual(t, "git command", "Bash(git)", ToolCallLabel("Bash", "git status"))
+	assertEqual(t, "other tool", "Grep", ToolCallLabel("Grep", ""))
+}
+
+func TestToolNGrams(t *testing.T) {
+	grams := ToolNGrams(toolCalls("s1", "Grep", "Read", "Read", "Edit", "Bash"), 3)
+	if len(grams) != 2 {
+		t.Fatalf("expected 2 trigrams, got %v", grams)
+	}
+	assertEqual(t, "first", "Grep → Read → Edit", grams[0])
+	assertEqual(t, "second", "Read → Edit → Bash(test)", grams[1])
+	if grams := ToolNGrams(toolCalls("s1", "Read"), 2); grams != nil {
+		t.Errorf("expected no bigrams of one call, got %v", grams)
+	}
+}
+
+func TestDetectEditFailLoops(t *testing.T) {
+	loops := DetectEditFailLoops(toolCalls("s1",
+		"Read", "Edit", "Bash!", "Read", "Edit", "Bash!", "Edit", "Bash!", "Edit", "Bash", // 3 failures, then a pass
+		"Edit", "Bash!", "Bash!", // a retry without an edit ends the run
+	))
+	if len(loops) != 1 {
+		t.Fatalf("expected 1 loop, got %+v", loops)
+	}
+	assertEqual(t, "start", int64(2), loops[0].Start)
+	assertEqual(t, "iterations", 3, loops[0].Iterations)
+}
+
+func TestSummarizeWorkflows(t *testing.T) {
+	s := SummarizeWorkflows([]ToolSequence{
+		{SessionID: "s1", Calls: toolCalls("s1", "Read", "Edit", "Bash!", "Edit", "Bash!", "Read", "Edit")},
+		{SessionID: "s2", Calls: toolCalls("s2", "Read", "Edit")},
+	}, 2)
+	assertEqual(t, "sessions", int64(2), s.Sessions)
+	assertEqual(t, "calls", int64(9), s.Calls)
+	assertEqual(t, "top pattern", "Read → Edit", s.Patterns[0].Pattern)
+	assertEqual(t, "top pattern count", int64(3), s.Patterns[0].Count)
+	assertEqual(t, "top pattern sessions", int64(2), s.Patterns[0].Sessions)
+	assertFloatNear(t, "rate", 1.5, s.PatternRate("Read → Edit"))
+	assertEqual(t, "loops", int64(1), s.Loops)
+	assertFloatNear(t, "loop session rate", 0.5, s.LoopSessionRate())
+
+	other := SummarizeWorkflows([]ToolSequence{{SessionID: "s3", Calls: toolCalls("s3", "Grep", "Edit")}}, 2)
+	rows := CompareWorkflows([]WorkflowSummary{s, other}, 1)
+	if len(rows) != 2 {
+		t.Fatalf("expected the top pattern of each summary, got %+v", rows)
+	}
+	assertEqual(t, "first compared", "Read → Edit", rows[0].Pattern)
+	assertFloatNear(t, "absent pattern rate", 0, rows[0].Rates[1])
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"time"
+)
+
+// PlanListPrices are the monthly list prices of the subscription plans in USD.
+var PlanListPrices = map[string]float64{
+	PlanPro:    20,
+	PlanMax5x:  100,
+	PlanMax20x: 200,
+}
+
+// PlanPrice is the monthly price paid for a subscription plan from the month
+// of EffectiveFrom until the next price takes effect. EffectiveFrom is the
+// first day of the month in UTC.
+type PlanPrice struct {
+	EffectiveFrom time.Time
+	PlanType      string
+	MonthlyUsd    float64
+	CreatedAt     time.Time
+}
+
+// MonthStart returns the first instant of t's month in UTC.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// PriceForMonth returns the price in effect during the month starting at
+// month, or nil before the first price. Prices must be sorted by
+// EffectiveFrom.
+func PriceForMonth(prices []*PlanPrice, month time.Time) *PlanPrice {
+	var current *PlanPrice
+	for _, p := range prices {
+		if p.EffectiveFrom.After(month) {
+			break
+		}
+		current = p
+	}
+	return current
+}
+
+// DailyModelCost is the cost of a project's sessions using one model on a UTC
+// day. Model is empty when the session's model is unknown.
+type DailyModelCost struct {
+	Date      time.Time
+	ProjectID string
+	Model     string
+	Sessions  int64
+	CostUsd   float64
+}
+
+// MonthValue compares the API-equivalent cost of a month's usage with the
+// plan price paid for it. Price is nil when no price was in effect.
+// BreakEvenAt is the day cumulative API cost reached the price, nil until it
+// does. ProjectedAPICostUsd extrapolates an unfinished month at its pace so
+// far and equals APICostUsd for past months.
+type MonthValue struct {
+	Month               time.Time
+	Price               *PlanPrice
+	APICostUsd          float64
+	ProjectedAPICostUsd float64
+	Sessions            int64
+	BreakEvenAt         *time.Time
+	Partial             bool
+}
+
+// PriceUsd returns the plan price of the month, or 0 without one.
+func (m MonthValue) PriceUsd() float64 {
+	if m.Price == nil {
+		return 0
+	}
+	return m.Price.MonthlyUsd
+}
+
+// ValueRatio returns the API-equivalent cost per dollar paid for the plan, or
+// 0 without a price. Above 1 the subscription is the cheaper option.
+func (m MonthValue) ValueRatio() float64 {
+	if m.PriceUsd() <= 0 {
+		return 0
+	}
+	return m.APICostUsd / m.PriceUsd()
+}
+
+// SavingsUsd returns what the subscription saved over pay-as-you-go pricing;
+// negative when the API would have been cheaper.
+func (m MonthValue) SavingsUsd() float64 {
+	if m.Price == nil {
+		return 0
+	}
+	return m.APICostUsd - m.Price.MonthlyUsd
+}
+
+// BreakEvenDailyUsd returns the average daily API-equivalent spend at which
+// the plan pays for itself over the month.
+func (m MonthValue) BreakEvenDailyUsd() float64 {
+	days := m.Month.AddDate(0, 1, 0).Sub(m.Month).Hours() / 24
+	return m.PriceUsd() / days
+}
+
+// NewMonthValue compares a month's daily costs with its price. Costs must be
+// sorted by date; costs outside the month are ignored. now marks how much of
+// the current month has elapsed.
+func NewMonthValue(month time.Time, price *PlanPrice, costs []DailyModelCost, now time.Time) MonthValue {
+	end := month.AddDate(0, 1, 0)
+	v := MonthValue{Month: month, Price: price}
+	for _, c := range costs {
+		if c.Date.Before(month) || !c.Date.Before(end) {
+			continue
+		}
+		v.APICostUsd += c.CostUsd
+		v.Sessions += c.Sessions
+		if price != nil && v.BreakEvenAt == nil && v.APICostUsd >= price.MonthlyUsd {
+			day := c.Date
+			v.BreakEvenAt = &day
+		}
+	}
+
+	v.ProjectedAPICostUsd = v.APICostUsd
+	if now.Before(end) {
+		v.Partial = true
+		if elapsed := now.Sub(month); elapsed > 0 {
+			v.ProjectedAPICostUsd = v.APICostUsd * float64(end.Sub(month)) / float64(elapsed)
+		}
+	}
+	return v
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "testing"
+
+func TestPriceForMonth(t *testing.T) {
+	prices := []*PlanPrice{
+		{EffectiveFrom: scheduleTime("2026-01-01T00:00:00Z"), PlanType: PlanPro, MonthlyUsd: 20},
+		{EffectiveFrom: scheduleTime("2026-03-01T00:00:00Z"), PlanType: PlanMax5x, MonthlyUsd: 100},
+	}
+
+	if p := PriceForMonth(prices, scheduleTime("2025-12-01T00:00:00Z")); p != nil {
+		t.Errorf("expected no price before the first one, got %+v", p)
+	}
+	assertEqual(t, "february", PlanPro, PriceForMonth(prices, scheduleTime("2026-02-01T00:00:00Z")).PlanType)
+	assertEqual(t, "march", PlanMax5x, PriceForMonth(prices, scheduleTime("2026-03-01T00:00:00Z")).PlanType)
+	assertEqual(t, "later", PlanMax5x, PriceForMonth(prices, scheduleTime("2026-09-01T00:00:00Z")).PlanType)
+}
+
+func TestNewMonthValue(t *testing.T) {
+	month := scheduleTime("2026-04-01T00:00:00Z")
+	price := &PlanPrice{EffectiveFrom: month, PlanType: PlanMax5x, MonthlyUsd: 100}
+	costs := []DailyModelCost{
+		{Date: scheduleTime("2026-03-31T00:00:00Z"), Sessions: 9, CostUsd: 90},
+		{Date: scheduleTime("2026-04-02T00:00:00Z"), Sessions: 2, CostUsd: 60},
+		{Date: scheduleTime("2026-04-05T00:00:00Z"), Sessions: 1, CostUsd: 30},
+		{Date: scheduleTime("2026-04-09T00:00:00Z"), Sessions: 3, CostUsd: 45},
+		{Date: scheduleTime("2026-05-01T00:00:00Z"), Sessions: 4, CostUsd: 80},
+	}
+
+	v := NewMonthValue(month, price, costs, scheduleTime("2026-06-01T00:00:00Z"))
+	if !floatEquals(v.APICostUsd, 135) || v.Sessions != 6 {
+		t.Errorf("expected $135 over 6 sessions, got $%.2f over %d", v.APICostUsd, v.Sessions)
+	}
+	if v.Partial || !floatEquals(v.ProjectedAPICostUsd, 135) {
+		t.Errorf("expected a complete month, got partial=%v projected=%.2f", v.Partial, v.ProjectedAPICostUsd)
+	}
+	if v.BreakEvenAt == nil || v.BreakEvenAt.Day() != 9 {
+		t.Errorf("expected break-even on April 9, got %v", v.BreakEvenAt)
+	}
+	if !floatEquals(v.ValueRatio(), 1.35) || !floatEquals(v.SavingsUsd(), 35) {
+		t.Errorf("unexpected ratio %.2f or savings %.2f", v.ValueRatio(), v.SavingsUsd())
+	}
+	if !floatEquals(v.BreakEvenDailyUsd(), 100.0/30) {
+		t.Errorf("expected break-even of $%.2f/day, got %.2f", 100.0/30, v.BreakEvenDailyUsd())
+	}
+
+	// Halfway through the month, $90 so far projects to $180
+	v = NewMonthValue(month, price, costs[:3], scheduleTime("2026-04-16T00:00:00Z"))
+	if !v.Partial || !floatEquals(v.ProjectedAPICostUsd, 180) || v.BreakEvenAt != nil {
+		t.Errorf("unexpected partial month: %+v", v)
+	}
+
+	v = NewMonthValue(month, nil, costs, scheduleTime("2026-06-01T00:00:00Z"))
+	if v.ValueRatio() != 0 || v.SavingsUsd() != 0 || v.BreakEvenAt != nil {
+		t.Errorf("expected no comparison without a price, got %+v", v)
+	}
+}
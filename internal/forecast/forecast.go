@@ -0,0 +1,200 @@
+// Package forecast projects spend to the end of the month and predicts when
+// budgets will be exhausted.
+package forecast
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// Forecaster fits spend forecasts to the daily cost of recorded sessions.
+type Forecaster struct {
+	stats    ports.StatsRepository
+	projects ports.ProjectRepository
+}
+
+func NewForecaster(stats ports.StatsRepository, projects ports.ProjectRepository) *Forecaster {
+	return &Forecaster{stats: stats, projects: projects}
+}
+
+// ProjectForecast is the month-end forecast of a single project.
+type ProjectForecast struct {
+	ProjectID string
+	Name      string
+	Forecast  domain.CostForecast
+}
+
+// Report is the month-end forecast overall and per project. Months and days
+// are in UTC, like the daily stats charts.
+type Report struct {
+	MonthStart time.Time
+	MonthEnd   time.Time
+	Overall    domain.CostForecast
+	Projects   []ProjectForecast
+}
+
+// BudgetForecast predicts when a budget will be exhausted within its period.
+// ExhaustsAt is nil when the budget is projected to last the period.
+type BudgetForecast struct {
+	Status     domain.BudgetStatus
+	ExhaustsAt *time.Time
+}
+
+// MonthEnd forecasts spend until the end of the current month. Projects are
+// ordered by projected spend, highest first.
+func (f *Forecaster) MonthEnd(ctx context.Context, now time.Time, method string) (*Report, error) {
+	now = now.UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+
+	h, err := f.load(ctx, now, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		MonthStart: monthStart,
+		MonthEnd:   monthEnd,
+		Overall:    h.forecast(h.overall, method, monthStart, monthEnd),
+		Projects:   []ProjectForecast{},
+	}
+	for projectID, costs := range h.byProject {
+		pf := ProjectForecast{
+			ProjectID: projectID,
+			Name:      projectID,
+			Forecast:  h.forecast(costs, method, monthStart, monthEnd),
+		}
+		if p, _ := f.projects.GetByID(ctx, projectID); p != nil {
+			pf.Name = p.Name
+		}
+		report.Projects = append(report.Projects, pf)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		a, b := report.Projects[i], report.Projects[j]
+		if a.Forecast.ProjectedUsd != b.Forecast.ProjectedUsd {
+			return a.Forecast.ProjectedUsd > b.Forecast.ProjectedUsd
+		}
+		return a.Name < b.Name
+	})
+	return report, nil
+}
+
+// Budgets predicts when each budget will be exhausted. Global and project
+// budgets use the forecast of their scope; experiments have no daily
+// history, so their budgets are extrapolated at the pace of the period so
+// far.
+func (f *Forecaster) Budgets(ctx context.Context, statuses []domain.BudgetStatus, now time.Time, method string) ([]BudgetForecast, error) {
+	forecasts := make([]BudgetForecast, len(statuses))
+	if len(statuses) == 0 {
+		return forecasts, nil
+	}
+
+	earliest := now
+	for _, s := range statuses {
+		if s.Start.Before(earliest) {
+			earliest = s.Start
+		}
+	}
+	h, err := f.load(ctx, now.UTC(), earliest.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	for i, s := range statuses {
+		forecasts[i].Status = s
+		if s.Exceeded() {
+			continue
+		}
+
+		var costs map[time.Time]float64
+		switch s.Budget.Scope() {
+		case domain.BudgetScopeExperiment:
+			forecasts[i].ExhaustsAt = paceExhaustion(s, now)
+			continue
+		case domain.BudgetScopeProject:
+			costs = h.byProject[*s.Budget.ProjectID]
+		default:
+			costs = h.overall
+		}
+		fc := h.forecast(costs, method, h.today, s.End)
+		if at, ok := fc.Exhaustion(s.SpentUsd, s.Budget.AmountUsd, s.End); ok {
+			forecasts[i].ExhaustsAt = &at
+		}
+	}
+	return forecasts, nil
+}
+
+// paceExhaustion extrapolates the spend of a budget's period so far.
+func paceExhaustion(s domain.BudgetStatus, now time.Time) *time.Time {
+	elapsed := now.Sub(s.Start)
+	if s.SpentUsd <= 0 || elapsed <= 0 {
+		return nil
+	}
+	at := s.Start.Add(time.Duration(float64(elapsed) * s.Budget.AmountUsd / s.SpentUsd))
+	if !at.Before(s.End) {
+		return nil
+	}
+	return &at
+}
+
+// history holds daily costs from the start of the fitting window to today.
+type history struct {
+	start     time.Time
+	today     time.Time
+	overall   map[time.Time]float64
+	byProject map[string]map[time.Time]float64
+}
+
+// load fetches daily costs covering the fitting window and since.
+func (f *Forecaster) load(ctx context.Context, now, since time.Time) (*history, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	h := &history{
+		start:     today.AddDate(0, 0, -domain.ForecastHistoryDays),
+		today:     today,
+		overall:   map[time.Time]float64{},
+		byProject: map[string]map[time.Time]float64{},
+	}
+	from := h.start
+	if since.Before(from) {
+		from = since
+	}
+
+	costs, err := f.stats.ListDailyCostByProject(ctx, from.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range costs {
+		h.overall[c.Date] += c.CostUsd
+		if h.byProject[c.ProjectID] == nil {
+			h.byProject[c.ProjectID] = map[time.Time]float64{}
+		}
+		h.byProject[c.ProjectID][c.Date] += c.CostUsd
+	}
+	return h, nil
+}
+
+// forecast fits costs over the window and projects them until end. Spend
+// counts from periodStart; days before the first recorded spend are not
+// fitted, so a new project is not dragged toward zero.
+func (h *history) forecast(costs map[time.Time]float64, method string, periodStart, end time.Time) domain.CostForecast {
+	start := h.start
+	for start.Before(h.today) && costs[start] == 0 {
+		start = start.AddDate(0, 0, 1)
+	}
+	values := []float64{}
+	for day := start; day.Before(h.today); day = day.AddDate(0, 0, 1) {
+		values = append(values, costs[day])
+	}
+
+	var spent float64
+	for day, cost := range costs {
+		if !day.Before(periodStart) && !day.After(h.today) {
+			spent += cost
+		}
+	}
+	return domain.ForecastCost(values, start, method, spent, costs[h.today], end)
+}
@@ -0,0 +1,109 @@
+package forecast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+type fakeStats struct {
+	ports.StatsRepository
+	costs []domain.DailyCost
+}
+
+func (f *fakeStats) ListDailyCostByProject(ctx context.Context, since string) ([]domain.DailyCost, error) {
+	return f.costs, nil
+}
+
+type fakeProjects struct {
+	ports.ProjectRepository
+}
+
+func (f *fakeProjects) GetByID(ctx context.Context, id string) (*domain.Project, error) {
+	return &domain.Project{ID: id, Name: "name-" + id}, nil
+}
+
+func day(s string) time.Time {
+	d, _ := time.Parse(time.DateOnly, s)
+	return d
+}
+
+func TestForecaster(t *testing.T) {
+	ctx := context.Background()
+	stats := &fakeStats{}
+	// $2 a day overall: project a alone until Mar 5, then split with b
+	for d := day("2026-03-01"); !d.After(day("2026-03-10")); d = d.AddDate(0, 0, 1) {
+		if d.Before(day("2026-03-06")) {
+			stats.costs = append(stats.costs, domain.DailyCost{Date: d, ProjectID: "a", CostUsd: 2})
+			continue
+		}
+		stats.costs = append(stats.costs,
+			domain.DailyCost{Date: d, ProjectID: "a", CostUsd: 1},
+			domain.DailyCost{Date: d, ProjectID: "b", CostUsd: 1},
+		)
+	}
+	f := NewForecaster(stats, &fakeProjects{})
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	report, err := f.MonthEnd(ctx, now, domain.ForecastLinear)
+	if err != nil {
+		t.Fatalf("MonthEnd failed: %v", err)
+	}
+	// 20 spent, today's $2 already spent, 21 more days at $2
+	if got := report.Overall.ProjectedUsd; got < 61.99 || got > 62.01 {
+		t.Errorf("overall projected = %v, want 62", got)
+	}
+	if len(report.Projects) != 2 {
+		t.Fatalf("expected 2 projects, got %+v", report.Projects)
+	}
+	for _, p := range report.Projects {
+		if p.Name != "name-"+p.ProjectID {
+			t.Errorf("expected project name to be resolved, got %q", p.Name)
+		}
+		// Days before project b's first spend are not fitted as zeros
+		if p.ProjectID == "b" && (p.Forecast.ProjectedUsd < 25.99 || p.Forecast.ProjectedUsd > 26.01) {
+			t.Errorf("project b projected = %v, want 26", p.Forecast.ProjectedUsd)
+		}
+	}
+
+	projectID := "b"
+	expID := "e"
+	statuses := []domain.BudgetStatus{
+		{
+			Budget:   &domain.Budget{Period: domain.BudgetMonthly, AmountUsd: 50},
+			Start:    day("2026-03-01"),
+			End:      day("2026-04-01"),
+			SpentUsd: 20,
+		},
+		{
+			Budget:   &domain.Budget{Period: domain.BudgetMonthly, AmountUsd: 50, ProjectID: &projectID},
+			Start:    day("2026-03-01"),
+			End:      day("2026-04-01"),
+			SpentUsd: 5,
+		},
+		{
+			Budget:   &domain.Budget{Period: domain.BudgetDaily, AmountUsd: 4, ExperimentID: &expID},
+			Start:    day("2026-03-10"),
+			End:      day("2026-03-11"),
+			SpentUsd: 3,
+		},
+	}
+	budgets, err := f.Budgets(ctx, statuses, now, domain.ForecastLinear)
+	if err != nil {
+		t.Fatalf("Budgets failed: %v", err)
+	}
+	// 20 + 2 a day from Mar 11 reaches 50 on Mar 25
+	if at := budgets[0].ExhaustsAt; at == nil || at.Format(time.DateOnly) != "2026-03-25" {
+		t.Errorf("global budget exhausts at %v, want 2026-03-25", at)
+	}
+	if budgets[1].ExhaustsAt != nil {
+		t.Errorf("expected the project budget to last the month, got %v", budgets[1].ExhaustsAt)
+	}
+	// $3 in 12 hours runs out at $4 after 16 hours
+	if at := budgets[2].ExhaustsAt; at == nil || !at.Equal(time.Date(2026, 3, 10, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("experiment budget exhausts at %v, want 16:00", at)
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
@@ -18,7 +22,16 @@ type ParsedTranscript struct {
 	Tools     []*domain.SessionTool
 	Files     []*domain.SessionFile
 	Commands  []*domain.SessionCommand
+	// ToolCalls holds every tool call, in order.
+	ToolCalls []*domain.SessionToolCall
 	Subagents []*domain.SessionSubagent
+	// FileChanges holds the lines added and removed per file, counted from
+	// Edit, MultiEdit and Write tool inputs.
+	FileChanges []*domain.SessionFileChange
+	// Requests holds the token usage of each assistant message, in order.
+	Requests []domain.UsageRequest
+	// RateLimitHits holds the usage-limit errors the session ran into.
+	RateLimitHits []domain.RateLimitHit
 }
 
 type TranscriptEntry struct {
@@ -29,10 +42,12 @@ type TranscriptEntry struct {
 	Usage             *Usage          `json:"usage,omitempty"`
 	Result            json.RawMessage `json:"result,omitempty"`
 	ToolUseResultData json.RawMessage `json:"toolUseResult,omitempty"`
+	IsAPIErrorMessage bool            `json:"isApiErrorMessage,omitempty"`
 }
 
 type Message struct {
 	Role    string    `json:"role"`
+	Model   string    `json:"model,omitempty"`
 	Content []Content `json:"content"`
 	Usage   *Usage    `json:"usage,omitempty"`
 }
@@ -44,18 +59,51 @@ type Content struct {
 	ToolUseIDRef string          `json:"tool_use_id,omitempty"` // In tool_result entries
 	Name         string          `json:"name,omitempty"`
 	Input        json.RawMessage `json:"input,omitempty"`
+	IsError      bool            `json:"is_error,omitempty"` // In tool_result entries
+	Output       json.RawMessage `json:"content,omitempty"`  // In tool_result entries: a string or text blocks
 }
 
 type Usage struct {
-	InputTokens              int64 `json:"input_tokens"`
-	OutputTokens             int64 `json:"output_tokens"`
-	CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
-	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+	InputTokens              int64         `json:"input_tokens"`
+	OutputTokens             int64         `json:"output_tokens"`
+	CacheReadInputTokens     int64         `json:"cache_read_input_tokens"`
+	CacheCreationInputTokens int64         `json:"cache_creation_input_tokens"`
+	CacheCreation            CacheCreation `json:"cache_creation"`
+	ServerToolUse            ServerToolUse `json:"server_tool_use"`
+}
+
+// CacheCreation splits cache_creation_input_tokens by cache lifetime.
+type CacheCreation struct {
+	Ephemeral5mInputTokens int64 `json:"ephemeral_5m_input_tokens"`
+	Ephemeral1hInputTokens int64 `json:"ephemeral_1h_input_tokens"`
+}
+
+// cacheWriteTiers returns the 5-minute and 1-hour cache writes. Usage
+// without a breakdown was written to the default 5-minute cache.
+func (u *Usage) cacheWriteTiers() (fiveMinutes, oneHour int64) {
+	oneHour = u.CacheCreation.Ephemeral1hInputTokens
+	return u.CacheCreationInputTokens - oneHour, oneHour
+}
+
+// ServerToolUse counts the server-side tool requests billed with a message.
+type ServerToolUse struct {
+	WebSearchRequests int64 `json:"web_search_requests"`
+	WebFetchRequests  int64 `json:"web_fetch_requests"`
 }
 
 type ToolInput struct {
-	FilePath string `json:"file_path,omitempty"`
-	Command  string `json:"command,omitempty"`
+	FilePath  string      `json:"file_path,omitempty"`
+	Command   string      `json:"command,omitempty"`
+	OldString string      `json:"old_string,omitempty"` // Edit
+	NewString string      `json:"new_string,omitempty"` // Edit
+	Content   string      `json:"content,omitempty"`    // Write
+	Edits     []EditInput `json:"edits,omitempty"`      // MultiEdit
+}
+
+// EditInput is one replacement of a MultiEdit tool call.
+type EditInput struct {
+	OldString string `json:"old_string"`
+	NewString string `json:"new_string"`
 }
 
 type ToolResult struct {
@@ -97,15 +145,22 @@ func ParseTranscript(sessionID, path string) (*ParsedTranscript, error) {
 		Metrics: &domain.SessionMetrics{
 			SessionID: sessionID,
 		},
-		Tools:     make([]*domain.SessionTool, 0),
-		Files:     make([]*domain.SessionFile, 0),
-		Commands:  make([]*domain.SessionCommand, 0),
-		Subagents: make([]*domain.SessionSubagent, 0),
+		Tools:         make([]*domain.SessionTool, 0),
+		Files:         make([]*domain.SessionFile, 0),
+		FileChanges:   make([]*domain.SessionFileChange, 0),
+		Commands:      make([]*domain.SessionCommand, 0),
+		ToolCalls:     make([]*domain.SessionToolCall, 0),
+		Subagents:     make([]*domain.SessionSubagent, 0),
+		Requests:      make([]domain.UsageRequest, 0),
+		RateLimitHits: make([]domain.RateLimitHit, 0),
 	}
 
 	toolCounts := make(map[string]*domain.SessionTool)
 	fileCounts := make(map[string]*domain.SessionFile) // key: filepath:operation
+	lines := newLineTracker(sessionID)
 	pendingSubagents := make(map[string]*pendingSubagent)
+	pendingCommands := make(map[string]*domain.SessionCommand) // by tool_use ID
+	pendingCalls := make(map[string]*domain.SessionToolCall)   // by tool_use ID
 
 	scanner := bufio.NewScanner(file)
 	// Increase buffer size for large lines
@@ -128,6 +183,7 @@ func ParseTranscript(sessionID, path string) (*ParsedTranscript, error) {
 		}
 
 		// Track timestamps
+		var entryTime *time.Time
 		if entry.Timestamp != "" {
 			t, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
 			if err == nil {
@@ -135,6 +191,7 @@ func ParseTranscript(sessionID, path string) (*ParsedTranscript, error) {
 					firstTimestamp = &t
 				}
 				lastTimestamp = &t
+				entryTime = &t
 			}
 		}
 
@@ -146,6 +203,10 @@ func ParseTranscript(sessionID, path string) (*ParsedTranscript, error) {
 			if len(entry.ToolUseResultData) > 0 && entry.Message != nil {
 				processSubagentResult(entry, sessionID, pendingSubagents, result)
 			}
+			if entry.Message != nil {
+				processCommandResults(entry.Message, pendingCommands)
+				processToolCallResults(entry.Message, pendingCalls)
+			}
 		case "assistant":
 			result.Metrics.MessageCountAssistant++
 			// Capture model ID from assistant messages (use first occurrence)
@@ -154,7 +215,16 @@ func ParseTranscript(sessionID, path string) (*ParsedTranscript, error) {
 				modelID = &m
 			}
 			if entry.Message != nil {
-				processAssistantMessage(entry.Message, sessionID, toolCounts, fileCounts, pendingSubagents, result)
+				processAssistantMessage(entry.Message, sessionID, toolCounts, fileCounts, lines, pendingSubagents, pendingCommands, pendingCalls, result)
+				if entry.IsAPIErrorMessage && entryTime != nil {
+					if window, ok := rateLimitWindow(entry.Message); ok {
+						result.RateLimitHits = append(result.RateLimitHits, domain.RateLimitHit{
+							SessionID: sessionID,
+							HitAt:     *entryTime,
+							Window:    window,
+						})
+					}
+				}
 			}
 		case "result":
 			// Tool results - check for errors
@@ -171,6 +241,29 @@ func ParseTranscript(sessionID, path string) (*ParsedTranscript, error) {
 			result.Metrics.TokenOutput += usage.OutputTokens
 			result.Metrics.TokenCacheRead += usage.CacheReadInputTokens
 			result.Metrics.TokenCacheWrite += usage.CacheCreationInputTokens
+			fiveMinutes, oneHour := usage.cacheWriteTiers()
+			result.Metrics.TokenCacheWrite5m += fiveMinutes
+			result.Metrics.TokenCacheWrite1h += oneHour
+			result.Metrics.WebSearchRequests += usage.ServerToolUse.WebSearchRequests
+			result.Metrics.WebFetchRequests += usage.ServerToolUse.WebFetchRequests
+
+			if entry.Type == "assistant" && entryTime != nil && *usage != (Usage{}) {
+				req := domain.UsageRequest{
+					SessionID:       sessionID,
+					Timestamp:       *entryTime,
+					TokenInput:      usage.InputTokens,
+					TokenOutput:     usage.OutputTokens,
+					TokenCacheRead:  usage.CacheReadInputTokens,
+					TokenCacheWrite: usage.CacheCreationInputTokens,
+				}
+				if m := entry.Model; m != "" {
+					req.Model = &m
+				} else if entry.Message != nil && entry.Message.Model != "" {
+					m := entry.Message.Model
+					req.Model = &m
+				}
+				result.Requests = append(result.Requests, req)
+			}
 		}
 	}
 
@@ -193,11 +286,17 @@ func ParseTranscript(sessionID, path string) (*ParsedTranscript, error) {
 	for _, file := range fileCounts {
 		result.Files = append(result.Files, file)
 	}
+	result.FileChanges = lines.fileChanges()
+	result.Metrics.LinesAdded, result.Metrics.LinesRemoved = domain.SumLineChanges(result.FileChanges)
+	if len(result.FileChanges) > 0 {
+		result.Metrics.LinesSource = domain.LinesSourceTools
+	}
+	result.Metrics.Outcome = domain.NewSessionOutcome(result.Commands)
 
 	return result, nil
 }
 
-func processAssistantMessage(msg *Message, sessionID string, toolCounts map[string]*domain.SessionTool, fileCounts map[string]*domain.SessionFile, pendingSubs map[string]*pendingSubagent, result *ParsedTranscript) {
+func processAssistantMessage(msg *Message, sessionID string, toolCounts map[string]*domain.SessionTool, fileCounts map[string]*domain.SessionFile, lines *lineTracker, pendingSubs map[string]*pendingSubagent, pendingCmds map[string]*domain.SessionCommand, pendingCalls map[string]*domain.SessionToolCall, result *ParsedTranscript) {
 	for _, content := range msg.Content {
 		if content.Type != "tool_use" {
 			continue
@@ -219,6 +318,17 @@ func processAssistantMessage(msg *Message, sessionID string, toolCounts map[stri
 			}
 		}
 
+		call := &domain.SessionToolCall{
+			SessionID: sessionID,
+			Sequence:  int64(len(result.ToolCalls) + 1),
+			ToolName:  toolName,
+			Label:     toolName,
+		}
+		result.ToolCalls = append(result.ToolCalls, call)
+		if content.ToolUseID != "" {
+			pendingCalls[content.ToolUseID] = call
+		}
+
 		// Detect sub-agent invocations (Task or Skill tool_use)
 		if (toolName == "Task" || toolName == "Skill") && len(content.Input) > 0 && content.ToolUseID != "" {
 			var subInput SubagentToolInput
@@ -269,20 +379,44 @@ func processAssistantMessage(msg *Message, sessionID string, toolCounts map[stri
 							}
 						}
 					}
+					lines.add(toolName, input)
 				}
 
 				// Track bash commands
 				if input.Command != "" && toolName == "Bash" {
-					result.Commands = append(result.Commands, &domain.SessionCommand{
+					call.Label = domain.ToolCallLabel(toolName, input.Command)
+					cmd := &domain.SessionCommand{
 						SessionID: sessionID,
 						Command:   input.Command,
-					})
+					}
+					result.Commands = append(result.Commands, cmd)
+					if content.ToolUseID != "" {
+						pendingCmds[content.ToolUseID] = cmd
+					}
 				}
 			}
 		}
 	}
 }
 
+// rateLimitWindow reports whether an API error message is a usage-limit
+// error, and which window it refers to. Claude Code reports these as e.g.
+// "Claude AI usage limit reached|1760000000", "5-hour limit reached ∙ resets
+// 2am" or "Weekly limit reached ∙ resets Oct 20, 9am".
+func rateLimitWindow(msg *Message) (string, bool) {
+	for _, c := range msg.Content {
+		text := strings.ToLower(c.Text)
+		if !strings.Contains(text, "limit reached") {
+			continue
+		}
+		if strings.Contains(text, "weekly") {
+			return domain.WindowWeekly, true
+		}
+		return domain.WindowSession, true
+	}
+	return "", false
+}
+
 func processSubagentResult(entry TranscriptEntry, sessionID string, pendingSubs map[string]*pendingSubagent, result *ParsedTranscript) {
 	// Parse the toolUseResult
 	var toolUseResult ToolUseResult
@@ -336,12 +470,17 @@ func processSubagentResult(entry TranscriptEntry, sessionID string, pendingSubs
 		subagent.TokenOutput = toolUseResult.Usage.OutputTokens
 		subagent.TokenCacheRead = toolUseResult.Usage.CacheReadInputTokens
 		subagent.TokenCacheWrite = toolUseResult.Usage.CacheCreationInputTokens
+		subagent.TokenCacheWrite5m, subagent.TokenCacheWrite1h = toolUseResult.Usage.cacheWriteTiers()
 
 		// Accumulate sub-agent tokens into session totals
 		result.Metrics.TokenInput += toolUseResult.Usage.InputTokens
 		result.Metrics.TokenOutput += toolUseResult.Usage.OutputTokens
 		result.Metrics.TokenCacheRead += toolUseResult.Usage.CacheReadInputTokens
 		result.Metrics.TokenCacheWrite += toolUseResult.Usage.CacheCreationInputTokens
+		result.Metrics.TokenCacheWrite5m += subagent.TokenCacheWrite5m
+		result.Metrics.TokenCacheWrite1h += subagent.TokenCacheWrite1h
+		result.Metrics.WebSearchRequests += toolUseResult.Usage.ServerToolUse.WebSearchRequests
+		result.Metrics.WebFetchRequests += toolUseResult.Usage.ServerToolUse.WebFetchRequests
 	}
 
 	result.Subagents = append(result.Subagents, subagent)
@@ -350,6 +489,72 @@ func processSubagentResult(entry TranscriptEntry, sessionID string, pendingSubs
 	delete(pendingSubs, matchedToolUseID)
 }
 
+// exitCodePattern matches the exit code Claude Code puts at the start of a
+// failed Bash command's output.
+var exitCodePattern = regexp.MustCompile(`^(?:Error: )?Exit code (\d+)`)
+
+// processCommandResults sets the exit code of the Bash commands whose
+// results a user message carries: 0 unless the result is an error, whose
+// output starts with the exit code.
+func processCommandResults(msg *Message, pending map[string]*domain.SessionCommand) {
+	for _, content := range msg.Content {
+		if content.Type != "tool_result" {
+			continue
+		}
+		cmd, ok := pending[content.ToolUseIDRef]
+		if !ok {
+			continue
+		}
+		delete(pending, content.ToolUseIDRef)
+		if cmd.ExitCode != nil {
+			continue
+		}
+		code := 0
+		if content.IsError {
+			code = 1
+			if m := exitCodePattern.FindStringSubmatch(toolResultText(content.Output)); m != nil {
+				code, _ = strconv.Atoi(m[1])
+			}
+		}
+		cmd.ExitCode = &code
+	}
+}
+
+// processToolCallResults records the size of the results a user message
+// carries on their tool calls, and marks them as errors when they are.
+func processToolCallResults(msg *Message, pending map[string]*domain.SessionToolCall) {
+	for _, content := range msg.Content {
+		if content.Type != "tool_result" {
+			continue
+		}
+		if call, ok := pending[content.ToolUseIDRef]; ok {
+			call.IsError = content.IsError
+			call.ResultBytes = int64(len(toolResultText(content.Output)))
+			delete(pending, content.ToolUseIDRef)
+		}
+	}
+}
+
+// toolResultText returns the text of a tool result's content, given either
+// as a string or as text blocks.
+func toolResultText(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var blocks []struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+	parts := make([]string, len(blocks))
+	for i, b := range blocks {
+		parts[i] = b.Text
+	}
+	return strings.Join(parts, "\n")
+}
+
 func processToolResult(entry TranscriptEntry, result *ParsedTranscript) {
 	if len(entry.Result) == 0 {
 		return
@@ -380,9 +585,66 @@ func getFileOperation(toolName string) string {
 		return "read"
 	case "Write":
 		return "write"
-	case "Edit":
+	case "Edit", "MultiEdit":
 		return "edit"
 	default:
 		return ""
 	}
 }
+
+// lineTracker counts the lines each Edit, MultiEdit and Write tool call adds
+// and removes. A Write is diffed against the file's previous Write in the
+// session; without one, all its lines count as added. Edits with
+// replace_all count a single replacement.
+type lineTracker struct {
+	sessionID string
+	changes   map[string]*domain.SessionFileChange
+	written   map[string]string // last content written per path
+}
+
+func newLineTracker(sessionID string) *lineTracker {
+	return &lineTracker{
+		sessionID: sessionID,
+		changes:   make(map[string]*domain.SessionFileChange),
+		written:   make(map[string]string),
+	}
+}
+
+func (t *lineTracker) add(toolName string, input ToolInput) {
+	var added, removed int64
+	switch toolName {
+	case "Edit":
+		added, removed = domain.CountLineChanges(input.OldString, input.NewString)
+	case "MultiEdit":
+		for _, e := range input.Edits {
+			a, r := domain.CountLineChanges(e.OldString, e.NewString)
+			added, removed = added+a, removed+r
+		}
+	case "Write":
+		added, removed = domain.CountLineChanges(t.written[input.FilePath], input.Content)
+		t.written[input.FilePath] = input.Content
+	default:
+		return
+	}
+	if added == 0 && removed == 0 {
+		return
+	}
+
+	c, ok := t.changes[input.FilePath]
+	if !ok {
+		c = &domain.SessionFileChange{SessionID: t.sessionID, FilePath: input.FilePath}
+		t.changes[input.FilePath] = c
+	}
+	c.LinesAdded += added
+	c.LinesRemoved += removed
+}
+
+// fileChanges returns the changes by path.
+func (t *lineTracker) fileChanges() []*domain.SessionFileChange {
+	changes := make([]*domain.SessionFileChange, 0, len(t.changes))
+	for _, c := range t.changes {
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].FilePath < changes[j].FilePath })
+	return changes
+}
@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
 )
 
 func TestParseTranscript_SubagentDetection(t *testing.T) {
@@ -99,3 +102,196 @@ func assertEqual[T comparable](t *testing.T, name string, expected, actual T) {
 		t.Errorf("%s: expected %v, got %v", name, expected, actual)
 	}
 }
+
+func TestParseTranscript_UsageRequestsAndRateLimits(t *testing.T) {
+	content := `{"type":"user","timestamp":"2025-01-17T10:00:00Z","message":{"role":"user","content":[{"type":"text","text":"Hello"}]}}
+{"type":"assistant","model":"claude-sonnet-4-20250514","timestamp":"2025-01-17T10:00:05Z","message":{"role":"assistant","content":[{"type":"text","text":"Hi!"}]},"usage":{"input_tokens":100,"output_tokens":50,"cache_read_input_tokens":10,"cache_creation_input_tokens":5}}
+{"type":"assistant","timestamp":"2025-01-17T10:01:00Z","message":{"role":"assistant","model":"claude-opus-4-20250514","content":[{"type":"text","text":"More"}],"usage":{"input_tokens":20,"output_tokens":30,"cache_read_input_tokens":0,"cache_creation_input_tokens":0}}}
+{"type":"assistant","timestamp":"2025-01-17T10:02:00Z","isApiErrorMessage":true,"message":{"role":"assistant","content":[{"type":"text","text":"5-hour limit reached ∙ resets 2pm"}],"usage":{"input_tokens":0,"output_tokens":0,"cache_read_input_tokens":0,"cache_creation_input_tokens":0}}}
+{"type":"assistant","timestamp":"2025-01-17T10:03:00Z","isApiErrorMessage":true,"message":{"role":"assistant","content":[{"type":"text","text":"Weekly limit reached ∙ resets Jan 20, 9am"}]}}
+{"type":"assistant","timestamp":"2025-01-17T10:04:00Z","isApiErrorMessage":true,"message":{"role":"assistant","content":[{"type":"text","text":"API Error: 500 Internal server error"}]}}
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test transcript: %v", err)
+	}
+
+	result, err := ParseTranscript("test-session", path)
+	if err != nil {
+		t.Fatalf("ParseTranscript failed: %v", err)
+	}
+
+	// Error messages with zero usage are not requests
+	if len(result.Requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(result.Requests))
+	}
+	r0 := result.Requests[0]
+	assertEqual(t, "r0.TokenInput", int64(100), r0.TokenInput)
+	assertEqual(t, "r0.TokenCacheWrite", int64(5), r0.TokenCacheWrite)
+	assertEqual(t, "r0.Timestamp", "2025-01-17T10:00:05Z", r0.Timestamp.Format(time.RFC3339))
+	if r0.Model == nil || *r0.Model != "claude-sonnet-4-20250514" {
+		t.Errorf("Expected model from entry, got %v", r0.Model)
+	}
+	r1 := result.Requests[1]
+	if r1.Model == nil || *r1.Model != "claude-opus-4-20250514" {
+		t.Errorf("Expected model from message, got %v", r1.Model)
+	}
+
+	if len(result.RateLimitHits) != 2 {
+		t.Fatalf("Expected 2 rate limit hits, got %d", len(result.RateLimitHits))
+	}
+	assertEqual(t, "hit0.Window", domain.WindowSession, result.RateLimitHits[0].Window)
+	assertEqual(t, "hit1.Window", domain.WindowWeekly, result.RateLimitHits[1].Window)
+	assertEqual(t, "hit1.SessionID", "test-session", result.RateLimitHits[1].SessionID)
+}
+
+func TestParseTranscript_ServerToolUse(t *testing.T) {
+	content := `{"type":"assistant","timestamp":"2025-01-17T10:00:05Z","message":{"role":"assistant","content":[{"type":"text","text":"Searching."},{"type":"tool_use","id":"task1","name":"Task","input":{"subagent_type":"general-purpose","prompt":"Research"}}],"usage":{"input_tokens":100,"output_tokens":50,"server_tool_use":{"web_search_requests":2,"web_fetch_requests":1}}}}
+{"type":"user","timestamp":"2025-01-17T10:00:10Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"task1","content":"Done"}]},"toolUseResult":{"status":"completed","usage":{"input_tokens":400,"output_tokens":50,"server_tool_use":{"web_search_requests":3}}}}
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test transcript: %v", err)
+	}
+
+	result, err := ParseTranscript("test-session", path)
+	if err != nil {
+		t.Fatalf("ParseTranscript failed: %v", err)
+	}
+
+	// Sub-agent requests count toward the session, like its tokens
+	assertEqual(t, "metrics.WebSearchRequests", int64(5), result.Metrics.WebSearchRequests)
+	assertEqual(t, "metrics.WebFetchRequests", int64(1), result.Metrics.WebFetchRequests)
+	assertEqual(t, "metrics.TokenInput", int64(500), result.Metrics.TokenInput)
+}
+
+func TestParseTranscript_CacheWriteTiers(t *testing.T) {
+	content := `{"type":"assistant","timestamp":"2025-01-17T10:00:05Z","message":{"role":"assistant","content":[{"type":"text","text":"Hi"}],"usage":{"input_tokens":10,"cache_creation_input_tokens":300,"cache_creation":{"ephemeral_5m_input_tokens":100,"ephemeral_1h_input_tokens":200}}}}
+{"type":"assistant","timestamp":"2025-01-17T10:00:10Z","message":{"role":"assistant","content":[{"type":"text","text":"Again"}],"usage":{"input_tokens":10,"cache_creation_input_tokens":50}}}
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test transcript: %v", err)
+	}
+
+	result, err := ParseTranscript("test-session", path)
+	if err != nil {
+		t.Fatalf("ParseTranscript failed: %v", err)
+	}
+
+	// Cache writes without a breakdown count as 5-minute writes
+	assertEqual(t, "metrics.TokenCacheWrite", int64(350), result.Metrics.TokenCacheWrite)
+	assertEqual(t, "metrics.TokenCacheWrite5m", int64(150), result.Metrics.TokenCacheWrite5m)
+	assertEqual(t, "metrics.TokenCacheWrite1h", int64(200), result.Metrics.TokenCacheWrite1h)
+}
+
+func TestParseTranscript_LineChanges(t *testing.T) {
+	content := `{"type":"assistant","timestamp":"2025-01-17T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"w1","name":"Write","input":{"file_path":"/p/a.go","content":"package a\n\nfunc A() {}\n"}}]}}
+{"type":"assistant","timestamp":"2025-01-17T10:00:05Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"e1","name":"Edit","input":{"file_path":"/p/a.go","old_string":"func A() {}","new_string":"func A() {\n\treturn\n}"}}]}}
+{"type":"assistant","timestamp":"2025-01-17T10:00:10Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"m1","name":"MultiEdit","input":{"file_path":"/p/b.go","edits":[{"old_string":"x := 1","new_string":"x := 2"},{"old_string":"// old\n","new_string":""}]}}]}}
+{"type":"assistant","timestamp":"2025-01-17T10:00:15Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"w2","name":"Write","input":{"file_path":"/p/a.go","content":"package a\n\nfunc A() {\n\treturn\n}\n\nfunc B() {}\n"}}]}}
+{"type":"assistant","timestamp":"2025-01-17T10:00:20Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"r1","name":"Read","input":{"file_path":"/p/c.go"}}]}}
+`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test transcript: %v", err)
+	}
+
+	result, err := ParseTranscript("test-session", path)
+	if err != nil {
+		t.Fatalf("ParseTranscript failed: %v", err)
+	}
+
+	if len(result.FileChanges) != 2 {
+		t.Fatalf("Expected changes to 2 files, got %+v", result.FileChanges)
+	}
+	// a.go: written (+3), edited (+3 -1), then rewritten against the first
+	// write, which adds the edit again and B (+5 -1)
+	a := result.FileChanges[0]
+	assertEqual(t, "a.FilePath", "/p/a.go", a.FilePath)
+	assertEqual(t, "a.SessionID", "test-session", a.SessionID)
+	assertEqual(t, "a.LinesAdded", int64(11), a.LinesAdded)
+	assertEqual(t, "a.LinesRemoved", int64(2), a.LinesRemoved)
+	b := result.FileChanges[1]
+	assertEqual(t, "b.LinesAdded", int64(1), b.LinesAdded)
+	assertEqual(t, "b.LinesRemoved", int64(2), b.LinesRemoved)
+
+	assertEqual(t, "LinesAdded", int64(12), result.Metrics.LinesAdded)
+	assertEqual(t, "LinesRemoved", int64(4), result.Metrics.LinesRemoved)
+	assertEqual(t, "LinesSource", domain.LinesSourceTools, result.Metrics.LinesSource)
+
+	var multiEdits int64
+	for _, f := range result.Files {
+		if f.FilePath == "/p/b.go" && f.Operation == "edit" {
+			multiEdits = f.OperationCount
+		}
+	}
+	assertEqual(t, "MultiEdit counted as edit", int64(1), multiEdits)
+}
+
+func TestParseTranscript_CommandOutcome(t *testing.T) {
+	content := `{"type":"assistant","timestamp":"2025-01-17T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"b1","name":"Bash","input":{"command":"go test ./..."}}]}}
+{"type":"user","timestamp":"2025-01-17T10:00:05Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"b1","is_error":true,"content":"Exit code 1\n--- FAIL: TestX"}]}}
+{"type":"assistant","timestamp":"2025-01-17T10:00:10Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"b2","name":"Bash","input":{"command":"go build ./... && go test ./..."}}]}}
+{"type":"user","timestamp":"2025-01-17T10:00:15Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"b2","content":[{"type":"text","text":"ok"}]}]}}
+{"type":"assistant","timestamp":"2025-01-17T10:00:20Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"b3","name":"Bash","input":{"command":"golangci-lint run"}}]}}
+{"type":"user","timestamp":"2025-01-17T10:00:25Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"b3","is_error":true,"content":"Error: Exit code 2"}]}}
+`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test transcript: %v", err)
+	}
+
+	result, err := ParseTranscript("test-session", path)
+	if err != nil {
+		t.Fatalf("ParseTranscript failed: %v", err)
+	}
+
+	if len(result.Commands) != 3 {
+		t.Fatalf("Expected 3 commands, got %d", len(result.Commands))
+	}
+	for i, want := range []int{1, 0, 2} {
+		if got := result.Commands[i].ExitCode; got == nil || *got != want {
+			t.Errorf("Command %d: expected exit code %d, got %v", i, want, got)
+		}
+	}
+	assertEqual(t, "Checks", "tests passed, build passed, lint failed", result.Metrics.Outcome.Summary())
+}
+
+func TestParseTranscript_ToolCalls(t *testing.T) {
+	content := `{"type":"assistant","timestamp":"2025-01-17T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Grep","input":{"pattern":"x"}},{"type":"tool_use","id":"t2","name":"Edit","input":{"file_path":"/a.go","old_string":"a","new_string":"b"}}]}}
+{"type":"user","timestamp":"2025-01-17T10:00:05Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"a.go"},{"type":"tool_result","tool_use_id":"t2","content":"ok"}]}}
+{"type":"assistant","timestamp":"2025-01-17T10:00:10Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t3","name":"Bash","input":{"command":"go test ./..."}}]}}
+{"type":"user","timestamp":"2025-01-17T10:00:15Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t3","is_error":true,"content":"Exit code 1"}]}}
+`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test transcript: %v", err)
+	}
+
+	result, err := ParseTranscript("test-session", path)
+	if err != nil {
+		t.Fatalf("ParseTranscript failed: %v", err)
+	}
+
+	if len(result.ToolCalls) != 3 {
+		t.Fatalf("Expected 3 tool calls, got %d", len(result.ToolCalls))
+	}
+	for i, want := range []string{"Grep", "Edit", "Bash(test)"} {
+		assertEqual(t, "Label", want, result.ToolCalls[i].Label)
+		assertEqual(t, "Sequence", int64(i+1), result.ToolCalls[i].Sequence)
+	}
+	if result.ToolCalls[1].IsError || !result.ToolCalls[2].IsError {
+		t.Errorf("Expected only the Bash call to fail, got %+v", result.ToolCalls)
+	}
+	assertEqual(t, "ResultBytes", int64(len("Exit code 1")), result.ToolCalls[2].ResultBytes)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type AlertRepository interface {
+	Claim(ctx context.Context, firing *domain.AlertFiring) (bool, error)
+	ListRecent(ctx context.Context, limit int) ([]*domain.AlertFiring, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type AnomalyRepository interface {
+	Set(ctx context.Context, anomaly *domain.SessionAnomaly) error
+	GetBySessionID(ctx context.Context, sessionID string) (*domain.SessionAnomaly, error)
+	Clear(ctx context.Context, sessionID string) error
+}
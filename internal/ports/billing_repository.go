@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type BillingRepository interface {
+	SetBatch(ctx context.Context, usage []domain.BillingUsage) error
+	ListSince(ctx context.Context, since string) ([]domain.BillingUsage, error)
+}
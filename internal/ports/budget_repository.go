@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type BudgetRepository interface {
+	Set(ctx context.Context, budget *domain.Budget) error
+	List(ctx context.Context) ([]*domain.Budget, error)
+	Delete(ctx context.Context, id string) (bool, error)
+}
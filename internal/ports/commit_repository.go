@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type ListCommitsOptions struct {
+	Limit        int
+	ExperimentID *string
+	// Since restricts the list to commits made at or after an RFC3339 time.
+	Since string
+}
+
+type CommitRepository interface {
+	// ReplaceForSession replaces the commits recorded for a session, so
+	// re-recording it drops commits that were amended or reset away.
+	ReplaceForSession(ctx context.Context, sessionID string, commits []*domain.SessionCommit) error
+	ListBySessionID(ctx context.Context, sessionID string) ([]*domain.SessionCommit, error)
+	List(ctx context.Context, opts ListCommitsOptions) ([]*domain.CommitListItem, error)
+	ListStatsByExperiment(ctx context.Context) ([]domain.ExperimentCommitStats, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type ExperimentNoteRepository interface {
+	Create(ctx context.Context, note *domain.ExperimentNote) error
+	ListByExperimentID(ctx context.Context, experimentID string) ([]*domain.ExperimentNote, error)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
 )
@@ -13,6 +14,7 @@ type ExperimentRepository interface {
 	GetActive(ctx context.Context) (*domain.Experiment, error)
 	List(ctx context.Context) ([]*domain.Experiment, error)
 	Update(ctx context.Context, experiment *domain.Experiment) error
+	SetConclusion(ctx context.Context, id string, verdict, conclusion *string, concludedAt *time.Time) error
 	Delete(ctx context.Context, id string) error
 	Activate(ctx context.Context, id string) error
 	Deactivate(ctx context.Context, id string) error
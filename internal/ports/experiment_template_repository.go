@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type ExperimentTemplateRepository interface {
+	Save(ctx context.Context, tmpl *domain.ExperimentTemplate) error
+	GetByName(ctx context.Context, name string) (*domain.ExperimentTemplate, error)
+	List(ctx context.Context) ([]*domain.ExperimentTemplate, error)
+	Delete(ctx context.Context, name string) error
+}
@@ -22,10 +22,18 @@ func TestSessionToolRepositoryConformance(t *testing.T) {
 	var _ ports.SessionToolRepository = (*turso.SessionToolRepository)(nil)
 }
 
+func TestSessionToolCallRepositoryConformance(t *testing.T) {
+	var _ ports.SessionToolCallRepository = (*turso.SessionToolCallRepository)(nil)
+}
+
 func TestSessionFileRepositoryConformance(t *testing.T) {
 	var _ ports.SessionFileRepository = (*turso.SessionFileRepository)(nil)
 }
 
+func TestSessionFileChangeRepositoryConformance(t *testing.T) {
+	var _ ports.SessionFileChangeRepository = (*turso.SessionFileChangeRepository)(nil)
+}
+
 func TestSessionCommandRepositoryConformance(t *testing.T) {
 	var _ ports.SessionCommandRepository = (*turso.SessionCommandRepository)(nil)
 }
@@ -34,6 +42,10 @@ func TestSessionSubagentRepositoryConformance(t *testing.T) {
 	var _ ports.SessionSubagentRepository = (*turso.SessionSubagentRepository)(nil)
 }
 
+func TestSessionGitStartRepositoryConformance(t *testing.T) {
+	var _ ports.SessionGitStartRepository = (*turso.SessionGitStartRepository)(nil)
+}
+
 func TestExperimentRepositoryConformance(t *testing.T) {
 	var _ ports.ExperimentRepository = (*turso.ExperimentRepository)(nil)
 }
@@ -42,6 +54,46 @@ func TestExperimentVariableRepositoryConformance(t *testing.T) {
 	var _ ports.ExperimentVariableRepository = (*turso.ExperimentVariableRepository)(nil)
 }
 
+func TestExperimentTemplateRepositoryConformance(t *testing.T) {
+	var _ ports.ExperimentTemplateRepository = (*turso.ExperimentTemplateRepository)(nil)
+}
+
+func TestExperimentNoteRepositoryConformance(t *testing.T) {
+	var _ ports.ExperimentNoteRepository = (*turso.ExperimentNoteRepository)(nil)
+}
+
+func TestBudgetRepositoryConformance(t *testing.T) {
+	var _ ports.BudgetRepository = (*turso.BudgetRepository)(nil)
+}
+
+func TestPlanRepositoryConformance(t *testing.T) {
+	var _ ports.PlanRepository = (*turso.PlanRepository)(nil)
+}
+
+func TestUsageRepositoryConformance(t *testing.T) {
+	var _ ports.UsageRepository = (*turso.UsageRepository)(nil)
+}
+
+func TestAnomalyRepositoryConformance(t *testing.T) {
+	var _ ports.AnomalyRepository = (*turso.AnomalyRepository)(nil)
+}
+
+func TestAlertRepositoryConformance(t *testing.T) {
+	var _ ports.AlertRepository = (*turso.AlertRepository)(nil)
+}
+
+func TestPlanPriceRepositoryConformance(t *testing.T) {
+	var _ ports.PlanPriceRepository = (*turso.PlanPriceRepository)(nil)
+}
+
+func TestPricingProfileRepositoryConformance(t *testing.T) {
+	var _ ports.PricingProfileRepository = (*turso.PricingProfileRepository)(nil)
+}
+
+func TestRotationGroupRepositoryConformance(t *testing.T) {
+	var _ ports.RotationGroupRepository = (*turso.RotationGroupRepository)(nil)
+}
+
 func TestProjectRepositoryConformance(t *testing.T) {
 	var _ ports.ProjectRepository = (*turso.ProjectRepository)(nil)
 }
@@ -57,3 +109,11 @@ func TestStatsRepositoryConformance(t *testing.T) {
 func TestToolEventRepositoryConformance(t *testing.T) {
 	var _ ports.ToolEventRepository = (*turso.ToolEventRepository)(nil)
 }
+
+func TestBillingRepositoryConformance(t *testing.T) {
+	var _ ports.BillingRepository = (*turso.BillingRepository)(nil)
+}
+
+func TestCommitRepositoryConformance(t *testing.T) {
+	var _ ports.CommitRepository = (*turso.CommitRepository)(nil)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type PlanPriceRepository interface {
+	Set(ctx context.Context, price *domain.PlanPrice) error
+	List(ctx context.Context) ([]*domain.PlanPrice, error)
+	Delete(ctx context.Context, effectiveFrom time.Time) (bool, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type PlanRepository interface {
+	Get(ctx context.Context) (*domain.PlanConfig, error)
+	Set(ctx context.Context, plan *domain.PlanConfig) error
+	Clear(ctx context.Context) error
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type PricingProfileRepository interface {
+	Set(ctx context.Context, profile *domain.PricingProfile) error
+	GetByName(ctx context.Context, name string) (*domain.PricingProfile, error)
+	GetDefault(ctx context.Context) (*domain.PricingProfile, error)
+	List(ctx context.Context) ([]*domain.PricingProfile, error)
+	SetDefault(ctx context.Context, name string) error
+	Delete(ctx context.Context, name string) (bool, error)
+	SetRate(ctx context.Context, name string, rate *domain.ModelPricing) error
+	DeleteRate(ctx context.Context, name, modelID string) (bool, error)
+	SetProject(ctx context.Context, projectID, name string) error
+	ClearProject(ctx context.Context, projectID string) (bool, error)
+	GetForProject(ctx context.Context, projectID string) (*domain.PricingProfile, error)
+	ListProjects(ctx context.Context) (map[string]string, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type RotationGroupRepository interface {
+	Create(ctx context.Context, group *domain.RotationGroup) error
+	GetByName(ctx context.Context, name string) (*domain.RotationGroup, error)
+	List(ctx context.Context) ([]*domain.RotationGroup, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
 )
@@ -12,18 +13,47 @@ type SessionRepository interface {
 	List(ctx context.Context, opts ListSessionsOptions) ([]*domain.Session, error)
 	ListWithMetrics(ctx context.Context, opts ListSessionsOptions) ([]*domain.SessionListItem, error)
 	Delete(ctx context.Context, id string) error
+	// DeleteDetails deletes what was parsed from a session's transcript, so
+	// saving the session again does not count it twice.
+	DeleteDetails(ctx context.Context, id string) error
 	DeleteBefore(ctx context.Context, before string) (int64, error)
 	DeleteByProject(ctx context.Context, projectID string) (int64, error)
 	DeleteByExperiment(ctx context.Context, experimentID string) (int64, error)
 	GetTranscriptPathsBefore(ctx context.Context, before string) ([]domain.TranscriptPathInfo, error)
 	GetTranscriptPathsByProject(ctx context.Context, projectID string) ([]domain.TranscriptPathInfo, error)
 	GetTranscriptPathsByExperiment(ctx context.Context, experimentID string) ([]domain.TranscriptPathInfo, error)
+	FindIDsByPrefix(ctx context.Context, prefix string) ([]string, error)
+	ListGitBranches(ctx context.Context) ([]string, error)
+	ListGitRepositories(ctx context.Context) ([]string, error)
+	ListIDsCreatedBetween(ctx context.Context, since, until string, projectID *string) ([]string, error)
+	ListExcluded(ctx context.Context, limit int) ([]*domain.Session, error)
+	AssignExperiment(ctx context.Context, sessionIDs []string, experimentID string) error
+	SetExcluded(ctx context.Context, sessionIDs []string, excluded bool, reason *string) error
 }
 
 type ListSessionsOptions struct {
 	Limit        int
 	ProjectID    *string
 	ExperimentID *string
+	// AnomalousOnly restricts ListWithMetrics to flagged sessions.
+	AnomalousOnly bool
+	// Branch restricts ListWithMetrics to sessions on a git branch, and
+	// Repository to those whose repository root or remote URL contains it.
+	Branch     string
+	Repository string
+	// Outcome restricts ListWithMetrics to sessions with a domain
+	// SessionOutcome, such as "green".
+	Outcome string
+}
+
+// SessionGitStartRepository keeps the git state captured when a session
+// starts, until the session is recorded.
+type SessionGitStartRepository interface {
+	// Set stores the state unless one was already captured for the session,
+	// so a resumed session keeps its original start.
+	Set(ctx context.Context, sessionID string, state *domain.GitState, capturedAt time.Time) error
+	Get(ctx context.Context, sessionID string) (*domain.GitState, error)
+	Delete(ctx context.Context, sessionID string) error
 }
 
 type SessionMetricsRepository interface {
@@ -39,11 +69,45 @@ type SessionToolRepository interface {
 type SessionFileRepository interface {
 	CreateBatch(ctx context.Context, files []*domain.SessionFile) error
 	ListBySessionID(ctx context.Context, sessionID string) ([]*domain.SessionFile, error)
+	ListTouches(ctx context.Context, opts ListFileTouchesOptions) ([]domain.FileTouch, error)
+}
+
+// ListFileTouchesOptions selects the sessions whose file operations are
+// analyzed.
+type ListFileTouchesOptions struct {
+	Since        string // RFC 3339 creation time of the earliest session
+	ProjectID    *string
+	ExperimentID *string
+}
+
+type SessionFileChangeRepository interface {
+	CreateBatch(ctx context.Context, changes []*domain.SessionFileChange) error
+	ListBySessionID(ctx context.Context, sessionID string) ([]*domain.SessionFileChange, error)
 }
 
 type SessionCommandRepository interface {
 	CreateBatch(ctx context.Context, commands []*domain.SessionCommand) error
 	ListBySessionID(ctx context.Context, sessionID string) ([]*domain.SessionCommand, error)
+	ListRuns(ctx context.Context, opts ListCommandRunsOptions) ([]domain.CommandRun, error)
+}
+
+// ListCommandRunsOptions selects the sessions whose commands are analyzed.
+type ListCommandRunsOptions struct {
+	Since        string // RFC 3339 creation time of the earliest session
+	ProjectID    *string
+	ExperimentID *string
+}
+
+type SessionToolCallRepository interface {
+	CreateBatch(ctx context.Context, calls []*domain.SessionToolCall) error
+	ListSequences(ctx context.Context, opts ListToolCallsOptions) ([]domain.ToolSequence, error)
+}
+
+// ListToolCallsOptions selects the sessions whose tool calls are mined.
+type ListToolCallsOptions struct {
+	Since        string // RFC 3339 creation time of the earliest session
+	ProjectID    *string
+	ExperimentID *string
 }
 
 type SessionSubagentRepository interface {
@@ -9,8 +9,18 @@ import (
 type StatsRepository interface {
 	GetAggregate(ctx context.Context, since string) (*domain.AggregateStats, error)
 	GetAggregateByExperiment(ctx context.Context, experimentID string, since string) (*domain.AggregateStats, error)
+	// GetExperimentSpend returns the cost of an experiment's sessions since a
+	// time, excluded ones included, as budgets count every dollar spent.
+	GetExperimentSpend(ctx context.Context, experimentID string, since string) (float64, error)
 	GetAggregateByProject(ctx context.Context, projectID string, since string) (*domain.AggregateStats, error)
 	GetTopTools(ctx context.Context, since string, limit int) ([]domain.ToolUsageStats, error)
 	GetAllExperimentStats(ctx context.Context) ([]domain.ExperimentStats, error)
 	GetTotalToolCallsByExperiment(ctx context.Context, experimentID string) (int64, error)
+	GetTopToolsByExperiment(ctx context.Context, experimentID string, limit int) ([]domain.ToolUsageStats, error)
+	ListSamplesByExperiment(ctx context.Context, experimentID string) ([]domain.SessionSample, error)
+	ListSamplesByProject(ctx context.Context, projectID, excludeSessionID, before string, limit int) ([]domain.SessionSample, error)
+	ListDailyCostByProject(ctx context.Context, since string) ([]domain.DailyCost, error)
+	ListDailyCostByModel(ctx context.Context, since string) ([]domain.DailyModelCost, error)
+	ListDailyUsageByModel(ctx context.Context, since, until string) ([]domain.DailyModelUsage, error)
+	ListSessionUsage(ctx context.Context, since, until string) ([]domain.SessionUsage, error)
 }
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type UsageRepository interface {
+	ReplaceForSession(ctx context.Context, sessionID string, requests []domain.UsageRequest, hits []domain.RateLimitHit) error
+	ListRequestsBetween(ctx context.Context, start, end time.Time) ([]domain.UsageRequest, error)
+	ListRateLimitHits(ctx context.Context) ([]domain.RateLimitHit, error)
+}
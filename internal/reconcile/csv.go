@@ -0,0 +1,250 @@
+package reconcile
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+// column is a field of a console export.
+type column int
+
+const (
+	colDate column = iota
+	colModel
+	colWorkspace
+	colAPIKey
+	colInput
+	colOutput
+	colCacheRead
+	colCacheWrite
+	colCacheWrite5m
+	colCacheWrite1h
+	colCost
+)
+
+// columnNames are the header names accepted for each column, after
+// normalization to lower snake case. The console's usage and cost exports
+// name some columns differently.
+var columnNames = map[column][]string{
+	colDate:         {"usage_date_utc", "usage_date", "date_utc", "date", "day"},
+	colModel:        {"model", "model_version", "model_id", "model_name"},
+	colWorkspace:    {"workspace", "workspace_name", "workspace_id"},
+	colAPIKey:       {"api_key", "api_key_name", "api_key_id", "key_name"},
+	colInput:        {"usage_input_tokens_no_cache", "input_tokens_no_cache", "uncached_input_tokens", "input_tokens"},
+	colOutput:       {"usage_output_tokens", "output_tokens"},
+	colCacheRead:    {"usage_input_tokens_cache_read", "input_tokens_cache_read", "cache_read_input_tokens", "cache_read_tokens"},
+	colCacheWrite:   {"usage_input_tokens_cache_write", "input_tokens_cache_write", "cache_creation_input_tokens", "cache_write_tokens"},
+	colCacheWrite5m: {"usage_input_tokens_cache_write_5m", "input_tokens_cache_write_5m", "cache_write_5m_tokens"},
+	colCacheWrite1h: {"usage_input_tokens_cache_write_1h", "input_tokens_cache_write_1h", "cache_write_1h_tokens"},
+	colCost:         {"cost_usd", "total_cost_usd", "amount_usd", "cost", "amount"},
+}
+
+var dateLayouts = []string{"2006-01-02", time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "01/02/2006"}
+
+// ParseCSV reads a usage or cost report exported from the Anthropic console.
+// Columns are matched by name and may come in any order; a date and a model
+// column are required, plus token or cost columns. Rows for the same day,
+// model, workspace and API key are summed, so exports broken down by usage
+// or token type collapse into one row each.
+func ParseCSV(r io.Reader) ([]domain.BillingUsage, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("empty billing export")
+		}
+		return nil, fmt.Errorf("failed to read billing export: %w", err)
+	}
+	cols := matchColumns(header)
+	if _, ok := cols[colDate]; !ok {
+		return nil, fmt.Errorf("billing export has no date column (expected one of %s)", strings.Join(columnNames[colDate], ", "))
+	}
+	if _, ok := cols[colModel]; !ok {
+		return nil, fmt.Errorf("billing export has no model column (expected one of %s)", strings.Join(columnNames[colModel], ", "))
+	}
+	_, hasCost := cols[colCost]
+	if !hasCost && !hasAny(cols, colInput, colOutput, colCacheRead, colCacheWrite, colCacheWrite5m, colCacheWrite1h) {
+		return nil, fmt.Errorf("billing export has no token or cost columns")
+	}
+
+	type key struct {
+		date                     string
+		model, workspace, apiKey string
+	}
+	rows := make(map[key]*domain.BillingUsage)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read billing export: %w", err)
+		}
+		if isBlank(record) {
+			continue
+		}
+
+		field := func(c column) string {
+			i, ok := cols[c]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		number := func(c column) (float64, error) {
+			v := strings.NewReplacer("$", "", ",", "", " ", "").Replace(field(c))
+			if v == "" {
+				return 0, nil
+			}
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: invalid number %q", line, field(c))
+			}
+			return n, nil
+		}
+
+		date, err := parseDate(field(colDate))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		model := field(colModel)
+		if model == "" {
+			model = UnknownModel
+		}
+
+		tokens := make(map[column]int64)
+		for _, c := range []column{colInput, colOutput, colCacheRead, colCacheWrite, colCacheWrite5m, colCacheWrite1h} {
+			n, err := number(c)
+			if err != nil {
+				return nil, err
+			}
+			tokens[c] = int64(n)
+		}
+		cost, err := number(colCost)
+		if err != nil {
+			return nil, err
+		}
+
+		k := key{date.Format("2006-01-02"), model, field(colWorkspace), field(colAPIKey)}
+		u, ok := rows[k]
+		if !ok {
+			u = &domain.BillingUsage{Date: date, Model: k.model, Workspace: k.workspace, APIKey: k.apiKey}
+			if hasCost {
+				u.CostUsd = new(float64)
+			}
+			rows[k] = u
+		}
+		// Without a total, cache writes are the sum of the tiers
+		cacheWrite := tokens[colCacheWrite]
+		if _, ok := cols[colCacheWrite]; !ok {
+			cacheWrite = tokens[colCacheWrite5m] + tokens[colCacheWrite1h]
+		}
+		u.Tokens = u.Tokens.Add(domain.TokenUsage{
+			Input:        tokens[colInput],
+			Output:       tokens[colOutput],
+			CacheRead:    tokens[colCacheRead],
+			CacheWrite:   cacheWrite,
+			CacheWrite1h: tokens[colCacheWrite1h],
+		})
+		if hasCost {
+			*u.CostUsd += cost
+		}
+	}
+
+	usage := make([]domain.BillingUsage, 0, len(rows))
+	for _, u := range rows {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		a, b := usage[i], usage[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if a.Model != b.Model {
+			return a.Model < b.Model
+		}
+		if a.Workspace != b.Workspace {
+			return a.Workspace < b.Workspace
+		}
+		return a.APIKey < b.APIKey
+	})
+	return usage, nil
+}
+
+func matchColumns(header []string) map[column]int {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		n := normalizeColumn(name)
+		if _, ok := index[n]; !ok {
+			index[n] = i
+		}
+	}
+	cols := make(map[column]int)
+	for c, names := range columnNames {
+		for _, name := range names {
+			if i, ok := index[name]; ok {
+				cols[c] = i
+				break
+			}
+		}
+	}
+	return cols
+}
+
+// normalizeColumn lowercases a header name and joins its words with
+// underscores, so "Usage Date (UTC)" matches usage_date_utc.
+func normalizeColumn(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		underscore = true
+	}
+	return b.String()
+}
+
+func hasAny(cols map[column]int, want ...column) bool {
+	for _, c := range want {
+		if _, ok := cols[c]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlank(record []string) bool {
+	for _, f := range record {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.UTC()
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,282 @@
+// Package reconcile compares the costs estimated for recorded sessions with
+// the usage and costs billed by Anthropic, imported from console exports.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// UnknownModel labels usage without a model.
+const UnknownModel = "unknown"
+
+// DefaultTolerance is the relative difference between estimated and billed
+// amounts below which they are considered to match.
+const DefaultTolerance = 0.05
+
+// Reconciler imports billed usage and compares it with the estimates.
+type Reconciler struct {
+	billing ports.BillingRepository
+	stats   ports.StatsRepository
+	pricing ports.PricingRepository
+}
+
+func NewReconciler(billing ports.BillingRepository, stats ports.StatsRepository, pricing ports.PricingRepository) *Reconciler {
+	return &Reconciler{billing: billing, stats: stats, pricing: pricing}
+}
+
+// Import stores the billed usage, replacing what was imported before for the
+// same day, model, workspace and API key. Models named by their display name
+// are stored under their pricing ID so they line up with the estimates.
+func (r *Reconciler) Import(ctx context.Context, usage []domain.BillingUsage, now time.Time) error {
+	pricing, err := r.pricing.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pricing: %w", err)
+	}
+	ids := make(map[string]string, 2*len(pricing))
+	for _, p := range pricing {
+		ids[strings.ToLower(p.DisplayName)] = p.ID
+		ids[strings.ToLower(p.ID)] = p.ID
+	}
+
+	for i := range usage {
+		if id, ok := ids[strings.ToLower(usage[i].Model)]; ok {
+			usage[i].Model = id
+		}
+		usage[i].ImportedAt = now
+	}
+	return r.billing.SetBatch(ctx, merge(usage))
+}
+
+// merge sums usage that maps to the same row once models are renamed.
+func merge(usage []domain.BillingUsage) []domain.BillingUsage {
+	type key struct {
+		date                     time.Time
+		model, workspace, apiKey string
+	}
+	index := make(map[key]int, len(usage))
+	merged := make([]domain.BillingUsage, 0, len(usage))
+	for _, u := range usage {
+		k := key{u.Date, u.Model, u.Workspace, u.APIKey}
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(merged)
+			merged = append(merged, u)
+			continue
+		}
+		m := &merged[i]
+		m.Tokens = m.Tokens.Add(u.Tokens)
+		m.CostUsd = addCost(m.CostUsd, u.CostUsd)
+	}
+	return merged
+}
+
+func addCost(a, b *float64) *float64 {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	sum := *a + *b
+	return &sum
+}
+
+// Day compares billed and estimated usage of a model on a UTC day.
+type Day struct {
+	Date time.Time
+	domain.BillingDiscrepancy
+}
+
+// Model compares billed and estimated usage of a model over the report.
+// Pricing is the model's configured pricing, nil when it has none.
+// Suggested is the pricing that would have matched the billed cost, set when
+// the costs differ but the tokens match, so the rates are what is off.
+type Model struct {
+	domain.BillingDiscrepancy
+	Days      int
+	Pricing   *domain.ModelPricing
+	Suggested *domain.ModelPricing
+}
+
+// Report compares billed and estimated usage over the days with billing
+// data, From to To inclusive. Models are ordered by billed cost, highest
+// first; days oldest first, then by model.
+type Report struct {
+	From      time.Time
+	To        time.Time
+	Tolerance float64
+	Total     domain.BillingDiscrepancy
+	Models    []Model
+	Days      []Day
+}
+
+// HasData reports whether any billed usage was found.
+func (r *Report) HasData() bool {
+	return !r.From.IsZero()
+}
+
+// Mismatches returns the days whose estimate is off by more than the
+// tolerance.
+func (r *Report) Mismatches() []Day {
+	var days []Day
+	for _, d := range r.Days {
+		if !d.CostMatches(r.Tolerance) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// Report compares the usage billed since the given time with the estimates
+// for the same days. Estimates on days without billing data are left out.
+func (r *Reconciler) Report(ctx context.Context, since time.Time, tolerance float64) (*Report, error) {
+	billed, err := r.billing.ListSince(ctx, since.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Tolerance: tolerance, Total: domain.BillingDiscrepancy{Model: "Total"}}
+	if len(billed) == 0 {
+		return report, nil
+	}
+	report.From = billed[0].Date
+	report.To = billed[len(billed)-1].Date
+
+	estimated, err := r.stats.ListDailyUsageByModel(ctx, report.From.Format(time.RFC3339), report.To.AddDate(0, 0, 1).Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		date  time.Time
+		model string
+	}
+	days := make(map[key]*Day)
+	day := func(date time.Time, model string) *Day {
+		if model == "" {
+			model = UnknownModel
+		}
+		k := key{date, model}
+		d, ok := days[k]
+		if !ok {
+			d = &Day{Date: date, BillingDiscrepancy: domain.BillingDiscrepancy{Model: model}}
+			days[k] = d
+		}
+		return d
+	}
+	// Exports have costs for every row or none, so estimates without billed
+	// usage compare as unbilled cost
+	hasCost := false
+	for _, b := range billed {
+		d := day(b.Date, b.Model)
+		d.BilledTokens += b.Tokens.Total()
+		if b.CostUsd != nil {
+			d.BilledUsd += *b.CostUsd
+			hasCost = true
+		}
+	}
+	for _, e := range estimated {
+		d := day(e.Date, e.Model)
+		d.EstimatedTokens += e.Tokens.Total()
+		d.EstimatedUsd += e.CostUsd
+	}
+
+	models := make(map[string]*Model)
+	for _, d := range days {
+		d.HasCost = hasCost
+		report.Days = append(report.Days, *d)
+		m, ok := models[d.Model]
+		if !ok {
+			m = &Model{BillingDiscrepancy: domain.BillingDiscrepancy{Model: d.Model}}
+			models[d.Model] = m
+		}
+		m.Days++
+		add(&m.BillingDiscrepancy, d.BillingDiscrepancy)
+		add(&report.Total, d.BillingDiscrepancy)
+	}
+	sort.Slice(report.Days, func(i, j int) bool {
+		a, b := report.Days[i], report.Days[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.Model < b.Model
+	})
+
+	for _, m := range models {
+		if m.Model != UnknownModel {
+			p, err := r.pricing.GetByID(ctx, m.Model)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pricing: %w", err)
+			}
+			m.Pricing = p
+		}
+		if m.Pricing != nil && !m.CostMatches(tolerance) && m.TokensMatch(tolerance) {
+			if factor := m.PriceCorrection(); factor > 0 {
+				m.Suggested = m.Pricing.Scale(factor)
+			}
+		}
+		report.Models = append(report.Models, *m)
+	}
+	sort.Slice(report.Models, func(i, j int) bool {
+		a, b := report.Models[i], report.Models[j]
+		if a.BilledUsd != b.BilledUsd {
+			return a.BilledUsd > b.BilledUsd
+		}
+		return a.Model < b.Model
+	})
+	return report, nil
+}
+
+func add(total *domain.BillingDiscrepancy, d domain.BillingDiscrepancy) {
+	total.BilledTokens += d.BilledTokens
+	total.EstimatedTokens += d.EstimatedTokens
+	total.BilledUsd += d.BilledUsd
+	total.EstimatedUsd += d.EstimatedUsd
+	total.HasCost = total.HasCost || d.HasCost
+}
+
+// CostSetCommand returns the 'mclaude cost set' command that stores the
+// pricing, with every rate it has.
+func CostSetCommand(p *domain.ModelPricing) string {
+	args := []string{"mclaude cost set", p.ID,
+		"--input", formatRate(p.InputPerMillion),
+		"--output", formatRate(p.OutputPerMillion),
+	}
+	optional := []struct {
+		flag string
+		rate *float64
+	}{
+		{"--cache-read", p.CacheReadPerMillion},
+		{"--cache-write", p.CacheWritePerMillion},
+		{"--cache-write-1h", p.CacheWrite1hPerMillion},
+		{"--long-input", p.LongContextInputPerMillion},
+		{"--long-output", p.LongContextOutputPerMillion},
+		{"--web-search", p.WebSearchPerThousand},
+		{"--web-fetch", p.WebFetchPerThousand},
+	}
+	for _, o := range optional {
+		if o.rate != nil {
+			args = append(args, o.flag, formatRate(*o.rate))
+		}
+	}
+	if p.LongContextThreshold != nil && (p.LongContextInputPerMillion != nil || p.LongContextOutputPerMillion != nil) {
+		args = append(args, "--long-threshold", strconv.FormatInt(*p.LongContextThreshold, 10))
+	}
+	if p.DisplayName != "" && p.DisplayName != p.ID {
+		args = append(args, "--name", strconv.Quote(p.DisplayName))
+	}
+	return strings.Join(args, " ")
+}
+
+// formatRate rounds a rate to a hundredth of a cent.
+func formatRate(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10000)/10000, 'f', -1, 64)
+}
@@ -0,0 +1,196 @@
+package reconcile
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+type fakeBilling struct {
+	usage []domain.BillingUsage
+}
+
+func (f *fakeBilling) SetBatch(ctx context.Context, usage []domain.BillingUsage) error {
+	f.usage = append(f.usage, usage...)
+	return nil
+}
+
+func (f *fakeBilling) ListSince(ctx context.Context, since string) ([]domain.BillingUsage, error) {
+	return f.usage, nil
+}
+
+type fakeStats struct {
+	ports.StatsRepository
+	usage []domain.DailyModelUsage
+}
+
+func (f *fakeStats) ListDailyUsageByModel(ctx context.Context, since, until string) ([]domain.DailyModelUsage, error) {
+	from, _ := time.Parse(time.RFC3339, since)
+	to, _ := time.Parse(time.RFC3339, until)
+	var usage []domain.DailyModelUsage
+	for _, u := range f.usage {
+		if !u.Date.Before(from) && u.Date.Before(to) {
+			usage = append(usage, u)
+		}
+	}
+	return usage, nil
+}
+
+type fakePricing struct {
+	ports.PricingRepository
+	models []*domain.ModelPricing
+}
+
+func (f *fakePricing) List(ctx context.Context) ([]*domain.ModelPricing, error) {
+	return f.models, nil
+}
+
+func (f *fakePricing) GetByID(ctx context.Context, id string) (*domain.ModelPricing, error) {
+	for _, m := range f.models {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+func day(d int) time.Time {
+	return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseCSV(t *testing.T) {
+	input := "\ufeffUsage Date (UTC),Model,Workspace,API Key,Token Type,Input Tokens,Cache Write 5m Tokens,Cache Write 1h Tokens,Output Tokens,Cost (USD)\n" +
+		"2026-03-01,claude-sonnet,Default,dev,input,\"1,000\",200,100,0,$1.50\n" +
+		"2026-03-01,claude-sonnet,Default,dev,output,0,0,0,500,2.25\n" +
+		"2026-03-02T00:00:00Z,claude-opus,Default,ci,input,10,0,0,5,0.10\n" +
+		",,,,,,,,,\n"
+
+	usage, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if len(usage) != 2 {
+		t.Fatalf("expected rows per day, model and key to be summed, got %+v", usage)
+	}
+	sonnet := usage[0]
+	if !sonnet.Date.Equal(day(1)) || sonnet.Model != "claude-sonnet" || sonnet.Workspace != "Default" || sonnet.APIKey != "dev" {
+		t.Errorf("unexpected row: %+v", sonnet)
+	}
+	want := domain.TokenUsage{Input: 1000, Output: 500, CacheWrite: 300, CacheWrite1h: 100}
+	if sonnet.Tokens != want || sonnet.CostUsd == nil || *sonnet.CostUsd != 3.75 {
+		t.Errorf("unexpected usage: %+v cost %v", sonnet.Tokens, sonnet.CostUsd)
+	}
+	if !usage[1].Date.Equal(day(2)) || usage[1].Model != "claude-opus" {
+		t.Errorf("unexpected row: %+v", usage[1])
+	}
+}
+
+func TestParseCSV_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":      "",
+		"no date":    "model,cost_usd\nopus,1\n",
+		"no model":   "date,cost_usd\n2026-03-01,1\n",
+		"no amounts": "date,model\n2026-03-01,opus\n",
+		"bad date":   "date,model,cost_usd\nyesterday,opus,1\n",
+		"bad number": "date,model,cost_usd\n2026-03-01,opus,lots\n",
+	}
+	for name, input := range tests {
+		if _, err := ParseCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestImport_MapsDisplayNames(t *testing.T) {
+	billing := &fakeBilling{}
+	pricing := &fakePricing{models: []*domain.ModelPricing{{ID: "claude-sonnet-4-5", DisplayName: "Claude Sonnet 4.5"}}}
+	r := NewReconciler(billing, &fakeStats{}, pricing)
+
+	one, two := 1.0, 2.0
+	now := time.Now()
+	err := r.Import(context.Background(), []domain.BillingUsage{
+		{Date: day(1), Model: "Claude Sonnet 4.5", CostUsd: &one},
+		{Date: day(1), Model: "claude-sonnet-4-5", CostUsd: &two},
+		{Date: day(1), Model: "other"},
+	}, now)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(billing.usage) != 2 || billing.usage[0].Model != "claude-sonnet-4-5" || *billing.usage[0].CostUsd != 3 || !billing.usage[0].ImportedAt.Equal(now) {
+		t.Errorf("unexpected stored usage: %+v", billing.usage)
+	}
+}
+
+func TestReport(t *testing.T) {
+	cacheRead := 0.3
+	sonnet := &domain.ModelPricing{ID: "sonnet", DisplayName: "Sonnet", InputPerMillion: 3, OutputPerMillion: 15, CacheReadPerMillion: &cacheRead}
+	opus := &domain.ModelPricing{ID: "opus", DisplayName: "opus", InputPerMillion: 5, OutputPerMillion: 25}
+	billedSonnet, billedOpus := 12.0, 5.0
+	billing := &fakeBilling{usage: []domain.BillingUsage{
+		{Date: day(1), Model: "sonnet", Workspace: "a", Tokens: domain.TokenUsage{Input: 500}, CostUsd: &billedSonnet},
+		{Date: day(1), Model: "sonnet", Workspace: "b", Tokens: domain.TokenUsage{Input: 500}, CostUsd: &billedSonnet},
+		{Date: day(2), Model: "opus", Tokens: domain.TokenUsage{Input: 1000}, CostUsd: &billedOpus},
+	}}
+	stats := &fakeStats{usage: []domain.DailyModelUsage{
+		// Sonnet tokens match but the estimate is 20% short: the rates are off
+		{Date: day(1), Model: "sonnet", Tokens: domain.TokenUsage{Input: 1000}, CostUsd: 20},
+		// Opus is short on tokens too, so no price is suggested
+		{Date: day(2), Model: "opus", Tokens: domain.TokenUsage{Input: 500}, CostUsd: 2.5},
+		// Not billed, e.g. subscription usage
+		{Date: day(2), Model: "", CostUsd: 1},
+		// After the billing data
+		{Date: day(3), Model: "sonnet", CostUsd: 100},
+	}}
+	r := NewReconciler(billing, stats, &fakePricing{models: []*domain.ModelPricing{sonnet, opus}})
+
+	report, err := r.Report(context.Background(), day(1), DefaultTolerance)
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if !report.HasData() || !report.From.Equal(day(1)) || !report.To.Equal(day(2)) {
+		t.Fatalf("unexpected range: %v - %v", report.From, report.To)
+	}
+	if report.Total.BilledUsd != 29 || report.Total.EstimatedUsd != 23.5 || len(report.Days) != 3 {
+		t.Errorf("unexpected total: %+v over %d days", report.Total, len(report.Days))
+	}
+	if len(report.Mismatches()) != 3 {
+		t.Errorf("expected every day to mismatch, got %+v", report.Mismatches())
+	}
+
+	if len(report.Models) != 3 || report.Models[0].Model != "sonnet" || report.Models[1].Model != "opus" || report.Models[2].Model != UnknownModel {
+		t.Fatalf("unexpected models: %+v", report.Models)
+	}
+	s := report.Models[0]
+	if s.Suggested == nil || math.Abs(s.Suggested.InputPerMillion-3.6) > 1e-9 || math.Abs(*s.Suggested.CacheReadPerMillion-0.36) > 1e-9 {
+		t.Errorf("expected sonnet rates scaled by 1.2, got %+v", s.Suggested)
+	}
+	if report.Models[1].Suggested != nil {
+		t.Errorf("expected no suggestion when tokens differ, got %+v", report.Models[1].Suggested)
+	}
+}
+
+func TestReport_NoData(t *testing.T) {
+	r := NewReconciler(&fakeBilling{}, &fakeStats{}, &fakePricing{})
+	report, err := r.Report(context.Background(), day(1), DefaultTolerance)
+	if err != nil || report.HasData() {
+		t.Errorf("expected an empty report, got %+v (%v)", report, err)
+	}
+}
+
+func TestCostSetCommand(t *testing.T) {
+	cacheRead, longInput, longOutput := 0.3, 6.0, 22.5
+	threshold := int64(200000)
+	p := &domain.ModelPricing{
+		ID: "sonnet", DisplayName: "Claude Sonnet", InputPerMillion: 3.6000001, OutputPerMillion: 18,
+		CacheReadPerMillion: &cacheRead, LongContextInputPerMillion: &longInput, LongContextOutputPerMillion: &longOutput, LongContextThreshold: &threshold,
+	}
+	want := `mclaude cost set sonnet --input 3.6 --output 18 --cache-read 0.3 --long-input 6 --long-output 22.5 --long-threshold 200000 --name "Claude Sonnet"`
+	if got := CostSetCommand(p); got != want {
+		t.Errorf("CostSetCommand() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,282 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/emiliopalmerini/mclaude/internal/util"
+)
+
+// Chart dimensions in SVG user units.
+const (
+	chartWidth  = 640
+	chartHeight = 160
+	deltaRowH   = 28
+	deltaLabelW = 150
+)
+
+type htmlView struct {
+	*Report
+	VariableKeys []string
+	DailyBars    []svgBar
+	DeltaRows    []deltaRow
+	ToolRows     []toolRow
+	ChartWidth   int
+	ChartHeight  int
+	DeltaHeight  int
+	DeltaZeroX   float64
+}
+
+type svgBar struct {
+	X, Y, W, H float64
+	Label      string
+}
+
+type deltaRow struct {
+	Delta
+	Y                 float64
+	BarX, BarW        float64
+	HasCI             bool
+	CIX1, CIX2        float64
+	ValueX            float64
+	FormattedBaseline string
+	FormattedValue    string
+	FormattedDelta    string
+	FormattedCI       string
+}
+
+type toolRow struct {
+	Tool
+	Pct float64
+}
+
+var htmlFuncs = template.FuncMap{
+	"tokens": util.FormatTokensInt,
+	"pct":    func(f float64) float64 { return f * 100 },
+	"dec":    func(i int) int { return i - 1 },
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Experiment report: {{.Experiment.Name}}</title>
+<style>
+body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",sans-serif;max-width:880px;margin:2rem auto;padding:0 1rem;color:#1f2937;line-height:1.5}
+h1{margin-bottom:.25rem}h2{margin-top:2rem;border-bottom:1px solid #e5e7eb;padding-bottom:.25rem}
+.muted{color:#6b7280;font-size:.9rem}
+table{border-collapse:collapse;width:100%;margin:.5rem 0}th,td{text-align:left;padding:.35rem .6rem;border-bottom:1px solid #f3f4f6}
+th{font-size:.8rem;text-transform:uppercase;color:#6b7280}
+td.num{text-align:right;font-variant-numeric:tabular-nums}
+blockquote{margin:0;padding:.5rem 1rem;border-left:4px solid #93c5fd;background:#eff6ff}
+.verdict{display:inline-block;padding:.1rem .6rem;border-radius:999px;font-weight:600}
+.verdict-confirmed{background:#dcfce7;color:#166534}.verdict-rejected{background:#fee2e2;color:#991b1b}.verdict-inconclusive{background:#fef9c3;color:#854d0e}
+.sig{color:#166534;font-weight:600}
+.tool-bar{background:#dbeafe;height:.9rem;border-radius:2px}
+svg text{font-size:11px;fill:#4b5563}
+</style>
+</head>
+<body>
+<h1>{{.Experiment.Name}}</h1>
+<p class="muted">Experiment report generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}</p>
+
+<table>
+<tr><th>Status</th><td>{{.Experiment.Status}}</td></tr>
+<tr><th>Started</th><td>{{.Experiment.StartedAt.Format "2006-01-02"}}</td></tr>
+{{- with .Experiment.EndedAt}}
+<tr><th>Ended</th><td>{{.Format "2006-01-02"}}</td></tr>
+{{- end}}
+{{- with .Experiment.Description}}
+<tr><th>Description</th><td>{{.}}</td></tr>
+{{- end}}
+{{- with .Experiment.ModelID}}
+<tr><th>Model</th><td>{{.}}</td></tr>
+{{- end}}
+{{- with .Experiment.PlanType}}
+<tr><th>Plan</th><td>{{.}}</td></tr>
+{{- end}}
+{{- with .Experiment.Notes}}
+<tr><th>Notes</th><td>{{.}}</td></tr>
+{{- end}}
+</table>
+
+{{- with .Experiment.Hypothesis}}
+<h2>Hypothesis</h2>
+<blockquote>{{.}}</blockquote>
+{{- end}}
+
+<h2>Verdict</h2>
+{{- if .Experiment.Verdict}}
+<p><span class="verdict verdict-{{.Experiment.Verdict}}">{{.Experiment.Verdict}}</span>
+{{- with .Experiment.ConcludedAt}} <span class="muted">{{.Format "2006-01-02"}}</span>{{end}}</p>
+{{- with .Experiment.Conclusion}}
+<p>{{.}}</p>
+{{- end}}
+{{- else}}
+<p class="muted">No verdict recorded.</p>
+{{- end}}
+
+{{- if .VariableKeys}}
+<h2>Variables</h2>
+<table>
+<tr><th>Key</th><th>Value</th></tr>
+{{- range .VariableKeys}}
+<tr><td>{{.}}</td><td>{{index $.Variables .}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<h2>Sessions</h2>
+<table>
+<tr><th>Sessions</th><td class="num">{{.Totals.Sessions}}</td><th>Tokens/turn</th><td class="num">{{printf "%.0f" .Normalized.TokensPerTurn}}</td></tr>
+<tr><th>Turns</th><td class="num">{{.Totals.Turns}}</td><th>Output ratio</th><td class="num">{{printf "%.2f" .Normalized.OutputRatio}}</td></tr>
+<tr><th>Tokens (in/out)</th><td class="num">{{tokens .Totals.TokenInput}} / {{tokens .Totals.TokenOutput}}</td><th>Cache hit rate</th><td class="num">{{printf "%.1f" (pct .Normalized.CacheHitRate)}}%</td></tr>
+<tr><th>Cache (read/write)</th><td class="num">{{tokens .Totals.CacheRead}} / {{tokens .Totals.CacheWrite}}</td><th>Errors/turn</th><td class="num">{{printf "%.3f" .Normalized.ErrorRate}}</td></tr>
+<tr><th>Cost</th><td class="num">${{printf "%.2f" .Totals.CostUsd}}</td><th>Tool calls/turn</th><td class="num">{{printf "%.2f" .Normalized.ToolCallsPerTurn}}</td></tr>
+<tr><th>Tool calls</th><td class="num">{{.Totals.ToolCalls}}</td><th>Errors</th><td class="num">{{.Totals.Errors}}</td></tr>
+{{- if .Totals.SessionsWithChecks}}
+<tr><th>Sessions with checks</th><td class="num">{{.Totals.SessionsWithChecks}}</td><th>Ended green</th><td class="num">{{printf "%.1f" (pct .Normalized.GreenRate)}}%</td></tr>
+{{- end}}
+</table>
+
+{{- with .Baseline}}
+<h2>Compared with {{.Name}}</h2>
+<p class="muted">{{.Sessions}} baseline sessions vs {{$.Totals.Sessions}} experiment sessions. Deltas are per session, with 95% confidence intervals (Welch's t).</p>
+<svg width="{{$.ChartWidth}}" height="{{$.DeltaHeight}}" viewBox="0 0 {{$.ChartWidth}} {{$.DeltaHeight}}" role="img" aria-label="Delta against baseline">
+<line x1="{{$.DeltaZeroX}}" y1="0" x2="{{$.DeltaZeroX}}" y2="{{$.DeltaHeight}}" stroke="#9ca3af"/>
+{{- range $.DeltaRows}}
+<text x="0" y="{{.Y}}" dy="14">{{.Metric}}</text>
+<rect x="{{.BarX}}" y="{{.Y}}" width="{{.BarW}}" height="18" fill="{{if .Significant}}#60a5fa{{else}}#d1d5db{{end}}"/>
+{{- if .HasCI}}
+<line x1="{{.CIX1}}" y1="{{.Y}}" x2="{{.CIX2}}" y2="{{.Y}}" transform="translate(0,9)" stroke="#1f2937"/>
+{{- end}}
+<text x="{{.ValueX}}" y="{{.Y}}" dy="14">{{printf "%+.1f" .DeltaPct}}%</text>
+{{- end}}
+</svg>
+<table>
+<tr><th>Metric</th><th>Baseline</th><th>Experiment</th><th>Delta</th><th>Delta %</th><th>95% CI</th><th>Significant</th></tr>
+{{- range $.DeltaRows}}
+<tr><td>{{.Metric}}</td><td class="num">{{.FormattedBaseline}}</td><td class="num">{{.FormattedValue}}</td><td class="num">{{.FormattedDelta}}</td><td class="num">{{printf "%+.1f" .DeltaPct}}%</td><td class="num">{{.FormattedCI}}</td><td>{{if .Significant}}<span class="sig">yes</span>{{else}}no{{end}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+{{- if .ToolRows}}
+<h2>Top tools</h2>
+<table>
+<tr><th>Tool</th><th></th><th>Calls</th><th>Errors</th></tr>
+{{- range .ToolRows}}
+<tr><td>{{.Name}}</td><td style="width:50%"><div class="tool-bar" style="width:{{printf "%.1f" .Pct}}%"></div></td><td class="num">{{.Invocations}}</td><td class="num">{{.Errors}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+{{- if .DailyBars}}
+<h2>Daily cost</h2>
+<svg width="{{.ChartWidth}}" height="{{.ChartHeight}}" viewBox="0 0 {{.ChartWidth}} {{.ChartHeight}}" role="img" aria-label="Daily cost">
+{{- range .DailyBars}}
+<rect x="{{.X}}" y="{{.Y}}" width="{{.W}}" height="{{.H}}" fill="#60a5fa"><title>{{.Label}}</title></rect>
+{{- end}}
+<line x1="0" y1="{{.ChartHeight}}" x2="{{.ChartWidth}}" y2="{{.ChartHeight}}" stroke="#9ca3af"/>
+</svg>
+<p class="muted">{{(index .Daily 0).Date}} to {{(index .Daily (len .Daily | dec)).Date}}</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a self-contained HTML page with inline SVG charts.
+func WriteHTML(w io.Writer, r *Report) error {
+	v := htmlView{
+		Report:       r,
+		VariableKeys: slices.Sorted(maps.Keys(r.Variables)),
+		ChartWidth:   chartWidth,
+		ChartHeight:  chartHeight,
+	}
+
+	var maxCost float64
+	for _, d := range r.Daily {
+		maxCost = max(maxCost, d.CostUsd)
+	}
+	if n := len(r.Daily); n > 0 {
+		slot := float64(chartWidth) / float64(n)
+		for i, d := range r.Daily {
+			h := 0.0
+			if maxCost > 0 {
+				h = d.CostUsd / maxCost * (chartHeight - 10)
+			}
+			v.DailyBars = append(v.DailyBars, svgBar{
+				X:     float64(i)*slot + slot*0.1,
+				Y:     chartHeight - h,
+				W:     slot * 0.8,
+				H:     h,
+				Label: fmt.Sprintf("%s: $%.2f across %d sessions", d.Date, d.CostUsd, d.Sessions),
+			})
+		}
+	}
+
+	if b := r.Baseline; b != nil {
+		v.DeltaRows, v.DeltaZeroX = deltaRows(b.Deltas)
+		v.DeltaHeight = len(b.Deltas) * deltaRowH
+	}
+
+	var maxCalls float64
+	for _, t := range r.TopTools {
+		maxCalls = max(maxCalls, float64(t.Invocations))
+	}
+	for _, t := range r.TopTools {
+		row := toolRow{Tool: t}
+		if maxCalls > 0 {
+			row.Pct = float64(t.Invocations) / maxCalls * 100
+		}
+		v.ToolRows = append(v.ToolRows, row)
+	}
+
+	return htmlTemplate.Execute(w, v)
+}
+
+// deltaRows lays out horizontal delta % bars around a zero line, with the
+// confidence interval drawn as a whisker in the same percent scale.
+func deltaRows(deltas []Delta) ([]deltaRow, float64) {
+	ciPct := func(d Delta, v *float64) float64 {
+		if v == nil || d.BaselineMean == 0 {
+			return 0
+		}
+		return *v / d.BaselineMean * 100
+	}
+
+	scale := 1.0
+	for _, d := range deltas {
+		scale = max(scale, math.Abs(d.DeltaPct), math.Abs(ciPct(d, d.CILow)), math.Abs(ciPct(d, d.CIHigh)))
+	}
+
+	plotW := float64(chartWidth-deltaLabelW) - 60
+	zero := float64(deltaLabelW) + plotW/2
+	x := func(pct float64) float64 { return zero + pct/scale*plotW/2 }
+
+	rows := make([]deltaRow, len(deltas))
+	for i, d := range deltas {
+		row := deltaRow{
+			Delta:             d,
+			Y:                 float64(i*deltaRowH) + 4,
+			FormattedBaseline: formatMetric(d.Metric, d.BaselineMean),
+			FormattedValue:    formatMetric(d.Metric, d.ExperimentMean),
+			FormattedDelta:    formatDelta(d.Metric, d.Delta),
+			FormattedCI:       formatInterval(d),
+		}
+		end := x(d.DeltaPct)
+		row.BarX, row.BarW = min(zero, end), math.Abs(end-zero)
+		row.ValueX = max(zero, end) + 4
+		if d.CILow != nil && d.CIHigh != nil && d.BaselineMean != 0 {
+			row.HasCI = true
+			row.CIX1, row.CIX2 = x(ciPct(d, d.CILow)), x(ciPct(d, d.CIHigh))
+			row.ValueX = max(row.ValueX, row.CIX2+4)
+		}
+		rows[i] = row
+	}
+	return rows, zero
+}
@@ -0,0 +1,183 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/emiliopalmerini/mclaude/internal/util"
+)
+
+// barWidth is the width in characters of the longest Markdown bar.
+const barWidth = 30
+
+// WriteMarkdown writes the report as a Markdown document with text bar charts.
+func WriteMarkdown(w io.Writer, r *Report) error {
+	bw := bufio.NewWriter(w)
+	e := r.Experiment
+
+	fmt.Fprintf(bw, "# Experiment report: %s\n\n", e.Name)
+	fmt.Fprintf(bw, "_Generated %s_\n\n", r.GeneratedAt.Format("2006-01-02 15:04 MST"))
+
+	fmt.Fprintln(bw, "| Field | Value |")
+	fmt.Fprintln(bw, "|-------|-------|")
+	fmt.Fprintf(bw, "| Status | %s |\n", e.Status)
+	fmt.Fprintf(bw, "| Started | %s |\n", e.StartedAt.Format("2006-01-02"))
+	if e.EndedAt != nil {
+		fmt.Fprintf(bw, "| Ended | %s |\n", e.EndedAt.Format("2006-01-02"))
+	}
+	for _, f := range [][2]string{{"Description", e.Description}, {"Model", e.ModelID}, {"Plan", e.PlanType}, {"Notes", e.Notes}} {
+		if f[1] != "" {
+			fmt.Fprintf(bw, "| %s | %s |\n", f[0], mdCell(f[1]))
+		}
+	}
+	fmt.Fprintln(bw)
+
+	if e.Hypothesis != "" {
+		fmt.Fprintf(bw, "## Hypothesis\n\n> %s\n\n", e.Hypothesis)
+	}
+
+	fmt.Fprintln(bw, "## Verdict")
+	fmt.Fprintln(bw)
+	if e.Verdict == "" {
+		fmt.Fprintln(bw, "_No verdict recorded._")
+	} else {
+		fmt.Fprintf(bw, "**%s**", e.Verdict)
+		if e.ConcludedAt != nil {
+			fmt.Fprintf(bw, " (%s)", e.ConcludedAt.Format("2006-01-02"))
+		}
+		fmt.Fprintln(bw)
+		if e.Conclusion != "" {
+			fmt.Fprintf(bw, "\n%s\n", e.Conclusion)
+		}
+	}
+	fmt.Fprintln(bw)
+
+	if len(r.Variables) > 0 {
+		fmt.Fprintln(bw, "## Variables")
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "| Key | Value |")
+		fmt.Fprintln(bw, "|-----|-------|")
+		for _, key := range slices.Sorted(maps.Keys(r.Variables)) {
+			fmt.Fprintf(bw, "| %s | %s |\n", mdCell(key), mdCell(r.Variables[key]))
+		}
+		fmt.Fprintln(bw)
+	}
+
+	t := r.Totals
+	fmt.Fprintln(bw, "## Sessions")
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "| Metric | Value |")
+	fmt.Fprintln(bw, "|--------|-------|")
+	fmt.Fprintf(bw, "| Sessions | %d |\n", t.Sessions)
+	fmt.Fprintf(bw, "| Turns | %d |\n", t.Turns)
+	fmt.Fprintf(bw, "| Tokens (in/out) | %s / %s |\n", util.FormatTokensInt(t.TokenInput), util.FormatTokensInt(t.TokenOutput))
+	fmt.Fprintf(bw, "| Cache (read/write) | %s / %s |\n", util.FormatTokensInt(t.CacheRead), util.FormatTokensInt(t.CacheWrite))
+	fmt.Fprintf(bw, "| Cost | $%.2f |\n", t.CostUsd)
+	fmt.Fprintf(bw, "| Tool calls | %d |\n", t.ToolCalls)
+	fmt.Fprintf(bw, "| Errors | %d |\n", t.Errors)
+	fmt.Fprintf(bw, "| Tokens/turn | %.0f |\n", r.Normalized.TokensPerTurn)
+	fmt.Fprintf(bw, "| Output ratio | %.2f |\n", r.Normalized.OutputRatio)
+	fmt.Fprintf(bw, "| Cache hit rate | %.1f%% |\n", r.Normalized.CacheHitRate*100)
+	fmt.Fprintf(bw, "| Errors/turn | %.3f |\n", r.Normalized.ErrorRate)
+	fmt.Fprintf(bw, "| Tool calls/turn | %.2f |\n", r.Normalized.ToolCallsPerTurn)
+	if t.SessionsWithChecks > 0 {
+		fmt.Fprintf(bw, "| Ended green | %.1f%% (%d/%d) |\n", r.Normalized.GreenRate*100, t.SessionsEndedGreen, t.SessionsWithChecks)
+	}
+	fmt.Fprintln(bw)
+
+	if b := r.Baseline; b != nil {
+		fmt.Fprintf(bw, "## Compared with %s\n\n", b.Name)
+		fmt.Fprintf(bw, "%d baseline sessions vs %d experiment sessions. Deltas are per session, with 95%% confidence intervals (Welch's t).\n\n", b.Sessions, t.Sessions)
+		fmt.Fprintln(bw, "| Metric | Baseline | Experiment | Delta | Delta % | 95% CI | Significant |")
+		fmt.Fprintln(bw, "|--------|----------|------------|-------|---------|--------|-------------|")
+		for _, d := range b.Deltas {
+			significant := "no"
+			if d.Significant {
+				significant = "**yes**"
+			}
+			fmt.Fprintf(bw, "| %s | %s | %s | %s | %+.1f%% | %s | %s |\n",
+				d.Metric, formatMetric(d.Metric, d.BaselineMean), formatMetric(d.Metric, d.ExperimentMean),
+				formatDelta(d.Metric, d.Delta), d.DeltaPct, formatInterval(d), significant)
+		}
+		fmt.Fprintln(bw)
+	}
+
+	if len(r.TopTools) > 0 {
+		fmt.Fprintln(bw, "## Top tools")
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "```text")
+		nameWidth := 0
+		var maxCalls float64
+		for _, tool := range r.TopTools {
+			nameWidth = max(nameWidth, len(tool.Name))
+			maxCalls = max(maxCalls, float64(tool.Invocations))
+		}
+		for _, tool := range r.TopTools {
+			fmt.Fprintf(bw, "%-*s %s %d", nameWidth, tool.Name, bar(float64(tool.Invocations), maxCalls), tool.Invocations)
+			if tool.Errors > 0 {
+				fmt.Fprintf(bw, " (%d errors)", tool.Errors)
+			}
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintln(bw, "```")
+		fmt.Fprintln(bw)
+	}
+
+	if len(r.Daily) > 0 {
+		fmt.Fprintln(bw, "## Daily cost")
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "```text")
+		var maxCost float64
+		for _, d := range r.Daily {
+			maxCost = max(maxCost, d.CostUsd)
+		}
+		for _, d := range r.Daily {
+			fmt.Fprintf(bw, "%s %s $%.2f (%d sessions)\n", d.Date, bar(d.CostUsd, maxCost), d.CostUsd, d.Sessions)
+		}
+		fmt.Fprintln(bw, "```")
+	}
+
+	return bw.Flush()
+}
+
+// bar draws a bar of block characters proportional to v/maxValue, padded to barWidth.
+func bar(v, maxValue float64) string {
+	n := 0
+	if maxValue > 0 {
+		n = int(v / maxValue * barWidth)
+	}
+	if n == 0 && v > 0 {
+		n = 1
+	}
+	return strings.Repeat("█", n) + strings.Repeat(" ", barWidth-n)
+}
+
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+func formatMetric(metric string, v float64) string {
+	if metric == "Cost/session" {
+		return fmt.Sprintf("$%.4f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+func formatDelta(metric string, v float64) string {
+	if metric == "Cost/session" {
+		return fmt.Sprintf("%+.4f", v)
+	}
+	return fmt.Sprintf("%+.1f", v)
+}
+
+func formatInterval(d Delta) string {
+	if d.CILow == nil || d.CIHigh == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf("[%s, %s]", formatDelta(d.Metric, *d.CILow), formatDelta(d.Metric, *d.CIHigh))
+}
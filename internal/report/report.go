@@ -0,0 +1,278 @@
+// Package report builds self-contained experiment reports and renders them
+// as Markdown, HTML or JSON.
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// Supported output formats.
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatJSON     = "json"
+)
+
+// topToolsLimit is the number of tools listed in a report.
+const topToolsLimit = 10
+
+// Report is the data rendered in an experiment report.
+type Report struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Experiment  Experiment        `json:"experiment"`
+	Variables   map[string]string `json:"variables"`
+	Totals      Totals            `json:"totals"`
+	Normalized  Normalized        `json:"normalized"`
+	TopTools    []Tool            `json:"top_tools"`
+	Daily       []Day             `json:"daily"`
+	Baseline    *Baseline         `json:"baseline,omitempty"`
+}
+
+// Experiment holds the metadata of the reported experiment.
+type Experiment struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Hypothesis  string     `json:"hypothesis,omitempty"`
+	ModelID     string     `json:"model_id,omitempty"`
+	PlanType    string     `json:"plan_type,omitempty"`
+	Notes       string     `json:"notes,omitempty"`
+	Status      string     `json:"status"`
+	StartedAt   time.Time  `json:"started_at"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+	Verdict     string     `json:"verdict,omitempty"`
+	Conclusion  string     `json:"conclusion,omitempty"`
+	ConcludedAt *time.Time `json:"concluded_at,omitempty"`
+}
+
+// Totals holds aggregate usage across the experiment's sessions.
+type Totals struct {
+	Sessions    int64   `json:"sessions"`
+	Turns       int64   `json:"turns"`
+	TokenInput  int64   `json:"token_input"`
+	TokenOutput int64   `json:"token_output"`
+	CacheRead   int64   `json:"cache_read"`
+	CacheWrite  int64   `json:"cache_write"`
+	CostUsd     float64 `json:"cost_usd"`
+	Errors      int64   `json:"errors"`
+	ToolCalls   int64   `json:"tool_calls"`
+	// Sessions that ran tests, builds or linters, and those whose last
+	// runs all passed
+	SessionsWithChecks int64 `json:"sessions_with_checks"`
+	SessionsEndedGreen int64 `json:"sessions_ended_green"`
+}
+
+// Normalized holds pricing-independent behavior metrics.
+type Normalized struct {
+	TokensPerTurn    float64 `json:"tokens_per_turn"`
+	OutputRatio      float64 `json:"output_ratio"`
+	CacheHitRate     float64 `json:"cache_hit_rate"`
+	ErrorRate        float64 `json:"error_rate"`
+	ToolCallsPerTurn float64 `json:"tool_calls_per_turn"`
+	GreenRate        float64 `json:"green_rate"`
+}
+
+// Tool holds usage of a single tool.
+type Tool struct {
+	Name        string `json:"name"`
+	Invocations int64  `json:"invocations"`
+	Errors      int64  `json:"errors"`
+}
+
+// Day holds the sessions, tokens and cost of a single day.
+type Day struct {
+	Date     string  `json:"date"`
+	Sessions int64   `json:"sessions"`
+	Tokens   int64   `json:"tokens"`
+	CostUsd  float64 `json:"cost_usd"`
+}
+
+// Baseline holds the comparison against a baseline experiment.
+type Baseline struct {
+	Name     string  `json:"name"`
+	Sessions int64   `json:"sessions"`
+	Deltas   []Delta `json:"deltas"`
+}
+
+// Delta compares a per-session metric against the baseline.
+// CILow and CIHigh are omitted when either side has fewer than two sessions.
+type Delta struct {
+	Metric         string   `json:"metric"`
+	BaselineMean   float64  `json:"baseline_mean"`
+	ExperimentMean float64  `json:"experiment_mean"`
+	Delta          float64  `json:"delta"`
+	DeltaPct       float64  `json:"delta_pct"`
+	CILow          *float64 `json:"ci_low,omitempty"`
+	CIHigh         *float64 `json:"ci_high,omitempty"`
+	Significant    bool     `json:"significant"`
+}
+
+// Builder gathers report data from the repositories.
+type Builder struct {
+	variables ports.ExperimentVariableRepository
+	stats     ports.StatsRepository
+}
+
+func NewBuilder(variables ports.ExperimentVariableRepository, stats ports.StatsRepository) *Builder {
+	return &Builder{variables: variables, stats: stats}
+}
+
+// Build gathers the report for exp, compared against baseline when it is not nil.
+func (b *Builder) Build(ctx context.Context, exp *domain.Experiment, baseline *domain.Experiment) (*Report, error) {
+	r := &Report{
+		GeneratedAt: time.Now().UTC(),
+		Experiment:  experimentInfo(exp),
+		Variables:   map[string]string{},
+		TopTools:    []Tool{},
+	}
+
+	vars, err := b.variables.ListByExperimentID(ctx, exp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variables: %w", err)
+	}
+	for _, v := range vars {
+		r.Variables[v.Key] = v.Value
+	}
+
+	agg, err := b.stats.GetAggregateByExperiment(ctx, exp.ID, "1970-01-01T00:00:00Z")
+	if err != nil {
+		return nil, err
+	}
+	toolCalls, err := b.stats.GetTotalToolCallsByExperiment(ctx, exp.ID)
+	if err != nil {
+		return nil, err
+	}
+	r.Totals = Totals{
+		Sessions:           agg.SessionCount,
+		Turns:              agg.TotalTurns,
+		TokenInput:         agg.TotalTokenInput,
+		TokenOutput:        agg.TotalTokenOutput,
+		CacheRead:          agg.TotalTokenCacheRead,
+		CacheWrite:         agg.TotalTokenCacheWrite,
+		CostUsd:            agg.TotalCostUsd,
+		Errors:             agg.TotalErrors,
+		ToolCalls:          toolCalls,
+		SessionsWithChecks: agg.SessionsWithChecks,
+		SessionsEndedGreen: agg.SessionsEndedGreen,
+	}
+	n := agg.ComputeNormalized(toolCalls)
+	r.Normalized = Normalized{
+		TokensPerTurn:    n.TokensPerTurn,
+		OutputRatio:      n.OutputRatio,
+		CacheHitRate:     n.CacheHitRate,
+		ErrorRate:        n.ErrorRate,
+		ToolCallsPerTurn: n.ToolCallsPerTurn,
+		GreenRate:        n.GreenRate,
+	}
+
+	tools, err := b.stats.GetTopToolsByExperiment(ctx, exp.ID, topToolsLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tools {
+		r.TopTools = append(r.TopTools, Tool{Name: t.ToolName, Invocations: t.TotalInvocations, Errors: t.TotalErrors})
+	}
+
+	samples, err := b.stats.ListSamplesByExperiment(ctx, exp.ID)
+	if err != nil {
+		return nil, err
+	}
+	r.Daily = dailyTotals(samples)
+
+	if baseline != nil {
+		baseSamples, err := b.stats.ListSamplesByExperiment(ctx, baseline.ID)
+		if err != nil {
+			return nil, err
+		}
+		r.Baseline = &Baseline{Name: baseline.Name, Sessions: int64(len(baseSamples))}
+		for _, d := range domain.CompareSessionSamples(baseSamples, samples) {
+			r.Baseline.Deltas = append(r.Baseline.Deltas, deltaFromDomain(d))
+		}
+	}
+
+	return r, nil
+}
+
+// IsValidFormat reports whether format is a supported output format.
+func IsValidFormat(format string) bool {
+	switch format {
+	case FormatMarkdown, FormatHTML, FormatJSON:
+		return true
+	}
+	return false
+}
+
+// Render writes the report in the given format.
+func Render(w io.Writer, r *Report, format string) error {
+	switch format {
+	case FormatMarkdown:
+		return WriteMarkdown(w, r)
+	case FormatHTML:
+		return WriteHTML(w, r)
+	case FormatJSON:
+		return WriteJSON(w, r)
+	default:
+		return fmt.Errorf("unknown report format %q (use %s, %s or %s)", format, FormatMarkdown, FormatHTML, FormatJSON)
+	}
+}
+
+func experimentInfo(exp *domain.Experiment) Experiment {
+	info := Experiment{
+		Name:        exp.Name,
+		Description: deref(exp.Description),
+		Hypothesis:  deref(exp.Hypothesis),
+		ModelID:     deref(exp.ModelID),
+		PlanType:    deref(exp.PlanType),
+		Notes:       deref(exp.Notes),
+		Status:      "inactive",
+		StartedAt:   exp.StartedAt,
+		EndedAt:     exp.EndedAt,
+		Verdict:     deref(exp.Verdict),
+		Conclusion:  deref(exp.Conclusion),
+		ConcludedAt: exp.ConcludedAt,
+	}
+	switch {
+	case exp.EndedAt != nil:
+		info.Status = "ended"
+	case exp.IsActive:
+		info.Status = "active"
+	}
+	return info
+}
+
+func deltaFromDomain(d domain.MetricDelta) Delta {
+	delta := Delta{
+		Metric:         d.Metric,
+		BaselineMean:   d.Baseline.Mean,
+		ExperimentMean: d.Treatment.Mean,
+		Delta:          d.Delta,
+		DeltaPct:       d.DeltaPct,
+		Significant:    d.Significant,
+	}
+	if d.HasInterval {
+		low, high := d.CILow, d.CIHigh
+		delta.CILow, delta.CIHigh = &low, &high
+	}
+	return delta
+}
+
+func dailyTotals(samples []domain.SessionSample) []Day {
+	totals := domain.GroupSamplesByDay(samples)
+	days := make([]Day, len(totals))
+	for i, t := range totals {
+		days[i] = Day{Date: t.Date, Sessions: t.Sessions, Tokens: t.Tokens, CostUsd: t.CostUsd}
+	}
+	return days
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
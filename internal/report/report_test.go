@@ -0,0 +1,106 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+func sampleReport() *Report {
+	low, high := -420.0, -180.0
+	return &Report{
+		GeneratedAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+		Experiment: Experiment{
+			Name:       "minimal-prompts",
+			Hypothesis: "Shorter prompts reduce tokens",
+			Status:     "ended",
+			StartedAt:  time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			Verdict:    domain.VerdictConfirmed,
+			Conclusion: "Tokens dropped by a third",
+		},
+		Variables: map[string]string{"effort": "high"},
+		Totals:    Totals{Sessions: 12, Turns: 80, TokenInput: 12000, TokenOutput: 3000, CostUsd: 1.5},
+		TopTools:  []Tool{{Name: "Read", Invocations: 40}, {Name: "Bash", Invocations: 10, Errors: 2}},
+		Daily:     []Day{{Date: "2026-03-01", Sessions: 5, CostUsd: 0.5}, {Date: "2026-03-02", Sessions: 7, CostUsd: 1}},
+		Baseline: &Baseline{
+			Name:     "baseline",
+			Sessions: 10,
+			Deltas: []Delta{
+				{Metric: "Tokens/session", BaselineMean: 1000, ExperimentMean: 700, Delta: -300, DeltaPct: -30, CILow: &low, CIHigh: &high, Significant: true},
+				{Metric: "Cost/session", BaselineMean: 0.2, ExperimentMean: 0.125, Delta: -0.075, DeltaPct: -37.5},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{FormatMarkdown, []string{
+			"# Experiment report: minimal-prompts",
+			"> Shorter prompts reduce tokens",
+			"**confirmed**",
+			"| effort | high |",
+			"## Compared with baseline",
+			"| Tokens/session | 1000.0 | 700.0 | -300.0 | -30.0% | [-420.0, -180.0] | **yes** |",
+			"| Cost/session | $0.2000 | $0.1250 | -0.0750 | -37.5% | n/a | no |",
+			"Bash",
+			"(2 errors)",
+			"2026-03-02",
+		}},
+		{FormatHTML, []string{
+			"<title>Experiment report: minimal-prompts</title>",
+			`class="verdict verdict-confirmed"`,
+			"Compared with baseline",
+			"[-420.0, -180.0]",
+			`aria-label="Daily cost"`,
+			"2026-03-01 to 2026-03-02",
+		}},
+		{FormatJSON, []string{
+			`"name": "minimal-prompts"`,
+			`"verdict": "confirmed"`,
+			`"ci_low": -420`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, sampleReport(), tt.format); err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			out := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestRender_UnknownFormat(t *testing.T) {
+	if err := Render(&bytes.Buffer{}, sampleReport(), "pdf"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestWriteJSON_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, sampleReport()); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var got Report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Baseline == nil || len(got.Baseline.Deltas) != 2 || got.Baseline.Deltas[1].CILow != nil {
+		t.Errorf("unexpected baseline after round trip: %+v", got.Baseline)
+	}
+}
@@ -0,0 +1,194 @@
+// Package simulate re-prices recorded usage under what-if pricing scenarios.
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// NoExperiment labels sessions recorded outside an experiment.
+const NoExperiment = "(none)"
+
+// Simulator compares recorded costs with the costs of a scenario. Sessions
+// are priced under their project's pricing profile, like when recorded.
+type Simulator struct {
+	stats       ports.StatsRepository
+	pricing     ports.PricingRepository
+	profiles    ports.PricingProfileRepository
+	projects    ports.ProjectRepository
+	experiments ports.ExperimentRepository
+}
+
+func NewSimulator(stats ports.StatsRepository, pricing ports.PricingRepository, profiles ports.PricingProfileRepository, projects ports.ProjectRepository, experiments ports.ExperimentRepository) *Simulator {
+	return &Simulator{stats: stats, pricing: pricing, profiles: profiles, projects: projects, experiments: experiments}
+}
+
+// Row is the actual and simulated cost of a group of sessions.
+type Row struct {
+	Name         string
+	Sessions     int64
+	ActualUsd    float64
+	SimulatedUsd float64
+}
+
+// DeltaUsd returns the simulated cost minus the actual cost.
+func (r Row) DeltaUsd() float64 {
+	return r.SimulatedUsd - r.ActualUsd
+}
+
+// DeltaPercent returns the change from the actual cost in percent, or 0
+// without an actual cost.
+func (r Row) DeltaPercent() float64 {
+	if r.ActualUsd == 0 {
+		return 0
+	}
+	return r.DeltaUsd() / r.ActualUsd * 100
+}
+
+// Result is a scenario's cost in total, per project and per experiment.
+// Projects and experiments are ordered by actual cost, highest first.
+type Result struct {
+	Total       Row
+	Projects    []Row
+	Experiments []Row
+}
+
+// Run re-prices the sessions created in [since, until) under the scenario.
+func (s *Simulator) Run(ctx context.Context, scenario domain.CostScenario, since, until time.Time) (*Result, error) {
+	sessions, err := s.stats.ListSessionUsage(ctx, since.UTC().Format(time.RFC3339), until.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	pricing, err := s.pricing.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pricing: %w", err)
+	}
+	byID := make(map[string]*domain.ModelPricing, len(pricing))
+	var fallback *domain.ModelPricing
+	for _, p := range pricing {
+		byID[p.ID] = p
+		if p.IsDefault {
+			fallback = p
+		}
+	}
+	profileFor, err := s.projectProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Total: Row{Name: "Total"}}
+	byProject := make(map[string]*Row)
+	byExperiment := make(map[string]*Row)
+	for _, session := range sessions {
+		profile := profileFor(session.ProjectID)
+		lookup := func(model string) *domain.ModelPricing {
+			if model == "" {
+				return nil
+			}
+			id := domain.ResolveModelAlias(model)
+			if r := profile.Rate(id); r != nil {
+				return r
+			}
+			return profile.Apply(byID[id])
+		}
+		sc := scenario
+		sc.Model = profile.Apply(scenario.Model)
+		sc.SubagentModel = profile.Apply(scenario.SubagentModel)
+
+		actual := session.ActualCostUsd()
+		simulated := sc.SimulateCost(session, lookup, profile.Apply(fallback))
+		experiment := NoExperiment
+		if session.ExperimentID != nil {
+			experiment = *session.ExperimentID
+		}
+		for _, row := range []*Row{&result.Total, group(byProject, session.ProjectID), group(byExperiment, experiment)} {
+			row.Sessions++
+			row.ActualUsd += actual
+			row.SimulatedUsd += simulated
+		}
+	}
+
+	for id, row := range byProject {
+		if p, _ := s.projects.GetByID(ctx, id); p != nil {
+			row.Name = p.Name
+		}
+	}
+	for id, row := range byExperiment {
+		if id == NoExperiment {
+			continue
+		}
+		if e, _ := s.experiments.GetByID(ctx, id); e != nil {
+			row.Name = e.Name
+		}
+	}
+	result.Projects = sortedRows(byProject)
+	result.Experiments = sortedRows(byExperiment)
+	return result, nil
+}
+
+// Pricing returns the pricing of a model ID or alias, or an error when the
+// model has no pricing configured.
+func (s *Simulator) Pricing(ctx context.Context, model string) (*domain.ModelPricing, error) {
+	p, err := s.pricing.GetByID(ctx, domain.ResolveModelAlias(model))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing: %w", err)
+	}
+	if p == nil {
+		return nil, fmt.Errorf("no pricing configured for model %q", model)
+	}
+	return p, nil
+}
+
+// projectProfiles returns a lookup of the pricing profile of each project.
+func (s *Simulator) projectProfiles(ctx context.Context) (func(projectID string) *domain.PricingProfile, error) {
+	profiles, err := s.profiles.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assigned, err := s.profiles.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.PricingProfile, len(profiles))
+	var def *domain.PricingProfile
+	for _, p := range profiles {
+		byName[p.Name] = p
+		if p.IsDefault {
+			def = p
+		}
+	}
+	return func(projectID string) *domain.PricingProfile {
+		if p, ok := byName[assigned[projectID]]; ok {
+			return p
+		}
+		return def
+	}, nil
+}
+
+func group(rows map[string]*Row, key string) *Row {
+	row, ok := rows[key]
+	if !ok {
+		row = &Row{Name: key}
+		rows[key] = row
+	}
+	return row
+}
+
+func sortedRows(rows map[string]*Row) []Row {
+	sorted := make([]Row, 0, len(rows))
+	for _, r := range rows {
+		sorted = append(sorted, *r)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].ActualUsd != sorted[j].ActualUsd {
+			return sorted[i].ActualUsd > sorted[j].ActualUsd
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
@@ -0,0 +1,134 @@
+package simulate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+type fakeStats struct {
+	ports.StatsRepository
+	sessions     []domain.SessionUsage
+	since, until string
+}
+
+func (f *fakeStats) ListSessionUsage(ctx context.Context, since, until string) ([]domain.SessionUsage, error) {
+	f.since, f.until = since, until
+	return f.sessions, nil
+}
+
+type fakePricing struct {
+	ports.PricingRepository
+	pricing []*domain.ModelPricing
+}
+
+func (f *fakePricing) List(ctx context.Context) ([]*domain.ModelPricing, error) {
+	return f.pricing, nil
+}
+
+func (f *fakePricing) GetByID(ctx context.Context, id string) (*domain.ModelPricing, error) {
+	for _, p := range f.pricing {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeProfiles struct {
+	ports.PricingProfileRepository
+	profiles []*domain.PricingProfile
+	projects map[string]string
+}
+
+func (f *fakeProfiles) List(ctx context.Context) ([]*domain.PricingProfile, error) {
+	return f.profiles, nil
+}
+
+func (f *fakeProfiles) ListProjects(ctx context.Context) (map[string]string, error) {
+	return f.projects, nil
+}
+
+type fakeProjects struct {
+	ports.ProjectRepository
+}
+
+func (f *fakeProjects) GetByID(ctx context.Context, id string) (*domain.Project, error) {
+	return &domain.Project{ID: id, Name: "name-" + id}, nil
+}
+
+type fakeExperiments struct {
+	ports.ExperimentRepository
+}
+
+func (f *fakeExperiments) GetByID(ctx context.Context, id string) (*domain.Experiment, error) {
+	return &domain.Experiment{ID: id, Name: "exp-" + id}, nil
+}
+
+func TestSimulator(t *testing.T) {
+	ctx := context.Background()
+	opus := &domain.ModelPricing{ID: "claude-opus-4-6-20260206", InputPerMillion: 5, OutputPerMillion: 25, IsDefault: true}
+	sonnet := &domain.ModelPricing{ID: "claude-sonnet-4-5-20250929", InputPerMillion: 3, OutputPerMillion: 15}
+	million := domain.TokenUsage{Input: 1_000_000, Output: 1_000_000}
+	exp := "e1"
+	stats := &fakeStats{sessions: []domain.SessionUsage{
+		{ProjectID: "a", ExperimentID: &exp, Model: opus.ID, Tokens: million, CostUsd: 30},
+		{ProjectID: "a", Model: "opus", Tokens: million, CostUsd: 30,
+			Subagents: []domain.SubagentUsage{{Model: "sonnet", Tokens: million, CostUsd: 18}}},
+		// Unknown models are priced at the default
+		{ProjectID: "b", Model: "mystery", Tokens: million, CostUsd: 30},
+	}}
+	sim := NewSimulator(stats, &fakePricing{pricing: []*domain.ModelPricing{opus, sonnet}}, &fakeProfiles{}, &fakeProjects{}, &fakeExperiments{})
+
+	model, err := sim.Pricing(ctx, "sonnet")
+	if err != nil || model != sonnet {
+		t.Fatalf("Pricing(sonnet) = %v, %v", model, err)
+	}
+	if _, err := sim.Pricing(ctx, "gpt"); err == nil {
+		t.Error("expected an error for a model without pricing")
+	}
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	result, err := sim.Run(ctx, domain.CostScenario{Model: sonnet}, since, since.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if stats.since != "2026-03-01T00:00:00Z" || stats.until != "2026-04-01T00:00:00Z" {
+		t.Errorf("unexpected range %s - %s", stats.since, stats.until)
+	}
+
+	total := result.Total
+	if total.Sessions != 3 || total.ActualUsd != 108 || total.SimulatedUsd != 72 || total.DeltaUsd() != -36 {
+		t.Errorf("unexpected total: %+v", total)
+	}
+	if len(result.Projects) != 2 || result.Projects[0].Name != "name-a" || result.Projects[0].SimulatedUsd != 54 {
+		t.Errorf("unexpected projects: %+v", result.Projects)
+	}
+	if len(result.Experiments) != 2 || result.Experiments[0].Name != NoExperiment || result.Experiments[1].Name != "exp-e1" {
+		t.Errorf("unexpected experiments: %+v", result.Experiments)
+	}
+
+	// The unchanged scenario re-prices at the recorded models
+	result, err = sim.Run(ctx, domain.CostScenario{}, since, since.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Total.SimulatedUsd != 108 || result.Total.DeltaPercent() != 0 {
+		t.Errorf("unexpected baseline: %+v", result.Total)
+	}
+
+	// Project b pays half price under its profile, for the scenario as well
+	half := &domain.PricingProfile{Name: "discount", Multiplier: 0.5}
+	profiles := &fakeProfiles{profiles: []*domain.PricingProfile{half}, projects: map[string]string{"b": "discount"}}
+	sim = NewSimulator(stats, &fakePricing{pricing: []*domain.ModelPricing{opus, sonnet}}, profiles, &fakeProjects{}, &fakeExperiments{})
+	result, err = sim.Run(ctx, domain.CostScenario{Model: sonnet}, since, since.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Projects[1].Name != "name-b" || result.Projects[1].SimulatedUsd != 9 {
+		t.Errorf("expected project b at half the Sonnet price, got %+v", result.Projects[1])
+	}
+}
@@ -0,0 +1,94 @@
+// Package usage tracks subscription usage windows against learned limits.
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// Tracker computes the usage of the current 5-hour and weekly windows.
+type Tracker struct {
+	plans ports.PlanRepository
+	usage ports.UsageRepository
+}
+
+func NewTracker(plans ports.PlanRepository, usage ports.UsageRepository) *Tracker {
+	return &Tracker{plans: plans, usage: usage}
+}
+
+// Status returns the usage of the current windows at now, or nil when no
+// plan is configured. Limits are learned from the usage recorded when
+// previous sessions hit a rate limit.
+func (t *Tracker) Status(ctx context.Context, now time.Time) (*domain.PlanUsage, error) {
+	plan, err := t.plans.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, nil
+	}
+
+	start, _ := domain.WeeklyBounds(plan.WeeklyResetAt, now)
+	requests, err := t.usage.ListRequestsBetween(ctx, earliest(start, now.Add(-domain.SessionWindowLookback)), now)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.PlanUsage{
+		Plan:    plan,
+		Session: domain.CurrentSessionWindow(requests, now),
+		Weekly:  domain.CurrentWeeklyWindow(requests, plan.WeeklyResetAt, now),
+	}
+
+	hits, err := t.usage.ListRateLimitHits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	samples := map[string][]float64{}
+	samplePlans := map[string][]string{}
+	for _, hit := range hits {
+		tokens, err := t.tokensAtHit(ctx, hit, plan.WeeklyResetAt)
+		if err != nil {
+			return nil, err
+		}
+		if tokens == 0 {
+			continue
+		}
+		// Hits recorded before a plan was configured count toward the
+		// current plan
+		hitPlan := plan.PlanType
+		if hit.PlanType != nil {
+			hitPlan = *hit.PlanType
+		}
+		samples[hit.Window] = append(samples[hit.Window], float64(tokens))
+		samplePlans[hit.Window] = append(samplePlans[hit.Window], hitPlan)
+	}
+
+	status.Session.Limit = domain.LearnLimit(plan.PlanType, samples[domain.WindowSession], samplePlans[domain.WindowSession])
+	status.Session.LimitSamples = len(samples[domain.WindowSession])
+	status.Weekly.Limit = domain.LearnLimit(plan.PlanType, samples[domain.WindowWeekly], samplePlans[domain.WindowWeekly])
+	status.Weekly.LimitSamples = len(samples[domain.WindowWeekly])
+	return status, nil
+}
+
+func (t *Tracker) tokensAtHit(ctx context.Context, hit domain.RateLimitHit, resetAt *time.Time) (int64, error) {
+	lookback := domain.SessionWindowLookback
+	if hit.Window == domain.WindowWeekly {
+		lookback = domain.WeeklyWindowLength
+	}
+	requests, err := t.usage.ListRequestsBetween(ctx, hit.HitAt.Add(-lookback), hit.HitAt)
+	if err != nil {
+		return 0, err
+	}
+	return domain.TokensAtHit(hit, requests, resetAt), nil
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,92 @@
+package usage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+type fakePlans struct{ plan *domain.PlanConfig }
+
+func (f *fakePlans) Get(ctx context.Context) (*domain.PlanConfig, error) { return f.plan, nil }
+func (f *fakePlans) Set(ctx context.Context, plan *domain.PlanConfig) error {
+	f.plan = plan
+	return nil
+}
+func (f *fakePlans) Clear(ctx context.Context) error {
+	f.plan = nil
+	return nil
+}
+
+type fakeUsage struct {
+	requests []domain.UsageRequest
+	hits     []domain.RateLimitHit
+}
+
+func (f *fakeUsage) ReplaceForSession(ctx context.Context, sessionID string, requests []domain.UsageRequest, hits []domain.RateLimitHit) error {
+	return nil
+}
+
+func (f *fakeUsage) ListRequestsBetween(ctx context.Context, start, end time.Time) ([]domain.UsageRequest, error) {
+	var out []domain.UsageRequest
+	for _, r := range f.requests {
+		if !r.Timestamp.Before(start) && !r.Timestamp.After(end) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeUsage) ListRateLimitHits(ctx context.Context) ([]domain.RateLimitHit, error) {
+	return f.hits, nil
+}
+
+func TestTracker_Status(t *testing.T) {
+	ctx := context.Background()
+	at := func(s string) time.Time {
+		ts, _ := time.Parse(time.RFC3339, s)
+		return ts
+	}
+	usage := &fakeUsage{
+		requests: []domain.UsageRequest{
+			// A window on the previous day that hit the limit at 1000 tokens
+			{Timestamp: at("2026-03-04T10:00:00Z"), TokenInput: 600},
+			{Timestamp: at("2026-03-04T11:00:00Z"), TokenInput: 400},
+			// The current window
+			{Timestamp: at("2026-03-05T09:30:00Z"), TokenInput: 250},
+		},
+		hits: []domain.RateLimitHit{
+			{HitAt: at("2026-03-04T11:30:00Z"), Window: domain.WindowSession},
+		},
+	}
+
+	tracker := NewTracker(&fakePlans{}, usage)
+	now := at("2026-03-05T10:00:00Z")
+	status, err := tracker.Status(ctx, now)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status != nil {
+		t.Fatalf("expected nil status without a plan, got %+v", status)
+	}
+
+	tracker = NewTracker(&fakePlans{plan: &domain.PlanConfig{PlanType: domain.PlanPro}}, usage)
+	status, err = tracker.Status(ctx, now)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !status.Session.Active || status.Session.Tokens != 250 {
+		t.Errorf("unexpected session window: %+v", status.Session)
+	}
+	if status.Session.Limit != 1000 || status.Session.LimitSamples != 1 {
+		t.Errorf("expected a learned limit of 1000 from 1 hit, got %d from %d", status.Session.Limit, status.Session.LimitSamples)
+	}
+	if status.Session.UsedFraction() != 0.25 {
+		t.Errorf("expected 25%% used, got %v", status.Session.UsedFraction())
+	}
+	if status.Weekly.Tokens != 1250 || status.Weekly.Limit != 0 {
+		t.Errorf("unexpected weekly window: %+v", status.Weekly)
+	}
+}
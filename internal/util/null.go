@@ -1,6 +1,9 @@
 package util
 
-import "database/sql"
+import (
+	"database/sql"
+	"time"
+)
 
 // NullString converts a string to sql.NullString.
 // Empty strings are treated as invalid (null).
@@ -29,6 +32,28 @@ func NullStringToPtr(ns sql.NullString) *string {
 	return &ns.String
 }
 
+// NullTimePtr converts a *time.Time to an RFC3339 sql.NullString.
+// Nil pointers are treated as invalid (null).
+func NullTimePtr(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.Format(time.RFC3339), Valid: true}
+}
+
+// NullTimeToPtr parses an RFC3339 sql.NullString into *time.Time.
+// Invalid or unparseable values are returned as nil.
+func NullTimeToPtr(ns sql.NullString) *time.Time {
+	if !ns.Valid {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, ns.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 // NullFloat64 converts a *float64 to sql.NullFloat64.
 // Nil pointers are treated as invalid (null).
 func NullFloat64(f *float64) sql.NullFloat64 {
@@ -64,3 +89,22 @@ func BoolToInt64(b bool) int64 {
 	}
 	return 0
 }
+
+// NullBoolPtr converts a *bool to an INTEGER sql.NullInt64 (true=1, false=0).
+// Nil pointers are treated as invalid (null).
+func NullBoolPtr(b *bool) sql.NullInt64 {
+	if b == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: BoolToInt64(*b), Valid: true}
+}
+
+// NullInt64ToBoolPtr converts an INTEGER sql.NullInt64 to *bool.
+// Invalid values are returned as nil.
+func NullInt64ToBoolPtr(ni sql.NullInt64) *bool {
+	if !ni.Valid {
+		return nil
+	}
+	b := ni.Int64 != 0
+	return &b
+}
@@ -20,3 +20,18 @@ func GetXDGDataDir() (string, error) {
 
 	return filepath.Join(homeDir, ".local", "share", "mclaude"), nil
 }
+
+// GetXDGConfigDir returns the XDG config directory for mclaude.
+// It respects XDG_CONFIG_HOME if set, otherwise falls back to ~/.config/mclaude
+func GetXDGConfigDir() (string, error) {
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return filepath.Join(configHome, "mclaude"), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, ".config", "mclaude"), nil
+}
@@ -0,0 +1,159 @@
+// Package value compares the API-equivalent cost of recorded usage with the
+// price paid for a subscription plan.
+package value
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+// UnknownModel labels sessions without a recorded model.
+const UnknownModel = "unknown"
+
+// Analyzer compares monthly API-equivalent cost with plan prices.
+type Analyzer struct {
+	stats    ports.StatsRepository
+	prices   ports.PlanPriceRepository
+	projects ports.ProjectRepository
+}
+
+func NewAnalyzer(stats ports.StatsRepository, prices ports.PlanPriceRepository, projects ports.ProjectRepository) *Analyzer {
+	return &Analyzer{stats: stats, prices: prices, projects: projects}
+}
+
+// CostShare is the API-equivalent cost of a model or project over the
+// report's months.
+type CostShare struct {
+	Name       string
+	Sessions   int64
+	APICostUsd float64
+	Fraction   float64
+}
+
+// Report compares API-equivalent cost with the plan price for each month,
+// oldest first. Months are in UTC, like the forecast. The totals cover the
+// months with a price; the breakdowns cover all months.
+type Report struct {
+	Months     []domain.MonthValue
+	ByModel    []CostShare
+	ByProject  []CostShare
+	APICostUsd float64
+	PriceUsd   float64
+}
+
+// HasPrices reports whether any month of the report has a plan price.
+func (r *Report) HasPrices() bool {
+	return r.PriceUsd > 0
+}
+
+// ValueRatio returns the API-equivalent cost per dollar paid over the months
+// with a price.
+func (r *Report) ValueRatio() float64 {
+	if r.PriceUsd <= 0 {
+		return 0
+	}
+	return r.APICostUsd / r.PriceUsd
+}
+
+// SavingsUsd returns what the subscription saved over pay-as-you-go pricing
+// across the months with a price.
+func (r *Report) SavingsUsd() float64 {
+	return r.APICostUsd - r.PriceUsd
+}
+
+// Current returns the month containing the report's end.
+func (r *Report) Current() domain.MonthValue {
+	return r.Months[len(r.Months)-1]
+}
+
+// Report compares the last months, including the current one, with the plan
+// prices in effect. Models and projects are ordered by cost, highest first.
+func (a *Analyzer) Report(ctx context.Context, now time.Time, months int) (*Report, error) {
+	if months < 1 {
+		months = 1
+	}
+	current := domain.MonthStart(now)
+	first := current.AddDate(0, -(months - 1), 0)
+
+	prices, err := a.prices.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	costs, err := a.stats.ListDailyCostByModel(ctx, first.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	for m := first; !m.After(current); m = m.AddDate(0, 1, 0) {
+		v := domain.NewMonthValue(m, domain.PriceForMonth(prices, m), costs, now)
+		if v.Price != nil {
+			report.APICostUsd += v.APICostUsd
+			report.PriceUsd += v.Price.MonthlyUsd
+		}
+		report.Months = append(report.Months, v)
+	}
+
+	byModel := make(map[string]*CostShare)
+	byProject := make(map[string]*CostShare)
+	var total float64
+	for _, c := range costs {
+		model := c.Model
+		if model == "" {
+			model = UnknownModel
+		}
+		addShare(byModel, model, c)
+		addShare(byProject, c.ProjectID, c)
+		total += c.CostUsd
+	}
+
+	for id, share := range byProject {
+		if p, _ := a.projects.GetByID(ctx, id); p != nil {
+			share.Name = p.Name
+		}
+	}
+	report.ByModel = sortedShares(byModel, total)
+	report.ByProject = sortedShares(byProject, total)
+	return report, nil
+}
+
+func addShare(shares map[string]*CostShare, name string, c domain.DailyModelCost) {
+	share, ok := shares[name]
+	if !ok {
+		share = &CostShare{Name: name}
+		shares[name] = share
+	}
+	share.Sessions += c.Sessions
+	share.APICostUsd += c.CostUsd
+}
+
+func sortedShares(shares map[string]*CostShare, total float64) []CostShare {
+	sorted := make([]CostShare, 0, len(shares))
+	for _, s := range shares {
+		if total > 0 {
+			s.Fraction = s.APICostUsd / total
+		}
+		sorted = append(sorted, *s)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].APICostUsd != sorted[j].APICostUsd {
+			return sorted[i].APICostUsd > sorted[j].APICostUsd
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// ParseMonth parses a month as YYYY-MM and returns its first day in UTC.
+func ParseMonth(s string) (time.Time, error) {
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month %q (use YYYY-MM)", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,102 @@
+package value
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+)
+
+type fakeStats struct {
+	ports.StatsRepository
+	costs []domain.DailyModelCost
+	since string
+}
+
+func (f *fakeStats) ListDailyCostByModel(ctx context.Context, since string) ([]domain.DailyModelCost, error) {
+	f.since = since
+	return f.costs, nil
+}
+
+type fakePrices struct {
+	ports.PlanPriceRepository
+	prices []*domain.PlanPrice
+}
+
+func (f *fakePrices) List(ctx context.Context) ([]*domain.PlanPrice, error) {
+	return f.prices, nil
+}
+
+type fakeProjects struct {
+	ports.ProjectRepository
+}
+
+func (f *fakeProjects) GetByID(ctx context.Context, id string) (*domain.Project, error) {
+	return &domain.Project{ID: id, Name: "name-" + id}, nil
+}
+
+func day(s string) time.Time {
+	d, _ := time.Parse(time.DateOnly, s)
+	return d
+}
+
+func TestAnalyzer(t *testing.T) {
+	ctx := context.Background()
+	stats := &fakeStats{costs: []domain.DailyModelCost{
+		{Date: day("2026-02-03"), ProjectID: "a", Model: "opus", Sessions: 4, CostUsd: 150},
+		{Date: day("2026-03-02"), ProjectID: "a", Model: "opus", Sessions: 2, CostUsd: 60},
+		{Date: day("2026-03-02"), ProjectID: "b", Model: "", Sessions: 1, CostUsd: 10},
+		{Date: day("2026-03-09"), ProjectID: "b", Model: "sonnet", Sessions: 3, CostUsd: 30},
+	}}
+	prices := &fakePrices{prices: []*domain.PlanPrice{
+		{EffectiveFrom: day("2026-02-01"), PlanType: domain.PlanMax5x, MonthlyUsd: 100},
+	}}
+	a := NewAnalyzer(stats, prices, &fakeProjects{})
+	now := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+
+	report, err := a.Report(ctx, now, 3)
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if stats.since != "2026-01-01T00:00:00Z" {
+		t.Errorf("expected costs since January, got %s", stats.since)
+	}
+	if len(report.Months) != 3 {
+		t.Fatalf("expected 3 months, got %d", len(report.Months))
+	}
+
+	// January has no price and is left out of the totals
+	if report.Months[0].Price != nil {
+		t.Errorf("expected no price in January, got %+v", report.Months[0].Price)
+	}
+	if report.APICostUsd != 250 || report.PriceUsd != 200 || report.SavingsUsd() != 50 {
+		t.Errorf("unexpected totals: api %.2f, price %.2f", report.APICostUsd, report.PriceUsd)
+	}
+
+	current := report.Current()
+	if !current.Partial || current.APICostUsd != 100 || current.BreakEvenAt == nil || !current.BreakEvenAt.Equal(day("2026-03-09")) {
+		t.Errorf("unexpected current month: %+v", current)
+	}
+
+	if len(report.ByModel) != 3 || report.ByModel[0].Name != "opus" || report.ByModel[0].Sessions != 6 {
+		t.Fatalf("unexpected models: %+v", report.ByModel)
+	}
+	if report.ByModel[2].Name != UnknownModel || report.ByModel[2].Fraction != 0.04 {
+		t.Errorf("expected the unknown model last with 4%%, got %+v", report.ByModel[2])
+	}
+	if len(report.ByProject) != 2 || report.ByProject[0].Name != "name-a" || report.ByProject[1].APICostUsd != 40 {
+		t.Errorf("unexpected projects: %+v", report.ByProject)
+	}
+}
+
+func TestParseMonth(t *testing.T) {
+	m, err := ParseMonth("2026-03")
+	if err != nil || !m.Equal(day("2026-03-01")) {
+		t.Errorf("ParseMonth = %v, %v", m, err)
+	}
+	if _, err := ParseMonth("March"); err == nil {
+		t.Error("expected an error for an invalid month")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/forecast"
 	"github.com/emiliopalmerini/mclaude/internal/util"
 	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
 )
@@ -102,10 +104,50 @@ func (s *Server) handleAPIChartCost(w http.ResponseWriter, r *http.Request) {
 		costs[i] = util.ToFloat64(stat.TotalCost)
 	}
 
+	// Projected daily cost for the rest of the month, overlaid on the chart
+	method := r.URL.Query().Get("forecast")
+	if !domain.IsValidForecastMethod(method) {
+		method = domain.ForecastSeasonal
+	}
+	projection := map[string]any{
+		"method": method,
+		"labels": []string{},
+		"costs":  []float64{},
+		"low":    []float64{},
+		"high":   []float64{},
+	}
+	report, err := forecast.NewForecaster(s.statsRepo, s.projectRepo).MonthEnd(ctx, time.Now(), method)
+	if err != nil {
+		slog.Error("api: chart cost forecast", "error", err)
+	} else if len(report.Overall.Days) > 1 {
+		// Today is already partly charted, so the overlay starts tomorrow
+		days := report.Overall.Days[1:]
+		pLabels := make([]string, len(days))
+		pCosts := make([]float64, len(days))
+		pLow := make([]float64, len(days))
+		pHigh := make([]float64, len(days))
+		for i, d := range days {
+			pLabels[i] = formatChartDate(d.Date)
+			pCosts[i] = d.CostUsd
+			pLow[i] = d.Low
+			pHigh[i] = d.High
+		}
+		projection = map[string]any{
+			"method":       report.Overall.Method,
+			"labels":       pLabels,
+			"costs":        pCosts,
+			"low":          pLow,
+			"high":         pHigh,
+			"has_interval": report.Overall.HasInterval,
+			"projected":    report.Overall.ProjectedUsd,
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(map[string]any{
-		"labels": labels,
-		"costs":  costs,
+		"labels":   labels,
+		"costs":    costs,
+		"forecast": projection,
 	})
 }
 
@@ -135,3 +177,50 @@ func (s *Server) handleAPIChartHeatmap(w http.ResponseWriter, r *http.Request) {
 		"data": data,
 	})
 }
+
+// handleAPIChartExperiment returns an experiment's daily tokens and cost,
+// with its journal entries for chart markers.
+func (s *Server) handleAPIChartExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	samples, err := s.statsRepo.ListSamplesByExperiment(ctx, id)
+	if err != nil {
+		slog.Error("api: chart experiment", "error", err)
+		http.Error(w, "failed to fetch experiment stats", http.StatusInternalServerError)
+		return
+	}
+	notes, err := s.expNoteRepo.ListByExperimentID(ctx, id)
+	if err != nil {
+		slog.Error("api: chart experiment notes", "error", err)
+		http.Error(w, "failed to fetch experiment notes", http.StatusInternalServerError)
+		return
+	}
+
+	days := domain.GroupSamplesByDay(samples)
+	labels := make([]string, len(days))
+	tokens := make([]int64, len(days))
+	costs := make([]float64, len(days))
+	for i, d := range days {
+		labels[i] = d.Date
+		tokens[i] = d.Tokens
+		costs[i] = d.CostUsd
+	}
+
+	type chartNote struct {
+		Time    string `json:"time"`
+		Content string `json:"content"`
+	}
+	markers := make([]chartNote, len(notes))
+	for i, n := range notes {
+		markers[i] = chartNote{Time: n.CreatedAt.Format(time.RFC3339), Content: n.Content}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"labels": labels,
+		"tokens": tokens,
+		"costs":  costs,
+		"notes":  markers,
+	})
+}
@@ -0,0 +1,96 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	"github.com/emiliopalmerini/mclaude/internal/web/templates"
+)
+
+const (
+	commandsPageLimit    = 50
+	commandsTopByProject = 5
+)
+
+// handleCommands reports the Bash commands run during sessions. Query
+// params: project (ID), days (default 30).
+func (s *Server) handleCommands(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	days := 30
+	if v, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && v > 0 {
+		days = v
+	}
+	opts := ports.ListCommandRunsOptions{
+		Since: time.Now().UTC().AddDate(0, 0, -days).Format(time.RFC3339),
+	}
+	filterProject := r.URL.Query().Get("project")
+	if filterProject != "" {
+		opts.ProjectID = &filterProject
+	}
+
+	runs, err := s.commandRepo.ListRuns(ctx, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := templates.CommandsPageData{
+		Days:          days,
+		FilterProject: filterProject,
+		Runs:          len(runs),
+	}
+	projectNames := make(map[string]string)
+	if projects, err := s.projectRepo.List(ctx); err == nil {
+		for _, p := range projects {
+			projectNames[p.ID] = p.Name
+			data.Projects = append(data.Projects, templates.FilterOption{ID: p.ID, Name: p.Name})
+		}
+	}
+
+	stats := domain.AggregateCommands(runs)
+	data.Distinct = len(stats)
+	for _, c := range domain.SummarizeCommandCategories(stats) {
+		data.Categories = append(data.Categories, templates.CommandCategoryRow{
+			Category:    c.Category,
+			Commands:    c.Commands,
+			Runs:        c.Runs,
+			Failures:    c.Failures,
+			FailureRate: c.FailureRate(),
+			Retries:     c.Retries,
+		})
+	}
+	for _, st := range stats[:min(commandsPageLimit, len(stats))] {
+		data.Commands = append(data.Commands, commandRow(st))
+	}
+	for _, st := range domain.RetriedCommands(stats) {
+		data.Retried = append(data.Retried, commandRow(st))
+	}
+	for _, p := range domain.TopCommandsByProject(runs, commandsTopByProject) {
+		row := templates.ProjectCommandsRow{ID: p.ProjectID, Name: projectNames[p.ProjectID], Runs: p.Runs}
+		if row.Name == "" {
+			row.Name = p.ProjectID
+		}
+		for _, st := range p.Commands {
+			row.Commands = append(row.Commands, commandRow(st))
+		}
+		data.ByProject = append(data.ByProject, row)
+	}
+
+	templates.CommandsPage(data).Render(ctx, w)
+}
+
+func commandRow(s domain.CommandStats) templates.CommandRow {
+	return templates.CommandRow{
+		Command:     s.Command,
+		Category:    s.Category,
+		Runs:        s.Runs,
+		Sessions:    s.Sessions,
+		Failures:    s.Failures,
+		FailureRate: s.FailureRate(),
+		Retries:     s.Retries,
+	}
+}
@@ -0,0 +1,89 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	"github.com/emiliopalmerini/mclaude/internal/web/templates"
+)
+
+// handleCommits lists the commits made during sessions and the commits per
+// experiment. Query params: experiment (ID), days (default 30).
+func (s *Server) handleCommits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	days := 30
+	if v, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && v > 0 {
+		days = v
+	}
+	opts := ports.ListCommitsOptions{
+		Limit: 100,
+		Since: time.Now().UTC().AddDate(0, 0, -days).Format(time.RFC3339),
+	}
+	filterExperiment := r.URL.Query().Get("experiment")
+	if filterExperiment != "" {
+		opts.ExperimentID = &filterExperiment
+	}
+
+	commits, err := s.commitRepo.List(ctx, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	stats, err := s.commitRepo.ListStatsByExperiment(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := templates.CommitsPageData{
+		Days:             days,
+		FilterExperiment: filterExperiment,
+	}
+	for _, c := range commits {
+		view := commitView(&c.SessionCommit)
+		view.CostUsd = c.CostUsd()
+		if c.ExperimentName != nil {
+			view.Experiment = *c.ExperimentName
+		}
+		data.Commits = append(data.Commits, view)
+	}
+	for _, st := range stats {
+		data.Experiments = append(data.Experiments, templates.FilterOption{ID: st.ExperimentID, Name: st.ExperimentName})
+		if filterExperiment != "" && st.ExperimentID != filterExperiment {
+			continue
+		}
+		data.ByExperiment = append(data.ByExperiment, templates.ExperimentCommitRow{
+			ID:                  st.ExperimentID,
+			Name:                st.ExperimentName,
+			Sessions:            st.Sessions,
+			SessionsWithCommits: st.SessionsWithCommits,
+			Commits:             st.Commits,
+			CommitsPerSession:   st.CommitsPerSession(),
+			LinesAdded:          st.LinesAdded,
+			LinesRemoved:        st.LinesRemoved,
+			CostUsd:             st.CostUsd,
+			CostPerCommit:       st.CostPerCommit(),
+		})
+	}
+
+	templates.CommitsPage(data).Render(ctx, w)
+}
+
+func commitView(c *domain.SessionCommit) templates.CommitView {
+	return templates.CommitView{
+		Hash:         c.Hash,
+		ShortHash:    c.ShortHash(),
+		Subject:      c.Subject(),
+		Author:       c.Author,
+		CommittedAt:  c.CommittedAt.Format(time.RFC3339),
+		SessionID:    c.SessionID,
+		FilesChanged: c.FilesChanged,
+		LinesAdded:   c.LinesAdded,
+		LinesRemoved: c.LinesRemoved,
+		DetectedBy:   c.DetectedBy,
+	}
+}
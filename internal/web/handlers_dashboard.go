@@ -4,10 +4,14 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"time"
 
+	"github.com/emiliopalmerini/mclaude/internal/budget"
 	"github.com/emiliopalmerini/mclaude/internal/domain"
 	"github.com/emiliopalmerini/mclaude/internal/ports"
+	"github.com/emiliopalmerini/mclaude/internal/usage"
 	"github.com/emiliopalmerini/mclaude/internal/util"
+	"github.com/emiliopalmerini/mclaude/internal/value"
 	"github.com/emiliopalmerini/mclaude/internal/web/templates"
 )
 
@@ -84,6 +88,36 @@ func (s *Server) fetchDashboardData(ctx context.Context, filters dashboardFilter
 		slog.Error("dashboard: recent sessions", "error", err)
 	}
 
+	// 8. Budgets (always for the current period, regardless of filters)
+	budgets, err := budget.NewChecker(s.budgetRepo, s.statsRepo, s.projectRepo, s.experimentRepo).Statuses(ctx, time.Now())
+	if err != nil {
+		slog.Error("dashboard: budgets", "error", err)
+	}
+
+	// 9. Plan usage windows (nil when no plan is set)
+	planUsage, err := usage.NewTracker(s.planRepo, s.usageRepo).Status(ctx, time.Now())
+	if err != nil {
+		slog.Error("dashboard: plan usage", "error", err)
+	}
+
+	// 10. Subscription value against API pricing (current month and the five before it)
+	valueReport, err := value.NewAnalyzer(s.statsRepo, s.planPriceRepo, s.projectRepo).Report(ctx, time.Now(), 6)
+	if err != nil {
+		slog.Error("dashboard: subscription value", "error", err)
+	}
+
+	// 11. File operations by language
+	touchOpts := ports.ListFileTouchesOptions{Since: startDate}
+	if filters.Experiment != "" {
+		touchOpts.ExperimentID = &filters.Experiment
+	} else if filters.Project != "" {
+		touchOpts.ProjectID = &filters.Project
+	}
+	touches, err := s.fileRepo.ListTouches(ctx, touchOpts)
+	if err != nil {
+		slog.Error("dashboard: file touches", "error", err)
+	}
+
 	// Assemble results
 	stats := templates.DashboardStats{
 		FilterPeriod:     filters.Period,
@@ -100,7 +134,11 @@ func (s *Server) fetchDashboardData(ctx context.Context, filters dashboardFilter
 		stats.TokenOutput = aggStats.TotalTokenOutput
 		stats.CacheRead = aggStats.TotalTokenCacheRead
 		stats.CacheWrite = aggStats.TotalTokenCacheWrite
+		stats.CacheWrite1h = aggStats.TotalTokenCacheWrite1h
 		stats.TotalErrors = aggStats.TotalErrors
+		stats.WebSearches = aggStats.TotalWebSearchRequests
+		stats.WebFetches = aggStats.TotalWebFetchRequests
+		stats.ServerToolCost = aggStats.TotalServerToolCostUsd
 	}
 
 	for _, e := range experiments {
@@ -110,6 +148,50 @@ func (s *Server) fetchDashboardData(ctx context.Context, filters dashboardFilter
 		stats.Projects = append(stats.Projects, templates.FilterOption{ID: p.ID, Name: p.Name})
 	}
 
+	if len(touches) > 0 {
+		languages := domain.AggregateLanguages(touches)
+		for _, l := range languages.Languages {
+			stats.Languages = append(stats.Languages, templates.LanguageRow{
+				Language:       l.Language,
+				Files:          l.Files,
+				Operations:     l.Operations(),
+				Share:          languages.Share(l),
+				TestShare:      l.KindShare(domain.FileKindTest),
+				Sessions:       l.Sessions,
+				SessionCostUsd: l.SessionCostUsd,
+			})
+		}
+		for _, k := range languages.Kinds {
+			stats.FileKinds = append(stats.FileKinds, templates.FileKindRow{
+				Kind:           k.Kind,
+				Files:          k.Files,
+				Operations:     k.Operations,
+				Share:          float64(k.Operations) / float64(languages.Operations),
+				SessionCostUsd: k.SessionCostUsd,
+			})
+		}
+	}
+	if byExperiment := domain.AggregateLanguagesByExperiment(touches); filters.Experiment == "" && len(byExperiment) > 1 {
+		experimentNames := make(map[string]string)
+		for _, e := range experiments {
+			experimentNames[e.ID] = e.Name
+		}
+		for _, e := range byExperiment {
+			name := experimentNames[e.ExperimentID]
+			if e.ExperimentID == "" {
+				name = "No experiment"
+			} else if name == "" {
+				name = e.ExperimentID
+			}
+			stats.ExperimentLanguages = append(stats.ExperimentLanguages, templates.ExperimentLanguageRow{
+				Name:           name,
+				Operations:     e.Operations,
+				SessionCostUsd: e.SessionCostUsd,
+				Mix:            e.MixLabel(4),
+			})
+		}
+	}
+
 	if activeExp != nil {
 		stats.ActiveExperiment = activeExp.Name
 	}
@@ -142,5 +224,72 @@ func (s *Server) fetchDashboardData(ctx context.Context, filters dashboardFilter
 	}
 	stats.RecentSessions = recentSessions
 
+	now := time.Now()
+	for _, b := range budgets {
+		stats.Budgets = append(stats.Budgets, templates.BudgetProgress{
+			Label:        b.Label,
+			Period:       string(b.Budget.Period),
+			Hard:         b.Budget.Hard,
+			SpentUsd:     b.SpentUsd,
+			AmountUsd:    b.Budget.AmountUsd,
+			ProjectedUsd: b.ProjectedUsd(now),
+			UsedPct:      b.UsedFraction() * 100,
+			ElapsedPct:   b.ElapsedFraction(now) * 100,
+			ResetsAt:     b.End.Format("Jan 2 15:04"),
+		})
+	}
+
+	if planUsage != nil {
+		stats.PlanUsage = &templates.PlanUsage{PlanType: planUsage.Plan.PlanType}
+		for _, w := range []struct {
+			label string
+			usage domain.WindowUsage
+		}{
+			{"5-hour window", planUsage.Session},
+			{"Weekly window", planUsage.Weekly},
+		} {
+			stats.PlanUsage.Windows = append(stats.PlanUsage.Windows, templates.UsageWindowProgress{
+				Label:        w.label,
+				Active:       w.usage.Active,
+				Tokens:       w.usage.Tokens,
+				Limit:        w.usage.Limit,
+				LimitSamples: w.usage.LimitSamples,
+				UsedPct:      w.usage.UsedFraction() * 100,
+				ResetsAt:     w.usage.End.Format("Jan 2 15:04"),
+			})
+		}
+	}
+
+	if valueReport != nil && valueReport.Current().Price != nil {
+		current := valueReport.Current()
+		monthEnd := current.Month.AddDate(0, 1, 0)
+		stats.Value = &templates.SubscriptionValue{
+			PlanType:          current.Price.PlanType,
+			PriceUsd:          current.PriceUsd(),
+			APICostUsd:        current.APICostUsd,
+			ProjectedUsd:      current.ProjectedAPICostUsd,
+			ValueRatio:        current.ValueRatio(),
+			BreakEvenPct:      current.ValueRatio() * 100,
+			ElapsedPct:        float64(now.Sub(current.Month)) / float64(monthEnd.Sub(current.Month)) * 100,
+			BreakEvenDailyUsd: current.BreakEvenDailyUsd(),
+		}
+		if current.BreakEvenAt != nil {
+			stats.Value.BreakEvenAt = current.BreakEvenAt.Format("Jan 2")
+		}
+		// Earlier months with a price, newest first
+		for i := len(valueReport.Months) - 2; i >= 0; i-- {
+			m := valueReport.Months[i]
+			if m.Price == nil {
+				continue
+			}
+			stats.Value.Months = append(stats.Value.Months, templates.MonthValueRow{
+				Month:      m.Month.Format("2006-01"),
+				APICostUsd: m.APICostUsd,
+				PriceUsd:   m.PriceUsd(),
+				ValueRatio: m.ValueRatio(),
+			})
+		}
+	}
+
 	return stats
 }
@@ -69,8 +69,15 @@ func testServer(t *testing.T, db *sql.DB) *Server {
 	return NewServer(
 		db, 0,
 		repos.Experiments,
-		repos.ExperimentVariables, repos.Pricing, repos.Sessions, repos.Metrics,
-		repos.Stats, repos.Projects,
+		repos.ExperimentVariables, repos.ExperimentNotes, repos.Pricing, repos.Sessions, repos.Metrics,
+		repos.Stats, repos.Projects, repos.Budgets, repos.Plans, repos.Usage,
+		repos.PlanPrices,
+		repos.PricingProfiles,
+		repos.Billing,
+		repos.Commits,
+		repos.Commands,
+		repos.Files,
+		repos.ToolCalls,
 	)
 }
 
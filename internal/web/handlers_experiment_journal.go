@@ -0,0 +1,48 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+)
+
+func (s *Server) handleAPIAddExperimentNote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	content := strings.TrimSpace(r.FormValue("content"))
+	if content == "" {
+		http.Error(w, "Note is required", http.StatusBadRequest)
+		return
+	}
+
+	exp, err := s.experimentRepo.GetByID(ctx, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if exp == nil {
+		http.Error(w, "Experiment not found", http.StatusNotFound)
+		return
+	}
+
+	note := &domain.ExperimentNote{
+		ExperimentID: exp.ID,
+		Content:      content,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := s.expNoteRepo.Create(ctx, note); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/experiments/"+exp.ID)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,114 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/report"
+)
+
+var reportContentTypes = map[string]string{
+	report.FormatMarkdown: "text/markdown; charset=utf-8",
+	report.FormatHTML:     "text/html; charset=utf-8",
+	report.FormatJSON:     "application/json",
+}
+
+// handleExperimentReport renders an experiment report.
+// Query params: format (md, html, json; default html), baseline (experiment ID).
+func (s *Server) handleExperimentReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = report.FormatHTML
+	}
+	if !report.IsValidFormat(format) {
+		http.Error(w, "Invalid format", http.StatusBadRequest)
+		return
+	}
+
+	exp, err := s.experimentRepo.GetByID(ctx, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if exp == nil {
+		http.Error(w, "Experiment not found", http.StatusNotFound)
+		return
+	}
+
+	var baseline *domain.Experiment
+	if baselineID := r.URL.Query().Get("baseline"); baselineID != "" {
+		baseline, err = s.experimentRepo.GetByID(ctx, baselineID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if baseline == nil {
+			http.Error(w, "Baseline experiment not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	rep, err := report.NewBuilder(s.expVariableRepo, s.statsRepo).Build(ctx, exp, baseline)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", reportContentTypes[format])
+	if format != report.FormatHTML {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", exp.Name+"-report."+format))
+	}
+	_ = report.Render(w, rep, format)
+}
+
+// handleAPIConcludeExperiment records the verdict and conclusion of an
+// experiment. An empty verdict clears them.
+func (s *Server) handleAPIConcludeExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	exp, err := s.experimentRepo.GetByID(ctx, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if exp == nil {
+		http.Error(w, "Experiment not found", http.StatusNotFound)
+		return
+	}
+
+	verdict := formValuePtr(r, "verdict")
+	conclusion := formValuePtr(r, "conclusion")
+	var concludedAt *time.Time
+	if verdict != nil {
+		v := strings.ToLower(*verdict)
+		if !domain.IsValidVerdict(v) {
+			http.Error(w, "Invalid verdict", http.StatusBadRequest)
+			return
+		}
+		verdict = &v
+		now := time.Now().UTC()
+		concludedAt = &now
+	} else {
+		conclusion = nil
+	}
+
+	if err := s.experimentRepo.SetConclusion(ctx, exp.ID, verdict, conclusion, concludedAt); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/experiments/"+exp.ID)
+	w.WriteHeader(http.StatusOK)
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
 	"github.com/emiliopalmerini/mclaude/internal/util"
 	"github.com/emiliopalmerini/mclaude/internal/web/templates"
 	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
@@ -109,6 +111,15 @@ func (s *Server) handleExperimentDetail(w http.ResponseWriter, r *http.Request)
 	if exp.Notes.Valid {
 		detail.Notes = exp.Notes.String
 	}
+	if exp.Verdict.Valid {
+		detail.Verdict = exp.Verdict.String
+	}
+	if exp.Conclusion.Valid {
+		detail.Conclusion = exp.Conclusion.String
+	}
+	if exp.ConcludedAt.Valid {
+		detail.ConcludedAt = exp.ConcludedAt.String
+	}
 
 	// Fetch variables
 	vars, _ := s.expVariableRepo.ListByExperimentID(ctx, id)
@@ -119,6 +130,14 @@ func (s *Server) handleExperimentDetail(w http.ResponseWriter, r *http.Request)
 		})
 	}
 
+	notes, _ := s.expNoteRepo.ListByExperimentID(ctx, id)
+	for _, n := range notes {
+		detail.Journal = append(detail.Journal, templates.ExperimentNote{
+			CreatedAt: n.CreatedAt.Format(time.RFC3339),
+			Content:   n.Content,
+		})
+	}
+
 	// Get aggregate stats
 	statsRow, err := queries.GetAggregateStatsByExperiment(ctx, sqlc.GetAggregateStatsByExperimentParams{
 		ExperimentID: util.NullString(exp.ID),
@@ -134,8 +153,14 @@ func (s *Server) handleExperimentDetail(w http.ResponseWriter, r *http.Request)
 		detail.TokenOutput = util.ToInt64(statsRow.TotalTokenOutput)
 		detail.CacheRead = util.ToInt64(statsRow.TotalTokenCacheRead)
 		detail.CacheWrite = util.ToInt64(statsRow.TotalTokenCacheWrite)
+		detail.CacheWrite1h = util.ToInt64(statsRow.TotalTokenCacheWrite1h)
 		detail.TotalTokens = detail.TokenInput + detail.TokenOutput
 		detail.TotalCost = util.ToFloat64(statsRow.TotalCostUsd)
+		detail.LinesChanged = util.ToInt64(statsRow.TotalLinesAdded) + util.ToInt64(statsRow.TotalLinesRemoved)
+		detail.SessionsWithChecks = util.ToInt64(statsRow.SessionsWithChecks)
+		if detail.LinesChanged > 0 {
+			detail.CostPer100Lines = detail.TotalCost / float64(detail.LinesChanged) * 100
+		}
 		if statsRow.SessionCount > 0 {
 			detail.TokensPerSession = detail.TotalTokens / statsRow.SessionCount
 			detail.CostPerSession = detail.TotalCost / float64(statsRow.SessionCount)
@@ -151,6 +176,8 @@ func (s *Server) handleExperimentDetail(w http.ResponseWriter, r *http.Request)
 		TotalTokenCacheRead:  detail.CacheRead,
 		TotalTokenCacheWrite: detail.CacheWrite,
 		TotalErrors:          detail.TotalErrors,
+		SessionsWithChecks:   detail.SessionsWithChecks,
+		SessionsEndedGreen:   util.ToInt64(statsRow.SessionsEndedGreen),
 	}
 	normalized := agStats.ComputeNormalized(toolCalls)
 	detail.TokensPerTurn = normalized.TokensPerTurn
@@ -158,6 +185,7 @@ func (s *Server) handleExperimentDetail(w http.ResponseWriter, r *http.Request)
 	detail.CacheHitRate = normalized.CacheHitRate
 	detail.ErrorRate = normalized.ErrorRate
 	detail.ToolCallsPerTurn = normalized.ToolCallsPerTurn
+	detail.GreenRate = normalized.GreenRate
 
 	// Get top tools for this experiment
 	tools, _ := queries.GetTopToolsUsageByExperiment(ctx, sqlc.GetTopToolsUsageByExperimentParams{
@@ -173,6 +201,8 @@ func (s *Server) handleExperimentDetail(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	detail.Workflows = s.experimentWorkflows(ctx, exp.ID, r.URL.Query().Get("baseline"))
+
 	// Get recent sessions for this experiment (with metrics in single query)
 	sessions, _ := queries.ListSessionsWithMetricsByExperiment(ctx, sqlc.ListSessionsWithMetricsByExperimentParams{
 		ExperimentID: util.NullString(exp.ID),
@@ -194,6 +224,75 @@ func (s *Server) handleExperimentDetail(w http.ResponseWriter, r *http.Request)
 	_ = templates.ExperimentDetailPage(detail).Render(ctx, w)
 }
 
+const (
+	experimentWorkflowLength = 3
+	experimentWorkflowLimit  = 10
+)
+
+// experimentWorkflows compares the tool call patterns of an experiment's
+// sessions with those of the baseline experiment, or of the sessions outside
+// the experiment when baselineID is empty, leaving out excluded sessions. It
+// returns nil when the experiment has no recorded tool calls.
+func (s *Server) experimentWorkflows(ctx context.Context, experimentID, baselineID string) *templates.ExperimentWorkflows {
+	sequences, err := s.toolCallRepo.ListSequences(ctx, ports.ListToolCallsOptions{Since: "1970-01-01T00:00:00Z"})
+	if err != nil {
+		return nil
+	}
+
+	var current, baseline []domain.ToolSequence
+	withCalls := make(map[string]bool)
+	for _, seq := range sequences {
+		if seq.Excluded {
+			continue
+		}
+		id := ""
+		if seq.ExperimentID != nil {
+			id = *seq.ExperimentID
+			withCalls[id] = true
+		}
+		switch {
+		case id == experimentID:
+			current = append(current, seq)
+		case baselineID == "" || id == baselineID:
+			baseline = append(baseline, seq)
+		}
+	}
+	if len(current) == 0 {
+		return nil
+	}
+
+	wf := &templates.ExperimentWorkflows{
+		Length:       experimentWorkflowLength,
+		BaselineID:   baselineID,
+		BaselineName: "Other sessions",
+	}
+	queries := sqlc.New(s.db)
+	if exps, err := queries.ListExperiments(ctx); err == nil {
+		for _, e := range exps {
+			if e.ID == baselineID {
+				wf.BaselineName = e.Name
+			}
+			if e.ID != experimentID && withCalls[e.ID] {
+				wf.Baselines = append(wf.Baselines, templates.FilterOption{ID: e.ID, Name: e.Name})
+			}
+		}
+	}
+
+	summary := domain.SummarizeWorkflows(current, experimentWorkflowLength)
+	other := domain.SummarizeWorkflows(baseline, experimentWorkflowLength)
+	wf.Sessions, wf.BaselineSessions = summary.Sessions, other.Sessions
+	wf.Loops, wf.BaselineLoops = summary.Loops, other.Loops
+	wf.LoopSessionRate, wf.BaselineLoopSessionRate = summary.LoopSessionRate(), other.LoopSessionRate()
+	for _, row := range domain.CompareWorkflows([]domain.WorkflowSummary{summary, other}, experimentWorkflowLimit) {
+		wf.Patterns = append(wf.Patterns, templates.WorkflowPatternRow{
+			Pattern:      row.Pattern,
+			Rate:         row.Rates[0],
+			BaselineRate: row.Rates[1],
+		})
+	}
+	return wf
+}
+
 func (s *Server) handleExperimentCompare(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	queries := sqlc.New(s.db)
@@ -257,6 +356,11 @@ func (s *Server) handleExperimentCompare(w http.ResponseWriter, r *http.Request)
 			item.CacheWrite = util.ToInt64(statsRow.TotalTokenCacheWrite)
 			item.TotalTokens = item.TokenInput + item.TokenOutput
 			item.TotalCost = util.ToFloat64(statsRow.TotalCostUsd)
+			item.LinesChanged = util.ToInt64(statsRow.TotalLinesAdded) + util.ToInt64(statsRow.TotalLinesRemoved)
+			item.SessionsWithChecks = util.ToInt64(statsRow.SessionsWithChecks)
+			if item.LinesChanged > 0 {
+				item.CostPer100Lines = item.TotalCost / float64(item.LinesChanged) * 100
+			}
 			if statsRow.SessionCount > 0 {
 				item.TokensPerSession = item.TotalTokens / statsRow.SessionCount
 				item.CostPerSession = item.TotalCost / float64(statsRow.SessionCount)
@@ -272,6 +376,8 @@ func (s *Server) handleExperimentCompare(w http.ResponseWriter, r *http.Request)
 			TotalTokenCacheRead:  item.CacheRead,
 			TotalTokenCacheWrite: item.CacheWrite,
 			TotalErrors:          item.TotalErrors,
+			SessionsWithChecks:   item.SessionsWithChecks,
+			SessionsEndedGreen:   util.ToInt64(statsRow.SessionsEndedGreen),
 		}
 		normalized := agStats.ComputeNormalized(toolCalls)
 		item.TokensPerTurn = normalized.TokensPerTurn
@@ -279,6 +385,7 @@ func (s *Server) handleExperimentCompare(w http.ResponseWriter, r *http.Request)
 		item.CacheHitRate = normalized.CacheHitRate
 		item.ErrorRate = normalized.ErrorRate
 		item.ToolCallsPerTurn = normalized.ToolCallsPerTurn
+		item.GreenRate = normalized.GreenRate
 
 		items = append(items, item)
 	}
@@ -325,30 +432,119 @@ func (s *Server) handleAPICreateExperiment(w http.ResponseWriter, r *http.Reques
 		IsActive:  true,
 		CreatedAt: now,
 	}
-	if desc := strings.TrimSpace(r.FormValue("description")); desc != "" {
-		exp.Description = &desc
+	applyExperimentForm(r, exp)
+
+	if err := s.experimentRepo.Create(ctx, exp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for key, value := range formVariables(r) {
+		if err := s.expVariableRepo.Set(ctx, exp.ID, key, value); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("HX-Redirect", "/experiments")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleAPIUpdateExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	exp, err := s.experimentRepo.GetByID(ctx, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-	if hyp := strings.TrimSpace(r.FormValue("hypothesis")); hyp != "" {
-		exp.Hypothesis = &hyp
+	if exp == nil {
+		http.Error(w, "Experiment not found", http.StatusNotFound)
+		return
 	}
-	if model := strings.TrimSpace(r.FormValue("model_id")); model != "" {
-		exp.ModelID = &model
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
 	}
-	if plan := strings.TrimSpace(r.FormValue("plan_type")); plan != "" {
-		exp.PlanType = &plan
+	if name != exp.Name {
+		existing, err := s.experimentRepo.GetByName(ctx, name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if existing != nil {
+			http.Error(w, fmt.Sprintf("Experiment %q already exists", name), http.StatusConflict)
+			return
+		}
+		exp.Name = name
 	}
-	if notes := strings.TrimSpace(r.FormValue("notes")); notes != "" {
-		exp.Notes = &notes
+
+	applyExperimentForm(r, exp)
+
+	if err := s.experimentRepo.Update(ctx, exp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	if err := s.experimentRepo.Create(ctx, exp); err != nil {
+	// The form holds the full variable set: upsert submitted ones, drop the rest
+	vars := formVariables(r)
+	existing, err := s.expVariableRepo.ListByExperimentID(ctx, id)
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	for _, v := range existing {
+		if _, ok := vars[v.Key]; ok {
+			continue
+		}
+		if err := s.expVariableRepo.Delete(ctx, id, v.Key); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	for key, value := range vars {
+		if err := s.expVariableRepo.Set(ctx, id, key, value); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("HX-Redirect", "/experiments/"+id)
+	w.WriteHeader(http.StatusOK)
+}
 
-	// Save variables (form fields: var_key[], var_value[])
+// applyExperimentForm sets the optional experiment metadata from form values.
+// Blank fields clear the corresponding value.
+func applyExperimentForm(r *http.Request, exp *domain.Experiment) {
+	exp.Description = formValuePtr(r, "description")
+	exp.Hypothesis = formValuePtr(r, "hypothesis")
+	exp.ModelID = formValuePtr(r, "model_id")
+	exp.PlanType = formValuePtr(r, "plan_type")
+	exp.Notes = formValuePtr(r, "notes")
+}
+
+func formValuePtr(r *http.Request, key string) *string {
+	v := strings.TrimSpace(r.FormValue(key))
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// formVariables collects experiment variables from the var_key[] and
+// var_value[] form fields, skipping incomplete pairs.
+func formVariables(r *http.Request) map[string]string {
 	keys := r.Form["var_key[]"]
 	values := r.Form["var_value[]"]
+	vars := make(map[string]string, len(keys))
 	for i := range keys {
 		key := strings.TrimSpace(keys[i])
 		if key == "" || i >= len(values) {
@@ -358,14 +554,9 @@ func (s *Server) handleAPICreateExperiment(w http.ResponseWriter, r *http.Reques
 		if value == "" {
 			continue
 		}
-		if err := s.expVariableRepo.Set(ctx, exp.ID, key, value); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+		vars[key] = value
 	}
-
-	w.Header().Set("HX-Redirect", "/experiments")
-	w.WriteHeader(http.StatusOK)
+	return vars
 }
 
 func (s *Server) handleAPIEndExperiment(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,98 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	"github.com/emiliopalmerini/mclaude/internal/web/templates"
+	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
+)
+
+type mcpShare struct {
+	Name        string  `json:"name"`
+	CallShare   float64 `json:"callShare"`
+	ResultShare float64 `json:"resultShare"`
+}
+
+// handleMCP reports the tool calls of MCP servers. Query params: experiment
+// (ID), project (ID), days (default 30).
+func (s *Server) handleMCP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	days := 30
+	if v, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && v > 0 {
+		days = v
+	}
+	opts := ports.ListToolCallsOptions{
+		Since: time.Now().UTC().AddDate(0, 0, -days).Format(time.RFC3339),
+	}
+	filterExperiment := r.URL.Query().Get("experiment")
+	if filterExperiment != "" {
+		opts.ExperimentID = &filterExperiment
+	}
+	filterProject := r.URL.Query().Get("project")
+	if filterProject != "" {
+		opts.ProjectID = &filterProject
+	}
+
+	sequences, err := s.toolCallRepo.ListSequences(ctx, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	b := domain.AggregateMCP(sequences)
+	data := templates.MCPPageData{
+		Days:             days,
+		FilterExperiment: filterExperiment,
+		FilterProject:    filterProject,
+		Sessions:         b.Sessions,
+		Calls:            b.Calls,
+		MCPCalls:         b.MCPCalls(),
+	}
+	if exps, err := sqlc.New(s.db).ListExperiments(ctx); err == nil {
+		for _, e := range exps {
+			data.Experiments = append(data.Experiments, templates.FilterOption{ID: e.ID, Name: e.Name})
+		}
+	}
+	if projects, err := s.projectRepo.List(ctx); err == nil {
+		for _, p := range projects {
+			data.Projects = append(data.Projects, templates.FilterOption{ID: p.ID, Name: p.Name})
+		}
+	}
+
+	shares := make([]mcpShare, 0, len(b.Servers))
+	for _, srv := range b.Servers {
+		row := templates.MCPServerRow{
+			Server:        srv.Server,
+			Calls:         srv.Calls,
+			Errors:        srv.Errors,
+			Sessions:      srv.Sessions,
+			ResultTokens:  srv.ResultTokens(),
+			ErrorRate:     srv.ErrorRate(),
+			SessionShare:  b.SessionShare(srv),
+			CallShare:     b.CallShare(srv),
+			ResultShare:   b.ResultShare(srv),
+			TokensPerCall: srv.TokensPerCall(),
+		}
+		for _, t := range srv.Tools {
+			row.Tools = append(row.Tools, templates.MCPToolRow{
+				Tool:         t.Tool,
+				Calls:        t.Calls,
+				Errors:       t.Errors,
+				ResultTokens: domain.EstimateResultTokens(t.ResultBytes),
+			})
+		}
+		data.ResultTokens += row.ResultTokens
+		data.Servers = append(data.Servers, row)
+		shares = append(shares, mcpShare{Name: srv.Server, CallShare: row.CallShare * 100, ResultShare: row.ResultShare * 100})
+	}
+	sharesJSON, _ := json.Marshal(shares)
+	data.SharesJSON = string(sharesJSON)
+
+	templates.MCPPage(data).Render(ctx, w)
+}
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/ports"
+	"github.com/emiliopalmerini/mclaude/internal/web/templates"
+)
+
+const projectHotspotsLimit = 100
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projects, err := s.projectRepo.List(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var data templates.ProjectsPageData
+	for _, p := range projects {
+		data.Projects = append(data.Projects, projectRow(p))
+	}
+
+	templates.ProjectsPage(data).Render(ctx, w)
+}
+
+// handleProjectDetail reports the files a project's sessions read and edited
+// most. Query params: days (default 30), sort (a domain.FileSort key).
+func (s *Server) handleProjectDetail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if project == nil {
+		http.Error(w, "Project not found", http.StatusNotFound)
+		return
+	}
+
+	days := 30
+	if v, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && v > 0 {
+		days = v
+	}
+	sortKey := r.URL.Query().Get("sort")
+	if !slices.Contains(domain.FileSortKeys, sortKey) {
+		sortKey = domain.FileSortOperations
+	}
+
+	touches, err := s.fileRepo.ListTouches(ctx, ports.ListFileTouchesOptions{
+		Since:     time.Now().UTC().AddDate(0, 0, -days).Format(time.RFC3339),
+		ProjectID: &project.ID,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	hotspots := domain.AggregateFileHotspots(touches, project.Path)
+	data := templates.ProjectDetailData{
+		Project:  projectRow(project),
+		Days:     days,
+		Sort:     sortKey,
+		Files:    len(hotspots),
+		TreeJSON: fileTreeJSON(domain.BuildFileTree(hotspots)),
+	}
+	domain.SortFileHotspots(hotspots, sortKey)
+	for _, h := range hotspots[:min(projectHotspotsLimit, len(hotspots))] {
+		data.Hotspots = append(data.Hotspots, templates.FileHotspotRow{
+			Path:           h.Path,
+			Reads:          h.Reads,
+			Edits:          h.Edits,
+			Writes:         h.Writes,
+			Sessions:       h.Sessions,
+			Rereads:        h.Rereads,
+			RereadRate:     h.RereadRate(),
+			SessionCostUsd: h.SessionCostUsd,
+		})
+	}
+
+	templates.ProjectDetailPage(data).Render(ctx, w)
+}
+
+func projectRow(p *domain.Project) templates.ProjectRow {
+	return templates.ProjectRow{
+		ID:        p.ID,
+		Name:      p.Name,
+		Path:      p.Path,
+		CreatedAt: p.CreatedAt.Format("2006-01-02"),
+	}
+}
+
+type treemapNode struct {
+	Name     string         `json:"name"`
+	Path     string         `json:"path"`
+	Value    int64          `json:"value"`
+	Children []*treemapNode `json:"children,omitempty"`
+}
+
+// fileTreeJSON serializes the top level of a file tree as ECharts treemap
+// data.
+func fileTreeJSON(root *domain.FileTreeNode) string {
+	var convert func(n *domain.FileTreeNode) *treemapNode
+	convert = func(n *domain.FileTreeNode) *treemapNode {
+		node := &treemapNode{Name: n.Name, Path: n.Path, Value: n.Value}
+		for _, c := range n.Children {
+			node.Children = append(node.Children, convert(c))
+		}
+		return node
+	}
+	nodes := make([]*treemapNode, 0, len(root.Children))
+	for _, c := range root.Children {
+		nodes = append(nodes, convert(c))
+	}
+	b, _ := json.Marshal(nodes)
+	return string(b)
+}
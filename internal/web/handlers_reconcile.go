@@ -0,0 +1,74 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/reconcile"
+	"github.com/emiliopalmerini/mclaude/internal/web/templates"
+)
+
+// handleReconcile compares imported billing data with the estimated costs.
+// Query params: days (default 30), tolerance (default 0.05).
+func (s *Server) handleReconcile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	days := 30
+	if v, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && v > 0 {
+		days = v
+	}
+	tolerance := reconcile.DefaultTolerance
+	if v, err := strconv.ParseFloat(r.URL.Query().Get("tolerance"), 64); err == nil && v >= 0 {
+		tolerance = v
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	report, err := reconcile.NewReconciler(s.billingRepo, s.statsRepo, s.pricingRepo).Report(ctx, since, tolerance)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := templates.ReconcilePageData{
+		Days:      days,
+		Tolerance: tolerance,
+		HasData:   report.HasData(),
+		Total:     reconcileRow(report.Total, tolerance),
+	}
+	if report.HasData() {
+		data.From = report.From.Format("2006-01-02")
+		data.To = report.To.Format("2006-01-02")
+	}
+	for _, m := range report.Models {
+		row := reconcileRow(m.BillingDiscrepancy, tolerance)
+		row.Days = m.Days
+		if m.Suggested != nil {
+			row.Suggestion = reconcile.CostSetCommand(m.Suggested)
+		}
+		data.Models = append(data.Models, row)
+	}
+	for _, d := range report.Mismatches() {
+		row := reconcileRow(d.BillingDiscrepancy, tolerance)
+		row.Date = d.Date.Format("2006-01-02")
+		data.Mismatches = append(data.Mismatches, row)
+	}
+
+	templates.ReconcilePage(data).Render(ctx, w)
+}
+
+func reconcileRow(d domain.BillingDiscrepancy, tolerance float64) templates.ReconcileRow {
+	return templates.ReconcileRow{
+		Model:           d.Model,
+		HasCost:         d.HasCost,
+		BilledUsd:       d.BilledUsd,
+		EstimatedUsd:    d.EstimatedUsd,
+		DeltaUsd:        d.DeltaUsd(),
+		DeltaPct:        d.DeltaPercent(),
+		BilledTokens:    d.BilledTokens,
+		EstimatedTokens: d.EstimatedTokens,
+		TokenDeltaPct:   d.TokenDeltaPercent(),
+		Matches:         d.CostMatches(tolerance),
+	}
+}
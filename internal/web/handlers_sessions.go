@@ -19,6 +19,10 @@ func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
 	experimentFilter := r.URL.Query().Get("experiment")
 	projectFilter := r.URL.Query().Get("project")
 	limitStr := r.URL.Query().Get("limit")
+	anomalousFilter := r.URL.Query().Get("anomalous") == "1"
+	branchFilter := r.URL.Query().Get("branch")
+	repositoryFilter := r.URL.Query().Get("repo")
+	outcomeFilter := r.URL.Query().Get("outcome")
 
 	limit := 50
 	if limitStr != "" {
@@ -28,7 +32,13 @@ func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// List sessions with joined metrics (single query, no N+1)
-	opts := ports.ListSessionsOptions{Limit: limit}
+	opts := ports.ListSessionsOptions{
+		Limit:         limit,
+		AnomalousOnly: anomalousFilter,
+		Branch:        branchFilter,
+		Repository:    repositoryFilter,
+		Outcome:       outcomeFilter,
+	}
 	if experimentFilter != "" {
 		opts.ExperimentID = &experimentFilter
 	}
@@ -78,6 +88,15 @@ func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
 		if item.Duration != nil {
 			summary.Duration = *item.Duration
 		}
+		if item.Anomaly != nil {
+			summary.Anomaly = item.Anomaly.Summary()
+		}
+		if item.GitBranch != nil {
+			summary.GitBranch = *item.GitBranch
+		}
+		if item.EndedGreen != nil {
+			summary.Outcome = domain.OutcomeOf(item.EndedGreen)
+		}
 
 		if summary.Tokens > maxTokens {
 			maxTokens = summary.Tokens
@@ -92,8 +111,14 @@ func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
 		FilterExperiment: experimentFilter,
 		FilterProject:    projectFilter,
 		FilterLimit:      limit,
+		FilterAnomalous:  anomalousFilter,
+		FilterBranch:     branchFilter,
+		FilterRepository: repositoryFilter,
+		FilterOutcome:    outcomeFilter,
 		MaxTokens:        maxTokens,
 	}
+	pageData.Branches, _ = s.sessionRepo.ListGitBranches(ctx)
+	pageData.Repositories, _ = s.sessionRepo.ListGitRepositories(ctx)
 
 	for id, name := range experimentNameMap {
 		pageData.Experiments = append(pageData.Experiments, templates.FilterOption{ID: id, Name: name})
@@ -147,6 +172,18 @@ func (s *Server) handleSessionDetail(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	// Get commits, each with its share of the session's cost
+	commits, _ := s.commitRepo.ListBySessionID(ctx, id)
+	for _, c := range commits {
+		item := domain.CommitListItem{SessionCommit: *c, SessionCommits: int64(len(commits))}
+		if metrics != nil && metrics.CostEstimateUsd.Valid {
+			item.SessionCostUsd = &metrics.CostEstimateUsd.Float64
+		}
+		view := commitView(c)
+		view.CostUsd = item.CostUsd()
+		detail.Commits = append(detail.Commits, view)
+	}
+
 	// Get sub-agents (aggregated by type+kind)
 	subagentStats, _ := queries.GetSubagentStatsBySession(ctx, id)
 	for _, sa := range subagentStats {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/simulate"
 	"github.com/emiliopalmerini/mclaude/internal/web/templates"
 )
 
@@ -31,16 +32,93 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 		if p.CacheWritePerMillion != nil {
 			model.CacheWritePerMillion = *p.CacheWritePerMillion
 		}
+		if p.CacheWrite1hPerMillion != nil {
+			model.CacheWrite1hPerMillion = *p.CacheWrite1hPerMillion
+		}
+		if p.WebSearchPerThousand != nil {
+			model.WebSearchPerThousand = *p.WebSearchPerThousand
+		}
 		models = append(models, model)
 	}
 
 	pageData := templates.SettingsPageData{
-		Pricing: models,
+		Pricing:    models,
+		Simulation: s.runSimulation(r),
 	}
 
 	templates.SettingsPage(pageData).Render(ctx, w)
 }
 
+// runSimulation reads the cost simulation form and, once submitted, runs the
+// scenario over the requested number of days.
+func (s *Server) runSimulation(r *http.Request) templates.CostSimulation {
+	ctx := r.Context()
+	q := r.URL.Query()
+	sim := templates.CostSimulation{
+		Model:         q.Get("model"),
+		SubagentModel: q.Get("subagent_model"),
+		CacheHitRate:  q.Get("cache_hit_rate"),
+		Days:          30,
+	}
+	if d, err := strconv.Atoi(q.Get("days")); err == nil && d > 0 {
+		sim.Days = d
+	}
+	if q.Get("simulate") == "" {
+		return sim
+	}
+
+	simulator := simulate.NewSimulator(s.statsRepo, s.pricingRepo, s.profileRepo, s.projectRepo, s.experimentRepo)
+	var scenario domain.CostScenario
+	var err error
+	if sim.Model != "" {
+		if scenario.Model, err = simulator.Pricing(ctx, sim.Model); err != nil {
+			sim.Error = err.Error()
+			return sim
+		}
+	}
+	if sim.SubagentModel != "" {
+		if scenario.SubagentModel, err = simulator.Pricing(ctx, sim.SubagentModel); err != nil {
+			sim.Error = err.Error()
+			return sim
+		}
+	}
+	if sim.CacheHitRate != "" {
+		rate, err := strconv.ParseFloat(sim.CacheHitRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			sim.Error = "Cache hit rate must be between 0 and 1"
+			return sim
+		}
+		scenario.CacheHitRate = &rate
+	}
+
+	now := time.Now().UTC()
+	result, err := simulator.Run(ctx, scenario, now.AddDate(0, 0, -sim.Days), now)
+	if err != nil {
+		sim.Error = err.Error()
+		return sim
+	}
+	sim.Ran = true
+	sim.Total = simulationRow(result.Total)
+	for _, row := range result.Projects {
+		sim.Projects = append(sim.Projects, simulationRow(row))
+	}
+	for _, row := range result.Experiments {
+		sim.Experiments = append(sim.Experiments, simulationRow(row))
+	}
+	return sim
+}
+
+func simulationRow(r simulate.Row) templates.SimulationRow {
+	return templates.SimulationRow{
+		Name:         r.Name,
+		Sessions:     r.Sessions,
+		ActualUsd:    r.ActualUsd,
+		SimulatedUsd: r.SimulatedUsd,
+		DeltaUsd:     r.DeltaUsd(),
+		DeltaPct:     r.DeltaPercent(),
+	}
+}
+
 func (s *Server) handleAPICreatePricing(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -85,17 +163,37 @@ func (s *Server) handleAPICreatePricing(w http.ResponseWriter, r *http.Request)
 	if v, err := strconv.ParseFloat(r.FormValue("cache_write"), 64); err == nil && v > 0 {
 		pricing.CacheWritePerMillion = &v
 	}
+	if v, err := strconv.ParseFloat(r.FormValue("cache_write_1h"), 64); err == nil && v > 0 {
+		pricing.CacheWrite1hPerMillion = &v
+	}
+	if v, err := strconv.ParseFloat(r.FormValue("web_search"), 64); err == nil && v >= 0 {
+		pricing.WebSearchPerThousand = &v
+	}
+	if v, err := strconv.ParseFloat(r.FormValue("web_fetch"), 64); err == nil && v >= 0 {
+		pricing.WebFetchPerThousand = &v
+	}
 
 	// Check if model exists (update) or create new
 	existing, _ := s.pricingRepo.GetByID(ctx, modelID)
 	if existing != nil {
 		pricing.IsDefault = existing.IsDefault
 		pricing.CreatedAt = existing.CreatedAt
+		// Blank server-side tool rates keep the current ones
+		if pricing.WebSearchPerThousand == nil {
+			pricing.WebSearchPerThousand = existing.WebSearchPerThousand
+		}
+		if pricing.WebFetchPerThousand == nil {
+			pricing.WebFetchPerThousand = existing.WebFetchPerThousand
+		}
 		if err := s.pricingRepo.Update(ctx, pricing); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	} else {
+		if pricing.WebSearchPerThousand == nil {
+			webSearch := domain.DefaultWebSearchPerThousand
+			pricing.WebSearchPerThousand = &webSearch
+		}
 		// If first model, set as default
 		allPricing, _ := s.pricingRepo.List(ctx)
 		if len(allPricing) == 0 {
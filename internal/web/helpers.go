@@ -1,9 +1,12 @@
 package web
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
 
+	"github.com/emiliopalmerini/mclaude/internal/domain"
+	"github.com/emiliopalmerini/mclaude/internal/util"
 	"github.com/emiliopalmerini/mclaude/internal/web/templates"
 	sqlc "github.com/emiliopalmerini/mclaude/sqlc/generated"
 )
@@ -29,6 +32,7 @@ func buildSessionDetail(session sqlc.Session, metrics *sqlc.SessionMetric) templ
 		PermissionMode: session.PermissionMode,
 		ExitReason:     session.ExitReason,
 		CreatedAt:      session.CreatedAt,
+		Excluded:       session.IsExcluded == 1,
 	}
 
 	if session.ExperimentID.Valid {
@@ -43,6 +47,17 @@ func buildSessionDetail(session sqlc.Session, metrics *sqlc.SessionMetric) templ
 	if session.DurationSeconds.Valid {
 		detail.DurationSeconds = session.DurationSeconds.Int64
 	}
+	if session.ExclusionReason.Valid {
+		detail.ExclusionReason = session.ExclusionReason.String
+	}
+	if session.GitRepoRoot.Valid {
+		detail.GitRepository = session.GitRepoRoot.String
+		if session.GitRemoteUrl.String != "" {
+			detail.GitRepository = session.GitRemoteUrl.String
+		}
+		detail.GitBranch = session.GitBranch.String
+		detail.GitCommits = gitCommitsLabel(session)
+	}
 
 	if metrics != nil {
 		detail.MessageCountUser = metrics.MessageCountUser
@@ -53,6 +68,14 @@ func buildSessionDetail(session sqlc.Session, metrics *sqlc.SessionMetric) templ
 		detail.TokenCacheRead = metrics.TokenCacheRead
 		detail.TokenCacheWrite = metrics.TokenCacheWrite
 		detail.ErrorCount = metrics.ErrorCount
+		detail.LinesAdded = metrics.LinesAdded
+		detail.LinesRemoved = metrics.LinesRemoved
+		detail.LinesSource = metrics.LinesSource.String
+		detail.Checks = domain.SessionOutcome{
+			TestsPassed: util.NullInt64ToBoolPtr(metrics.TestsPassed),
+			BuildPassed: util.NullInt64ToBoolPtr(metrics.BuildPassed),
+			LintPassed:  util.NullInt64ToBoolPtr(metrics.LintPassed),
+		}.Summary()
 		if metrics.CostEstimateUsd.Valid {
 			detail.CostEstimateUsd = metrics.CostEstimateUsd.Float64
 		}
@@ -63,3 +86,28 @@ func buildSessionDetail(session sqlc.Session, metrics *sqlc.SessionMetric) templ
 
 	return detail
 }
+
+// gitCommitsLabel describes how HEAD moved during a session, with short
+// hashes and a note when the working tree had uncommitted changes.
+func gitCommitsLabel(session sqlc.Session) string {
+	short := func(hash string) string {
+		if hash == "" {
+			return "?"
+		}
+		if len(hash) > 7 {
+			return hash[:7]
+		}
+		return hash
+	}
+	dirty := func(v sql.NullInt64) string {
+		if v.Valid && v.Int64 == 1 {
+			return " (dirty)"
+		}
+		return ""
+	}
+	start, end := session.GitStartCommit.String, session.GitEndCommit.String
+	if start == end || start == "" && !session.GitStartDirty.Valid {
+		return short(end) + dirty(session.GitEndDirty)
+	}
+	return short(start) + dirty(session.GitStartDirty) + " → " + short(end) + dirty(session.GitEndDirty)
+}
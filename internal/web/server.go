@@ -21,11 +21,22 @@ type Server struct {
 	port            int
 	experimentRepo  ports.ExperimentRepository
 	expVariableRepo ports.ExperimentVariableRepository
+	expNoteRepo     ports.ExperimentNoteRepository
 	pricingRepo     ports.PricingRepository
 	sessionRepo     ports.SessionRepository
 	metricsRepo     ports.SessionMetricsRepository
 	statsRepo       ports.StatsRepository
 	projectRepo     ports.ProjectRepository
+	budgetRepo      ports.BudgetRepository
+	planRepo        ports.PlanRepository
+	usageRepo       ports.UsageRepository
+	planPriceRepo   ports.PlanPriceRepository
+	profileRepo     ports.PricingProfileRepository
+	billingRepo     ports.BillingRepository
+	commitRepo      ports.CommitRepository
+	commandRepo     ports.SessionCommandRepository
+	fileRepo        ports.SessionFileRepository
+	toolCallRepo    ports.SessionToolCallRepository
 }
 
 func NewServer(
@@ -33,11 +44,22 @@ func NewServer(
 	port int,
 	er ports.ExperimentRepository,
 	evr ports.ExperimentVariableRepository,
+	enr ports.ExperimentNoteRepository,
 	pr ports.PricingRepository,
 	sr ports.SessionRepository,
 	mr ports.SessionMetricsRepository,
 	str ports.StatsRepository,
 	projr ports.ProjectRepository,
+	br ports.BudgetRepository,
+	plr ports.PlanRepository,
+	ur ports.UsageRepository,
+	ppr ports.PlanPriceRepository,
+	pfr ports.PricingProfileRepository,
+	bir ports.BillingRepository,
+	cr ports.CommitRepository,
+	cmr ports.SessionCommandRepository,
+	fr ports.SessionFileRepository,
+	tcr ports.SessionToolCallRepository,
 ) *Server {
 	s := &Server{
 		db:              db,
@@ -45,11 +67,22 @@ func NewServer(
 		port:            port,
 		experimentRepo:  er,
 		expVariableRepo: evr,
+		expNoteRepo:     enr,
 		pricingRepo:     pr,
 		sessionRepo:     sr,
 		metricsRepo:     mr,
 		statsRepo:       str,
 		projectRepo:     projr,
+		budgetRepo:      br,
+		planRepo:        plr,
+		usageRepo:       ur,
+		planPriceRepo:   ppr,
+		profileRepo:     pfr,
+		billingRepo:     bir,
+		commitRepo:      cr,
+		commandRepo:     cmr,
+		fileRepo:        fr,
+		toolCallRepo:    tcr,
 	}
 	s.setupRoutes()
 	return s
@@ -76,6 +109,13 @@ func (s *Server) setupRoutes() {
 	s.router.HandleFunc("GET /experiments", s.handleExperiments)
 	s.router.HandleFunc("GET /experiments/compare", s.handleExperimentCompare)
 	s.router.HandleFunc("GET /experiments/{id}", s.handleExperimentDetail)
+	s.router.HandleFunc("GET /experiments/{id}/report", s.handleExperimentReport)
+	s.router.HandleFunc("GET /commits", s.handleCommits)
+	s.router.HandleFunc("GET /commands", s.handleCommands)
+	s.router.HandleFunc("GET /projects", s.handleProjects)
+	s.router.HandleFunc("GET /projects/{id}", s.handleProjectDetail)
+	s.router.HandleFunc("GET /mcp", s.handleMCP)
+	s.router.HandleFunc("GET /reconcile", s.handleReconcile)
 	s.router.HandleFunc("GET /settings", s.handleSettings)
 
 	// API endpoints (for HTMX)
@@ -83,12 +123,16 @@ func (s *Server) setupRoutes() {
 	s.router.HandleFunc("GET /api/charts/tokens", s.handleAPIChartTokens)
 	s.router.HandleFunc("GET /api/charts/cost", s.handleAPIChartCost)
 	s.router.HandleFunc("GET /api/charts/heatmap", s.handleAPIChartHeatmap)
+	s.router.HandleFunc("GET /api/charts/experiments/{id}", s.handleAPIChartExperiment)
 	s.router.HandleFunc("POST /api/experiments", s.handleAPICreateExperiment)
+	s.router.HandleFunc("PUT /api/experiments/{id}", s.handleAPIUpdateExperiment)
 	s.router.HandleFunc("POST /api/experiments/{id}/activate", s.handleAPIActivateExperiment)
 	s.router.HandleFunc("POST /api/experiments/{id}/deactivate", s.handleAPIDeactivateExperiment)
 	s.router.HandleFunc("DELETE /api/experiments/{id}", s.handleAPIDeleteExperiment)
 
 	s.router.HandleFunc("POST /api/experiments/{id}/end", s.handleAPIEndExperiment)
+	s.router.HandleFunc("POST /api/experiments/{id}/conclude", s.handleAPIConcludeExperiment)
+	s.router.HandleFunc("POST /api/experiments/{id}/notes", s.handleAPIAddExperimentNote)
 
 	// Session management
 	s.router.HandleFunc("DELETE /api/sessions/{id}", s.handleAPIDeleteSession)
@@ -1,7 +1,9 @@
 package templates
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/a-h/templ"
@@ -51,10 +53,53 @@ func formatCostPrecise(c float64) string {
 	return fmt.Sprintf("$%.4f", c)
 }
 
+// formatCostPer100Lines shows "-" when no lines were changed.
+func formatCostPer100Lines(linesChanged int64, cost float64) string {
+	if linesChanged == 0 {
+		return "-"
+	}
+	return formatCostPrecise(cost)
+}
+
+func formatCommitCost(cost *float64) string {
+	if cost == nil {
+		return "-"
+	}
+	return formatCostPrecise(*cost)
+}
+
+// formatGreenRate shows "-" when no session ran tests, builds or linters.
+func formatGreenRate(sessionsWithChecks int64, rate float64) string {
+	if sessionsWithChecks == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
+
+// topCommandsLabel lists commands with their runs, as in "go test (12)".
+func topCommandsLabel(commands []CommandRow) string {
+	labels := make([]string, len(commands))
+	for i, c := range commands {
+		labels[i] = fmt.Sprintf("%s (%d)", c.Command, c.Runs)
+	}
+	return strings.Join(labels, ", ")
+}
+
 func formatInt(n int64) string {
 	return fmt.Sprintf("%d", n)
 }
 
+func verdictBadgeClass(verdict string) string {
+	switch verdict {
+	case "confirmed":
+		return "badge badge-green"
+	case "rejected":
+		return "badge badge-red"
+	default:
+		return "badge badge-yellow"
+	}
+}
+
 func colSpan(n int) string {
 	return fmt.Sprintf("%d", n)
 }
@@ -96,6 +141,68 @@ func tokenBarWidth(tokens, maxTokens int64) string {
 	return fmt.Sprintf("width: %.0f%%", pct)
 }
 
+// budgetFillClass colors a budget bar by whether spend is exceeded or over pace.
+func budgetFillClass(b BudgetProgress) string {
+	switch {
+	case b.UsedPct >= 100:
+		return "budget-fill budget-fill-error"
+	case b.ProjectedUsd > b.AmountUsd:
+		return "budget-fill budget-fill-warning"
+	default:
+		return "budget-fill"
+	}
+}
+
+func budgetFillStyle(b BudgetProgress) string {
+	return fmt.Sprintf("width: %.0f%%", min(b.UsedPct, 100))
+}
+
+func budgetMarkerStyle(b BudgetProgress) string {
+	return fmt.Sprintf("left: %.0f%%", b.ElapsedPct)
+}
+
+// usageFillClass colors a usage window bar as it approaches its limit.
+func usageFillClass(w UsageWindowProgress) string {
+	switch {
+	case w.UsedPct >= 100:
+		return "budget-fill budget-fill-error"
+	case w.UsedPct >= 80:
+		return "budget-fill budget-fill-warning"
+	default:
+		return "budget-fill"
+	}
+}
+
+func usageFillStyle(w UsageWindowProgress) string {
+	return fmt.Sprintf("width: %.0f%%", min(w.UsedPct, 100))
+}
+
+// valueFillClass warns when the plan is not on pace to pay for itself.
+func valueFillClass(v *SubscriptionValue) string {
+	if v.ProjectedUsd < v.PriceUsd {
+		return "budget-fill budget-fill-warning"
+	}
+	return "budget-fill"
+}
+
+func valueFillStyle(v *SubscriptionValue) string {
+	return fmt.Sprintf("width: %.0f%%", min(v.BreakEvenPct, 100))
+}
+
+func valueMarkerStyle(v *SubscriptionValue) string {
+	return fmt.Sprintf("left: %.0f%%", v.ElapsedPct)
+}
+
+func deltaClass(delta float64) string {
+	switch {
+	case delta > 0:
+		return "text-red-600"
+	case delta < 0:
+		return "text-green-600"
+	}
+	return ""
+}
+
 func shortModelName(modelID string) string {
 	// Strip common prefixes for compact display
 	// e.g. "claude-opus-4-5-20250929" -> "opus-4-5"
@@ -121,3 +228,28 @@ func shortModelName(modelID string) string {
 	}
 	return parts
 }
+
+// variablesAlpineData returns the Alpine x-data object for editing experiment variables.
+func variablesAlpineData(vars []ExperimentVariable) string {
+	if vars == nil {
+		vars = []ExperimentVariable{}
+	}
+	data, _ := json.Marshal(vars)
+	return "{ vars: " + string(data) + " }"
+}
+
+// reconcileSuggestions returns the models with a suggested pricing correction.
+func reconcileSuggestions(models []ReconcileRow) []ReconcileRow {
+	var suggested []ReconcileRow
+	for _, m := range models {
+		if m.Suggestion != "" {
+			suggested = append(suggested, m)
+		}
+	}
+	return suggested
+}
+
+// projectSortURL links a project page sorted by a domain.FileSort key.
+func projectSortURL(data ProjectDetailData, key string) templ.SafeURL {
+	return templ.SafeURL(fmt.Sprintf("/projects/%s?days=%d&sort=%s", data.Project.ID, data.Days, key))
+}
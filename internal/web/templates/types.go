@@ -9,11 +9,23 @@ type DashboardStats struct {
 	TokenOutput      int64
 	CacheRead        int64
 	CacheWrite       int64
+	CacheWrite1h     int64 // Part of CacheWrite
 	TotalErrors      int64
+	WebSearches      int64
+	WebFetches       int64
+	ServerToolCost   float64
 	ActiveExperiment string
 	DefaultModel     string // Display name of the default model for cost calculations
 	TopTools         []ToolUsage
 	RecentSessions   []SessionSummary
+	Budgets          []BudgetProgress
+	PlanUsage        *PlanUsage
+	Value            *SubscriptionValue
+	// File operations by language and kind, and the language mix of each
+	// experiment when not filtered by one
+	Languages           []LanguageRow
+	FileKinds           []FileKindRow
+	ExperimentLanguages []ExperimentLanguageRow
 	// Filters
 	FilterPeriod     string
 	FilterExperiment string
@@ -22,6 +34,60 @@ type DashboardStats struct {
 	Projects         []FilterOption
 }
 
+// BudgetProgress is a budget's burn-down within its current period.
+type BudgetProgress struct {
+	Label        string
+	Period       string
+	Hard         bool
+	SpentUsd     float64
+	AmountUsd    float64
+	ProjectedUsd float64
+	UsedPct      float64
+	ElapsedPct   float64
+	ResetsAt     string
+}
+
+// PlanUsage is the usage of the current subscription windows.
+type PlanUsage struct {
+	PlanType string
+	Windows  []UsageWindowProgress
+}
+
+// UsageWindowProgress is a rate-limit window's usage against its learned
+// limit. Limit is zero until a limit has been learned.
+type UsageWindowProgress struct {
+	Label        string
+	Active       bool
+	Tokens       int64
+	Limit        int64
+	LimitSamples int
+	UsedPct      float64
+	ResetsAt     string
+}
+
+// SubscriptionValue compares this month's API-equivalent cost with the plan
+// price, with earlier priced months for context.
+type SubscriptionValue struct {
+	PlanType          string
+	PriceUsd          float64
+	APICostUsd        float64
+	ProjectedUsd      float64
+	ValueRatio        float64
+	BreakEvenPct      float64
+	ElapsedPct        float64
+	BreakEvenDailyUsd float64
+	BreakEvenAt       string
+	Months            []MonthValueRow
+}
+
+// MonthValueRow is a past month's API-equivalent cost against its plan price.
+type MonthValueRow struct {
+	Month      string
+	APICostUsd float64
+	PriceUsd   float64
+	ValueRatio float64
+}
+
 // FilterOption for dropdown population.
 type FilterOption struct {
 	ID   string
@@ -34,14 +100,183 @@ type SessionsPageData struct {
 	FilterExperiment string
 	FilterProject    string
 	FilterLimit      int
+	FilterAnomalous  bool
+	FilterBranch     string
+	FilterRepository string
+	FilterOutcome    string
 	Experiments      []FilterOption
 	Projects         []FilterOption
+	Branches         []string
+	Repositories     []string
 	MaxTokens        int64
 }
 
-// SettingsPageData wraps pricing for the settings page.
+// SettingsPageData wraps pricing and the cost simulation for the settings page.
 type SettingsPageData struct {
-	Pricing []ModelPricing
+	Pricing    []ModelPricing
+	Simulation CostSimulation
+}
+
+// CostSimulation holds the what-if scenario form state and, once run, its
+// result per project and experiment.
+type CostSimulation struct {
+	Model         string
+	SubagentModel string
+	CacheHitRate  string
+	Days          int
+	Ran           bool
+	Error         string
+	Total         SimulationRow
+	Projects      []SimulationRow
+	Experiments   []SimulationRow
+}
+
+// SimulationRow is the actual and simulated cost of a group of sessions.
+type SimulationRow struct {
+	Name         string
+	Sessions     int64
+	ActualUsd    float64
+	SimulatedUsd float64
+	DeltaUsd     float64
+	DeltaPct     float64
+}
+
+// CommitsPageData lists the commits made during sessions and the commits
+// per experiment.
+type CommitsPageData struct {
+	Days             int
+	FilterExperiment string
+	Experiments      []FilterOption
+	Commits          []CommitView
+	ByExperiment     []ExperimentCommitRow
+}
+
+// CommitView is a commit made during a session. CostUsd is its share of the
+// session's cost, nil when the cost is unknown.
+type CommitView struct {
+	Hash         string
+	ShortHash    string
+	Subject      string
+	Author       string
+	CommittedAt  string
+	SessionID    string
+	Experiment   string
+	FilesChanged int64
+	LinesAdded   int64
+	LinesRemoved int64
+	DetectedBy   string
+	CostUsd      *float64
+}
+
+type ExperimentCommitRow struct {
+	ID                  string
+	Name                string
+	Sessions            int64
+	SessionsWithCommits int64
+	Commits             int64
+	CommitsPerSession   float64
+	LinesAdded          int64
+	LinesRemoved        int64
+	CostUsd             float64
+	CostPerCommit       *float64
+}
+
+// CommandsPageData reports the Bash commands run during sessions by
+// category, by normalized command, and per project.
+type CommandsPageData struct {
+	Days          int
+	FilterProject string
+	Projects      []FilterOption
+	Runs          int
+	Distinct      int // Normalized commands, Commands holds the most run
+	Categories    []CommandCategoryRow
+	Commands      []CommandRow
+	Retried       []CommandRow
+	ByProject     []ProjectCommandsRow
+}
+
+type CommandCategoryRow struct {
+	Category    string
+	Commands    int64
+	Runs        int64
+	Failures    int64
+	FailureRate float64
+	Retries     int64
+}
+
+// CommandRow is a normalized command, such as "go test".
+type CommandRow struct {
+	Command     string
+	Category    string
+	Runs        int64
+	Sessions    int64
+	Failures    int64
+	FailureRate float64
+	Retries     int64
+}
+
+type ProjectCommandsRow struct {
+	ID       string
+	Name     string
+	Runs     int64
+	Commands []CommandRow
+}
+
+// ReconcilePageData compares billed usage with the estimates over the days
+// with billing data.
+type ReconcilePageData struct {
+	Days       int
+	Tolerance  float64
+	HasData    bool
+	From       string
+	To         string
+	Total      ReconcileRow
+	Models     []ReconcileRow
+	Mismatches []ReconcileRow
+}
+
+// ReconcileRow is the billed and estimated usage of a model, overall or on a
+// day. Suggestion is the 'mclaude cost set' command that would have matched
+// the bill, empty when the rates look right or the tokens differ.
+type ReconcileRow struct {
+	Date            string
+	Model           string
+	Days            int
+	HasCost         bool
+	BilledUsd       float64
+	EstimatedUsd    float64
+	DeltaUsd        float64
+	DeltaPct        float64
+	BilledTokens    int64
+	EstimatedTokens int64
+	TokenDeltaPct   float64
+	Matches         bool
+	Suggestion      string
+}
+
+type LanguageRow struct {
+	Language       string
+	Files          int64
+	Operations     int64
+	Share          float64
+	TestShare      float64
+	Sessions       int64
+	SessionCostUsd float64
+}
+
+type FileKindRow struct {
+	Kind           string
+	Files          int64
+	Operations     int64
+	Share          float64
+	SessionCostUsd float64
+}
+
+type ExperimentLanguageRow struct {
+	Name           string
+	Operations     int64
+	SessionCostUsd float64
+	Mix            string // Share of the top languages, as in "Go 62%, SQL 20%"
 }
 
 type ToolUsage struct {
@@ -63,6 +298,9 @@ type SessionSummary struct {
 	Model          string
 	Duration       int64
 	SubagentCount  int64
+	Anomaly        string // reasons the session was flagged, empty if normal
+	GitBranch      string
+	Outcome        string // "green" or "red", empty when no checks ran
 }
 
 type SubagentUsage struct {
@@ -94,6 +332,11 @@ type SessionDetail struct {
 	EndedAt               string
 	DurationSeconds       int64
 	CreatedAt             string
+	Excluded              bool
+	ExclusionReason       string
+	GitRepository         string
+	GitBranch             string
+	GitCommits            string // HEAD at the start and end, e.g. "1a2b3c4 → 5d6e7f8"
 	MessageCountUser      int64
 	MessageCountAssistant int64
 	TurnCount             int64
@@ -103,6 +346,11 @@ type SessionDetail struct {
 	TokenCacheWrite       int64
 	CostEstimateUsd       float64
 	ErrorCount            int64
+	LinesAdded            int64
+	LinesRemoved          int64
+	LinesSource           string // "tools" or "git"
+	Checks                string // e.g. "tests passed, lint failed", empty when none ran
+	Commits               []CommitView
 	Tools                 []ToolUsage
 	Files                 []FileOperation
 	Subagents             []SubagentUsage
@@ -140,6 +388,11 @@ type ExperimentVariable struct {
 	Value string
 }
 
+type ExperimentNote struct {
+	CreatedAt string
+	Content   string
+}
+
 type ExperimentDetail struct {
 	ID          string
 	Name        string
@@ -153,6 +406,12 @@ type ExperimentDetail struct {
 	PlanType    string
 	Notes       string
 	Variables   []ExperimentVariable
+	// Outcome
+	Verdict     string
+	Conclusion  string
+	ConcludedAt string
+	// Journal, oldest first
+	Journal []ExperimentNote
 	// Stats
 	SessionCount      int64
 	TotalTurns        int64
@@ -163,10 +422,13 @@ type ExperimentDetail struct {
 	TokenOutput       int64
 	CacheRead         int64
 	CacheWrite        int64
+	CacheWrite1h      int64 // Part of CacheWrite
 	TotalTokens       int64
 	TotalCost         float64
 	TokensPerSession  int64
 	CostPerSession    float64
+	LinesChanged      int64
+	CostPer100Lines   float64 // 0 without changed lines
 	// Top tools
 	TopTools []ToolUsage
 	// Recent sessions
@@ -177,6 +439,35 @@ type ExperimentDetail struct {
 	CacheHitRate     float64
 	ErrorRate        float64
 	ToolCallsPerTurn float64
+	// Sessions that ran tests, builds or linters, and the share that ended green
+	SessionsWithChecks int64
+	GreenRate          float64
+	// Tool call patterns against a baseline, nil without tool calls
+	Workflows *ExperimentWorkflows
+}
+
+// ExperimentWorkflows compares an experiment's tool call patterns and
+// Edit→Bash failure loops with a baseline experiment, or with the sessions
+// outside the experiment.
+type ExperimentWorkflows struct {
+	Length                  int
+	BaselineID              string // Empty for the sessions outside the experiment
+	BaselineName            string
+	Baselines               []FilterOption
+	Sessions                int64
+	BaselineSessions        int64
+	Loops                   int64
+	BaselineLoops           int64
+	LoopSessionRate         float64
+	BaselineLoopSessionRate float64
+	Patterns                []WorkflowPatternRow
+}
+
+// WorkflowPatternRow is a pattern's occurrences per session.
+type WorkflowPatternRow struct {
+	Pattern      string
+	Rate         float64
+	BaselineRate float64
 }
 
 type ExperimentComparison struct {
@@ -202,20 +493,97 @@ type ExperimentCompareItem struct {
 	TotalCost         float64
 	TokensPerSession  int64
 	CostPerSession    float64
+	LinesChanged      int64
+	CostPer100Lines   float64 // 0 without changed lines
 	// Normalized behavior metrics
 	TokensPerTurn    float64
 	OutputRatio      float64
 	CacheHitRate     float64
 	ErrorRate        float64
 	ToolCallsPerTurn float64
+	// Sessions that ran tests, builds or linters, and the share that ended green
+	SessionsWithChecks int64
+	GreenRate          float64
 }
 
 type ModelPricing struct {
-	ID                   string
-	DisplayName          string
-	InputPerMillion      float64
-	OutputPerMillion     float64
-	CacheReadPerMillion  float64
-	CacheWritePerMillion float64
-	IsDefault            bool
+	ID                     string
+	DisplayName            string
+	InputPerMillion        float64
+	OutputPerMillion       float64
+	CacheReadPerMillion    float64
+	CacheWritePerMillion   float64
+	CacheWrite1hPerMillion float64
+	WebSearchPerThousand   float64
+	IsDefault              bool
+}
+
+// ProjectsPageData lists the recorded projects.
+type ProjectsPageData struct {
+	Projects []ProjectRow
+}
+
+type ProjectRow struct {
+	ID        string
+	Name      string
+	Path      string
+	CreatedAt string
+}
+
+// ProjectDetailData reports the files a project's sessions touched most.
+type ProjectDetailData struct {
+	Project  ProjectRow
+	Days     int
+	Sort     string
+	Files    int // Files touched, Hotspots holds the top ones
+	Hotspots []FileHotspotRow
+	TreeJSON string // domain.BuildFileTree as ECharts treemap data
+}
+
+type FileHotspotRow struct {
+	Path           string
+	Reads          int64
+	Edits          int64
+	Writes         int64
+	Sessions       int64
+	Rereads        int64
+	RereadRate     float64
+	SessionCostUsd float64
+}
+
+// MCPPageData reports the calls, errors and result sizes of the tools of
+// MCP servers, against all tool calls.
+type MCPPageData struct {
+	Days             int
+	FilterExperiment string
+	FilterProject    string
+	Experiments      []FilterOption
+	Projects         []FilterOption
+	Sessions         int64 // Sessions with tool calls
+	Calls            int64
+	MCPCalls         int64
+	ResultTokens     int64 // Of MCP results
+	Servers          []MCPServerRow
+	SharesJSON       string // Call and result shares per server, for the chart
+}
+
+type MCPServerRow struct {
+	Server        string
+	Calls         int64
+	Errors        int64
+	Sessions      int64
+	ResultTokens  int64
+	ErrorRate     float64
+	SessionShare  float64
+	CallShare     float64
+	ResultShare   float64
+	TokensPerCall float64
+	Tools         []MCPToolRow
+}
+
+type MCPToolRow struct {
+	Tool         string
+	Calls        int64
+	Errors       int64
+	ResultTokens int64
 }
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS rotation_group_members;
+DROP TABLE IF EXISTS rotation_groups;
+ALTER TABLE experiments DROP COLUMN scheduled_end;
+ALTER TABLE experiments DROP COLUMN scheduled_start;
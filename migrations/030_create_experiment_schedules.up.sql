@@ -0,0 +1,20 @@
+ALTER TABLE experiments ADD COLUMN scheduled_start TEXT;
+ALTER TABLE experiments ADD COLUMN scheduled_end TEXT;
+
+CREATE TABLE IF NOT EXISTS rotation_groups (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    interval_seconds INTEGER NOT NULL,
+    anchor_at TEXT NOT NULL,
+    ends_at TEXT,
+    created_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS rotation_group_members (
+    group_id TEXT NOT NULL REFERENCES rotation_groups(id) ON DELETE CASCADE,
+    experiment_id TEXT NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (group_id, experiment_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rotation_group_members_group_id ON rotation_group_members(group_id);
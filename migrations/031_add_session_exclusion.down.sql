@@ -0,0 +1,2 @@
+ALTER TABLE sessions DROP COLUMN exclusion_reason;
+ALTER TABLE sessions DROP COLUMN is_excluded;
@@ -0,0 +1,2 @@
+ALTER TABLE sessions ADD COLUMN is_excluded INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE sessions ADD COLUMN exclusion_reason TEXT;
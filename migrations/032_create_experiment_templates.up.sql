@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS experiment_templates (
+    name TEXT PRIMARY KEY,
+    description TEXT,
+    hypothesis TEXT,
+    model_id TEXT,
+    plan_type TEXT,
+    notes TEXT,
+    variables TEXT NOT NULL DEFAULT '{}',
+    created_at TEXT NOT NULL
+);
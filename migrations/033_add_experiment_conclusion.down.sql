@@ -0,0 +1,3 @@
+ALTER TABLE experiments DROP COLUMN concluded_at;
+ALTER TABLE experiments DROP COLUMN conclusion;
+ALTER TABLE experiments DROP COLUMN verdict;
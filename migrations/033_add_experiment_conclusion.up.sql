@@ -0,0 +1,3 @@
+ALTER TABLE experiments ADD COLUMN verdict TEXT;
+ALTER TABLE experiments ADD COLUMN conclusion TEXT;
+ALTER TABLE experiments ADD COLUMN concluded_at TEXT;
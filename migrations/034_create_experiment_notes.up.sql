@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS experiment_notes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    experiment_id TEXT NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_experiment_notes_experiment_id ON experiment_notes(experiment_id);
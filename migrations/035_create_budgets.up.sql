@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS budgets (
+    id TEXT PRIMARY KEY,
+    period TEXT NOT NULL CHECK (period IN ('daily', 'weekly', 'monthly')),
+    amount_usd REAL NOT NULL,
+    project_id TEXT REFERENCES projects(id) ON DELETE CASCADE,
+    experiment_id TEXT REFERENCES experiments(id) ON DELETE CASCADE,
+    is_hard INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL
+);
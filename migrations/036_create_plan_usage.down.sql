@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS rate_limit_hits;
+DROP TABLE IF EXISTS usage_requests;
+DROP TABLE IF EXISTS plan_config;
@@ -0,0 +1,36 @@
+-- Replace the unused usage tables from 012–018 with per-request usage tracking.
+DROP TABLE IF EXISTS usage_metrics;
+DROP TABLE IF EXISTS usage_limits;
+DROP TABLE IF EXISTS plan_config;
+
+CREATE TABLE IF NOT EXISTS plan_config (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    plan_type TEXT NOT NULL,
+    weekly_reset_at TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS usage_requests (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    timestamp TEXT NOT NULL,
+    model TEXT,
+    token_input INTEGER NOT NULL DEFAULT 0,
+    token_output INTEGER NOT NULL DEFAULT 0,
+    token_cache_read INTEGER NOT NULL DEFAULT 0,
+    token_cache_write INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_requests_timestamp ON usage_requests(timestamp);
+CREATE INDEX IF NOT EXISTS idx_usage_requests_session_id ON usage_requests(session_id);
+
+CREATE TABLE IF NOT EXISTS rate_limit_hits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    hit_at TEXT NOT NULL,
+    window_type TEXT NOT NULL CHECK (window_type IN ('5h', 'weekly')),
+    plan_type TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_hits_session_id ON rate_limit_hits(session_id);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS session_anomalies (
+    session_id TEXT PRIMARY KEY REFERENCES sessions(id) ON DELETE CASCADE,
+    score REAL NOT NULL,
+    reasons TEXT NOT NULL,
+    detected_at TEXT NOT NULL
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS alert_firings (
+    rule TEXT NOT NULL,
+    alert_key TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    value REAL NOT NULL,
+    threshold REAL NOT NULL,
+    message TEXT NOT NULL,
+    fired_at TEXT NOT NULL,
+    PRIMARY KEY (rule, alert_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_firings_fired_at ON alert_firings(fired_at);
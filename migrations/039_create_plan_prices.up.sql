@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS plan_prices (
+    effective_from TEXT PRIMARY KEY,
+    plan_type TEXT NOT NULL,
+    monthly_usd REAL NOT NULL,
+    created_at TEXT NOT NULL
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS project_pricing_profiles;
+DROP TABLE IF EXISTS pricing_profile_rates;
+DROP TABLE IF EXISTS pricing_profiles;
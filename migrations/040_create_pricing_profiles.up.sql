@@ -0,0 +1,36 @@
+-- Pricing profiles adjust the model pricing catalog to what is actually paid
+-- through a provider or contract: either a multiplier on the catalog rates or
+-- per-model rates that replace them.
+CREATE TABLE IF NOT EXISTS pricing_profiles (
+    name TEXT PRIMARY KEY,
+    description TEXT,
+    multiplier REAL NOT NULL DEFAULT 1.0,
+    is_default INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS pricing_profile_rates (
+    profile_name TEXT NOT NULL REFERENCES pricing_profiles(name) ON DELETE CASCADE,
+    model_id TEXT NOT NULL,
+    input_per_million REAL NOT NULL,
+    output_per_million REAL NOT NULL,
+    cache_read_per_million REAL,
+    cache_write_per_million REAL,
+    long_context_input_per_million REAL,
+    long_context_output_per_million REAL,
+    long_context_threshold INTEGER,
+    PRIMARY KEY (profile_name, model_id)
+);
+
+-- Projects without a profile use the default one
+CREATE TABLE IF NOT EXISTS project_pricing_profiles (
+    project_id TEXT PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+    profile_name TEXT NOT NULL REFERENCES pricing_profiles(name) ON DELETE CASCADE
+);
+
+-- Bedrock and Vertex AI global endpoints bill at the Anthropic API list prices
+INSERT INTO pricing_profiles (name, description, multiplier, is_default, created_at)
+VALUES
+    ('anthropic-api', 'Anthropic API list prices', 1.0, 1, '2026-01-01T00:00:00Z'),
+    ('bedrock', 'Amazon Bedrock on-demand, global endpoints', 1.0, 0, '2026-01-01T00:00:00Z'),
+    ('vertex', 'Google Vertex AI, global endpoint', 1.0, 0, '2026-01-01T00:00:00Z');
@@ -0,0 +1,9 @@
+ALTER TABLE session_metrics DROP COLUMN server_tool_cost_usd;
+ALTER TABLE session_metrics DROP COLUMN web_fetch_requests;
+ALTER TABLE session_metrics DROP COLUMN web_search_requests;
+
+ALTER TABLE pricing_profile_rates DROP COLUMN web_fetch_per_thousand;
+ALTER TABLE pricing_profile_rates DROP COLUMN web_search_per_thousand;
+
+ALTER TABLE model_pricing DROP COLUMN web_fetch_per_thousand;
+ALTER TABLE model_pricing DROP COLUMN web_search_per_thousand;
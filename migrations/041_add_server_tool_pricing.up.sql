@@ -0,0 +1,14 @@
+-- Server-side tools (web search, web fetch) are billed per request on top of
+-- tokens. Rates are USD per 1,000 requests.
+ALTER TABLE model_pricing ADD COLUMN web_search_per_thousand REAL;
+ALTER TABLE model_pricing ADD COLUMN web_fetch_per_thousand REAL;
+
+ALTER TABLE pricing_profile_rates ADD COLUMN web_search_per_thousand REAL;
+ALTER TABLE pricing_profile_rates ADD COLUMN web_fetch_per_thousand REAL;
+
+ALTER TABLE session_metrics ADD COLUMN web_search_requests INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE session_metrics ADD COLUMN web_fetch_requests INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE session_metrics ADD COLUMN server_tool_cost_usd REAL;
+
+-- Web search is $10 per 1,000 searches; web fetch has no charge beyond tokens
+UPDATE model_pricing SET web_search_per_thousand = 10.00, web_fetch_per_thousand = 0;
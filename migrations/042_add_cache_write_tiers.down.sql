@@ -0,0 +1,9 @@
+ALTER TABLE session_subagents DROP COLUMN token_cache_write_1h;
+ALTER TABLE session_subagents DROP COLUMN token_cache_write_5m;
+
+ALTER TABLE session_metrics DROP COLUMN cache_write_1h_rate;
+ALTER TABLE session_metrics DROP COLUMN token_cache_write_1h;
+ALTER TABLE session_metrics DROP COLUMN token_cache_write_5m;
+
+ALTER TABLE pricing_profile_rates DROP COLUMN cache_write_1h_per_million;
+ALTER TABLE model_pricing DROP COLUMN cache_write_1h_per_million;
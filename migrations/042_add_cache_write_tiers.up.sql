@@ -0,0 +1,21 @@
+-- Cache writes are billed by cache lifetime: the 5-minute tier at the
+-- cache_write rate and the 1-hour tier at its own rate (2x input at list
+-- prices). token_cache_write stays the total of both tiers.
+ALTER TABLE model_pricing ADD COLUMN cache_write_1h_per_million REAL;
+ALTER TABLE pricing_profile_rates ADD COLUMN cache_write_1h_per_million REAL;
+
+UPDATE model_pricing SET cache_write_1h_per_million = input_per_million * 2
+WHERE cache_write_per_million IS NOT NULL;
+UPDATE pricing_profile_rates SET cache_write_1h_per_million = input_per_million * 2
+WHERE cache_write_per_million IS NOT NULL;
+
+ALTER TABLE session_metrics ADD COLUMN token_cache_write_5m INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE session_metrics ADD COLUMN token_cache_write_1h INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE session_metrics ADD COLUMN cache_write_1h_rate REAL;
+
+ALTER TABLE session_subagents ADD COLUMN token_cache_write_5m INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE session_subagents ADD COLUMN token_cache_write_1h INTEGER NOT NULL DEFAULT 0;
+
+-- Cache writes recorded before the split were all priced at the 5-minute rate
+UPDATE session_metrics SET token_cache_write_5m = token_cache_write;
+UPDATE session_subagents SET token_cache_write_5m = token_cache_write;
@@ -0,0 +1,17 @@
+-- Usage and cost billed by Anthropic, imported from console exports to
+-- reconcile with the estimated costs. usage_date is a UTC day (YYYY-MM-DD);
+-- workspace and api_key are empty when the export has no such breakdown.
+CREATE TABLE IF NOT EXISTS billing_usage (
+    usage_date TEXT NOT NULL,
+    model TEXT NOT NULL,
+    workspace TEXT NOT NULL DEFAULT '',
+    api_key TEXT NOT NULL DEFAULT '',
+    token_input INTEGER NOT NULL DEFAULT 0,
+    token_output INTEGER NOT NULL DEFAULT 0,
+    token_cache_read INTEGER NOT NULL DEFAULT 0,
+    token_cache_write INTEGER NOT NULL DEFAULT 0,
+    token_cache_write_1h INTEGER NOT NULL DEFAULT 0,
+    cost_usd REAL,
+    imported_at TEXT NOT NULL,
+    PRIMARY KEY (usage_date, model, workspace, api_key)
+);
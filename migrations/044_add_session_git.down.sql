@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS session_git_starts;
+DROP INDEX IF EXISTS idx_sessions_git_branch;
+ALTER TABLE sessions DROP COLUMN git_end_dirty;
+ALTER TABLE sessions DROP COLUMN git_start_dirty;
+ALTER TABLE sessions DROP COLUMN git_end_commit;
+ALTER TABLE sessions DROP COLUMN git_start_commit;
+ALTER TABLE sessions DROP COLUMN git_branch;
+ALTER TABLE sessions DROP COLUMN git_remote_url;
+ALTER TABLE sessions DROP COLUMN git_repo_root;
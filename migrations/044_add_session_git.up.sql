@@ -0,0 +1,22 @@
+-- Git context of a session's working directory. The start of the session is
+-- captured by the SessionStart hook into session_git_starts, before the
+-- session is recorded, and merged into the session when it is.
+ALTER TABLE sessions ADD COLUMN git_repo_root TEXT;
+ALTER TABLE sessions ADD COLUMN git_remote_url TEXT;
+ALTER TABLE sessions ADD COLUMN git_branch TEXT;
+ALTER TABLE sessions ADD COLUMN git_start_commit TEXT;
+ALTER TABLE sessions ADD COLUMN git_end_commit TEXT;
+ALTER TABLE sessions ADD COLUMN git_start_dirty INTEGER;
+ALTER TABLE sessions ADD COLUMN git_end_dirty INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_git_branch ON sessions(git_branch);
+
+CREATE TABLE IF NOT EXISTS session_git_starts (
+    session_id TEXT PRIMARY KEY,
+    repo_root TEXT NOT NULL,
+    remote_url TEXT NOT NULL DEFAULT '',
+    branch TEXT NOT NULL DEFAULT '',
+    commit_hash TEXT NOT NULL DEFAULT '',
+    dirty INTEGER NOT NULL DEFAULT 0,
+    captured_at TEXT NOT NULL
+);
@@ -0,0 +1,5 @@
+ALTER TABLE session_metrics DROP COLUMN lines_source;
+ALTER TABLE session_metrics DROP COLUMN lines_removed;
+ALTER TABLE session_metrics DROP COLUMN lines_added;
+
+DROP TABLE IF EXISTS session_file_changes;
@@ -0,0 +1,14 @@
+-- Lines added and removed by a session, per file and in total. They are
+-- counted from Edit, MultiEdit and Write tool inputs ('tools'), or from the
+-- git diff between the commit the session started at and its end ('git').
+CREATE TABLE session_file_changes (
+    session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    file_path TEXT NOT NULL,
+    lines_added INTEGER NOT NULL DEFAULT 0,
+    lines_removed INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (session_id, file_path)
+);
+
+ALTER TABLE session_metrics ADD COLUMN lines_added INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE session_metrics ADD COLUMN lines_removed INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE session_metrics ADD COLUMN lines_source TEXT;
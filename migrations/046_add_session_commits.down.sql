@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_session_commits_committed_at;
+DROP TABLE IF EXISTS session_commits;
@@ -0,0 +1,18 @@
+-- Commits made during a session. They are the new commits between the HEAD
+-- the session started at and its end ('head'), or, when the start is
+-- unknown, the commits on HEAD made while a session that ran `git commit`
+-- was active ('command').
+CREATE TABLE session_commits (
+    session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    commit_hash TEXT NOT NULL,
+    message TEXT NOT NULL,
+    author TEXT,
+    committed_at TEXT NOT NULL,
+    files_changed INTEGER NOT NULL DEFAULT 0,
+    lines_added INTEGER NOT NULL DEFAULT 0,
+    lines_removed INTEGER NOT NULL DEFAULT 0,
+    detected_by TEXT NOT NULL,
+    PRIMARY KEY (session_id, commit_hash)
+);
+
+CREATE INDEX idx_session_commits_committed_at ON session_commits(committed_at);
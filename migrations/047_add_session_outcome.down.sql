@@ -0,0 +1,4 @@
+ALTER TABLE session_metrics DROP COLUMN ended_green;
+ALTER TABLE session_metrics DROP COLUMN lint_passed;
+ALTER TABLE session_metrics DROP COLUMN build_passed;
+ALTER TABLE session_metrics DROP COLUMN tests_passed;
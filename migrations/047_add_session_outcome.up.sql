@@ -0,0 +1,7 @@
+-- Whether the last test, build and lint command a session ran passed (1) or
+-- failed (0), NULL when it ran none. ended_green is 1 when every one of them
+-- that ran passed.
+ALTER TABLE session_metrics ADD COLUMN tests_passed INTEGER;
+ALTER TABLE session_metrics ADD COLUMN build_passed INTEGER;
+ALTER TABLE session_metrics ADD COLUMN lint_passed INTEGER;
+ALTER TABLE session_metrics ADD COLUMN ended_green INTEGER;
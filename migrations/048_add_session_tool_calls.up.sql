@@ -0,0 +1,12 @@
+-- The tool calls of a session in the order they were made, for workflow
+-- mining. label is the tool name, with Bash calls qualified by the category
+-- of their command, as in 'Bash(test)'; is_error is 1 when the call's result
+-- was an error.
+CREATE TABLE session_tool_calls (
+    session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL,
+    tool_name TEXT NOT NULL,
+    label TEXT NOT NULL,
+    is_error INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (session_id, sequence)
+);
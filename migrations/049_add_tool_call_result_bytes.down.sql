@@ -0,0 +1 @@
+ALTER TABLE session_tool_calls DROP COLUMN result_bytes;
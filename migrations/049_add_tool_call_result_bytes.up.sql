@@ -0,0 +1,3 @@
+-- Size in bytes of each tool call's result text, for attributing context
+-- growth to tools and MCP servers.
+ALTER TABLE session_tool_calls ADD COLUMN result_bytes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,18 @@
+-- Restores the (group_id, experiment_id) key; groups listing an experiment
+-- more than once keep its first position only.
+CREATE TABLE rotation_group_members_old (
+    group_id TEXT NOT NULL REFERENCES rotation_groups(id) ON DELETE CASCADE,
+    experiment_id TEXT NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (group_id, experiment_id)
+);
+
+INSERT INTO rotation_group_members_old (group_id, experiment_id, position)
+SELECT group_id, experiment_id, MIN(position)
+FROM rotation_group_members
+GROUP BY group_id, experiment_id;
+
+DROP TABLE rotation_group_members;
+ALTER TABLE rotation_group_members_old RENAME TO rotation_group_members;
+
+CREATE INDEX IF NOT EXISTS idx_rotation_group_members_group_id ON rotation_group_members(group_id);
@@ -0,0 +1,18 @@
+-- Key rotation members by their position so an experiment can appear more
+-- than once in a group, as in a weighted A, A, B rotation.
+CREATE TABLE rotation_group_members_new (
+    group_id TEXT NOT NULL REFERENCES rotation_groups(id) ON DELETE CASCADE,
+    experiment_id TEXT NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (group_id, position)
+);
+
+INSERT INTO rotation_group_members_new (group_id, experiment_id, position)
+SELECT group_id, experiment_id, position
+FROM rotation_group_members;
+
+DROP TABLE rotation_group_members;
+ALTER TABLE rotation_group_members_new RENAME TO rotation_group_members;
+
+CREATE INDEX IF NOT EXISTS idx_rotation_group_members_group_id ON rotation_group_members(group_id);
+CREATE INDEX IF NOT EXISTS idx_rotation_group_members_experiment_id ON rotation_group_members(experiment_id);
@@ -20,22 +20,24 @@ func (q *Queries) ActivateExperiment(ctx context.Context, id string) error {
 }
 
 const createExperiment = `-- name: CreateExperiment :exec
-INSERT INTO experiments (id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO experiments (id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes, scheduled_start, scheduled_end)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateExperimentParams struct {
-	ID          string         `json:"id"`
-	Name        string         `json:"name"`
-	Description sql.NullString `json:"description"`
-	Hypothesis  sql.NullString `json:"hypothesis"`
-	StartedAt   string         `json:"started_at"`
-	EndedAt     sql.NullString `json:"ended_at"`
-	IsActive    int64          `json:"is_active"`
-	CreatedAt   string         `json:"created_at"`
-	ModelID     sql.NullString `json:"model_id"`
-	PlanType    sql.NullString `json:"plan_type"`
-	Notes       sql.NullString `json:"notes"`
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Description    sql.NullString `json:"description"`
+	Hypothesis     sql.NullString `json:"hypothesis"`
+	StartedAt      string         `json:"started_at"`
+	EndedAt        sql.NullString `json:"ended_at"`
+	IsActive       int64          `json:"is_active"`
+	CreatedAt      string         `json:"created_at"`
+	ModelID        sql.NullString `json:"model_id"`
+	PlanType       sql.NullString `json:"plan_type"`
+	Notes          sql.NullString `json:"notes"`
+	ScheduledStart sql.NullString `json:"scheduled_start"`
+	ScheduledEnd   sql.NullString `json:"scheduled_end"`
 }
 
 func (q *Queries) CreateExperiment(ctx context.Context, arg CreateExperimentParams) error {
@@ -51,10 +53,28 @@ func (q *Queries) CreateExperiment(ctx context.Context, arg CreateExperimentPara
 		arg.ModelID,
 		arg.PlanType,
 		arg.Notes,
+		arg.ScheduledStart,
+		arg.ScheduledEnd,
 	)
 	return err
 }
 
+const createExperimentNote = `-- name: CreateExperimentNote :exec
+INSERT INTO experiment_notes (experiment_id, content, created_at)
+VALUES (?, ?, ?)
+`
+
+type CreateExperimentNoteParams struct {
+	ExperimentID string `json:"experiment_id"`
+	Content      string `json:"content"`
+	CreatedAt    string `json:"created_at"`
+}
+
+func (q *Queries) CreateExperimentNote(ctx context.Context, arg CreateExperimentNoteParams) error {
+	_, err := q.db.ExecContext(ctx, createExperimentNote, arg.ExperimentID, arg.Content, arg.CreatedAt)
+	return err
+}
+
 const deactivateAllExperiments = `-- name: DeactivateAllExperiments :exec
 UPDATE experiments SET is_active = 0
 `
@@ -97,7 +117,7 @@ func (q *Queries) DeleteExperimentVariable(ctx context.Context, arg DeleteExperi
 }
 
 const getActiveExperiment = `-- name: GetActiveExperiment :one
-SELECT id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes FROM experiments WHERE is_active = 1 LIMIT 1
+SELECT id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes, scheduled_start, scheduled_end, verdict, conclusion, concluded_at FROM experiments WHERE is_active = 1 LIMIT 1
 `
 
 func (q *Queries) GetActiveExperiment(ctx context.Context) (Experiment, error) {
@@ -115,12 +135,17 @@ func (q *Queries) GetActiveExperiment(ctx context.Context) (Experiment, error) {
 		&i.ModelID,
 		&i.PlanType,
 		&i.Notes,
+		&i.ScheduledStart,
+		&i.ScheduledEnd,
+		&i.Verdict,
+		&i.Conclusion,
+		&i.ConcludedAt,
 	)
 	return i, err
 }
 
 const getExperimentByID = `-- name: GetExperimentByID :one
-SELECT id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes FROM experiments WHERE id = ?
+SELECT id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes, scheduled_start, scheduled_end, verdict, conclusion, concluded_at FROM experiments WHERE id = ?
 `
 
 func (q *Queries) GetExperimentByID(ctx context.Context, id string) (Experiment, error) {
@@ -138,12 +163,17 @@ func (q *Queries) GetExperimentByID(ctx context.Context, id string) (Experiment,
 		&i.ModelID,
 		&i.PlanType,
 		&i.Notes,
+		&i.ScheduledStart,
+		&i.ScheduledEnd,
+		&i.Verdict,
+		&i.Conclusion,
+		&i.ConcludedAt,
 	)
 	return i, err
 }
 
 const getExperimentByName = `-- name: GetExperimentByName :one
-SELECT id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes FROM experiments WHERE name = ?
+SELECT id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes, scheduled_start, scheduled_end, verdict, conclusion, concluded_at FROM experiments WHERE name = ?
 `
 
 func (q *Queries) GetExperimentByName(ctx context.Context, name string) (Experiment, error) {
@@ -161,10 +191,47 @@ func (q *Queries) GetExperimentByName(ctx context.Context, name string) (Experim
 		&i.ModelID,
 		&i.PlanType,
 		&i.Notes,
+		&i.ScheduledStart,
+		&i.ScheduledEnd,
+		&i.Verdict,
+		&i.Conclusion,
+		&i.ConcludedAt,
 	)
 	return i, err
 }
 
+const listExperimentNotesByExperimentID = `-- name: ListExperimentNotesByExperimentID :many
+SELECT id, experiment_id, content, created_at FROM experiment_notes WHERE experiment_id = ? ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListExperimentNotesByExperimentID(ctx context.Context, experimentID string) ([]ExperimentNote, error) {
+	rows, err := q.db.QueryContext(ctx, listExperimentNotesByExperimentID, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExperimentNote{}
+	for rows.Next() {
+		var i ExperimentNote
+		if err := rows.Scan(
+			&i.ID,
+			&i.ExperimentID,
+			&i.Content,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listExperimentVariablesByExperimentID = `-- name: ListExperimentVariablesByExperimentID :many
 SELECT id, experiment_id, "key", value, created_at FROM experiment_variables WHERE experiment_id = ? ORDER BY key
 `
@@ -199,7 +266,7 @@ func (q *Queries) ListExperimentVariablesByExperimentID(ctx context.Context, exp
 }
 
 const listExperiments = `-- name: ListExperiments :many
-SELECT id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes FROM experiments ORDER BY created_at DESC
+SELECT id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes, scheduled_start, scheduled_end, verdict, conclusion, concluded_at FROM experiments ORDER BY created_at DESC
 `
 
 func (q *Queries) ListExperiments(ctx context.Context) ([]Experiment, error) {
@@ -223,6 +290,11 @@ func (q *Queries) ListExperiments(ctx context.Context) ([]Experiment, error) {
 			&i.ModelID,
 			&i.PlanType,
 			&i.Notes,
+			&i.ScheduledStart,
+			&i.ScheduledEnd,
+			&i.Verdict,
+			&i.Conclusion,
+			&i.ConcludedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -237,23 +309,46 @@ func (q *Queries) ListExperiments(ctx context.Context) ([]Experiment, error) {
 	return items, nil
 }
 
+const setExperimentConclusion = `-- name: SetExperimentConclusion :exec
+UPDATE experiments SET verdict = ?, conclusion = ?, concluded_at = ? WHERE id = ?
+`
+
+type SetExperimentConclusionParams struct {
+	Verdict     sql.NullString `json:"verdict"`
+	Conclusion  sql.NullString `json:"conclusion"`
+	ConcludedAt sql.NullString `json:"concluded_at"`
+	ID          string         `json:"id"`
+}
+
+func (q *Queries) SetExperimentConclusion(ctx context.Context, arg SetExperimentConclusionParams) error {
+	_, err := q.db.ExecContext(ctx, setExperimentConclusion,
+		arg.Verdict,
+		arg.Conclusion,
+		arg.ConcludedAt,
+		arg.ID,
+	)
+	return err
+}
+
 const updateExperiment = `-- name: UpdateExperiment :exec
 UPDATE experiments
-SET name = ?, description = ?, hypothesis = ?, started_at = ?, ended_at = ?, is_active = ?, model_id = ?, plan_type = ?, notes = ?
+SET name = ?, description = ?, hypothesis = ?, started_at = ?, ended_at = ?, is_active = ?, model_id = ?, plan_type = ?, notes = ?, scheduled_start = ?, scheduled_end = ?
 WHERE id = ?
 `
 
 type UpdateExperimentParams struct {
-	Name        string         `json:"name"`
-	Description sql.NullString `json:"description"`
-	Hypothesis  sql.NullString `json:"hypothesis"`
-	StartedAt   string         `json:"started_at"`
-	EndedAt     sql.NullString `json:"ended_at"`
-	IsActive    int64          `json:"is_active"`
-	ModelID     sql.NullString `json:"model_id"`
-	PlanType    sql.NullString `json:"plan_type"`
-	Notes       sql.NullString `json:"notes"`
-	ID          string         `json:"id"`
+	Name           string         `json:"name"`
+	Description    sql.NullString `json:"description"`
+	Hypothesis     sql.NullString `json:"hypothesis"`
+	StartedAt      string         `json:"started_at"`
+	EndedAt        sql.NullString `json:"ended_at"`
+	IsActive       int64          `json:"is_active"`
+	ModelID        sql.NullString `json:"model_id"`
+	PlanType       sql.NullString `json:"plan_type"`
+	Notes          sql.NullString `json:"notes"`
+	ScheduledStart sql.NullString `json:"scheduled_start"`
+	ScheduledEnd   sql.NullString `json:"scheduled_end"`
+	ID             string         `json:"id"`
 }
 
 func (q *Queries) UpdateExperiment(ctx context.Context, arg UpdateExperimentParams) error {
@@ -267,6 +362,8 @@ func (q *Queries) UpdateExperiment(ctx context.Context, arg UpdateExperimentPara
 		arg.ModelID,
 		arg.PlanType,
 		arg.Notes,
+		arg.ScheduledStart,
+		arg.ScheduledEnd,
 		arg.ID,
 	)
 	return err
@@ -56,9 +56,31 @@ func (q *Queries) CreateSessionFile(ctx context.Context, arg CreateSessionFilePa
 	return err
 }
 
+const createSessionFileChange = `-- name: CreateSessionFileChange :exec
+INSERT OR REPLACE INTO session_file_changes (session_id, file_path, lines_added, lines_removed)
+VALUES (?, ?, ?, ?)
+`
+
+type CreateSessionFileChangeParams struct {
+	SessionID    string `json:"session_id"`
+	FilePath     string `json:"file_path"`
+	LinesAdded   int64  `json:"lines_added"`
+	LinesRemoved int64  `json:"lines_removed"`
+}
+
+func (q *Queries) CreateSessionFileChange(ctx context.Context, arg CreateSessionFileChangeParams) error {
+	_, err := q.db.ExecContext(ctx, createSessionFileChange,
+		arg.SessionID,
+		arg.FilePath,
+		arg.LinesAdded,
+		arg.LinesRemoved,
+	)
+	return err
+}
+
 const createSessionMetrics = `-- name: CreateSessionMetrics :exec
-INSERT OR REPLACE INTO session_metrics (session_id, model_id, message_count_user, message_count_assistant, turn_count, token_input, token_output, token_cache_read, token_cache_write, cost_estimate_usd, error_count, input_rate, output_rate, cache_read_rate, cache_write_rate)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT OR REPLACE INTO session_metrics (session_id, model_id, message_count_user, message_count_assistant, turn_count, token_input, token_output, token_cache_read, token_cache_write, cost_estimate_usd, error_count, input_rate, output_rate, cache_read_rate, cache_write_rate, web_search_requests, web_fetch_requests, server_tool_cost_usd, token_cache_write_5m, token_cache_write_1h, cache_write_1h_rate, lines_added, lines_removed, lines_source, tests_passed, build_passed, lint_passed, ended_green)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateSessionMetricsParams struct {
@@ -77,6 +99,19 @@ type CreateSessionMetricsParams struct {
 	OutputRate            sql.NullFloat64 `json:"output_rate"`
 	CacheReadRate         sql.NullFloat64 `json:"cache_read_rate"`
 	CacheWriteRate        sql.NullFloat64 `json:"cache_write_rate"`
+	WebSearchRequests     int64           `json:"web_search_requests"`
+	WebFetchRequests      int64           `json:"web_fetch_requests"`
+	ServerToolCostUsd     sql.NullFloat64 `json:"server_tool_cost_usd"`
+	TokenCacheWrite5m     int64           `json:"token_cache_write_5m"`
+	TokenCacheWrite1h     int64           `json:"token_cache_write_1h"`
+	CacheWrite1hRate      sql.NullFloat64 `json:"cache_write_1h_rate"`
+	LinesAdded            int64           `json:"lines_added"`
+	LinesRemoved          int64           `json:"lines_removed"`
+	LinesSource           sql.NullString  `json:"lines_source"`
+	TestsPassed           sql.NullInt64   `json:"tests_passed"`
+	BuildPassed           sql.NullInt64   `json:"build_passed"`
+	LintPassed            sql.NullInt64   `json:"lint_passed"`
+	EndedGreen            sql.NullInt64   `json:"ended_green"`
 }
 
 func (q *Queries) CreateSessionMetrics(ctx context.Context, arg CreateSessionMetricsParams) error {
@@ -96,29 +131,44 @@ func (q *Queries) CreateSessionMetrics(ctx context.Context, arg CreateSessionMet
 		arg.OutputRate,
 		arg.CacheReadRate,
 		arg.CacheWriteRate,
+		arg.WebSearchRequests,
+		arg.WebFetchRequests,
+		arg.ServerToolCostUsd,
+		arg.TokenCacheWrite5m,
+		arg.TokenCacheWrite1h,
+		arg.CacheWrite1hRate,
+		arg.LinesAdded,
+		arg.LinesRemoved,
+		arg.LinesSource,
+		arg.TestsPassed,
+		arg.BuildPassed,
+		arg.LintPassed,
+		arg.EndedGreen,
 	)
 	return err
 }
 
 const createSessionSubagent = `-- name: CreateSessionSubagent :exec
-INSERT INTO session_subagents (session_id, agent_type, agent_kind, description, model, total_tokens, token_input, token_output, token_cache_read, token_cache_write, total_duration_ms, tool_use_count, cost_estimate_usd)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO session_subagents (session_id, agent_type, agent_kind, description, model, total_tokens, token_input, token_output, token_cache_read, token_cache_write, total_duration_ms, tool_use_count, cost_estimate_usd, token_cache_write_5m, token_cache_write_1h)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateSessionSubagentParams struct {
-	SessionID       string          `json:"session_id"`
-	AgentType       string          `json:"agent_type"`
-	AgentKind       string          `json:"agent_kind"`
-	Description     sql.NullString  `json:"description"`
-	Model           sql.NullString  `json:"model"`
-	TotalTokens     int64           `json:"total_tokens"`
-	TokenInput      int64           `json:"token_input"`
-	TokenOutput     int64           `json:"token_output"`
-	TokenCacheRead  int64           `json:"token_cache_read"`
-	TokenCacheWrite int64           `json:"token_cache_write"`
-	TotalDurationMs sql.NullInt64   `json:"total_duration_ms"`
-	ToolUseCount    int64           `json:"tool_use_count"`
-	CostEstimateUsd sql.NullFloat64 `json:"cost_estimate_usd"`
+	SessionID         string          `json:"session_id"`
+	AgentType         string          `json:"agent_type"`
+	AgentKind         string          `json:"agent_kind"`
+	Description       sql.NullString  `json:"description"`
+	Model             sql.NullString  `json:"model"`
+	TotalTokens       int64           `json:"total_tokens"`
+	TokenInput        int64           `json:"token_input"`
+	TokenOutput       int64           `json:"token_output"`
+	TokenCacheRead    int64           `json:"token_cache_read"`
+	TokenCacheWrite   int64           `json:"token_cache_write"`
+	TotalDurationMs   sql.NullInt64   `json:"total_duration_ms"`
+	ToolUseCount      int64           `json:"tool_use_count"`
+	CostEstimateUsd   sql.NullFloat64 `json:"cost_estimate_usd"`
+	TokenCacheWrite5m int64           `json:"token_cache_write_5m"`
+	TokenCacheWrite1h int64           `json:"token_cache_write_1h"`
 }
 
 func (q *Queries) CreateSessionSubagent(ctx context.Context, arg CreateSessionSubagentParams) error {
@@ -136,6 +186,8 @@ func (q *Queries) CreateSessionSubagent(ctx context.Context, arg CreateSessionSu
 		arg.TotalDurationMs,
 		arg.ToolUseCount,
 		arg.CostEstimateUsd,
+		arg.TokenCacheWrite5m,
+		arg.TokenCacheWrite1h,
 	)
 	return err
 }
@@ -167,6 +219,86 @@ func (q *Queries) CreateSessionTool(ctx context.Context, arg CreateSessionToolPa
 	return err
 }
 
+const createSessionToolCall = `-- name: CreateSessionToolCall :exec
+INSERT OR REPLACE INTO session_tool_calls (session_id, sequence, tool_name, label, is_error, result_bytes)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateSessionToolCallParams struct {
+	SessionID   string `json:"session_id"`
+	Sequence    int64  `json:"sequence"`
+	ToolName    string `json:"tool_name"`
+	Label       string `json:"label"`
+	IsError     int64  `json:"is_error"`
+	ResultBytes int64  `json:"result_bytes"`
+}
+
+func (q *Queries) CreateSessionToolCall(ctx context.Context, arg CreateSessionToolCallParams) error {
+	_, err := q.db.ExecContext(ctx, createSessionToolCall,
+		arg.SessionID,
+		arg.Sequence,
+		arg.ToolName,
+		arg.Label,
+		arg.IsError,
+		arg.ResultBytes,
+	)
+	return err
+}
+
+const deleteSessionCommandsBySession = `-- name: DeleteSessionCommandsBySession :exec
+DELETE FROM session_commands WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSessionCommandsBySession(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionCommandsBySession, sessionID)
+	return err
+}
+
+const deleteSessionFileChangesBySession = `-- name: DeleteSessionFileChangesBySession :exec
+DELETE FROM session_file_changes WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSessionFileChangesBySession(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionFileChangesBySession, sessionID)
+	return err
+}
+
+const deleteSessionFilesBySession = `-- name: DeleteSessionFilesBySession :exec
+DELETE FROM session_files WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSessionFilesBySession(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionFilesBySession, sessionID)
+	return err
+}
+
+const deleteSessionSubagentsBySession = `-- name: DeleteSessionSubagentsBySession :exec
+DELETE FROM session_subagents WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSessionSubagentsBySession(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionSubagentsBySession, sessionID)
+	return err
+}
+
+const deleteSessionToolCallsBySession = `-- name: DeleteSessionToolCallsBySession :exec
+DELETE FROM session_tool_calls WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSessionToolCallsBySession(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionToolCallsBySession, sessionID)
+	return err
+}
+
+const deleteSessionToolsBySession = `-- name: DeleteSessionToolsBySession :exec
+DELETE FROM session_tools WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSessionToolsBySession(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionToolsBySession, sessionID)
+	return err
+}
+
 const getAggregateStats = `-- name: GetAggregateStats :one
 SELECT
     COUNT(DISTINCT s.id) as session_count,
@@ -177,8 +309,16 @@ SELECT
     COALESCE(SUM(m.token_output), 0) as total_token_output,
     COALESCE(SUM(m.token_cache_read), 0) as total_token_cache_read,
     COALESCE(SUM(m.token_cache_write), 0) as total_token_cache_write,
+    COALESCE(SUM(m.token_cache_write_1h), 0) as total_token_cache_write_1h,
     COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd,
-    COALESCE(SUM(m.error_count), 0) as total_errors
+    COALESCE(SUM(m.error_count), 0) as total_errors,
+    COALESCE(SUM(m.web_search_requests), 0) as total_web_search_requests,
+    COALESCE(SUM(m.web_fetch_requests), 0) as total_web_fetch_requests,
+    COALESCE(SUM(m.server_tool_cost_usd), 0) as total_server_tool_cost_usd,
+    COALESCE(SUM(m.lines_added), 0) as total_lines_added,
+    COALESCE(SUM(m.lines_removed), 0) as total_lines_removed,
+    COALESCE(SUM(CASE WHEN m.ended_green IS NOT NULL THEN 1 ELSE 0 END), 0) as sessions_with_checks,
+    COALESCE(SUM(m.ended_green), 0) as sessions_ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
 WHERE s.created_at >= ?
@@ -193,8 +333,16 @@ type GetAggregateStatsRow struct {
 	TotalTokenOutput       interface{} `json:"total_token_output"`
 	TotalTokenCacheRead    interface{} `json:"total_token_cache_read"`
 	TotalTokenCacheWrite   interface{} `json:"total_token_cache_write"`
+	TotalTokenCacheWrite1h interface{} `json:"total_token_cache_write_1h"`
 	TotalCostUsd           interface{} `json:"total_cost_usd"`
 	TotalErrors            interface{} `json:"total_errors"`
+	TotalWebSearchRequests interface{} `json:"total_web_search_requests"`
+	TotalWebFetchRequests  interface{} `json:"total_web_fetch_requests"`
+	TotalServerToolCostUsd interface{} `json:"total_server_tool_cost_usd"`
+	TotalLinesAdded        interface{} `json:"total_lines_added"`
+	TotalLinesRemoved      interface{} `json:"total_lines_removed"`
+	SessionsWithChecks     interface{} `json:"sessions_with_checks"`
+	SessionsEndedGreen     interface{} `json:"sessions_ended_green"`
 }
 
 func (q *Queries) GetAggregateStats(ctx context.Context, createdAt string) (GetAggregateStatsRow, error) {
@@ -209,8 +357,16 @@ func (q *Queries) GetAggregateStats(ctx context.Context, createdAt string) (GetA
 		&i.TotalTokenOutput,
 		&i.TotalTokenCacheRead,
 		&i.TotalTokenCacheWrite,
+		&i.TotalTokenCacheWrite1h,
 		&i.TotalCostUsd,
 		&i.TotalErrors,
+		&i.TotalWebSearchRequests,
+		&i.TotalWebFetchRequests,
+		&i.TotalServerToolCostUsd,
+		&i.TotalLinesAdded,
+		&i.TotalLinesRemoved,
+		&i.SessionsWithChecks,
+		&i.SessionsEndedGreen,
 	)
 	return i, err
 }
@@ -225,11 +381,19 @@ SELECT
     COALESCE(SUM(m.token_output), 0) as total_token_output,
     COALESCE(SUM(m.token_cache_read), 0) as total_token_cache_read,
     COALESCE(SUM(m.token_cache_write), 0) as total_token_cache_write,
+    COALESCE(SUM(m.token_cache_write_1h), 0) as total_token_cache_write_1h,
     COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd,
-    COALESCE(SUM(m.error_count), 0) as total_errors
+    COALESCE(SUM(m.error_count), 0) as total_errors,
+    COALESCE(SUM(m.web_search_requests), 0) as total_web_search_requests,
+    COALESCE(SUM(m.web_fetch_requests), 0) as total_web_fetch_requests,
+    COALESCE(SUM(m.server_tool_cost_usd), 0) as total_server_tool_cost_usd,
+    COALESCE(SUM(m.lines_added), 0) as total_lines_added,
+    COALESCE(SUM(m.lines_removed), 0) as total_lines_removed,
+    COALESCE(SUM(CASE WHEN m.ended_green IS NOT NULL THEN 1 ELSE 0 END), 0) as sessions_with_checks,
+    COALESCE(SUM(m.ended_green), 0) as sessions_ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
-WHERE s.experiment_id = ? AND s.created_at >= ?
+WHERE s.experiment_id = ? AND s.created_at >= ? AND s.is_excluded = 0
 `
 
 type GetAggregateStatsByExperimentParams struct {
@@ -246,8 +410,16 @@ type GetAggregateStatsByExperimentRow struct {
 	TotalTokenOutput       interface{} `json:"total_token_output"`
 	TotalTokenCacheRead    interface{} `json:"total_token_cache_read"`
 	TotalTokenCacheWrite   interface{} `json:"total_token_cache_write"`
+	TotalTokenCacheWrite1h interface{} `json:"total_token_cache_write_1h"`
 	TotalCostUsd           interface{} `json:"total_cost_usd"`
 	TotalErrors            interface{} `json:"total_errors"`
+	TotalWebSearchRequests interface{} `json:"total_web_search_requests"`
+	TotalWebFetchRequests  interface{} `json:"total_web_fetch_requests"`
+	TotalServerToolCostUsd interface{} `json:"total_server_tool_cost_usd"`
+	TotalLinesAdded        interface{} `json:"total_lines_added"`
+	TotalLinesRemoved      interface{} `json:"total_lines_removed"`
+	SessionsWithChecks     interface{} `json:"sessions_with_checks"`
+	SessionsEndedGreen     interface{} `json:"sessions_ended_green"`
 }
 
 func (q *Queries) GetAggregateStatsByExperiment(ctx context.Context, arg GetAggregateStatsByExperimentParams) (GetAggregateStatsByExperimentRow, error) {
@@ -262,8 +434,16 @@ func (q *Queries) GetAggregateStatsByExperiment(ctx context.Context, arg GetAggr
 		&i.TotalTokenOutput,
 		&i.TotalTokenCacheRead,
 		&i.TotalTokenCacheWrite,
+		&i.TotalTokenCacheWrite1h,
 		&i.TotalCostUsd,
 		&i.TotalErrors,
+		&i.TotalWebSearchRequests,
+		&i.TotalWebFetchRequests,
+		&i.TotalServerToolCostUsd,
+		&i.TotalLinesAdded,
+		&i.TotalLinesRemoved,
+		&i.SessionsWithChecks,
+		&i.SessionsEndedGreen,
 	)
 	return i, err
 }
@@ -278,8 +458,16 @@ SELECT
     COALESCE(SUM(m.token_output), 0) as total_token_output,
     COALESCE(SUM(m.token_cache_read), 0) as total_token_cache_read,
     COALESCE(SUM(m.token_cache_write), 0) as total_token_cache_write,
+    COALESCE(SUM(m.token_cache_write_1h), 0) as total_token_cache_write_1h,
     COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd,
-    COALESCE(SUM(m.error_count), 0) as total_errors
+    COALESCE(SUM(m.error_count), 0) as total_errors,
+    COALESCE(SUM(m.web_search_requests), 0) as total_web_search_requests,
+    COALESCE(SUM(m.web_fetch_requests), 0) as total_web_fetch_requests,
+    COALESCE(SUM(m.server_tool_cost_usd), 0) as total_server_tool_cost_usd,
+    COALESCE(SUM(m.lines_added), 0) as total_lines_added,
+    COALESCE(SUM(m.lines_removed), 0) as total_lines_removed,
+    COALESCE(SUM(CASE WHEN m.ended_green IS NOT NULL THEN 1 ELSE 0 END), 0) as sessions_with_checks,
+    COALESCE(SUM(m.ended_green), 0) as sessions_ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
 WHERE s.project_id = ? AND s.created_at >= ?
@@ -299,8 +487,16 @@ type GetAggregateStatsByProjectRow struct {
 	TotalTokenOutput       interface{} `json:"total_token_output"`
 	TotalTokenCacheRead    interface{} `json:"total_token_cache_read"`
 	TotalTokenCacheWrite   interface{} `json:"total_token_cache_write"`
+	TotalTokenCacheWrite1h interface{} `json:"total_token_cache_write_1h"`
 	TotalCostUsd           interface{} `json:"total_cost_usd"`
 	TotalErrors            interface{} `json:"total_errors"`
+	TotalWebSearchRequests interface{} `json:"total_web_search_requests"`
+	TotalWebFetchRequests  interface{} `json:"total_web_fetch_requests"`
+	TotalServerToolCostUsd interface{} `json:"total_server_tool_cost_usd"`
+	TotalLinesAdded        interface{} `json:"total_lines_added"`
+	TotalLinesRemoved      interface{} `json:"total_lines_removed"`
+	SessionsWithChecks     interface{} `json:"sessions_with_checks"`
+	SessionsEndedGreen     interface{} `json:"sessions_ended_green"`
 }
 
 func (q *Queries) GetAggregateStatsByProject(ctx context.Context, arg GetAggregateStatsByProjectParams) (GetAggregateStatsByProjectRow, error) {
@@ -315,12 +511,112 @@ func (q *Queries) GetAggregateStatsByProject(ctx context.Context, arg GetAggrega
 		&i.TotalTokenOutput,
 		&i.TotalTokenCacheRead,
 		&i.TotalTokenCacheWrite,
+		&i.TotalTokenCacheWrite1h,
 		&i.TotalCostUsd,
 		&i.TotalErrors,
+		&i.TotalWebSearchRequests,
+		&i.TotalWebFetchRequests,
+		&i.TotalServerToolCostUsd,
+		&i.TotalLinesAdded,
+		&i.TotalLinesRemoved,
+		&i.SessionsWithChecks,
+		&i.SessionsEndedGreen,
 	)
 	return i, err
 }
 
+const getDailyCostByModelAndProject = `-- name: GetDailyCostByModelAndProject :many
+SELECT
+    DATE(s.created_at) as date,
+    s.project_id,
+    m.model_id,
+    COUNT(DISTINCT s.id) as session_count,
+    COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.created_at >= ?
+GROUP BY DATE(s.created_at), s.project_id, m.model_id
+ORDER BY date ASC, s.project_id ASC, m.model_id ASC
+`
+
+type GetDailyCostByModelAndProjectRow struct {
+	Date         interface{}    `json:"date"`
+	ProjectID    string         `json:"project_id"`
+	ModelID      sql.NullString `json:"model_id"`
+	SessionCount int64          `json:"session_count"`
+	TotalCost    interface{}    `json:"total_cost"`
+}
+
+func (q *Queries) GetDailyCostByModelAndProject(ctx context.Context, createdAt string) ([]GetDailyCostByModelAndProjectRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDailyCostByModelAndProject, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyCostByModelAndProjectRow{}
+	for rows.Next() {
+		var i GetDailyCostByModelAndProjectRow
+		if err := rows.Scan(
+			&i.Date,
+			&i.ProjectID,
+			&i.ModelID,
+			&i.SessionCount,
+			&i.TotalCost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyCostByProject = `-- name: GetDailyCostByProject :many
+SELECT
+    DATE(s.created_at) as date,
+    s.project_id,
+    COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.created_at >= ?
+GROUP BY DATE(s.created_at), s.project_id
+ORDER BY date ASC, s.project_id ASC
+`
+
+type GetDailyCostByProjectRow struct {
+	Date      interface{} `json:"date"`
+	ProjectID string      `json:"project_id"`
+	TotalCost interface{} `json:"total_cost"`
+}
+
+func (q *Queries) GetDailyCostByProject(ctx context.Context, createdAt string) ([]GetDailyCostByProjectRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDailyCostByProject, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyCostByProjectRow{}
+	for rows.Next() {
+		var i GetDailyCostByProjectRow
+		if err := rows.Scan(&i.Date, &i.ProjectID, &i.TotalCost); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getDailyStats = `-- name: GetDailyStats :many
 SELECT
     DATE(s.created_at) as date,
@@ -375,8 +671,27 @@ func (q *Queries) GetDailyStats(ctx context.Context, arg GetDailyStatsParams) ([
 	return items, nil
 }
 
+const getExperimentSpend = `-- name: GetExperimentSpend :one
+SELECT COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.experiment_id = ? AND s.created_at >= ?
+`
+
+type GetExperimentSpendParams struct {
+	ExperimentID sql.NullString `json:"experiment_id"`
+	CreatedAt    string         `json:"created_at"`
+}
+
+func (q *Queries) GetExperimentSpend(ctx context.Context, arg GetExperimentSpendParams) (interface{}, error) {
+	row := q.db.QueryRowContext(ctx, getExperimentSpend, arg.ExperimentID, arg.CreatedAt)
+	var total_cost_usd interface{}
+	err := row.Scan(&total_cost_usd)
+	return total_cost_usd, err
+}
+
 const getSessionMetricsBySessionID = `-- name: GetSessionMetricsBySessionID :one
-SELECT session_id, message_count_user, message_count_assistant, turn_count, token_input, token_output, token_cache_read, token_cache_write, cost_estimate_usd, error_count, model_id, input_rate, output_rate, cache_read_rate, cache_write_rate FROM session_metrics WHERE session_id = ?
+SELECT session_id, message_count_user, message_count_assistant, turn_count, token_input, token_output, token_cache_read, token_cache_write, cost_estimate_usd, error_count, model_id, input_rate, output_rate, cache_read_rate, cache_write_rate, web_search_requests, web_fetch_requests, server_tool_cost_usd, token_cache_write_5m, token_cache_write_1h, cache_write_1h_rate, lines_added, lines_removed, lines_source, tests_passed, build_passed, lint_passed, ended_green FROM session_metrics WHERE session_id = ?
 `
 
 func (q *Queries) GetSessionMetricsBySessionID(ctx context.Context, sessionID string) (SessionMetric, error) {
@@ -398,6 +713,19 @@ func (q *Queries) GetSessionMetricsBySessionID(ctx context.Context, sessionID st
 		&i.OutputRate,
 		&i.CacheReadRate,
 		&i.CacheWriteRate,
+		&i.WebSearchRequests,
+		&i.WebFetchRequests,
+		&i.ServerToolCostUsd,
+		&i.TokenCacheWrite5m,
+		&i.TokenCacheWrite1h,
+		&i.CacheWrite1hRate,
+		&i.LinesAdded,
+		&i.LinesRemoved,
+		&i.LinesSource,
+		&i.TestsPassed,
+		&i.BuildPassed,
+		&i.LintPassed,
+		&i.EndedGreen,
 	)
 	return i, err
 }
@@ -414,10 +742,18 @@ SELECT
     COALESCE(SUM(m.token_output), 0) as total_token_output,
     COALESCE(SUM(m.token_cache_read), 0) as total_token_cache_read,
     COALESCE(SUM(m.token_cache_write), 0) as total_token_cache_write,
+    COALESCE(SUM(m.token_cache_write_1h), 0) as total_token_cache_write_1h,
     COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd,
-    COALESCE(SUM(m.error_count), 0) as total_errors
+    COALESCE(SUM(m.error_count), 0) as total_errors,
+    COALESCE(SUM(m.web_search_requests), 0) as total_web_search_requests,
+    COALESCE(SUM(m.web_fetch_requests), 0) as total_web_fetch_requests,
+    COALESCE(SUM(m.server_tool_cost_usd), 0) as total_server_tool_cost_usd,
+    COALESCE(SUM(m.lines_added), 0) as total_lines_added,
+    COALESCE(SUM(m.lines_removed), 0) as total_lines_removed,
+    COALESCE(SUM(CASE WHEN m.ended_green IS NOT NULL THEN 1 ELSE 0 END), 0) as sessions_with_checks,
+    COALESCE(SUM(m.ended_green), 0) as sessions_ended_green
 FROM experiments e
-LEFT JOIN sessions s ON s.experiment_id = e.id
+LEFT JOIN sessions s ON s.experiment_id = e.id AND s.is_excluded = 0
 LEFT JOIN session_metrics m ON s.id = m.session_id
 GROUP BY e.id, e.name
 ORDER BY e.created_at DESC
@@ -434,8 +770,16 @@ type GetStatsForAllExperimentsRow struct {
 	TotalTokenOutput       interface{} `json:"total_token_output"`
 	TotalTokenCacheRead    interface{} `json:"total_token_cache_read"`
 	TotalTokenCacheWrite   interface{} `json:"total_token_cache_write"`
+	TotalTokenCacheWrite1h interface{} `json:"total_token_cache_write_1h"`
 	TotalCostUsd           interface{} `json:"total_cost_usd"`
 	TotalErrors            interface{} `json:"total_errors"`
+	TotalWebSearchRequests interface{} `json:"total_web_search_requests"`
+	TotalWebFetchRequests  interface{} `json:"total_web_fetch_requests"`
+	TotalServerToolCostUsd interface{} `json:"total_server_tool_cost_usd"`
+	TotalLinesAdded        interface{} `json:"total_lines_added"`
+	TotalLinesRemoved      interface{} `json:"total_lines_removed"`
+	SessionsWithChecks     interface{} `json:"sessions_with_checks"`
+	SessionsEndedGreen     interface{} `json:"sessions_ended_green"`
 }
 
 func (q *Queries) GetStatsForAllExperiments(ctx context.Context) ([]GetStatsForAllExperimentsRow, error) {
@@ -458,8 +802,16 @@ func (q *Queries) GetStatsForAllExperiments(ctx context.Context) ([]GetStatsForA
 			&i.TotalTokenOutput,
 			&i.TotalTokenCacheRead,
 			&i.TotalTokenCacheWrite,
+			&i.TotalTokenCacheWrite1h,
 			&i.TotalCostUsd,
 			&i.TotalErrors,
+			&i.TotalWebSearchRequests,
+			&i.TotalWebFetchRequests,
+			&i.TotalServerToolCostUsd,
+			&i.TotalLinesAdded,
+			&i.TotalLinesRemoved,
+			&i.SessionsWithChecks,
+			&i.SessionsEndedGreen,
 		); err != nil {
 			return nil, err
 		}
@@ -635,7 +987,7 @@ SELECT
     SUM(error_count) as total_errors
 FROM session_tools st
 JOIN sessions s ON st.session_id = s.id
-WHERE s.experiment_id = ?
+WHERE s.experiment_id = ? AND s.is_excluded = 0
 GROUP BY tool_name
 ORDER BY total_invocations DESC
 LIMIT ?
@@ -679,7 +1031,7 @@ const getTotalToolCallsByExperiment = `-- name: GetTotalToolCallsByExperiment :o
 SELECT COALESCE(SUM(st.invocation_count), 0) as total_tool_calls
 FROM session_tools st
 JOIN sessions s ON st.session_id = s.id
-WHERE s.experiment_id = ?
+WHERE s.experiment_id = ? AND s.is_excluded = 0
 `
 
 func (q *Queries) GetTotalToolCallsByExperiment(ctx context.Context, experimentID sql.NullString) (interface{}, error) {
@@ -689,6 +1041,122 @@ func (q *Queries) GetTotalToolCallsByExperiment(ctx context.Context, experimentI
 	return total_tool_calls, err
 }
 
+const listCommandRuns = `-- name: ListCommandRuns :many
+SELECT c.session_id, s.project_id, c.command, c.exit_code
+FROM session_commands c
+JOIN sessions s ON s.id = c.session_id
+WHERE s.created_at >= ?
+    AND s.project_id LIKE ? ESCAPE '\'
+ORDER BY c.session_id, c.id
+`
+
+type ListCommandRunsParams struct {
+	Since     string `json:"since"`
+	ProjectID string `json:"project_id"`
+}
+
+type ListCommandRunsRow struct {
+	SessionID string        `json:"session_id"`
+	ProjectID string        `json:"project_id"`
+	Command   string        `json:"command"`
+	ExitCode  sql.NullInt64 `json:"exit_code"`
+}
+
+func (q *Queries) ListCommandRuns(ctx context.Context, arg ListCommandRunsParams) ([]ListCommandRunsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCommandRuns, arg.Since, arg.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCommandRunsRow{}
+	for rows.Next() {
+		var i ListCommandRunsRow
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.ProjectID,
+			&i.Command,
+			&i.ExitCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFileTouches = `-- name: ListFileTouches :many
+SELECT f.session_id, s.project_id, s.experiment_id, s.is_excluded, f.file_path, f.operation, f.operation_count, m.cost_estimate_usd
+FROM session_files f
+JOIN sessions s ON s.id = f.session_id
+LEFT JOIN session_metrics m ON m.session_id = f.session_id
+WHERE s.created_at >= ?
+    AND s.project_id LIKE ? ESCAPE '\'
+    AND IFNULL(s.experiment_id, '') LIKE ? ESCAPE '\'
+    AND (CAST(? AS INTEGER) = 0 OR s.is_excluded = 0)
+ORDER BY f.session_id, f.file_path
+`
+
+type ListFileTouchesParams struct {
+	Since        string `json:"since"`
+	ProjectID    string `json:"project_id"`
+	ExperimentID string `json:"experiment_id"`
+	SkipExcluded int64  `json:"skip_excluded"`
+}
+
+type ListFileTouchesRow struct {
+	SessionID       string          `json:"session_id"`
+	ProjectID       string          `json:"project_id"`
+	ExperimentID    sql.NullString  `json:"experiment_id"`
+	IsExcluded      int64           `json:"is_excluded"`
+	FilePath        string          `json:"file_path"`
+	Operation       string          `json:"operation"`
+	OperationCount  int64           `json:"operation_count"`
+	CostEstimateUsd sql.NullFloat64 `json:"cost_estimate_usd"`
+}
+
+func (q *Queries) ListFileTouches(ctx context.Context, arg ListFileTouchesParams) ([]ListFileTouchesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFileTouches,
+		arg.Since,
+		arg.ProjectID,
+		arg.ExperimentID,
+		arg.SkipExcluded,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListFileTouchesRow{}
+	for rows.Next() {
+		var i ListFileTouchesRow
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.ProjectID,
+			&i.ExperimentID,
+			&i.IsExcluded,
+			&i.FilePath,
+			&i.Operation,
+			&i.OperationCount,
+			&i.CostEstimateUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSessionCommandsBySessionID = `-- name: ListSessionCommandsBySessionID :many
 SELECT id, session_id, command, exit_code, executed_at FROM session_commands WHERE session_id = ? ORDER BY id ASC
 `
@@ -722,6 +1190,38 @@ func (q *Queries) ListSessionCommandsBySessionID(ctx context.Context, sessionID
 	return items, nil
 }
 
+const listSessionFileChangesBySessionID = `-- name: ListSessionFileChangesBySessionID :many
+SELECT session_id, file_path, lines_added, lines_removed FROM session_file_changes WHERE session_id = ? ORDER BY lines_added + lines_removed DESC, file_path ASC
+`
+
+func (q *Queries) ListSessionFileChangesBySessionID(ctx context.Context, sessionID string) ([]SessionFileChange, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionFileChangesBySessionID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SessionFileChange{}
+	for rows.Next() {
+		var i SessionFileChange
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.FilePath,
+			&i.LinesAdded,
+			&i.LinesRemoved,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSessionFilesBySessionID = `-- name: ListSessionFilesBySessionID :many
 SELECT id, session_id, file_path, operation, operation_count FROM session_files WHERE session_id = ? ORDER BY operation_count DESC
 `
@@ -755,8 +1255,139 @@ func (q *Queries) ListSessionFilesBySessionID(ctx context.Context, sessionID str
 	return items, nil
 }
 
+const listSessionSamplesByExperiment = `-- name: ListSessionSamplesByExperiment :many
+SELECT
+    s.id,
+    s.created_at,
+    s.duration_seconds,
+    COALESCE(m.turn_count, 0) as turn_count,
+    COALESCE(m.token_input + m.token_output, 0) as total_tokens,
+    COALESCE(m.cost_estimate_usd, 0) as cost_usd,
+    COALESCE(m.error_count, 0) as error_count,
+    (SELECT COALESCE(SUM(st.invocation_count), 0) FROM session_tools st WHERE st.session_id = s.id) as tool_calls
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.experiment_id = ? AND s.is_excluded = 0
+ORDER BY s.created_at ASC
+`
+
+type ListSessionSamplesByExperimentRow struct {
+	ID              string        `json:"id"`
+	CreatedAt       string        `json:"created_at"`
+	DurationSeconds sql.NullInt64 `json:"duration_seconds"`
+	TurnCount       int64         `json:"turn_count"`
+	TotalTokens     interface{}   `json:"total_tokens"`
+	CostUsd         float64       `json:"cost_usd"`
+	ErrorCount      int64         `json:"error_count"`
+	ToolCalls       interface{}   `json:"tool_calls"`
+}
+
+func (q *Queries) ListSessionSamplesByExperiment(ctx context.Context, experimentID sql.NullString) ([]ListSessionSamplesByExperimentRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionSamplesByExperiment, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSessionSamplesByExperimentRow{}
+	for rows.Next() {
+		var i ListSessionSamplesByExperimentRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.DurationSeconds,
+			&i.TurnCount,
+			&i.TotalTokens,
+			&i.CostUsd,
+			&i.ErrorCount,
+			&i.ToolCalls,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionSamplesByProject = `-- name: ListSessionSamplesByProject :many
+SELECT
+    s.id,
+    s.created_at,
+    s.duration_seconds,
+    COALESCE(m.turn_count, 0) as turn_count,
+    COALESCE(m.token_input + m.token_output, 0) as total_tokens,
+    COALESCE(m.cost_estimate_usd, 0) as cost_usd,
+    COALESCE(m.error_count, 0) as error_count,
+    (SELECT COALESCE(SUM(st.invocation_count), 0) FROM session_tools st WHERE st.session_id = s.id) as tool_calls
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.project_id = ? AND s.id != ? AND s.created_at <= ? AND s.is_excluded = 0
+ORDER BY s.created_at DESC
+LIMIT ?
+`
+
+type ListSessionSamplesByProjectParams struct {
+	ProjectID string `json:"project_id"`
+	ID        string `json:"id"`
+	CreatedAt string `json:"created_at"`
+	Limit     int64  `json:"limit"`
+}
+
+type ListSessionSamplesByProjectRow struct {
+	ID              string        `json:"id"`
+	CreatedAt       string        `json:"created_at"`
+	DurationSeconds sql.NullInt64 `json:"duration_seconds"`
+	TurnCount       int64         `json:"turn_count"`
+	TotalTokens     interface{}   `json:"total_tokens"`
+	CostUsd         float64       `json:"cost_usd"`
+	ErrorCount      int64         `json:"error_count"`
+	ToolCalls       interface{}   `json:"tool_calls"`
+}
+
+func (q *Queries) ListSessionSamplesByProject(ctx context.Context, arg ListSessionSamplesByProjectParams) ([]ListSessionSamplesByProjectRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionSamplesByProject,
+		arg.ProjectID,
+		arg.ID,
+		arg.CreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSessionSamplesByProjectRow{}
+	for rows.Next() {
+		var i ListSessionSamplesByProjectRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.DurationSeconds,
+			&i.TurnCount,
+			&i.TotalTokens,
+			&i.CostUsd,
+			&i.ErrorCount,
+			&i.ToolCalls,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSessionSubagentsBySessionID = `-- name: ListSessionSubagentsBySessionID :many
-SELECT id, session_id, agent_type, agent_kind, description, model, total_tokens, token_input, token_output, token_cache_read, token_cache_write, total_duration_ms, tool_use_count, cost_estimate_usd FROM session_subagents WHERE session_id = ? ORDER BY id ASC
+SELECT id, session_id, agent_type, agent_kind, description, model, total_tokens, token_input, token_output, token_cache_read, token_cache_write, total_duration_ms, tool_use_count, cost_estimate_usd, token_cache_write_5m, token_cache_write_1h FROM session_subagents WHERE session_id = ? ORDER BY id ASC
 `
 
 func (q *Queries) ListSessionSubagentsBySessionID(ctx context.Context, sessionID string) ([]SessionSubagent, error) {
@@ -783,6 +1414,74 @@ func (q *Queries) ListSessionSubagentsBySessionID(ctx context.Context, sessionID
 			&i.TotalDurationMs,
 			&i.ToolUseCount,
 			&i.CostEstimateUsd,
+			&i.TokenCacheWrite5m,
+			&i.TokenCacheWrite1h,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionToolCalls = `-- name: ListSessionToolCalls :many
+SELECT c.session_id, s.experiment_id, s.is_excluded, c.sequence, c.tool_name, c.label, c.is_error, c.result_bytes
+FROM session_tool_calls c
+JOIN sessions s ON s.id = c.session_id
+WHERE s.created_at >= ?
+    AND s.project_id LIKE ? ESCAPE '\'
+    AND IFNULL(s.experiment_id, '') LIKE ? ESCAPE '\'
+    AND (CAST(? AS INTEGER) = 0 OR s.is_excluded = 0)
+ORDER BY c.session_id, c.sequence
+`
+
+type ListSessionToolCallsParams struct {
+	Since        string `json:"since"`
+	ProjectID    string `json:"project_id"`
+	ExperimentID string `json:"experiment_id"`
+	SkipExcluded int64  `json:"skip_excluded"`
+}
+
+type ListSessionToolCallsRow struct {
+	SessionID    string         `json:"session_id"`
+	ExperimentID sql.NullString `json:"experiment_id"`
+	IsExcluded   int64          `json:"is_excluded"`
+	Sequence     int64          `json:"sequence"`
+	ToolName     string         `json:"tool_name"`
+	Label        string         `json:"label"`
+	IsError      int64          `json:"is_error"`
+	ResultBytes  int64          `json:"result_bytes"`
+}
+
+func (q *Queries) ListSessionToolCalls(ctx context.Context, arg ListSessionToolCallsParams) ([]ListSessionToolCallsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionToolCalls,
+		arg.Since,
+		arg.ProjectID,
+		arg.ExperimentID,
+		arg.SkipExcluded,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSessionToolCallsRow{}
+	for rows.Next() {
+		var i ListSessionToolCallsRow
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.ExperimentID,
+			&i.IsExcluded,
+			&i.Sequence,
+			&i.ToolName,
+			&i.Label,
+			&i.IsError,
+			&i.ResultBytes,
 		); err != nil {
 			return nil, err
 		}
@@ -830,3 +1529,146 @@ func (q *Queries) ListSessionToolsBySessionID(ctx context.Context, sessionID str
 	}
 	return items, nil
 }
+
+const listSessionUsageBetween = `-- name: ListSessionUsageBetween :many
+SELECT
+    s.id,
+    s.project_id,
+    s.experiment_id,
+    s.created_at,
+    m.model_id,
+    m.token_input,
+    m.token_output,
+    m.token_cache_read,
+    m.token_cache_write,
+    m.token_cache_write_1h,
+    m.web_search_requests,
+    m.web_fetch_requests,
+    m.cost_estimate_usd
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.created_at >= ? AND s.created_at < ?
+ORDER BY s.created_at ASC
+`
+
+type ListSessionUsageBetweenParams struct {
+	CreatedAt   string `json:"created_at"`
+	CreatedAt_2 string `json:"created_at_2"`
+}
+
+type ListSessionUsageBetweenRow struct {
+	ID                string          `json:"id"`
+	ProjectID         string          `json:"project_id"`
+	ExperimentID      sql.NullString  `json:"experiment_id"`
+	CreatedAt         string          `json:"created_at"`
+	ModelID           sql.NullString  `json:"model_id"`
+	TokenInput        sql.NullInt64   `json:"token_input"`
+	TokenOutput       sql.NullInt64   `json:"token_output"`
+	TokenCacheRead    sql.NullInt64   `json:"token_cache_read"`
+	TokenCacheWrite   sql.NullInt64   `json:"token_cache_write"`
+	TokenCacheWrite1h sql.NullInt64   `json:"token_cache_write_1h"`
+	WebSearchRequests sql.NullInt64   `json:"web_search_requests"`
+	WebFetchRequests  sql.NullInt64   `json:"web_fetch_requests"`
+	CostEstimateUsd   sql.NullFloat64 `json:"cost_estimate_usd"`
+}
+
+func (q *Queries) ListSessionUsageBetween(ctx context.Context, arg ListSessionUsageBetweenParams) ([]ListSessionUsageBetweenRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionUsageBetween, arg.CreatedAt, arg.CreatedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSessionUsageBetweenRow{}
+	for rows.Next() {
+		var i ListSessionUsageBetweenRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.ExperimentID,
+			&i.CreatedAt,
+			&i.ModelID,
+			&i.TokenInput,
+			&i.TokenOutput,
+			&i.TokenCacheRead,
+			&i.TokenCacheWrite,
+			&i.TokenCacheWrite1h,
+			&i.WebSearchRequests,
+			&i.WebFetchRequests,
+			&i.CostEstimateUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubagentUsageBetween = `-- name: ListSubagentUsageBetween :many
+SELECT
+    sa.session_id,
+    sa.model,
+    sa.token_input,
+    sa.token_output,
+    sa.token_cache_read,
+    sa.token_cache_write,
+    sa.token_cache_write_1h,
+    sa.cost_estimate_usd
+FROM session_subagents sa
+JOIN sessions s ON s.id = sa.session_id
+WHERE s.created_at >= ? AND s.created_at < ?
+ORDER BY sa.id ASC
+`
+
+type ListSubagentUsageBetweenParams struct {
+	CreatedAt   string `json:"created_at"`
+	CreatedAt_2 string `json:"created_at_2"`
+}
+
+type ListSubagentUsageBetweenRow struct {
+	SessionID         string          `json:"session_id"`
+	Model             sql.NullString  `json:"model"`
+	TokenInput        int64           `json:"token_input"`
+	TokenOutput       int64           `json:"token_output"`
+	TokenCacheRead    int64           `json:"token_cache_read"`
+	TokenCacheWrite   int64           `json:"token_cache_write"`
+	TokenCacheWrite1h int64           `json:"token_cache_write_1h"`
+	CostEstimateUsd   sql.NullFloat64 `json:"cost_estimate_usd"`
+}
+
+func (q *Queries) ListSubagentUsageBetween(ctx context.Context, arg ListSubagentUsageBetweenParams) ([]ListSubagentUsageBetweenRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSubagentUsageBetween, arg.CreatedAt, arg.CreatedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSubagentUsageBetweenRow{}
+	for rows.Next() {
+		var i ListSubagentUsageBetweenRow
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.Model,
+			&i.TokenInput,
+			&i.TokenOutput,
+			&i.TokenCacheRead,
+			&i.TokenCacheWrite,
+			&i.TokenCacheWrite1h,
+			&i.CostEstimateUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
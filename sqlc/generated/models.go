@@ -8,18 +8,75 @@ import (
 	"database/sql"
 )
 
+type AlertFiring struct {
+	Rule      string  `json:"rule"`
+	AlertKey  string  `json:"alert_key"`
+	Scope     string  `json:"scope"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Message   string  `json:"message"`
+	FiredAt   string  `json:"fired_at"`
+}
+
+type BillingUsage struct {
+	UsageDate         string          `json:"usage_date"`
+	Model             string          `json:"model"`
+	Workspace         string          `json:"workspace"`
+	ApiKey            string          `json:"api_key"`
+	TokenInput        int64           `json:"token_input"`
+	TokenOutput       int64           `json:"token_output"`
+	TokenCacheRead    int64           `json:"token_cache_read"`
+	TokenCacheWrite   int64           `json:"token_cache_write"`
+	TokenCacheWrite1h int64           `json:"token_cache_write_1h"`
+	CostUsd           sql.NullFloat64 `json:"cost_usd"`
+	ImportedAt        string          `json:"imported_at"`
+}
+
+type Budget struct {
+	ID           string         `json:"id"`
+	Period       string         `json:"period"`
+	AmountUsd    float64        `json:"amount_usd"`
+	ProjectID    sql.NullString `json:"project_id"`
+	ExperimentID sql.NullString `json:"experiment_id"`
+	IsHard       int64          `json:"is_hard"`
+	CreatedAt    string         `json:"created_at"`
+}
+
 type Experiment struct {
-	ID          string         `json:"id"`
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Description    sql.NullString `json:"description"`
+	Hypothesis     sql.NullString `json:"hypothesis"`
+	StartedAt      string         `json:"started_at"`
+	EndedAt        sql.NullString `json:"ended_at"`
+	IsActive       int64          `json:"is_active"`
+	CreatedAt      string         `json:"created_at"`
+	ModelID        sql.NullString `json:"model_id"`
+	PlanType       sql.NullString `json:"plan_type"`
+	Notes          sql.NullString `json:"notes"`
+	ScheduledStart sql.NullString `json:"scheduled_start"`
+	ScheduledEnd   sql.NullString `json:"scheduled_end"`
+	Verdict        sql.NullString `json:"verdict"`
+	Conclusion     sql.NullString `json:"conclusion"`
+	ConcludedAt    sql.NullString `json:"concluded_at"`
+}
+
+type ExperimentNote struct {
+	ID           int64  `json:"id"`
+	ExperimentID string `json:"experiment_id"`
+	Content      string `json:"content"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type ExperimentTemplate struct {
 	Name        string         `json:"name"`
 	Description sql.NullString `json:"description"`
 	Hypothesis  sql.NullString `json:"hypothesis"`
-	StartedAt   string         `json:"started_at"`
-	EndedAt     sql.NullString `json:"ended_at"`
-	IsActive    int64          `json:"is_active"`
-	CreatedAt   string         `json:"created_at"`
 	ModelID     sql.NullString `json:"model_id"`
 	PlanType    sql.NullString `json:"plan_type"`
 	Notes       sql.NullString `json:"notes"`
+	Variables   string         `json:"variables"`
+	CreatedAt   string         `json:"created_at"`
 }
 
 type ExperimentVariable struct {
@@ -49,20 +106,47 @@ type ModelPricing struct {
 	LongContextInputPerMillion  sql.NullFloat64 `json:"long_context_input_per_million"`
 	LongContextOutputPerMillion sql.NullFloat64 `json:"long_context_output_per_million"`
 	LongContextThreshold        sql.NullInt64   `json:"long_context_threshold"`
+	WebSearchPerThousand        sql.NullFloat64 `json:"web_search_per_thousand"`
+	WebFetchPerThousand         sql.NullFloat64 `json:"web_fetch_per_thousand"`
+	CacheWrite1hPerMillion      sql.NullFloat64 `json:"cache_write_1h_per_million"`
 }
 
 type PlanConfig struct {
-	ID                      int64           `json:"id"`
-	PlanType                string          `json:"plan_type"`
-	WindowHours             int64           `json:"window_hours"`
-	LearnedTokenLimit       sql.NullFloat64 `json:"learned_token_limit"`
-	LearnedAt               sql.NullString  `json:"learned_at"`
-	CreatedAt               string          `json:"created_at"`
-	UpdatedAt               string          `json:"updated_at"`
-	WindowStartTime         sql.NullString  `json:"window_start_time"`
-	WeeklyWindowStartTime   sql.NullString  `json:"weekly_window_start_time"`
-	WeeklyLearnedTokenLimit sql.NullFloat64 `json:"weekly_learned_token_limit"`
-	WeeklyLearnedAt         sql.NullString  `json:"weekly_learned_at"`
+	ID            int64          `json:"id"`
+	PlanType      string         `json:"plan_type"`
+	WeeklyResetAt sql.NullString `json:"weekly_reset_at"`
+	CreatedAt     string         `json:"created_at"`
+	UpdatedAt     string         `json:"updated_at"`
+}
+
+type PlanPrice struct {
+	EffectiveFrom string  `json:"effective_from"`
+	PlanType      string  `json:"plan_type"`
+	MonthlyUsd    float64 `json:"monthly_usd"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+type PricingProfile struct {
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	Multiplier  float64        `json:"multiplier"`
+	IsDefault   int64          `json:"is_default"`
+	CreatedAt   string         `json:"created_at"`
+}
+
+type PricingProfileRate struct {
+	ProfileName                 string          `json:"profile_name"`
+	ModelID                     string          `json:"model_id"`
+	InputPerMillion             float64         `json:"input_per_million"`
+	OutputPerMillion            float64         `json:"output_per_million"`
+	CacheReadPerMillion         sql.NullFloat64 `json:"cache_read_per_million"`
+	CacheWritePerMillion        sql.NullFloat64 `json:"cache_write_per_million"`
+	LongContextInputPerMillion  sql.NullFloat64 `json:"long_context_input_per_million"`
+	LongContextOutputPerMillion sql.NullFloat64 `json:"long_context_output_per_million"`
+	LongContextThreshold        sql.NullInt64   `json:"long_context_threshold"`
+	WebSearchPerThousand        sql.NullFloat64 `json:"web_search_per_thousand"`
+	WebFetchPerThousand         sql.NullFloat64 `json:"web_fetch_per_thousand"`
+	CacheWrite1hPerMillion      sql.NullFloat64 `json:"cache_write_1h_per_million"`
 }
 
 type Project struct {
@@ -72,6 +156,34 @@ type Project struct {
 	CreatedAt string `json:"created_at"`
 }
 
+type ProjectPricingProfile struct {
+	ProjectID   string `json:"project_id"`
+	ProfileName string `json:"profile_name"`
+}
+
+type RateLimitHit struct {
+	ID         int64          `json:"id"`
+	SessionID  string         `json:"session_id"`
+	HitAt      string         `json:"hit_at"`
+	WindowType string         `json:"window_type"`
+	PlanType   sql.NullString `json:"plan_type"`
+}
+
+type RotationGroup struct {
+	ID              string         `json:"id"`
+	Name            string         `json:"name"`
+	IntervalSeconds int64          `json:"interval_seconds"`
+	AnchorAt        string         `json:"anchor_at"`
+	EndsAt          sql.NullString `json:"ends_at"`
+	CreatedAt       string         `json:"created_at"`
+}
+
+type RotationGroupMember struct {
+	GroupID      string `json:"group_id"`
+	ExperimentID string `json:"experiment_id"`
+	Position     int64  `json:"position"`
+}
+
 type Session struct {
 	ID                   string         `json:"id"`
 	ProjectID            string         `json:"project_id"`
@@ -85,6 +197,22 @@ type Session struct {
 	EndedAt              sql.NullString `json:"ended_at"`
 	DurationSeconds      sql.NullInt64  `json:"duration_seconds"`
 	CreatedAt            string         `json:"created_at"`
+	IsExcluded           int64          `json:"is_excluded"`
+	ExclusionReason      sql.NullString `json:"exclusion_reason"`
+	GitRepoRoot          sql.NullString `json:"git_repo_root"`
+	GitRemoteUrl         sql.NullString `json:"git_remote_url"`
+	GitBranch            sql.NullString `json:"git_branch"`
+	GitStartCommit       sql.NullString `json:"git_start_commit"`
+	GitEndCommit         sql.NullString `json:"git_end_commit"`
+	GitStartDirty        sql.NullInt64  `json:"git_start_dirty"`
+	GitEndDirty          sql.NullInt64  `json:"git_end_dirty"`
+}
+
+type SessionAnomaly struct {
+	SessionID  string  `json:"session_id"`
+	Score      float64 `json:"score"`
+	Reasons    string  `json:"reasons"`
+	DetectedAt string  `json:"detected_at"`
 }
 
 type SessionCommand struct {
@@ -95,6 +223,18 @@ type SessionCommand struct {
 	ExecutedAt sql.NullString `json:"executed_at"`
 }
 
+type SessionCommit struct {
+	SessionID    string         `json:"session_id"`
+	CommitHash   string         `json:"commit_hash"`
+	Message      string         `json:"message"`
+	Author       sql.NullString `json:"author"`
+	CommittedAt  string         `json:"committed_at"`
+	FilesChanged int64          `json:"files_changed"`
+	LinesAdded   int64          `json:"lines_added"`
+	LinesRemoved int64          `json:"lines_removed"`
+	DetectedBy   string         `json:"detected_by"`
+}
+
 type SessionFile struct {
 	ID             int64  `json:"id"`
 	SessionID      string `json:"session_id"`
@@ -103,6 +243,23 @@ type SessionFile struct {
 	OperationCount int64  `json:"operation_count"`
 }
 
+type SessionFileChange struct {
+	SessionID    string `json:"session_id"`
+	FilePath     string `json:"file_path"`
+	LinesAdded   int64  `json:"lines_added"`
+	LinesRemoved int64  `json:"lines_removed"`
+}
+
+type SessionGitStart struct {
+	SessionID  string `json:"session_id"`
+	RepoRoot   string `json:"repo_root"`
+	RemoteUrl  string `json:"remote_url"`
+	Branch     string `json:"branch"`
+	CommitHash string `json:"commit_hash"`
+	Dirty      int64  `json:"dirty"`
+	CapturedAt string `json:"captured_at"`
+}
+
 type SessionMetric struct {
 	SessionID             string          `json:"session_id"`
 	MessageCountUser      int64           `json:"message_count_user"`
@@ -119,23 +276,38 @@ type SessionMetric struct {
 	OutputRate            sql.NullFloat64 `json:"output_rate"`
 	CacheReadRate         sql.NullFloat64 `json:"cache_read_rate"`
 	CacheWriteRate        sql.NullFloat64 `json:"cache_write_rate"`
+	WebSearchRequests     int64           `json:"web_search_requests"`
+	WebFetchRequests      int64           `json:"web_fetch_requests"`
+	ServerToolCostUsd     sql.NullFloat64 `json:"server_tool_cost_usd"`
+	TokenCacheWrite5m     int64           `json:"token_cache_write_5m"`
+	TokenCacheWrite1h     int64           `json:"token_cache_write_1h"`
+	CacheWrite1hRate      sql.NullFloat64 `json:"cache_write_1h_rate"`
+	LinesAdded            int64           `json:"lines_added"`
+	LinesRemoved          int64           `json:"lines_removed"`
+	LinesSource           sql.NullString  `json:"lines_source"`
+	TestsPassed           sql.NullInt64   `json:"tests_passed"`
+	BuildPassed           sql.NullInt64   `json:"build_passed"`
+	LintPassed            sql.NullInt64   `json:"lint_passed"`
+	EndedGreen            sql.NullInt64   `json:"ended_green"`
 }
 
 type SessionSubagent struct {
-	ID              int64           `json:"id"`
-	SessionID       string          `json:"session_id"`
-	AgentType       string          `json:"agent_type"`
-	AgentKind       string          `json:"agent_kind"`
-	Description     sql.NullString  `json:"description"`
-	Model           sql.NullString  `json:"model"`
-	TotalTokens     int64           `json:"total_tokens"`
-	TokenInput      int64           `json:"token_input"`
-	TokenOutput     int64           `json:"token_output"`
-	TokenCacheRead  int64           `json:"token_cache_read"`
-	TokenCacheWrite int64           `json:"token_cache_write"`
-	TotalDurationMs sql.NullInt64   `json:"total_duration_ms"`
-	ToolUseCount    int64           `json:"tool_use_count"`
-	CostEstimateUsd sql.NullFloat64 `json:"cost_estimate_usd"`
+	ID                int64           `json:"id"`
+	SessionID         string          `json:"session_id"`
+	AgentType         string          `json:"agent_type"`
+	AgentKind         string          `json:"agent_kind"`
+	Description       sql.NullString  `json:"description"`
+	Model             sql.NullString  `json:"model"`
+	TotalTokens       int64           `json:"total_tokens"`
+	TokenInput        int64           `json:"token_input"`
+	TokenOutput       int64           `json:"token_output"`
+	TokenCacheRead    int64           `json:"token_cache_read"`
+	TokenCacheWrite   int64           `json:"token_cache_write"`
+	TotalDurationMs   sql.NullInt64   `json:"total_duration_ms"`
+	ToolUseCount      int64           `json:"tool_use_count"`
+	CostEstimateUsd   sql.NullFloat64 `json:"cost_estimate_usd"`
+	TokenCacheWrite5m int64           `json:"token_cache_write_5m"`
+	TokenCacheWrite1h int64           `json:"token_cache_write_1h"`
 }
 
 type SessionTool struct {
@@ -147,6 +319,15 @@ type SessionTool struct {
 	ErrorCount      int64         `json:"error_count"`
 }
 
+type SessionToolCall struct {
+	SessionID   string `json:"session_id"`
+	Sequence    int64  `json:"sequence"`
+	ToolName    string `json:"tool_name"`
+	Label       string `json:"label"`
+	IsError     int64  `json:"is_error"`
+	ResultBytes int64  `json:"result_bytes"`
+}
+
 type ToolEvent struct {
 	ID           int64          `json:"id"`
 	SessionID    string         `json:"session_id"`
@@ -157,20 +338,13 @@ type ToolEvent struct {
 	CapturedAt   string         `json:"captured_at"`
 }
 
-type UsageLimit struct {
-	ID            string          `json:"id"`
-	LimitValue    float64         `json:"limit_value"`
-	WarnThreshold sql.NullFloat64 `json:"warn_threshold"`
-	Enabled       int64           `json:"enabled"`
-	CreatedAt     string          `json:"created_at"`
-	UpdatedAt     string          `json:"updated_at"`
-}
-
-type UsageMetric struct {
-	ID         int64          `json:"id"`
-	MetricName string         `json:"metric_name"`
-	Value      float64        `json:"value"`
-	Attributes sql.NullString `json:"attributes"`
-	RecordedAt string         `json:"recorded_at"`
-	CreatedAt  string         `json:"created_at"`
+type UsageRequest struct {
+	ID              int64          `json:"id"`
+	SessionID       string         `json:"session_id"`
+	Timestamp       string         `json:"timestamp"`
+	Model           sql.NullString `json:"model"`
+	TokenInput      int64          `json:"token_input"`
+	TokenOutput     int64          `json:"token_output"`
+	TokenCacheRead  int64          `json:"token_cache_read"`
+	TokenCacheWrite int64          `json:"token_cache_write"`
 }
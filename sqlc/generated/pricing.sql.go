@@ -11,8 +11,8 @@ import (
 )
 
 const createModelPricing = `-- name: CreateModelPricing :exec
-INSERT INTO model_pricing (id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, long_context_input_per_million, long_context_output_per_million, long_context_threshold, is_default, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO model_pricing (id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, long_context_input_per_million, long_context_output_per_million, long_context_threshold, web_search_per_thousand, web_fetch_per_thousand, cache_write_1h_per_million, is_default, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateModelPricingParams struct {
@@ -25,6 +25,9 @@ type CreateModelPricingParams struct {
 	LongContextInputPerMillion  sql.NullFloat64 `json:"long_context_input_per_million"`
 	LongContextOutputPerMillion sql.NullFloat64 `json:"long_context_output_per_million"`
 	LongContextThreshold        sql.NullInt64   `json:"long_context_threshold"`
+	WebSearchPerThousand        sql.NullFloat64 `json:"web_search_per_thousand"`
+	WebFetchPerThousand         sql.NullFloat64 `json:"web_fetch_per_thousand"`
+	CacheWrite1hPerMillion      sql.NullFloat64 `json:"cache_write_1h_per_million"`
 	IsDefault                   int64           `json:"is_default"`
 	CreatedAt                   string          `json:"created_at"`
 }
@@ -40,6 +43,9 @@ func (q *Queries) CreateModelPricing(ctx context.Context, arg CreateModelPricing
 		arg.LongContextInputPerMillion,
 		arg.LongContextOutputPerMillion,
 		arg.LongContextThreshold,
+		arg.WebSearchPerThousand,
+		arg.WebFetchPerThousand,
+		arg.CacheWrite1hPerMillion,
 		arg.IsDefault,
 		arg.CreatedAt,
 	)
@@ -56,7 +62,7 @@ func (q *Queries) DeleteModelPricing(ctx context.Context, id string) error {
 }
 
 const getDefaultModelPricing = `-- name: GetDefaultModelPricing :one
-SELECT id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, is_default, created_at, long_context_input_per_million, long_context_output_per_million, long_context_threshold FROM model_pricing WHERE is_default = 1 LIMIT 1
+SELECT id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, is_default, created_at, long_context_input_per_million, long_context_output_per_million, long_context_threshold, web_search_per_thousand, web_fetch_per_thousand, cache_write_1h_per_million FROM model_pricing WHERE is_default = 1 LIMIT 1
 `
 
 func (q *Queries) GetDefaultModelPricing(ctx context.Context) (ModelPricing, error) {
@@ -74,12 +80,15 @@ func (q *Queries) GetDefaultModelPricing(ctx context.Context) (ModelPricing, err
 		&i.LongContextInputPerMillion,
 		&i.LongContextOutputPerMillion,
 		&i.LongContextThreshold,
+		&i.WebSearchPerThousand,
+		&i.WebFetchPerThousand,
+		&i.CacheWrite1hPerMillion,
 	)
 	return i, err
 }
 
 const getModelPricingByID = `-- name: GetModelPricingByID :one
-SELECT id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, is_default, created_at, long_context_input_per_million, long_context_output_per_million, long_context_threshold FROM model_pricing WHERE id = ?
+SELECT id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, is_default, created_at, long_context_input_per_million, long_context_output_per_million, long_context_threshold, web_search_per_thousand, web_fetch_per_thousand, cache_write_1h_per_million FROM model_pricing WHERE id = ?
 `
 
 func (q *Queries) GetModelPricingByID(ctx context.Context, id string) (ModelPricing, error) {
@@ -97,12 +106,15 @@ func (q *Queries) GetModelPricingByID(ctx context.Context, id string) (ModelPric
 		&i.LongContextInputPerMillion,
 		&i.LongContextOutputPerMillion,
 		&i.LongContextThreshold,
+		&i.WebSearchPerThousand,
+		&i.WebFetchPerThousand,
+		&i.CacheWrite1hPerMillion,
 	)
 	return i, err
 }
 
 const listModelPricing = `-- name: ListModelPricing :many
-SELECT id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, is_default, created_at, long_context_input_per_million, long_context_output_per_million, long_context_threshold FROM model_pricing ORDER BY display_name ASC
+SELECT id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, is_default, created_at, long_context_input_per_million, long_context_output_per_million, long_context_threshold, web_search_per_thousand, web_fetch_per_thousand, cache_write_1h_per_million FROM model_pricing ORDER BY display_name ASC
 `
 
 func (q *Queries) ListModelPricing(ctx context.Context) ([]ModelPricing, error) {
@@ -126,6 +138,9 @@ func (q *Queries) ListModelPricing(ctx context.Context) ([]ModelPricing, error)
 			&i.LongContextInputPerMillion,
 			&i.LongContextOutputPerMillion,
 			&i.LongContextThreshold,
+			&i.WebSearchPerThousand,
+			&i.WebFetchPerThousand,
+			&i.CacheWrite1hPerMillion,
 		); err != nil {
 			return nil, err
 		}
@@ -151,7 +166,7 @@ func (q *Queries) SetDefaultModelPricing(ctx context.Context, id string) error {
 
 const updateModelPricing = `-- name: UpdateModelPricing :exec
 UPDATE model_pricing
-SET display_name = ?, input_per_million = ?, output_per_million = ?, cache_read_per_million = ?, cache_write_per_million = ?, long_context_input_per_million = ?, long_context_output_per_million = ?, long_context_threshold = ?, is_default = ?
+SET display_name = ?, input_per_million = ?, output_per_million = ?, cache_read_per_million = ?, cache_write_per_million = ?, long_context_input_per_million = ?, long_context_output_per_million = ?, long_context_threshold = ?, web_search_per_thousand = ?, web_fetch_per_thousand = ?, cache_write_1h_per_million = ?, is_default = ?
 WHERE id = ?
 `
 
@@ -164,6 +179,9 @@ type UpdateModelPricingParams struct {
 	LongContextInputPerMillion  sql.NullFloat64 `json:"long_context_input_per_million"`
 	LongContextOutputPerMillion sql.NullFloat64 `json:"long_context_output_per_million"`
 	LongContextThreshold        sql.NullInt64   `json:"long_context_threshold"`
+	WebSearchPerThousand        sql.NullFloat64 `json:"web_search_per_thousand"`
+	WebFetchPerThousand         sql.NullFloat64 `json:"web_fetch_per_thousand"`
+	CacheWrite1hPerMillion      sql.NullFloat64 `json:"cache_write_1h_per_million"`
 	IsDefault                   int64           `json:"is_default"`
 	ID                          string          `json:"id"`
 }
@@ -178,6 +196,9 @@ func (q *Queries) UpdateModelPricing(ctx context.Context, arg UpdateModelPricing
 		arg.LongContextInputPerMillion,
 		arg.LongContextOutputPerMillion,
 		arg.LongContextThreshold,
+		arg.WebSearchPerThousand,
+		arg.WebFetchPerThousand,
+		arg.CacheWrite1hPerMillion,
 		arg.IsDefault,
 		arg.ID,
 	)
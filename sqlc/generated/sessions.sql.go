@@ -11,8 +11,26 @@ import (
 )
 
 const createSession = `-- name: CreateSession :exec
-INSERT OR REPLACE INTO sessions (id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO sessions (id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at, git_repo_root, git_remote_url, git_branch, git_start_commit, git_end_commit, git_start_dirty, git_end_dirty)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+    project_id = excluded.project_id,
+    transcript_path = excluded.transcript_path,
+    transcript_stored_path = excluded.transcript_stored_path,
+    cwd = excluded.cwd,
+    permission_mode = excluded.permission_mode,
+    exit_reason = excluded.exit_reason,
+    started_at = excluded.started_at,
+    ended_at = excluded.ended_at,
+    duration_seconds = excluded.duration_seconds,
+    created_at = excluded.created_at,
+    git_repo_root = excluded.git_repo_root,
+    git_remote_url = excluded.git_remote_url,
+    git_branch = excluded.git_branch,
+    git_start_commit = excluded.git_start_commit,
+    git_end_commit = excluded.git_end_commit,
+    git_start_dirty = excluded.git_start_dirty,
+    git_end_dirty = excluded.git_end_dirty
 `
 
 type CreateSessionParams struct {
@@ -28,6 +46,13 @@ type CreateSessionParams struct {
 	EndedAt              sql.NullString `json:"ended_at"`
 	DurationSeconds      sql.NullInt64  `json:"duration_seconds"`
 	CreatedAt            string         `json:"created_at"`
+	GitRepoRoot          sql.NullString `json:"git_repo_root"`
+	GitRemoteUrl         sql.NullString `json:"git_remote_url"`
+	GitBranch            sql.NullString `json:"git_branch"`
+	GitStartCommit       sql.NullString `json:"git_start_commit"`
+	GitEndCommit         sql.NullString `json:"git_end_commit"`
+	GitStartDirty        sql.NullInt64  `json:"git_start_dirty"`
+	GitEndDirty          sql.NullInt64  `json:"git_end_dirty"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) error {
@@ -44,6 +69,13 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) er
 		arg.EndedAt,
 		arg.DurationSeconds,
 		arg.CreatedAt,
+		arg.GitRepoRoot,
+		arg.GitRemoteUrl,
+		arg.GitBranch,
+		arg.GitStartCommit,
+		arg.GitEndCommit,
+		arg.GitStartDirty,
+		arg.GitEndDirty,
 	)
 	return err
 }
@@ -57,6 +89,15 @@ func (q *Queries) DeleteSession(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteSessionGitStart = `-- name: DeleteSessionGitStart :exec
+DELETE FROM session_git_starts WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSessionGitStart(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionGitStart, sessionID)
+	return err
+}
+
 const deleteSessionsBefore = `-- name: DeleteSessionsBefore :execrows
 DELETE FROM sessions WHERE created_at < ?
 `
@@ -94,7 +135,7 @@ func (q *Queries) DeleteSessionsByProject(ctx context.Context, projectID string)
 }
 
 const getSessionByID = `-- name: GetSessionByID :one
-SELECT id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at FROM sessions WHERE id = ?
+SELECT id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at, is_excluded, exclusion_reason, git_repo_root, git_remote_url, git_branch, git_start_commit, git_end_commit, git_start_dirty, git_end_dirty FROM sessions WHERE id = ?
 `
 
 func (q *Queries) GetSessionByID(ctx context.Context, id string) (Session, error) {
@@ -113,6 +154,34 @@ func (q *Queries) GetSessionByID(ctx context.Context, id string) (Session, error
 		&i.EndedAt,
 		&i.DurationSeconds,
 		&i.CreatedAt,
+		&i.IsExcluded,
+		&i.ExclusionReason,
+		&i.GitRepoRoot,
+		&i.GitRemoteUrl,
+		&i.GitBranch,
+		&i.GitStartCommit,
+		&i.GitEndCommit,
+		&i.GitStartDirty,
+		&i.GitEndDirty,
+	)
+	return i, err
+}
+
+const getSessionGitStart = `-- name: GetSessionGitStart :one
+SELECT session_id, repo_root, remote_url, branch, commit_hash, dirty, captured_at FROM session_git_starts WHERE session_id = ?
+`
+
+func (q *Queries) GetSessionGitStart(ctx context.Context, sessionID string) (SessionGitStart, error) {
+	row := q.db.QueryRowContext(ctx, getSessionGitStart, sessionID)
+	var i SessionGitStart
+	err := row.Scan(
+		&i.SessionID,
+		&i.RepoRoot,
+		&i.RemoteUrl,
+		&i.Branch,
+		&i.CommitHash,
+		&i.Dirty,
+		&i.CapturedAt,
 	)
 	return i, err
 }
@@ -245,8 +314,242 @@ func (q *Queries) GetSessionTranscriptPathsByProject(ctx context.Context, projec
 	return items, nil
 }
 
+const insertSessionGitStart = `-- name: InsertSessionGitStart :exec
+INSERT OR IGNORE INTO session_git_starts (session_id, repo_root, remote_url, branch, commit_hash, dirty, captured_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type InsertSessionGitStartParams struct {
+	SessionID  string `json:"session_id"`
+	RepoRoot   string `json:"repo_root"`
+	RemoteUrl  string `json:"remote_url"`
+	Branch     string `json:"branch"`
+	CommitHash string `json:"commit_hash"`
+	Dirty      int64  `json:"dirty"`
+	CapturedAt string `json:"captured_at"`
+}
+
+func (q *Queries) InsertSessionGitStart(ctx context.Context, arg InsertSessionGitStartParams) error {
+	_, err := q.db.ExecContext(ctx, insertSessionGitStart,
+		arg.SessionID,
+		arg.RepoRoot,
+		arg.RemoteUrl,
+		arg.Branch,
+		arg.CommitHash,
+		arg.Dirty,
+		arg.CapturedAt,
+	)
+	return err
+}
+
+const listExcludedSessions = `-- name: ListExcludedSessions :many
+SELECT id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at, is_excluded, exclusion_reason, git_repo_root, git_remote_url, git_branch, git_start_commit, git_end_commit, git_start_dirty, git_end_dirty FROM sessions
+WHERE is_excluded = 1
+ORDER BY created_at DESC
+LIMIT ?
+`
+
+func (q *Queries) ListExcludedSessions(ctx context.Context, limit int64) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, listExcludedSessions, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.ExperimentID,
+			&i.TranscriptPath,
+			&i.TranscriptStoredPath,
+			&i.Cwd,
+			&i.PermissionMode,
+			&i.ExitReason,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.DurationSeconds,
+			&i.CreatedAt,
+			&i.IsExcluded,
+			&i.ExclusionReason,
+			&i.GitRepoRoot,
+			&i.GitRemoteUrl,
+			&i.GitBranch,
+			&i.GitStartCommit,
+			&i.GitEndCommit,
+			&i.GitStartDirty,
+			&i.GitEndDirty,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionGitBranches = `-- name: ListSessionGitBranches :many
+SELECT DISTINCT git_branch FROM sessions
+WHERE git_branch IS NOT NULL AND git_branch != ''
+ORDER BY git_branch ASC
+`
+
+func (q *Queries) ListSessionGitBranches(ctx context.Context) ([]sql.NullString, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionGitBranches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []sql.NullString{}
+	for rows.Next() {
+		var git_branch sql.NullString
+		if err := rows.Scan(&git_branch); err != nil {
+			return nil, err
+		}
+		items = append(items, git_branch)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionGitRepositories = `-- name: ListSessionGitRepositories :many
+SELECT DISTINCT CAST(COALESCE(NULLIF(git_remote_url, ''), git_repo_root) AS TEXT) AS repository FROM sessions
+WHERE git_repo_root IS NOT NULL
+ORDER BY repository ASC
+`
+
+func (q *Queries) ListSessionGitRepositories(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionGitRepositories)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var repository string
+		if err := rows.Scan(&repository); err != nil {
+			return nil, err
+		}
+		items = append(items, repository)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionIDsByPrefix = `-- name: ListSessionIDsByPrefix :many
+SELECT id FROM sessions WHERE id LIKE ? ORDER BY created_at DESC
+`
+
+func (q *Queries) ListSessionIDsByPrefix(ctx context.Context, id string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionIDsByPrefix, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionIDsCreatedBetween = `-- name: ListSessionIDsCreatedBetween :many
+SELECT id FROM sessions
+WHERE created_at >= ? AND created_at < ?
+ORDER BY created_at ASC
+`
+
+type ListSessionIDsCreatedBetweenParams struct {
+	CreatedAt   string `json:"created_at"`
+	CreatedAt_2 string `json:"created_at_2"`
+}
+
+func (q *Queries) ListSessionIDsCreatedBetween(ctx context.Context, arg ListSessionIDsCreatedBetweenParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionIDsCreatedBetween, arg.CreatedAt, arg.CreatedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionIDsCreatedBetweenByProject = `-- name: ListSessionIDsCreatedBetweenByProject :many
+SELECT id FROM sessions
+WHERE project_id = ? AND created_at >= ? AND created_at < ?
+ORDER BY created_at ASC
+`
+
+type ListSessionIDsCreatedBetweenByProjectParams struct {
+	ProjectID   string `json:"project_id"`
+	CreatedAt   string `json:"created_at"`
+	CreatedAt_2 string `json:"created_at_2"`
+}
+
+func (q *Queries) ListSessionIDsCreatedBetweenByProject(ctx context.Context, arg ListSessionIDsCreatedBetweenByProjectParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionIDsCreatedBetweenByProject, arg.ProjectID, arg.CreatedAt, arg.CreatedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSessions = `-- name: ListSessions :many
-SELECT id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at FROM sessions
+SELECT id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at, is_excluded, exclusion_reason, git_repo_root, git_remote_url, git_branch, git_start_commit, git_end_commit, git_start_dirty, git_end_dirty FROM sessions
 ORDER BY created_at DESC
 LIMIT ?
 `
@@ -273,6 +576,15 @@ func (q *Queries) ListSessions(ctx context.Context, limit int64) ([]Session, err
 			&i.EndedAt,
 			&i.DurationSeconds,
 			&i.CreatedAt,
+			&i.IsExcluded,
+			&i.ExclusionReason,
+			&i.GitRepoRoot,
+			&i.GitRemoteUrl,
+			&i.GitBranch,
+			&i.GitStartCommit,
+			&i.GitEndCommit,
+			&i.GitStartDirty,
+			&i.GitEndDirty,
 		); err != nil {
 			return nil, err
 		}
@@ -288,7 +600,7 @@ func (q *Queries) ListSessions(ctx context.Context, limit int64) ([]Session, err
 }
 
 const listSessionsByExperiment = `-- name: ListSessionsByExperiment :many
-SELECT id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at FROM sessions
+SELECT id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at, is_excluded, exclusion_reason, git_repo_root, git_remote_url, git_branch, git_start_commit, git_end_commit, git_start_dirty, git_end_dirty FROM sessions
 WHERE experiment_id = ?
 ORDER BY created_at DESC
 LIMIT ?
@@ -321,6 +633,15 @@ func (q *Queries) ListSessionsByExperiment(ctx context.Context, arg ListSessions
 			&i.EndedAt,
 			&i.DurationSeconds,
 			&i.CreatedAt,
+			&i.IsExcluded,
+			&i.ExclusionReason,
+			&i.GitRepoRoot,
+			&i.GitRemoteUrl,
+			&i.GitBranch,
+			&i.GitStartCommit,
+			&i.GitEndCommit,
+			&i.GitStartDirty,
+			&i.GitEndDirty,
 		); err != nil {
 			return nil, err
 		}
@@ -336,7 +657,7 @@ func (q *Queries) ListSessionsByExperiment(ctx context.Context, arg ListSessions
 }
 
 const listSessionsByProject = `-- name: ListSessionsByProject :many
-SELECT id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at FROM sessions
+SELECT id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at, is_excluded, exclusion_reason, git_repo_root, git_remote_url, git_branch, git_start_commit, git_end_commit, git_start_dirty, git_end_dirty FROM sessions
 WHERE project_id = ?
 ORDER BY created_at DESC
 LIMIT ?
@@ -369,6 +690,15 @@ func (q *Queries) ListSessionsByProject(ctx context.Context, arg ListSessionsByP
 			&i.EndedAt,
 			&i.DurationSeconds,
 			&i.CreatedAt,
+			&i.IsExcluded,
+			&i.ExclusionReason,
+			&i.GitRepoRoot,
+			&i.GitRemoteUrl,
+			&i.GitBranch,
+			&i.GitStartCommit,
+			&i.GitEndCommit,
+			&i.GitStartDirty,
+			&i.GitEndDirty,
 		); err != nil {
 			return nil, err
 		}
@@ -529,13 +859,30 @@ SELECT
     COALESCE(m.token_input, 0) + COALESCE(m.token_output, 0) as total_tokens,
     m.cost_estimate_usd,
     m.model_id,
-    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count
+    s.git_branch,
+    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count,
+    a.score as anomaly_score,
+    a.reasons as anomaly_reasons,
+    m.ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
+LEFT JOIN session_anomalies a ON s.id = a.session_id
+WHERE (CAST(? AS INTEGER) = 0 OR a.session_id IS NOT NULL)
+    AND IFNULL(s.git_branch, '') LIKE ? ESCAPE '\'
+    AND IFNULL(s.git_repo_root, '') || ' ' || IFNULL(s.git_remote_url, '') LIKE ? ESCAPE '\'
+    AND IFNULL(CAST(m.ended_green AS TEXT), '') LIKE ?
 ORDER BY s.created_at DESC
 LIMIT ?
 `
 
+type ListSessionsWithMetricsFullParams struct {
+	AnomalousOnly int64  `json:"anomalous_only"`
+	GitBranch     string `json:"git_branch"`
+	GitRepository string `json:"git_repository"`
+	EndedGreen    string `json:"ended_green"`
+	Limit         int64  `json:"limit"`
+}
+
 type ListSessionsWithMetricsFullRow struct {
 	ID              string          `json:"id"`
 	ProjectID       string          `json:"project_id"`
@@ -547,11 +894,21 @@ type ListSessionsWithMetricsFullRow struct {
 	TotalTokens     int64           `json:"total_tokens"`
 	CostEstimateUsd sql.NullFloat64 `json:"cost_estimate_usd"`
 	ModelID         sql.NullString  `json:"model_id"`
+	GitBranch       sql.NullString  `json:"git_branch"`
 	SubagentCount   int64           `json:"subagent_count"`
+	AnomalyScore    sql.NullFloat64 `json:"anomaly_score"`
+	AnomalyReasons  sql.NullString  `json:"anomaly_reasons"`
+	EndedGreen      sql.NullInt64   `json:"ended_green"`
 }
 
-func (q *Queries) ListSessionsWithMetricsFull(ctx context.Context, limit int64) ([]ListSessionsWithMetricsFullRow, error) {
-	rows, err := q.db.QueryContext(ctx, listSessionsWithMetricsFull, limit)
+func (q *Queries) ListSessionsWithMetricsFull(ctx context.Context, arg ListSessionsWithMetricsFullParams) ([]ListSessionsWithMetricsFullRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionsWithMetricsFull,
+		arg.AnomalousOnly,
+		arg.GitBranch,
+		arg.GitRepository,
+		arg.EndedGreen,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -570,7 +927,11 @@ func (q *Queries) ListSessionsWithMetricsFull(ctx context.Context, limit int64)
 			&i.TotalTokens,
 			&i.CostEstimateUsd,
 			&i.ModelID,
+			&i.GitBranch,
 			&i.SubagentCount,
+			&i.AnomalyScore,
+			&i.AnomalyReasons,
+			&i.EndedGreen,
 		); err != nil {
 			return nil, err
 		}
@@ -593,17 +954,29 @@ SELECT
     COALESCE(m.token_input, 0) + COALESCE(m.token_output, 0) as total_tokens,
     m.cost_estimate_usd,
     m.model_id,
-    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count
+    s.git_branch,
+    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count,
+    a.score as anomaly_score,
+    a.reasons as anomaly_reasons,
+    m.ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
-WHERE s.experiment_id = ?
+LEFT JOIN session_anomalies a ON s.id = a.session_id
+WHERE s.experiment_id = ? AND (CAST(? AS INTEGER) = 0 OR a.session_id IS NOT NULL)
+    AND IFNULL(s.git_branch, '') LIKE ? ESCAPE '\'
+    AND IFNULL(s.git_repo_root, '') || ' ' || IFNULL(s.git_remote_url, '') LIKE ? ESCAPE '\'
+    AND IFNULL(CAST(m.ended_green AS TEXT), '') LIKE ?
 ORDER BY s.created_at DESC
 LIMIT ?
 `
 
 type ListSessionsWithMetricsFullByExperimentParams struct {
-	ExperimentID sql.NullString `json:"experiment_id"`
-	Limit        int64          `json:"limit"`
+	ExperimentID  sql.NullString `json:"experiment_id"`
+	AnomalousOnly int64          `json:"anomalous_only"`
+	GitBranch     string         `json:"git_branch"`
+	GitRepository string         `json:"git_repository"`
+	EndedGreen    string         `json:"ended_green"`
+	Limit         int64          `json:"limit"`
 }
 
 type ListSessionsWithMetricsFullByExperimentRow struct {
@@ -617,11 +990,22 @@ type ListSessionsWithMetricsFullByExperimentRow struct {
 	TotalTokens     int64           `json:"total_tokens"`
 	CostEstimateUsd sql.NullFloat64 `json:"cost_estimate_usd"`
 	ModelID         sql.NullString  `json:"model_id"`
+	GitBranch       sql.NullString  `json:"git_branch"`
 	SubagentCount   int64           `json:"subagent_count"`
+	AnomalyScore    sql.NullFloat64 `json:"anomaly_score"`
+	AnomalyReasons  sql.NullString  `json:"anomaly_reasons"`
+	EndedGreen      sql.NullInt64   `json:"ended_green"`
 }
 
 func (q *Queries) ListSessionsWithMetricsFullByExperiment(ctx context.Context, arg ListSessionsWithMetricsFullByExperimentParams) ([]ListSessionsWithMetricsFullByExperimentRow, error) {
-	rows, err := q.db.QueryContext(ctx, listSessionsWithMetricsFullByExperiment, arg.ExperimentID, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, listSessionsWithMetricsFullByExperiment,
+		arg.ExperimentID,
+		arg.AnomalousOnly,
+		arg.GitBranch,
+		arg.GitRepository,
+		arg.EndedGreen,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -640,7 +1024,11 @@ func (q *Queries) ListSessionsWithMetricsFullByExperiment(ctx context.Context, a
 			&i.TotalTokens,
 			&i.CostEstimateUsd,
 			&i.ModelID,
+			&i.GitBranch,
 			&i.SubagentCount,
+			&i.AnomalyScore,
+			&i.AnomalyReasons,
+			&i.EndedGreen,
 		); err != nil {
 			return nil, err
 		}
@@ -663,17 +1051,29 @@ SELECT
     COALESCE(m.token_input, 0) + COALESCE(m.token_output, 0) as total_tokens,
     m.cost_estimate_usd,
     m.model_id,
-    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count
+    s.git_branch,
+    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count,
+    a.score as anomaly_score,
+    a.reasons as anomaly_reasons,
+    m.ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
-WHERE s.project_id = ?
+LEFT JOIN session_anomalies a ON s.id = a.session_id
+WHERE s.project_id = ? AND (CAST(? AS INTEGER) = 0 OR a.session_id IS NOT NULL)
+    AND IFNULL(s.git_branch, '') LIKE ? ESCAPE '\'
+    AND IFNULL(s.git_repo_root, '') || ' ' || IFNULL(s.git_remote_url, '') LIKE ? ESCAPE '\'
+    AND IFNULL(CAST(m.ended_green AS TEXT), '') LIKE ?
 ORDER BY s.created_at DESC
 LIMIT ?
 `
 
 type ListSessionsWithMetricsFullByProjectParams struct {
-	ProjectID string `json:"project_id"`
-	Limit     int64  `json:"limit"`
+	ProjectID     string `json:"project_id"`
+	AnomalousOnly int64  `json:"anomalous_only"`
+	GitBranch     string `json:"git_branch"`
+	GitRepository string `json:"git_repository"`
+	EndedGreen    string `json:"ended_green"`
+	Limit         int64  `json:"limit"`
 }
 
 type ListSessionsWithMetricsFullByProjectRow struct {
@@ -687,11 +1087,22 @@ type ListSessionsWithMetricsFullByProjectRow struct {
 	TotalTokens     int64           `json:"total_tokens"`
 	CostEstimateUsd sql.NullFloat64 `json:"cost_estimate_usd"`
 	ModelID         sql.NullString  `json:"model_id"`
+	GitBranch       sql.NullString  `json:"git_branch"`
 	SubagentCount   int64           `json:"subagent_count"`
+	AnomalyScore    sql.NullFloat64 `json:"anomaly_score"`
+	AnomalyReasons  sql.NullString  `json:"anomaly_reasons"`
+	EndedGreen      sql.NullInt64   `json:"ended_green"`
 }
 
 func (q *Queries) ListSessionsWithMetricsFullByProject(ctx context.Context, arg ListSessionsWithMetricsFullByProjectParams) ([]ListSessionsWithMetricsFullByProjectRow, error) {
-	rows, err := q.db.QueryContext(ctx, listSessionsWithMetricsFullByProject, arg.ProjectID, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, listSessionsWithMetricsFullByProject,
+		arg.ProjectID,
+		arg.AnomalousOnly,
+		arg.GitBranch,
+		arg.GitRepository,
+		arg.EndedGreen,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -710,7 +1121,11 @@ func (q *Queries) ListSessionsWithMetricsFullByProject(ctx context.Context, arg
 			&i.TotalTokens,
 			&i.CostEstimateUsd,
 			&i.ModelID,
+			&i.GitBranch,
 			&i.SubagentCount,
+			&i.AnomalyScore,
+			&i.AnomalyReasons,
+			&i.EndedGreen,
 		); err != nil {
 			return nil, err
 		}
@@ -733,18 +1148,30 @@ SELECT
     COALESCE(m.token_input, 0) + COALESCE(m.token_output, 0) as total_tokens,
     m.cost_estimate_usd,
     m.model_id,
-    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count
+    s.git_branch,
+    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count,
+    a.score as anomaly_score,
+    a.reasons as anomaly_reasons,
+    m.ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
-WHERE s.project_id = ? AND s.experiment_id = ?
+LEFT JOIN session_anomalies a ON s.id = a.session_id
+WHERE s.project_id = ? AND s.experiment_id = ? AND (CAST(? AS INTEGER) = 0 OR a.session_id IS NOT NULL)
+    AND IFNULL(s.git_branch, '') LIKE ? ESCAPE '\'
+    AND IFNULL(s.git_repo_root, '') || ' ' || IFNULL(s.git_remote_url, '') LIKE ? ESCAPE '\'
+    AND IFNULL(CAST(m.ended_green AS TEXT), '') LIKE ?
 ORDER BY s.created_at DESC
 LIMIT ?
 `
 
 type ListSessionsWithMetricsFullByProjectAndExperimentParams struct {
-	ProjectID    string         `json:"project_id"`
-	ExperimentID sql.NullString `json:"experiment_id"`
-	Limit        int64          `json:"limit"`
+	ProjectID     string         `json:"project_id"`
+	ExperimentID  sql.NullString `json:"experiment_id"`
+	AnomalousOnly int64          `json:"anomalous_only"`
+	GitBranch     string         `json:"git_branch"`
+	GitRepository string         `json:"git_repository"`
+	EndedGreen    string         `json:"ended_green"`
+	Limit         int64          `json:"limit"`
 }
 
 type ListSessionsWithMetricsFullByProjectAndExperimentRow struct {
@@ -758,11 +1185,23 @@ type ListSessionsWithMetricsFullByProjectAndExperimentRow struct {
 	TotalTokens     int64           `json:"total_tokens"`
 	CostEstimateUsd sql.NullFloat64 `json:"cost_estimate_usd"`
 	ModelID         sql.NullString  `json:"model_id"`
+	GitBranch       sql.NullString  `json:"git_branch"`
 	SubagentCount   int64           `json:"subagent_count"`
+	AnomalyScore    sql.NullFloat64 `json:"anomaly_score"`
+	AnomalyReasons  sql.NullString  `json:"anomaly_reasons"`
+	EndedGreen      sql.NullInt64   `json:"ended_green"`
 }
 
 func (q *Queries) ListSessionsWithMetricsFullByProjectAndExperiment(ctx context.Context, arg ListSessionsWithMetricsFullByProjectAndExperimentParams) ([]ListSessionsWithMetricsFullByProjectAndExperimentRow, error) {
-	rows, err := q.db.QueryContext(ctx, listSessionsWithMetricsFullByProjectAndExperiment, arg.ProjectID, arg.ExperimentID, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, listSessionsWithMetricsFullByProjectAndExperiment,
+		arg.ProjectID,
+		arg.ExperimentID,
+		arg.AnomalousOnly,
+		arg.GitBranch,
+		arg.GitRepository,
+		arg.EndedGreen,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -781,7 +1220,11 @@ func (q *Queries) ListSessionsWithMetricsFullByProjectAndExperiment(ctx context.
 			&i.TotalTokens,
 			&i.CostEstimateUsd,
 			&i.ModelID,
+			&i.GitBranch,
 			&i.SubagentCount,
+			&i.AnomalyScore,
+			&i.AnomalyReasons,
+			&i.EndedGreen,
 		); err != nil {
 			return nil, err
 		}
@@ -795,3 +1238,32 @@ func (q *Queries) ListSessionsWithMetricsFullByProjectAndExperiment(ctx context.
 	}
 	return items, nil
 }
+
+const setSessionExclusion = `-- name: SetSessionExclusion :exec
+UPDATE sessions SET is_excluded = ?, exclusion_reason = ? WHERE id = ?
+`
+
+type SetSessionExclusionParams struct {
+	IsExcluded      int64          `json:"is_excluded"`
+	ExclusionReason sql.NullString `json:"exclusion_reason"`
+	ID              string         `json:"id"`
+}
+
+func (q *Queries) SetSessionExclusion(ctx context.Context, arg SetSessionExclusionParams) error {
+	_, err := q.db.ExecContext(ctx, setSessionExclusion, arg.IsExcluded, arg.ExclusionReason, arg.ID)
+	return err
+}
+
+const updateSessionExperiment = `-- name: UpdateSessionExperiment :exec
+UPDATE sessions SET experiment_id = ? WHERE id = ?
+`
+
+type UpdateSessionExperimentParams struct {
+	ExperimentID sql.NullString `json:"experiment_id"`
+	ID           string         `json:"id"`
+}
+
+func (q *Queries) UpdateSessionExperiment(ctx context.Context, arg UpdateSessionExperimentParams) error {
+	_, err := q.db.ExecContext(ctx, updateSessionExperiment, arg.ExperimentID, arg.ID)
+	return err
+}
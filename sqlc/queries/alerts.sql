@@ -0,0 +1,6 @@
+-- name: ClaimAlertFiring :execrows
+INSERT OR IGNORE INTO alert_firings (rule, alert_key, scope, value, threshold, message, fired_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListAlertFirings :many
+SELECT * FROM alert_firings ORDER BY fired_at DESC LIMIT ?;
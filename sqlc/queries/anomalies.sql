@@ -0,0 +1,13 @@
+-- name: UpsertSessionAnomaly :exec
+INSERT INTO session_anomalies (session_id, score, reasons, detected_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(session_id) DO UPDATE SET
+    score = excluded.score,
+    reasons = excluded.reasons,
+    detected_at = excluded.detected_at;
+
+-- name: GetSessionAnomaly :one
+SELECT * FROM session_anomalies WHERE session_id = ?;
+
+-- name: DeleteSessionAnomaly :exec
+DELETE FROM session_anomalies WHERE session_id = ?;
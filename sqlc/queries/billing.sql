@@ -0,0 +1,16 @@
+-- name: UpsertBillingUsage :exec
+INSERT INTO billing_usage (usage_date, model, workspace, api_key, token_input, token_output, token_cache_read, token_cache_write, token_cache_write_1h, cost_usd, imported_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(usage_date, model, workspace, api_key) DO UPDATE SET
+    token_input = excluded.token_input,
+    token_output = excluded.token_output,
+    token_cache_read = excluded.token_cache_read,
+    token_cache_write = excluded.token_cache_write,
+    token_cache_write_1h = excluded.token_cache_write_1h,
+    cost_usd = excluded.cost_usd,
+    imported_at = excluded.imported_at;
+
+-- name: ListBillingUsageSince :many
+SELECT * FROM billing_usage
+WHERE usage_date >= ?
+ORDER BY usage_date ASC, model ASC, workspace ASC, api_key ASC;
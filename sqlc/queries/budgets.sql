@@ -0,0 +1,12 @@
+-- name: UpsertBudget :exec
+INSERT INTO budgets (id, period, amount_usd, project_id, experiment_id, is_hard, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+    amount_usd = excluded.amount_usd,
+    is_hard = excluded.is_hard;
+
+-- name: ListBudgets :many
+SELECT * FROM budgets ORDER BY created_at ASC, id ASC;
+
+-- name: DeleteBudget :execrows
+DELETE FROM budgets WHERE id = ?;
@@ -0,0 +1,62 @@
+-- name: CreateSessionCommit :exec
+INSERT OR REPLACE INTO session_commits (session_id, commit_hash, message, author, committed_at, files_changed, lines_added, lines_removed, detected_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteSessionCommitsBySession :exec
+DELETE FROM session_commits WHERE session_id = ?;
+
+-- name: ListSessionCommitsBySessionID :many
+SELECT * FROM session_commits WHERE session_id = ? ORDER BY committed_at ASC, commit_hash ASC;
+
+-- name: ListCommitsWithCost :many
+SELECT
+    c.session_id, c.commit_hash, c.message, c.author, c.committed_at,
+    c.files_changed, c.lines_added, c.lines_removed, c.detected_by,
+    s.experiment_id,
+    e.name as experiment_name,
+    m.cost_estimate_usd,
+    (SELECT COUNT(*) FROM session_commits sc WHERE sc.session_id = c.session_id) as session_commit_count
+FROM session_commits c
+JOIN sessions s ON s.id = c.session_id
+LEFT JOIN experiments e ON e.id = s.experiment_id
+LEFT JOIN session_metrics m ON m.session_id = c.session_id
+WHERE c.committed_at >= ?
+ORDER BY c.committed_at DESC, c.commit_hash ASC
+LIMIT ?;
+
+-- name: ListCommitsWithCostByExperiment :many
+SELECT
+    c.session_id, c.commit_hash, c.message, c.author, c.committed_at,
+    c.files_changed, c.lines_added, c.lines_removed, c.detected_by,
+    s.experiment_id,
+    e.name as experiment_name,
+    m.cost_estimate_usd,
+    (SELECT COUNT(*) FROM session_commits sc WHERE sc.session_id = c.session_id) as session_commit_count
+FROM session_commits c
+JOIN sessions s ON s.id = c.session_id
+LEFT JOIN experiments e ON e.id = s.experiment_id
+LEFT JOIN session_metrics m ON m.session_id = c.session_id
+WHERE s.experiment_id = ? AND s.is_excluded = 0 AND c.committed_at >= ?
+ORDER BY c.committed_at DESC, c.commit_hash ASC
+LIMIT ?;
+
+-- name: ListCommitStatsByExperiment :many
+SELECT
+    e.id,
+    e.name,
+    COUNT(s.id) as session_count,
+    COALESCE(SUM(CASE WHEN sc.commit_count > 0 THEN 1 ELSE 0 END), 0) as sessions_with_commits,
+    COALESCE(SUM(sc.commit_count), 0) as commit_count,
+    COALESCE(SUM(sc.lines_added), 0) as lines_added,
+    COALESCE(SUM(sc.lines_removed), 0) as lines_removed,
+    COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd
+FROM experiments e
+JOIN sessions s ON s.experiment_id = e.id AND s.is_excluded = 0
+LEFT JOIN session_metrics m ON m.session_id = s.id
+LEFT JOIN (
+    SELECT session_id, COUNT(*) as commit_count, SUM(lines_added) as lines_added, SUM(lines_removed) as lines_removed
+    FROM session_commits
+    GROUP BY session_id
+) sc ON sc.session_id = s.id
+GROUP BY e.id, e.name
+ORDER BY e.created_at DESC;
@@ -1,6 +1,6 @@
 -- name: CreateExperiment :exec
-INSERT INTO experiments (id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT INTO experiments (id, name, description, hypothesis, started_at, ended_at, is_active, created_at, model_id, plan_type, notes, scheduled_start, scheduled_end)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetExperimentByID :one
 SELECT * FROM experiments WHERE id = ?;
@@ -16,9 +16,12 @@ SELECT * FROM experiments ORDER BY created_at DESC;
 
 -- name: UpdateExperiment :exec
 UPDATE experiments
-SET name = ?, description = ?, hypothesis = ?, started_at = ?, ended_at = ?, is_active = ?, model_id = ?, plan_type = ?, notes = ?
+SET name = ?, description = ?, hypothesis = ?, started_at = ?, ended_at = ?, is_active = ?, model_id = ?, plan_type = ?, notes = ?, scheduled_start = ?, scheduled_end = ?
 WHERE id = ?;
 
+-- name: SetExperimentConclusion :exec
+UPDATE experiments SET verdict = ?, conclusion = ?, concluded_at = ? WHERE id = ?;
+
 -- name: DeleteExperiment :exec
 DELETE FROM experiments WHERE id = ?;
 
@@ -41,3 +44,10 @@ SELECT * FROM experiment_variables WHERE experiment_id = ? ORDER BY key;
 
 -- name: DeleteExperimentVariable :exec
 DELETE FROM experiment_variables WHERE experiment_id = ? AND key = ?;
+
+-- name: CreateExperimentNote :exec
+INSERT INTO experiment_notes (experiment_id, content, created_at)
+VALUES (?, ?, ?);
+
+-- name: ListExperimentNotesByExperimentID :many
+SELECT * FROM experiment_notes WHERE experiment_id = ? ORDER BY created_at ASC, id ASC;
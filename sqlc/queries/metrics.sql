@@ -1,6 +1,6 @@
 -- name: CreateSessionMetrics :exec
-INSERT OR REPLACE INTO session_metrics (session_id, model_id, message_count_user, message_count_assistant, turn_count, token_input, token_output, token_cache_read, token_cache_write, cost_estimate_usd, error_count, input_rate, output_rate, cache_read_rate, cache_write_rate)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT OR REPLACE INTO session_metrics (session_id, model_id, message_count_user, message_count_assistant, turn_count, token_input, token_output, token_cache_read, token_cache_write, cost_estimate_usd, error_count, input_rate, output_rate, cache_read_rate, cache_write_rate, web_search_requests, web_fetch_requests, server_tool_cost_usd, token_cache_write_5m, token_cache_write_1h, cache_write_1h_rate, lines_added, lines_removed, lines_source, tests_passed, build_passed, lint_passed, ended_green)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetSessionMetricsBySessionID :one
 SELECT * FROM session_metrics WHERE session_id = ?;
@@ -12,9 +12,41 @@ ON CONFLICT (session_id, tool_name) DO UPDATE SET
     invocation_count = invocation_count + excluded.invocation_count,
     error_count = error_count + excluded.error_count;
 
+-- name: DeleteSessionToolsBySession :exec
+DELETE FROM session_tools WHERE session_id = ?;
+
+-- name: DeleteSessionToolCallsBySession :exec
+DELETE FROM session_tool_calls WHERE session_id = ?;
+
+-- name: DeleteSessionFilesBySession :exec
+DELETE FROM session_files WHERE session_id = ?;
+
+-- name: DeleteSessionFileChangesBySession :exec
+DELETE FROM session_file_changes WHERE session_id = ?;
+
+-- name: DeleteSessionCommandsBySession :exec
+DELETE FROM session_commands WHERE session_id = ?;
+
+-- name: DeleteSessionSubagentsBySession :exec
+DELETE FROM session_subagents WHERE session_id = ?;
+
 -- name: ListSessionToolsBySessionID :many
 SELECT * FROM session_tools WHERE session_id = ? ORDER BY invocation_count DESC;
 
+-- name: CreateSessionToolCall :exec
+INSERT OR REPLACE INTO session_tool_calls (session_id, sequence, tool_name, label, is_error, result_bytes)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListSessionToolCalls :many
+SELECT c.session_id, s.experiment_id, s.is_excluded, c.sequence, c.tool_name, c.label, c.is_error, c.result_bytes
+FROM session_tool_calls c
+JOIN sessions s ON s.id = c.session_id
+WHERE s.created_at >= sqlc.arg(since)
+    AND s.project_id LIKE sqlc.arg(project_id) ESCAPE '\'
+    AND IFNULL(s.experiment_id, '') LIKE sqlc.arg(experiment_id) ESCAPE '\'
+    AND (CAST(sqlc.arg(skip_excluded) AS INTEGER) = 0 OR s.is_excluded = 0)
+ORDER BY c.session_id, c.sequence;
+
 -- name: CreateSessionFile :exec
 INSERT INTO session_files (session_id, file_path, operation, operation_count)
 VALUES (?, ?, ?, ?)
@@ -24,6 +56,24 @@ ON CONFLICT (session_id, file_path, operation) DO UPDATE SET
 -- name: ListSessionFilesBySessionID :many
 SELECT * FROM session_files WHERE session_id = ? ORDER BY operation_count DESC;
 
+-- name: ListFileTouches :many
+SELECT f.session_id, s.project_id, s.experiment_id, s.is_excluded, f.file_path, f.operation, f.operation_count, m.cost_estimate_usd
+FROM session_files f
+JOIN sessions s ON s.id = f.session_id
+LEFT JOIN session_metrics m ON m.session_id = f.session_id
+WHERE s.created_at >= sqlc.arg(since)
+    AND s.project_id LIKE sqlc.arg(project_id) ESCAPE '\'
+    AND IFNULL(s.experiment_id, '') LIKE sqlc.arg(experiment_id) ESCAPE '\'
+    AND (CAST(sqlc.arg(skip_excluded) AS INTEGER) = 0 OR s.is_excluded = 0)
+ORDER BY f.session_id, f.file_path;
+
+-- name: CreateSessionFileChange :exec
+INSERT OR REPLACE INTO session_file_changes (session_id, file_path, lines_added, lines_removed)
+VALUES (?, ?, ?, ?);
+
+-- name: ListSessionFileChangesBySessionID :many
+SELECT * FROM session_file_changes WHERE session_id = ? ORDER BY lines_added + lines_removed DESC, file_path ASC;
+
 -- name: CreateSessionCommand :exec
 INSERT INTO session_commands (session_id, command, exit_code, executed_at)
 VALUES (?, ?, ?, ?);
@@ -31,6 +81,14 @@ VALUES (?, ?, ?, ?);
 -- name: ListSessionCommandsBySessionID :many
 SELECT * FROM session_commands WHERE session_id = ? ORDER BY id ASC;
 
+-- name: ListCommandRuns :many
+SELECT c.session_id, s.project_id, c.command, c.exit_code
+FROM session_commands c
+JOIN sessions s ON s.id = c.session_id
+WHERE s.created_at >= sqlc.arg(since)
+    AND s.project_id LIKE sqlc.arg(project_id) ESCAPE '\'
+ORDER BY c.session_id, c.id;
+
 -- name: GetAggregateStats :one
 SELECT
     COUNT(DISTINCT s.id) as session_count,
@@ -41,8 +99,16 @@ SELECT
     COALESCE(SUM(m.token_output), 0) as total_token_output,
     COALESCE(SUM(m.token_cache_read), 0) as total_token_cache_read,
     COALESCE(SUM(m.token_cache_write), 0) as total_token_cache_write,
+    COALESCE(SUM(m.token_cache_write_1h), 0) as total_token_cache_write_1h,
     COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd,
-    COALESCE(SUM(m.error_count), 0) as total_errors
+    COALESCE(SUM(m.error_count), 0) as total_errors,
+    COALESCE(SUM(m.web_search_requests), 0) as total_web_search_requests,
+    COALESCE(SUM(m.web_fetch_requests), 0) as total_web_fetch_requests,
+    COALESCE(SUM(m.server_tool_cost_usd), 0) as total_server_tool_cost_usd,
+    COALESCE(SUM(m.lines_added), 0) as total_lines_added,
+    COALESCE(SUM(m.lines_removed), 0) as total_lines_removed,
+    COALESCE(SUM(CASE WHEN m.ended_green IS NOT NULL THEN 1 ELSE 0 END), 0) as sessions_with_checks,
+    COALESCE(SUM(m.ended_green), 0) as sessions_ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
 WHERE s.created_at >= ?;
@@ -57,11 +123,19 @@ SELECT
     COALESCE(SUM(m.token_output), 0) as total_token_output,
     COALESCE(SUM(m.token_cache_read), 0) as total_token_cache_read,
     COALESCE(SUM(m.token_cache_write), 0) as total_token_cache_write,
+    COALESCE(SUM(m.token_cache_write_1h), 0) as total_token_cache_write_1h,
     COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd,
-    COALESCE(SUM(m.error_count), 0) as total_errors
+    COALESCE(SUM(m.error_count), 0) as total_errors,
+    COALESCE(SUM(m.web_search_requests), 0) as total_web_search_requests,
+    COALESCE(SUM(m.web_fetch_requests), 0) as total_web_fetch_requests,
+    COALESCE(SUM(m.server_tool_cost_usd), 0) as total_server_tool_cost_usd,
+    COALESCE(SUM(m.lines_added), 0) as total_lines_added,
+    COALESCE(SUM(m.lines_removed), 0) as total_lines_removed,
+    COALESCE(SUM(CASE WHEN m.ended_green IS NOT NULL THEN 1 ELSE 0 END), 0) as sessions_with_checks,
+    COALESCE(SUM(m.ended_green), 0) as sessions_ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
-WHERE s.experiment_id = ? AND s.created_at >= ?;
+WHERE s.experiment_id = ? AND s.created_at >= ? AND s.is_excluded = 0;
 
 -- name: GetAggregateStatsByProject :one
 SELECT
@@ -73,8 +147,16 @@ SELECT
     COALESCE(SUM(m.token_output), 0) as total_token_output,
     COALESCE(SUM(m.token_cache_read), 0) as total_token_cache_read,
     COALESCE(SUM(m.token_cache_write), 0) as total_token_cache_write,
+    COALESCE(SUM(m.token_cache_write_1h), 0) as total_token_cache_write_1h,
     COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd,
-    COALESCE(SUM(m.error_count), 0) as total_errors
+    COALESCE(SUM(m.error_count), 0) as total_errors,
+    COALESCE(SUM(m.web_search_requests), 0) as total_web_search_requests,
+    COALESCE(SUM(m.web_fetch_requests), 0) as total_web_fetch_requests,
+    COALESCE(SUM(m.server_tool_cost_usd), 0) as total_server_tool_cost_usd,
+    COALESCE(SUM(m.lines_added), 0) as total_lines_added,
+    COALESCE(SUM(m.lines_removed), 0) as total_lines_removed,
+    COALESCE(SUM(CASE WHEN m.ended_green IS NOT NULL THEN 1 ELSE 0 END), 0) as sessions_with_checks,
+    COALESCE(SUM(m.ended_green), 0) as sessions_ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
 WHERE s.project_id = ? AND s.created_at >= ?;
@@ -91,6 +173,30 @@ GROUP BY tool_name
 ORDER BY total_invocations DESC
 LIMIT ?;
 
+-- name: GetDailyCostByModelAndProject :many
+SELECT
+    DATE(s.created_at) as date,
+    s.project_id,
+    m.model_id,
+    COUNT(DISTINCT s.id) as session_count,
+    COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.created_at >= ?
+GROUP BY DATE(s.created_at), s.project_id, m.model_id
+ORDER BY date ASC, s.project_id ASC, m.model_id ASC;
+
+-- name: GetDailyCostByProject :many
+SELECT
+    DATE(s.created_at) as date,
+    s.project_id,
+    COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.created_at >= ?
+GROUP BY DATE(s.created_at), s.project_id
+ORDER BY date ASC, s.project_id ASC;
+
 -- name: GetDailyStats :many
 SELECT
     DATE(s.created_at) as date,
@@ -104,6 +210,12 @@ GROUP BY DATE(s.created_at)
 ORDER BY date ASC
 LIMIT ?;
 
+-- name: GetExperimentSpend :one
+SELECT COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.experiment_id = ? AND s.created_at >= ?;
+
 -- name: GetStatsForAllExperiments :many
 SELECT
     e.id as experiment_id,
@@ -116,17 +228,25 @@ SELECT
     COALESCE(SUM(m.token_output), 0) as total_token_output,
     COALESCE(SUM(m.token_cache_read), 0) as total_token_cache_read,
     COALESCE(SUM(m.token_cache_write), 0) as total_token_cache_write,
+    COALESCE(SUM(m.token_cache_write_1h), 0) as total_token_cache_write_1h,
     COALESCE(SUM(m.cost_estimate_usd), 0) as total_cost_usd,
-    COALESCE(SUM(m.error_count), 0) as total_errors
+    COALESCE(SUM(m.error_count), 0) as total_errors,
+    COALESCE(SUM(m.web_search_requests), 0) as total_web_search_requests,
+    COALESCE(SUM(m.web_fetch_requests), 0) as total_web_fetch_requests,
+    COALESCE(SUM(m.server_tool_cost_usd), 0) as total_server_tool_cost_usd,
+    COALESCE(SUM(m.lines_added), 0) as total_lines_added,
+    COALESCE(SUM(m.lines_removed), 0) as total_lines_removed,
+    COALESCE(SUM(CASE WHEN m.ended_green IS NOT NULL THEN 1 ELSE 0 END), 0) as sessions_with_checks,
+    COALESCE(SUM(m.ended_green), 0) as sessions_ended_green
 FROM experiments e
-LEFT JOIN sessions s ON s.experiment_id = e.id
+LEFT JOIN sessions s ON s.experiment_id = e.id AND s.is_excluded = 0
 LEFT JOIN session_metrics m ON s.id = m.session_id
 GROUP BY e.id, e.name
 ORDER BY e.created_at DESC;
 
 -- name: CreateSessionSubagent :exec
-INSERT INTO session_subagents (session_id, agent_type, agent_kind, description, model, total_tokens, token_input, token_output, token_cache_read, token_cache_write, total_duration_ms, tool_use_count, cost_estimate_usd)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT INTO session_subagents (session_id, agent_type, agent_kind, description, model, total_tokens, token_input, token_output, token_cache_read, token_cache_write, total_duration_ms, tool_use_count, cost_estimate_usd, token_cache_write_5m, token_cache_write_1h)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: ListSessionSubagentsBySessionID :many
 SELECT * FROM session_subagents WHERE session_id = ? ORDER BY id ASC;
@@ -161,7 +281,7 @@ LIMIT ?;
 SELECT COALESCE(SUM(st.invocation_count), 0) as total_tool_calls
 FROM session_tools st
 JOIN sessions s ON st.session_id = s.id
-WHERE s.experiment_id = ?;
+WHERE s.experiment_id = ? AND s.is_excluded = 0;
 
 -- name: GetTopToolsUsageByExperiment :many
 SELECT
@@ -170,7 +290,73 @@ SELECT
     SUM(error_count) as total_errors
 FROM session_tools st
 JOIN sessions s ON st.session_id = s.id
-WHERE s.experiment_id = ?
+WHERE s.experiment_id = ? AND s.is_excluded = 0
 GROUP BY tool_name
 ORDER BY total_invocations DESC
 LIMIT ?;
+
+-- name: ListSessionSamplesByExperiment :many
+SELECT
+    s.id,
+    s.created_at,
+    s.duration_seconds,
+    COALESCE(m.turn_count, 0) as turn_count,
+    COALESCE(m.token_input + m.token_output, 0) as total_tokens,
+    COALESCE(m.cost_estimate_usd, 0) as cost_usd,
+    COALESCE(m.error_count, 0) as error_count,
+    (SELECT COALESCE(SUM(st.invocation_count), 0) FROM session_tools st WHERE st.session_id = s.id) as tool_calls
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.experiment_id = ? AND s.is_excluded = 0
+ORDER BY s.created_at ASC;
+
+-- name: ListSessionSamplesByProject :many
+SELECT
+    s.id,
+    s.created_at,
+    s.duration_seconds,
+    COALESCE(m.turn_count, 0) as turn_count,
+    COALESCE(m.token_input + m.token_output, 0) as total_tokens,
+    COALESCE(m.cost_estimate_usd, 0) as cost_usd,
+    COALESCE(m.error_count, 0) as error_count,
+    (SELECT COALESCE(SUM(st.invocation_count), 0) FROM session_tools st WHERE st.session_id = s.id) as tool_calls
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.project_id = ? AND s.id != ? AND s.created_at <= ? AND s.is_excluded = 0
+ORDER BY s.created_at DESC
+LIMIT ?;
+
+-- name: ListSessionUsageBetween :many
+SELECT
+    s.id,
+    s.project_id,
+    s.experiment_id,
+    s.created_at,
+    m.model_id,
+    m.token_input,
+    m.token_output,
+    m.token_cache_read,
+    m.token_cache_write,
+    m.token_cache_write_1h,
+    m.web_search_requests,
+    m.web_fetch_requests,
+    m.cost_estimate_usd
+FROM sessions s
+LEFT JOIN session_metrics m ON s.id = m.session_id
+WHERE s.created_at >= ? AND s.created_at < ?
+ORDER BY s.created_at ASC;
+
+-- name: ListSubagentUsageBetween :many
+SELECT
+    sa.session_id,
+    sa.model,
+    sa.token_input,
+    sa.token_output,
+    sa.token_cache_read,
+    sa.token_cache_write,
+    sa.token_cache_write_1h,
+    sa.cost_estimate_usd
+FROM session_subagents sa
+JOIN sessions s ON s.id = sa.session_id
+WHERE s.created_at >= ? AND s.created_at < ?
+ORDER BY sa.id ASC;
@@ -1,6 +1,6 @@
 -- name: CreateModelPricing :exec
-INSERT INTO model_pricing (id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, long_context_input_per_million, long_context_output_per_million, long_context_threshold, is_default, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT INTO model_pricing (id, display_name, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, long_context_input_per_million, long_context_output_per_million, long_context_threshold, web_search_per_thousand, web_fetch_per_thousand, cache_write_1h_per_million, is_default, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetModelPricingByID :one
 SELECT * FROM model_pricing WHERE id = ?;
@@ -13,7 +13,7 @@ SELECT * FROM model_pricing ORDER BY display_name ASC;
 
 -- name: UpdateModelPricing :exec
 UPDATE model_pricing
-SET display_name = ?, input_per_million = ?, output_per_million = ?, cache_read_per_million = ?, cache_write_per_million = ?, long_context_input_per_million = ?, long_context_output_per_million = ?, long_context_threshold = ?, is_default = ?
+SET display_name = ?, input_per_million = ?, output_per_million = ?, cache_read_per_million = ?, cache_write_per_million = ?, long_context_input_per_million = ?, long_context_output_per_million = ?, long_context_threshold = ?, web_search_per_thousand = ?, web_fetch_per_thousand = ?, cache_write_1h_per_million = ?, is_default = ?
 WHERE id = ?;
 
 -- name: SetDefaultModelPricing :exec
@@ -0,0 +1,62 @@
+-- name: UpsertPricingProfile :exec
+INSERT INTO pricing_profiles (name, description, multiplier, is_default, created_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(name) DO UPDATE SET
+    description = excluded.description,
+    multiplier = excluded.multiplier;
+
+-- name: GetPricingProfile :one
+SELECT * FROM pricing_profiles WHERE name = ?;
+
+-- name: GetDefaultPricingProfile :one
+SELECT * FROM pricing_profiles WHERE is_default = 1 LIMIT 1;
+
+-- name: ListPricingProfiles :many
+SELECT * FROM pricing_profiles ORDER BY name ASC;
+
+-- name: SetDefaultPricingProfile :exec
+UPDATE pricing_profiles SET is_default = CASE WHEN name = ? THEN 1 ELSE 0 END;
+
+-- name: DeletePricingProfile :execrows
+DELETE FROM pricing_profiles WHERE name = ?;
+
+-- name: UpsertPricingProfileRate :exec
+INSERT INTO pricing_profile_rates (profile_name, model_id, input_per_million, output_per_million, cache_read_per_million, cache_write_per_million, long_context_input_per_million, long_context_output_per_million, long_context_threshold, web_search_per_thousand, web_fetch_per_thousand, cache_write_1h_per_million)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(profile_name, model_id) DO UPDATE SET
+    input_per_million = excluded.input_per_million,
+    output_per_million = excluded.output_per_million,
+    cache_read_per_million = excluded.cache_read_per_million,
+    cache_write_per_million = excluded.cache_write_per_million,
+    long_context_input_per_million = excluded.long_context_input_per_million,
+    long_context_output_per_million = excluded.long_context_output_per_million,
+    long_context_threshold = excluded.long_context_threshold,
+    web_search_per_thousand = excluded.web_search_per_thousand,
+    web_fetch_per_thousand = excluded.web_fetch_per_thousand,
+    cache_write_1h_per_million = excluded.cache_write_1h_per_million;
+
+-- name: ListPricingProfileRates :many
+SELECT * FROM pricing_profile_rates WHERE profile_name = ? ORDER BY model_id ASC;
+
+-- name: DeletePricingProfileRate :execrows
+DELETE FROM pricing_profile_rates WHERE profile_name = ? AND model_id = ?;
+
+-- name: DeletePricingProfileRates :exec
+DELETE FROM pricing_profile_rates WHERE profile_name = ?;
+
+-- name: SetProjectPricingProfile :exec
+INSERT INTO project_pricing_profiles (project_id, profile_name)
+VALUES (?, ?)
+ON CONFLICT(project_id) DO UPDATE SET profile_name = excluded.profile_name;
+
+-- name: GetProjectPricingProfile :one
+SELECT profile_name FROM project_pricing_profiles WHERE project_id = ?;
+
+-- name: ListProjectPricingProfiles :many
+SELECT project_id, profile_name FROM project_pricing_profiles ORDER BY project_id ASC;
+
+-- name: DeleteProjectPricingProfile :execrows
+DELETE FROM project_pricing_profiles WHERE project_id = ?;
+
+-- name: DeleteProjectPricingProfilesByProfile :exec
+DELETE FROM project_pricing_profiles WHERE profile_name = ?;
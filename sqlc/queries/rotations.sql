@@ -0,0 +1,19 @@
+-- name: CreateRotationGroup :exec
+INSERT INTO rotation_groups (id, name, interval_seconds, anchor_at, ends_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetRotationGroupByName :one
+SELECT * FROM rotation_groups WHERE name = ?;
+
+-- name: ListRotationGroups :many
+SELECT * FROM rotation_groups ORDER BY anchor_at ASC;
+
+-- name: DeleteRotationGroup :exec
+DELETE FROM rotation_groups WHERE id = ?;
+
+-- name: AddRotationGroupMember :exec
+INSERT INTO rotation_group_members (group_id, experiment_id, position)
+VALUES (?, ?, ?);
+
+-- name: ListRotationGroupMembers :many
+SELECT * FROM rotation_group_members WHERE group_id = ? ORDER BY position ASC;
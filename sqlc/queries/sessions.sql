@@ -1,6 +1,24 @@
 -- name: CreateSession :exec
-INSERT OR REPLACE INTO sessions (id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT INTO sessions (id, project_id, experiment_id, transcript_path, transcript_stored_path, cwd, permission_mode, exit_reason, started_at, ended_at, duration_seconds, created_at, git_repo_root, git_remote_url, git_branch, git_start_commit, git_end_commit, git_start_dirty, git_end_dirty)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+    project_id = excluded.project_id,
+    transcript_path = excluded.transcript_path,
+    transcript_stored_path = excluded.transcript_stored_path,
+    cwd = excluded.cwd,
+    permission_mode = excluded.permission_mode,
+    exit_reason = excluded.exit_reason,
+    started_at = excluded.started_at,
+    ended_at = excluded.ended_at,
+    duration_seconds = excluded.duration_seconds,
+    created_at = excluded.created_at,
+    git_repo_root = excluded.git_repo_root,
+    git_remote_url = excluded.git_remote_url,
+    git_branch = excluded.git_branch,
+    git_start_commit = excluded.git_start_commit,
+    git_end_commit = excluded.git_end_commit,
+    git_start_dirty = excluded.git_start_dirty,
+    git_end_dirty = excluded.git_end_dirty;
 
 -- name: GetSessionByID :one
 SELECT * FROM sessions WHERE id = ?;
@@ -22,6 +40,31 @@ WHERE experiment_id = ?
 ORDER BY created_at DESC
 LIMIT ?;
 
+-- name: UpdateSessionExperiment :exec
+UPDATE sessions SET experiment_id = ? WHERE id = ?;
+
+-- name: SetSessionExclusion :exec
+UPDATE sessions SET is_excluded = ?, exclusion_reason = ? WHERE id = ?;
+
+-- name: ListSessionIDsByPrefix :many
+SELECT id FROM sessions WHERE id LIKE ? ORDER BY created_at DESC;
+
+-- name: ListSessionIDsCreatedBetween :many
+SELECT id FROM sessions
+WHERE created_at >= ? AND created_at < ?
+ORDER BY created_at ASC;
+
+-- name: ListSessionIDsCreatedBetweenByProject :many
+SELECT id FROM sessions
+WHERE project_id = ? AND created_at >= ? AND created_at < ?
+ORDER BY created_at ASC;
+
+-- name: ListExcludedSessions :many
+SELECT * FROM sessions
+WHERE is_excluded = 1
+ORDER BY created_at DESC
+LIMIT ?;
+
 -- name: DeleteSession :exec
 DELETE FROM sessions WHERE id = ?;
 
@@ -79,9 +122,18 @@ SELECT
     COALESCE(m.token_input, 0) + COALESCE(m.token_output, 0) as total_tokens,
     m.cost_estimate_usd,
     m.model_id,
-    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count
+    s.git_branch,
+    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count,
+    a.score as anomaly_score,
+    a.reasons as anomaly_reasons,
+    m.ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
+LEFT JOIN session_anomalies a ON s.id = a.session_id
+WHERE (CAST(sqlc.arg(anomalous_only) AS INTEGER) = 0 OR a.session_id IS NOT NULL)
+    AND IFNULL(s.git_branch, '') LIKE sqlc.arg(git_branch) ESCAPE '\'
+    AND IFNULL(s.git_repo_root, '') || ' ' || IFNULL(s.git_remote_url, '') LIKE sqlc.arg(git_repository) ESCAPE '\'
+    AND IFNULL(CAST(m.ended_green AS TEXT), '') LIKE sqlc.arg(ended_green)
 ORDER BY s.created_at DESC
 LIMIT ?;
 
@@ -93,10 +145,18 @@ SELECT
     COALESCE(m.token_input, 0) + COALESCE(m.token_output, 0) as total_tokens,
     m.cost_estimate_usd,
     m.model_id,
-    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count
+    s.git_branch,
+    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count,
+    a.score as anomaly_score,
+    a.reasons as anomaly_reasons,
+    m.ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
-WHERE s.experiment_id = ?
+LEFT JOIN session_anomalies a ON s.id = a.session_id
+WHERE s.experiment_id = ? AND (CAST(sqlc.arg(anomalous_only) AS INTEGER) = 0 OR a.session_id IS NOT NULL)
+    AND IFNULL(s.git_branch, '') LIKE sqlc.arg(git_branch) ESCAPE '\'
+    AND IFNULL(s.git_repo_root, '') || ' ' || IFNULL(s.git_remote_url, '') LIKE sqlc.arg(git_repository) ESCAPE '\'
+    AND IFNULL(CAST(m.ended_green AS TEXT), '') LIKE sqlc.arg(ended_green)
 ORDER BY s.created_at DESC
 LIMIT ?;
 
@@ -108,10 +168,18 @@ SELECT
     COALESCE(m.token_input, 0) + COALESCE(m.token_output, 0) as total_tokens,
     m.cost_estimate_usd,
     m.model_id,
-    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count
+    s.git_branch,
+    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count,
+    a.score as anomaly_score,
+    a.reasons as anomaly_reasons,
+    m.ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
-WHERE s.project_id = ?
+LEFT JOIN session_anomalies a ON s.id = a.session_id
+WHERE s.project_id = ? AND (CAST(sqlc.arg(anomalous_only) AS INTEGER) = 0 OR a.session_id IS NOT NULL)
+    AND IFNULL(s.git_branch, '') LIKE sqlc.arg(git_branch) ESCAPE '\'
+    AND IFNULL(s.git_repo_root, '') || ' ' || IFNULL(s.git_remote_url, '') LIKE sqlc.arg(git_repository) ESCAPE '\'
+    AND IFNULL(CAST(m.ended_green AS TEXT), '') LIKE sqlc.arg(ended_green)
 ORDER BY s.created_at DESC
 LIMIT ?;
 
@@ -123,9 +191,37 @@ SELECT
     COALESCE(m.token_input, 0) + COALESCE(m.token_output, 0) as total_tokens,
     m.cost_estimate_usd,
     m.model_id,
-    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count
+    s.git_branch,
+    (SELECT COUNT(*) FROM session_subagents sa WHERE sa.session_id = s.id) as subagent_count,
+    a.score as anomaly_score,
+    a.reasons as anomaly_reasons,
+    m.ended_green
 FROM sessions s
 LEFT JOIN session_metrics m ON s.id = m.session_id
-WHERE s.project_id = ? AND s.experiment_id = ?
+LEFT JOIN session_anomalies a ON s.id = a.session_id
+WHERE s.project_id = ? AND s.experiment_id = ? AND (CAST(sqlc.arg(anomalous_only) AS INTEGER) = 0 OR a.session_id IS NOT NULL)
+    AND IFNULL(s.git_branch, '') LIKE sqlc.arg(git_branch) ESCAPE '\'
+    AND IFNULL(s.git_repo_root, '') || ' ' || IFNULL(s.git_remote_url, '') LIKE sqlc.arg(git_repository) ESCAPE '\'
+    AND IFNULL(CAST(m.ended_green AS TEXT), '') LIKE sqlc.arg(ended_green)
 ORDER BY s.created_at DESC
 LIMIT ?;
+
+-- name: ListSessionGitBranches :many
+SELECT DISTINCT git_branch FROM sessions
+WHERE git_branch IS NOT NULL AND git_branch != ''
+ORDER BY git_branch ASC;
+
+-- name: ListSessionGitRepositories :many
+SELECT DISTINCT CAST(COALESCE(NULLIF(git_remote_url, ''), git_repo_root) AS TEXT) AS repository FROM sessions
+WHERE git_repo_root IS NOT NULL
+ORDER BY repository ASC;
+
+-- name: InsertSessionGitStart :exec
+INSERT OR IGNORE INTO session_git_starts (session_id, repo_root, remote_url, branch, commit_hash, dirty, captured_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetSessionGitStart :one
+SELECT * FROM session_git_starts WHERE session_id = ?;
+
+-- name: DeleteSessionGitStart :exec
+DELETE FROM session_git_starts WHERE session_id = ?;
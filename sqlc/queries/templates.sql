@@ -0,0 +1,19 @@
+-- name: UpsertExperimentTemplate :exec
+INSERT INTO experiment_templates (name, description, hypothesis, model_id, plan_type, notes, variables, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(name) DO UPDATE SET
+    description = excluded.description,
+    hypothesis = excluded.hypothesis,
+    model_id = excluded.model_id,
+    plan_type = excluded.plan_type,
+    notes = excluded.notes,
+    variables = excluded.variables;
+
+-- name: GetExperimentTemplateByName :one
+SELECT * FROM experiment_templates WHERE name = ?;
+
+-- name: ListExperimentTemplates :many
+SELECT * FROM experiment_templates ORDER BY name ASC;
+
+-- name: DeleteExperimentTemplate :exec
+DELETE FROM experiment_templates WHERE name = ?;
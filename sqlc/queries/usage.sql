@@ -0,0 +1,48 @@
+-- name: GetPlanConfig :one
+SELECT * FROM plan_config WHERE id = 1;
+
+-- name: UpsertPlanConfig :exec
+INSERT INTO plan_config (id, plan_type, weekly_reset_at, created_at, updated_at)
+VALUES (1, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+    plan_type = excluded.plan_type,
+    weekly_reset_at = excluded.weekly_reset_at,
+    updated_at = excluded.updated_at;
+
+-- name: DeletePlanConfig :exec
+DELETE FROM plan_config WHERE id = 1;
+
+-- name: UpsertPlanPrice :exec
+INSERT INTO plan_prices (effective_from, plan_type, monthly_usd, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(effective_from) DO UPDATE SET
+    plan_type = excluded.plan_type,
+    monthly_usd = excluded.monthly_usd;
+
+-- name: ListPlanPrices :many
+SELECT * FROM plan_prices ORDER BY effective_from ASC;
+
+-- name: DeletePlanPrice :execrows
+DELETE FROM plan_prices WHERE effective_from = ?;
+
+-- name: CreateUsageRequest :exec
+INSERT INTO usage_requests (session_id, timestamp, model, token_input, token_output, token_cache_read, token_cache_write)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteUsageRequestsBySession :exec
+DELETE FROM usage_requests WHERE session_id = ?;
+
+-- name: ListUsageRequestsBetween :many
+SELECT * FROM usage_requests
+WHERE timestamp >= ? AND timestamp <= ?
+ORDER BY timestamp ASC, id ASC;
+
+-- name: CreateRateLimitHit :exec
+INSERT INTO rate_limit_hits (session_id, hit_at, window_type, plan_type)
+VALUES (?, ?, ?, ?);
+
+-- name: DeleteRateLimitHitsBySession :exec
+DELETE FROM rate_limit_hits WHERE session_id = ?;
+
+-- name: ListRateLimitHits :many
+SELECT * FROM rate_limit_hits ORDER BY hit_at ASC;